@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Rancheroo/r8s/internal/knowledge"
+)
+
+var kbBundlePath string // Bundle whose kb.d is included (optional)
+
+func init() {
+	kbCmd.PersistentFlags().StringVar(&kbBundlePath, "bundle", "", "also load the bundle's kb.d directory")
+
+	kbCmd.AddCommand(kbListCmd)
+	kbCmd.AddCommand(kbShowCmd)
+	kbCmd.AddCommand(kbLintCmd)
+	rootCmd.AddCommand(kbCmd)
+}
+
+var kbCmd = &cobra.Command{
+	Use:   "kb",
+	Short: "Browse and extend the known-issue knowledge base",
+	Long: `Browse and extend the known-issue knowledge base.
+
+r8s ships a versioned knowledge base of error signatures such as
+"mvcc: database space exceeded" or "x509: certificate has expired". Signatures
+are matched against bundle logs, events and Attention Dashboard items; press
+'d' on a dashboard item to see what the error means, its likely cause, how to
+fix it and links to the docs.
+
+Add your own entries, or replace a built-in one by reusing its id, with YAML
+files in ~/.r8s/kb.d/ or the bundle's kb.d/ directory.
+
+ENTRY FORMAT:
+  entries:
+  - id: multipath-longhorn            # unique id (required)
+    title: multipathd claims Longhorn devices
+    severity: warning                 # critical, warning or info (default warning)
+    signatures:                       # case-insensitive regexes (required)
+    - 'multipathd.*sd[a-z]+: add path'
+    explanation: multipathd grabs the iSCSI devices Longhorn attaches.
+    cause: multipath.conf does not blacklist Longhorn devices.
+    remediation:
+    - Add a blacklist for Longhorn devices to /etc/multipath.conf
+    - systemctl restart multipathd
+    links:
+    - https://longhorn.io/kb/troubleshooting-volume-with-multipath/
+
+EXAMPLES:
+  # List all entries
+  r8s kb list
+
+  # Show one entry
+  r8s kb show etcd-db-space-exceeded
+
+  # Validate ~/.r8s/kb.d or specific files
+  r8s kb lint
+  r8s kb lint ./team-kb/`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Help()
+	},
+}
+
+var kbListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List knowledge base entries",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kb, err := loadKnowledgeBase()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Knowledge base %s: %d entries\n\n", kb.Version, len(kb.Entries))
+		for _, e := range kb.Entries {
+			source := ""
+			if e.Source != knowledge.BuiltinSource {
+				source = "  (" + e.Source + ")"
+			}
+			fmt.Printf("  %-28s %-9s %s%s\n", e.ID, e.Severity, e.Title, source)
+		}
+		for _, p := range kb.Problems {
+			fmt.Printf("  ✗ %s (skipped)\n", p)
+		}
+		return nil
+	},
+}
+
+var kbShowCmd = &cobra.Command{
+	Use:   "show ID",
+	Short: "Show a knowledge base entry",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kb, err := loadKnowledgeBase()
+		if err != nil {
+			return err
+		}
+		e := kb.Lookup(args[0])
+		if e == nil {
+			return fmt.Errorf("no entry %q (see r8s kb list)", args[0])
+		}
+
+		fmt.Printf("%s [%s, %s]\n", e.Title, e.ID, e.Severity)
+		fmt.Printf("Source: %s\n", e.Source)
+		fmt.Printf("Signatures:\n  %s\n", strings.Join(e.Signatures, "\n  "))
+		if e.Explanation != "" {
+			fmt.Printf("\nWhat it means:\n  %s\n", e.Explanation)
+		}
+		if e.Cause != "" {
+			fmt.Printf("\nLikely cause:\n  %s\n", e.Cause)
+		}
+		if len(e.Remediation) > 0 {
+			fmt.Println("\nHow to fix:")
+			for i, step := range e.Remediation {
+				fmt.Printf("  %d. %s\n", i+1, step)
+			}
+		}
+		if len(e.Links) > 0 {
+			fmt.Printf("\nDocs:\n  %s\n", strings.Join(e.Links, "\n  "))
+		}
+		return nil
+	},
+}
+
+var kbLintCmd = &cobra.Command{
+	Use:   "lint [PATH...]",
+	Short: "Validate knowledge base files",
+	Long: `Validate knowledge base files: YAML syntax, unknown keys, required
+fields, severities, signature regexes, link URLs and duplicate ids.
+
+PATH may be a file or a directory of *.yaml/*.yml files. Without arguments,
+~/.r8s/kb.d and (with --bundle) the bundle's kb.d are linted.
+
+EXAMPLES:
+  r8s kb lint
+  r8s kb lint ./team-kb/ disk.yaml`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths := args
+		if len(paths) == 0 {
+			root, err := kbBundleRoot()
+			if err != nil {
+				return err
+			}
+			paths = knowledge.DefaultDirs(root)
+		}
+		if len(paths) == 0 {
+			fmt.Println("No knowledge base directories found (~/.r8s/kb.d)")
+			return nil
+		}
+
+		kb := knowledge.Load(paths...)
+		for _, p := range kb.Problems {
+			fmt.Printf("  ✗ %s\n", p)
+		}
+		if len(kb.Problems) > 0 {
+			return fmt.Errorf("%d problem(s) in %d file(s)", len(kb.Problems), len(kb.Files))
+		}
+		user := 0
+		for _, e := range kb.Entries {
+			if e.Source != knowledge.BuiltinSource {
+				user++
+			}
+		}
+		fmt.Printf("✓ %d entries in %d file(s) OK\n", user, len(kb.Files))
+		return nil
+	},
+}
+
+// loadKnowledgeBase loads the built-in entries plus ~/.r8s/kb.d and the --bundle's kb.d
+func loadKnowledgeBase() (*knowledge.Base, error) {
+	root, err := kbBundleRoot()
+	if err != nil {
+		return nil, err
+	}
+	return knowledge.Load(knowledge.DefaultDirs(root)...), nil
+}
+
+// kbBundleRoot returns the root of the --bundle bundle, or "" if none was given
+func kbBundleRoot() (string, error) {
+	if kbBundlePath == "" {
+		return "", nil
+	}
+	ds, err := openBundleDataSource(kbBundlePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load bundle: %w", err)
+	}
+	defer ds.Close()
+	return ds.BundleRoot(), nil
+}
@@ -0,0 +1,229 @@
+// Package cmd implements the CLI commands and flags for r8s.
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+	"github.com/Rancheroo/r8s/internal/rbac"
+)
+
+var rbacBundlePath string // Bundle to query (default: embedded demo bundle)
+
+func init() {
+	rbacCmd.PersistentFlags().StringVar(&rbacBundlePath, "bundle", "", "path to extracted bundle (default: demo bundle)")
+
+	rbacCmd.AddCommand(rbacWhoCanCmd)
+	rbacCmd.AddCommand(rbacWhatCanCmd)
+	rbacCmd.AddCommand(rbacRiskyCmd)
+	rootCmd.AddCommand(rbacCmd)
+}
+
+var rbacCmd = &cobra.Command{
+	Use:   "rbac",
+	Short: "Query RBAC permissions in a bundle",
+	Long: `Resolve subjects to roles and query effective permissions from the
+clusterroles, clusterrolebindings, roles and rolebindings in a bundle.
+
+Bundles usually collect roles as table output without rules. For well-known
+roles (cluster-admin, admin, edit, view, Rancher project roles) built-in
+rules are used and marked "(inferred)"; bindings to other roles are shown
+as possible grants.
+
+EXAMPLES:
+  # Who can list secrets in cattle-system?
+  r8s rbac who-can list secrets -n cattle-system --bundle ./bundle/
+
+  # What can a service account do?
+  r8s rbac what-can system:serviceaccount:cattle-system:rancher
+
+  # Show risky grants (wildcards, cluster-admin, escalate/bind/impersonate)
+  r8s rbac risky`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Help()
+	},
+}
+
+var rbacWhoCanCmd = &cobra.Command{
+	Use:   "who-can VERB RESOURCE",
+	Short: "List subjects allowed to perform VERB on RESOURCE",
+	Long: `List subjects allowed to perform VERB on RESOURCE.
+
+RESOURCE may be qualified with its API group (deployments.apps). Without
+-n the query is cluster-scoped and only ClusterRoleBindings apply.
+
+EXAMPLES:
+  r8s rbac who-can list secrets -n cattle-system
+  r8s rbac who-can create pods/exec -n default
+  r8s rbac who-can delete nodes`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eval, err := loadRBACEvaluator()
+		if err != nil {
+			return err
+		}
+
+		verb, resource := args[0], args[1]
+		where := "cluster-wide"
+		if namespace != "" {
+			where = "in namespace " + namespace
+		}
+		fmt.Printf("Who can %s %s %s:\n\n", verb, resource, where)
+
+		grants := eval.WhoCan(verb, resource, namespace)
+		var possible []rbac.Grant
+		count := 0
+		for _, g := range grants {
+			if !g.Known {
+				possible = append(possible, g)
+				continue
+			}
+			count++
+			note := ""
+			if g.Inferred {
+				note = " (inferred)"
+			}
+			if g.NameRestricted() {
+				note += fmt.Sprintf(" [only names: %s]", strings.Join(g.ResourceNames, ","))
+			}
+			fmt.Printf("  %-16s %-60s via %s/%s -> %s/%s%s\n",
+				g.Subject.Kind, rbac.SubjectString(g.Subject), g.Binding.Kind, g.Binding.Name,
+				g.Role.Kind, g.Role.Name, note)
+		}
+		if count == 0 {
+			fmt.Println("  (no subject with known rules)")
+		}
+
+		if len(possible) > 0 {
+			fmt.Printf("\nPossible (role rules not collected in bundle): %d\n", len(possible))
+			for _, g := range possible {
+				fmt.Printf("  %-16s %-60s via %s/%s -> %s/%s\n",
+					g.Subject.Kind, rbac.SubjectString(g.Subject), g.Binding.Kind, g.Binding.Name,
+					g.Role.Kind, g.Role.Name)
+			}
+		}
+		return nil
+	},
+}
+
+var rbacWhatCanCmd = &cobra.Command{
+	Use:   "what-can SUBJECT",
+	Short: "Show the roles and rules granted to SUBJECT",
+	Long: `Show the roles and rules granted to SUBJECT, including bindings to
+groups it implicitly belongs to (system:authenticated, system:serviceaccounts).
+
+SUBJECT formats:
+  system:serviceaccount:NAMESPACE:NAME   service account
+  NAMESPACE/NAME                         service account
+  group:NAME                             group
+  NAME                                   user
+
+EXAMPLES:
+  r8s rbac what-can system:serviceaccount:cattle-system:rancher
+  r8s rbac what-can kube-system/helm-rke2-coredns
+  r8s rbac what-can group:system:masters`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eval, err := loadRBACEvaluator()
+		if err != nil {
+			return err
+		}
+
+		subject := rbac.ParseSubject(args[0])
+		fmt.Printf("%s %s:\n\n", subject.Kind, rbac.SubjectString(subject))
+
+		grants := eval.SubjectGrants(subject)
+		if len(grants) == 0 {
+			fmt.Println("  (no bindings)")
+			return nil
+		}
+
+		for _, g := range grants {
+			via := ""
+			if g.Subject != subject {
+				via = fmt.Sprintf(" [via %s %s]", strings.ToLower(g.Subject.Kind), g.Subject.Name)
+			}
+			fmt.Printf("  %s/%s (%s) -> %s/%s%s\n", g.Binding.Kind, g.Binding.Name, g.Scope, g.Role.Kind, g.Role.Name, via)
+
+			rules := eval.Rules(g)
+			if len(rules) == 0 {
+				fmt.Println("      (rules not collected in bundle)")
+				continue
+			}
+			if g.Inferred {
+				fmt.Println("      (inferred from built-in defaults)")
+			}
+			for _, rule := range rules {
+				fmt.Printf("      %s\n", rbac.FormatRule(rule))
+			}
+		}
+		return nil
+	},
+}
+
+var rbacRiskyCmd = &cobra.Command{
+	Use:   "risky",
+	Short: "List risky RBAC grants",
+	Long: `List risky RBAC grants: wildcard verbs, cluster-admin (or equivalent)
+bound to non-system subjects, and escalate/bind/impersonate rights.
+
+Subjects named system:* and service accounts in kube-system, *-system and
+cattle-* namespaces are treated as platform components.
+
+EXAMPLES:
+  r8s rbac risky --bundle ./bundle/`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		eval, err := loadRBACEvaluator()
+		if err != nil {
+			return err
+		}
+
+		risks := eval.RiskyGrants()
+		if len(risks) == 0 {
+			fmt.Println("✓ No risky grants found")
+			return nil
+		}
+
+		fmt.Printf("⚠️  %d risky grant(s):\n\n", len(risks))
+		for _, r := range risks {
+			fmt.Printf("  %-16s %-50s %s\n", r.Grant.Subject.Kind, rbac.SubjectString(r.Grant.Subject), r.Reason)
+			fmt.Printf("  %-16s %-50s via %s/%s\n", "", "", r.Grant.Binding.Kind, r.Grant.Binding.Name)
+		}
+		return nil
+	},
+}
+
+// openBundleDataSource opens the bundle given by path, or the embedded demo bundle if empty
+func openBundleDataSource(path string) (datasource.DataSource, error) {
+	if path == "" {
+		return datasource.NewEmbeddedDataSource(verbose)
+	}
+	return datasource.NewBundleDataSource(path, verbose)
+}
+
+// loadRBACEvaluator loads roles and bindings from the selected bundle
+func loadRBACEvaluator() (*rbac.Evaluator, error) {
+	ds, err := openBundleDataSource(rbacBundlePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load bundle: %w", err)
+	}
+	defer ds.Close()
+
+	roles, err := ds.GetRoles()
+	if err != nil {
+		return nil, err
+	}
+	bindings, err := ds.GetRoleBindings()
+	if err != nil {
+		return nil, err
+	}
+	if len(bindings) == 0 {
+		return nil, fmt.Errorf("no role bindings found in bundle")
+	}
+
+	return rbac.NewEvaluator(roles, bindings), nil
+}
@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/Rancheroo/r8s/internal/rules"
+)
+
+var rulesBundlePath string // Bundle to test against (default: embedded demo bundle)
+
+func init() {
+	rulesCmd.PersistentFlags().StringVar(&rulesBundlePath, "bundle", "", "path to extracted bundle (default: demo bundle)")
+
+	rulesCmd.AddCommand(rulesLintCmd)
+	rulesCmd.AddCommand(rulesTestCmd)
+	rootCmd.AddCommand(rulesCmd)
+}
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Lint and test user-defined attention rules",
+	Long: `Lint and test user-defined attention rules.
+
+Rules are YAML files in ~/.r8s/rules.d/ and the bundle's rules.d/ directory,
+loaded when the TUI starts. Each file holds one rule or a list under "rules:".
+A rule matches exactly one of: pod/event/node fields, log lines, or lines of
+bundle files, and adds an item to the Attention Dashboard when the number of
+matches reaches its threshold.
+
+RULE FORMAT:
+  rules:
+  - id: ingress-restarts            # unique id (required)
+    title: Ingress controller restarting
+    severity: warning               # critical, warning or info
+    emoji: 🚪                       # optional
+    description: "{count} pods, e.g. {resource}"
+    runbook: https://wiki.example.com/ingress
+    threshold: 1                    # minimum matches (default 1)
+    perResource: false              # one item per pod/event/node/source/file
+    match:
+      resource: pod                 # pod, event or node
+      fields:                       # regex (whole value) or comparison
+        namespace: kube-system
+        name: rke2-ingress-nginx-.*
+        restarts: ">3"
+
+  Other match kinds:
+    match:
+      logs:
+        pattern: 'x509: certificate has expired'
+        source: 'journald|kube-system/.*'   # optional regex filters
+        namespace: ''
+        pod: ''
+    match:
+      file:
+        path: journald/*                    # glob relative to the bundle root
+        pattern: 'multipathd.*sd[a-z]+'
+
+FIELDS:
+  pod:   name namespace node state ready restarts age
+  event: namespace type reason object kind pod message source count
+  node:  name status
+
+  Descriptions may use {count}, {resource}, {namespace}, {pod} and {match}.
+
+EXAMPLES:
+  # Lint ~/.r8s/rules.d
+  r8s rules lint
+
+  # Lint specific files or directories
+  r8s rules lint ./my-rules/ team.yaml
+
+  # Show which rules fire against a bundle
+  r8s rules test --bundle ./bundle/ ./my-rules/`,
+	Run: func(cmd *cobra.Command, args []string) {
+		_ = cmd.Help()
+	},
+}
+
+var rulesLintCmd = &cobra.Command{
+	Use:   "lint [PATH...]",
+	Short: "Validate rule files",
+	Long: `Validate rule files: YAML syntax, unknown keys, required fields,
+severities, match kinds, field names, regexes and duplicate rule ids.
+
+PATH may be a rule file or a directory of *.yaml/*.yml files. Without
+arguments, ~/.r8s/rules.d and (with --bundle) the bundle's rules.d are linted.
+
+EXAMPLES:
+  r8s rules lint
+  r8s rules lint ./my-rules/ team.yaml
+  r8s rules lint --bundle ./bundle/`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		paths := args
+		if len(paths) == 0 {
+			root, err := rulesBundleRoot()
+			if err != nil {
+				return err
+			}
+			paths = rules.DefaultDirs(root)
+		}
+		if len(paths) == 0 {
+			fmt.Println("No rule directories found (~/.r8s/rules.d)")
+			return nil
+		}
+
+		set := rules.Load(paths...)
+		for _, p := range set.Problems {
+			fmt.Printf("  ✗ %s\n", p)
+		}
+		if len(set.Problems) > 0 {
+			return fmt.Errorf("%d problem(s) in %d rule file(s)", len(set.Problems), len(set.Files))
+		}
+		fmt.Printf("✓ %d rule(s) in %d file(s) OK\n", len(set.Rules), len(set.Files))
+		return nil
+	},
+}
+
+var rulesTestCmd = &cobra.Command{
+	Use:   "test [PATH...]",
+	Short: "Run rules against a bundle and show what fires",
+	Long: `Run rules against a bundle and show, for every rule, how many
+matches it found, whether it reaches its threshold, and the matching lines.
+
+Without arguments the rules the TUI would load are tested: ~/.r8s/rules.d
+and the bundle's rules.d.
+
+EXAMPLES:
+  r8s rules test --bundle ./bundle/
+  r8s rules test --bundle ./bundle/ ./my-rules/multipath.yaml`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ds, err := openBundleDataSource(rulesBundlePath)
+		if err != nil {
+			return fmt.Errorf("failed to load bundle: %w", err)
+		}
+		defer ds.Close()
+
+		paths := args
+		if len(paths) == 0 {
+			paths = rules.DefaultDirs(ds.BundleRoot())
+		}
+		set := rules.Load(paths...)
+		for _, p := range set.Problems {
+			fmt.Printf("  ✗ %s (skipped)\n", p)
+		}
+		if len(set.Rules) == 0 {
+			return fmt.Errorf("no valid rules to test")
+		}
+
+		fired := 0
+		for _, rule := range set.Rules {
+			hits, err := rule.Hits(ds)
+			if err != nil {
+				fmt.Printf("\n✗ %s: %v\n", rule.ID, err)
+				continue
+			}
+			findings := rule.Findings(hits)
+			status := "not fired"
+			if len(findings) > 0 {
+				status = fmt.Sprintf("FIRED (%s)", rule.Severity)
+				fired++
+			}
+			fmt.Printf("\n%s %s [%s] %s\n", rule.Icon(), rule.ID, filepath.Base(rule.File), rule.Title)
+			fmt.Printf("  %d match(es), threshold %d: %s\n", len(hits), rule.MinMatches(), status)
+			for _, f := range findings {
+				fmt.Printf("  → %s\n", f.Description)
+				for _, line := range f.Evidence {
+					fmt.Printf("      %s\n", line)
+				}
+			}
+			if rule.Runbook != "" && len(findings) > 0 {
+				fmt.Printf("  Runbook: %s\n", rule.Runbook)
+			}
+		}
+
+		fmt.Printf("\n%d of %d rule(s) fired\n", fired, len(set.Rules))
+		return nil
+	},
+}
+
+// rulesBundleRoot returns the root of the --bundle bundle, or "" if none was given
+func rulesBundleRoot() (string, error) {
+	if rulesBundlePath == "" {
+		return "", nil
+	}
+	ds, err := openBundleDataSource(rulesBundlePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to load bundle: %w", err)
+	}
+	defer ds.Close()
+	return ds.BundleRoot(), nil
+}
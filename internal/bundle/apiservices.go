@@ -0,0 +1,159 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// APIServiceInfo contains an APIService and the state of its backing service
+type APIServiceInfo struct {
+	rancher.APIService
+	ServiceBackends
+}
+
+// Local reports whether the APIService is served by the kube-apiserver itself
+func (a *APIServiceInfo) Local() bool {
+	return a.ServiceName == ""
+}
+
+var (
+	// discoveryGroupVersionRe matches "<group>/<version>" pairs in discovery errors,
+	// e.g. "metrics.k8s.io/v1beta1: the server is currently unable to handle the request"
+	discoveryGroupVersionRe = regexp.MustCompile(`([a-z0-9][a-z0-9-]*(?:\.[a-z0-9-]+)+)/(v[0-9]+(?:alpha[0-9]+|beta[0-9]+)?)\b`)
+
+	// apiServiceNameRe matches APIService names logged by the apiserver's availability
+	// controller, e.g. "v1beta1.metrics.k8s.io failed with: failing or missing response"
+	apiServiceNameRe = regexp.MustCompile(`\b(v[0-9]+(?:alpha[0-9]+|beta[0-9]+)?\.[a-z0-9-]+(?:\.[a-z0-9-]+)+)\b`)
+)
+
+// APIServiceFailurePattern matches log and event lines reporting aggregated API discovery
+// or availability failures
+var APIServiceFailurePattern = NewLogPattern(`unable to retrieve the complete list of server APIs|failed with: failing or missing response|FailedDiscoveryCheck|MissingEndpoints`,
+	"unable to retrieve the complete list of server APIs", "failed with: failing or missing response", "FailedDiscoveryCheck", "MissingEndpoints")
+
+// apiServiceLogPods are the control plane pods logging aggregated API failures: the
+// kube-apiserver's availability controller and kube-controller-manager's discovery
+var apiServiceLogPods = []string{"kube-apiserver-", "kube-controller-manager-"}
+
+// APIServiceLogFiles returns a LogSearch file filter for the logs that report failures of
+// the given APIServices: the control plane pods, the APIServices' backend pods and the
+// host logs (journald units and syslog, where k3s and rke2 servers log)
+func APIServiceLogFiles(infos []APIServiceInfo) func(*LogFileInfo) bool {
+	backends := make(map[string]bool)
+	for _, info := range infos {
+		for _, pod := range info.BackendPods {
+			backends[info.ServiceNamespace+"/"+pod] = true
+		}
+	}
+	return func(f *LogFileInfo) bool {
+		switch f.Type {
+		case LogTypeSystem, LogTypeJournald:
+			return true
+		case LogTypePod:
+			if backends[f.Namespace+"/"+f.PodName] {
+				return true
+			}
+			if f.Namespace != "kube-system" {
+				return false
+			}
+			for _, prefix := range apiServiceLogPods {
+				if strings.HasPrefix(f.PodName, prefix) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// APIServicesInLine returns the APIService names ("<version>.<group>") referenced by a
+// discovery or availability error line
+func APIServicesInLine(line string) []string {
+	var names []string
+	seen := make(map[string]bool)
+
+	for _, m := range discoveryGroupVersionRe.FindAllStringSubmatch(line, -1) {
+		name := m[2] + "." + m[1]
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	for _, m := range apiServiceNameRe.FindAllStringSubmatch(line, -1) {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			names = append(names, m[1])
+		}
+	}
+
+	return names
+}
+
+// ParseAPIServices parses kubectl get apiservices output from bundle
+// Format: NAME SERVICE AVAILABLE AGE
+// Note: SERVICE is "Local" or "namespace/name"; AVAILABLE is "True" or "False (Reason)"
+func ParseAPIServices(extractPath string) ([]rancher.APIService, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	path := filepath.Join(bundleRoot, "rke2/kubectl/apiservices")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	table := ParseKubectlTable(content)
+	var apiServices []rancher.APIService
+
+	for _, row := range table.Rows {
+		name := table.Value(row, "NAME")
+		if name == "" {
+			continue
+		}
+
+		svc := rancher.APIService{
+			Name: name,
+			Age:  table.Value(row, "AGE"),
+		}
+
+		if service := table.Value(row, "SERVICE"); service != "Local" {
+			if parts := strings.SplitN(service, "/", 2); len(parts) == 2 {
+				svc.ServiceNamespace = parts[0]
+				svc.ServiceName = parts[1]
+			}
+		}
+
+		available := table.Value(row, "AVAILABLE")
+		svc.Available = strings.HasPrefix(available, "True")
+		if start := strings.Index(available, "("); start >= 0 {
+			svc.Reason = strings.TrimSuffix(available[start+1:], ")")
+		}
+
+		apiServices = append(apiServices, svc)
+	}
+
+	return apiServices, nil
+}
+
+// AnalyzeAPIServices resolves every aggregated APIService against the bundle's Services,
+// Endpoints and Pods
+func AnalyzeAPIServices(extractPath string) ([]APIServiceInfo, error) {
+	apiServices, err := ParseAPIServices(extractPath)
+	if err != nil {
+		return nil, err
+	}
+
+	services := loadServiceIndex(extractPath)
+
+	infos := make([]APIServiceInfo, len(apiServices))
+	for i, svc := range apiServices {
+		infos[i].APIService = svc
+		if svc.ServiceName != "" {
+			infos[i].ServiceBackends = services.resolve(svc.ServiceNamespace, svc.ServiceName)
+		}
+	}
+
+	return infos, nil
+}
@@ -0,0 +1,76 @@
+package bundle
+
+import "testing"
+
+// TestAnalyzeAPIServices_MissingEndpoints tests parsing of unavailable APIServices and
+// resolution of their backing service
+func TestAnalyzeAPIServices_MissingEndpoints(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "apiservices", `NAME                            SERVICE                                                          AVAILABLE                  AGE
+v1.apps                         Local                                                            True                       14d
+v1beta1.custom.metrics.k8s.io   cattle-monitoring-system/rancher-monitoring-prometheus-adapter   True                       8d
+v1beta1.metrics.k8s.io          kube-system/rke2-metrics-server                                  False (MissingEndpoints)   14d
+`)
+	writeKubectlFile(t, root, "services", `NAMESPACE     NAME                  TYPE        CLUSTER-IP    EXTERNAL-IP   PORT(S)   AGE   SELECTOR
+kube-system   rke2-metrics-server   ClusterIP   10.43.12.34   <none>        443/TCP   14d   app=rke2-metrics-server
+`)
+	writeKubectlFile(t, root, "endpoints", `NAMESPACE     NAME                  ENDPOINTS   AGE
+kube-system   rke2-metrics-server   <none>      14d
+`)
+	writeKubectlFile(t, root, "pods", `NAMESPACE     NAME                                   READY   STATUS             RESTARTS       AGE   IP           NODE     NOMINATED NODE   READINESS GATES
+kube-system   rke2-metrics-server-75866c5bb5-8wd6x   0/1     CrashLoopBackOff   9 (2m ago)     14d   10.42.0.9    node-1   <none>           <none>
+`)
+
+	infos, err := AnalyzeAPIServices(root)
+	if err != nil {
+		t.Fatalf("AnalyzeAPIServices() error = %v", err)
+	}
+	if len(infos) != 3 {
+		t.Fatalf("expected 3 APIServices, got %d", len(infos))
+	}
+
+	if !infos[0].Local() || !infos[0].Available {
+		t.Errorf("v1.apps should be Local and available: %+v", infos[0])
+	}
+
+	metrics := infos[2]
+	if metrics.Available || metrics.Reason != "MissingEndpoints" {
+		t.Errorf("expected unavailable (MissingEndpoints), got available=%v reason=%q", metrics.Available, metrics.Reason)
+	}
+	if !metrics.ServiceFound || metrics.ReadyBackends != 0 {
+		t.Errorf("expected service found with no ready backends: %+v", metrics.ServiceBackends)
+	}
+	if len(metrics.BackendPods) != 1 || metrics.BackendPods[0] != "rke2-metrics-server-75866c5bb5-8wd6x" {
+		t.Errorf("expected candidate metrics-server pod, got %v", metrics.BackendPods)
+	}
+}
+
+// TestAPIServicesInLine tests extraction of APIService names from discovery errors
+func TestAPIServicesInLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want []string
+	}{
+		{
+			line: `E1204 09:15:57 memcache.go:265] couldn't get current server API group list: unable to retrieve the complete list of server APIs: metrics.k8s.io/v1beta1: the server is currently unable to handle the request, custom.metrics.k8s.io/v1beta1: stale GroupVersion discovery`,
+			want: []string{"v1beta1.metrics.k8s.io", "v1beta1.custom.metrics.k8s.io"},
+		},
+		{
+			line: `E1204 controller.go:146] "Unhandled Error" err="v1beta1.metrics.k8s.io failed with: failing or missing response from https://10.42.0.9:10250/apis/metrics.k8s.io/v1beta1"`,
+			want: []string{"v1beta1.metrics.k8s.io"},
+		},
+	}
+
+	for _, tt := range tests {
+		got := APIServicesInLine(tt.line)
+		if len(got) != len(tt.want) {
+			t.Errorf("APIServicesInLine() = %v, want %v", got, tt.want)
+			continue
+		}
+		for i := range tt.want {
+			if got[i] != tt.want[i] {
+				t.Errorf("APIServicesInLine()[%d] = %q, want %q", i, got[i], tt.want[i])
+			}
+		}
+	}
+}
@@ -2,7 +2,7 @@ package bundle
 
 import (
 	"fmt"
-	"os"
+	"io"
 )
 
 // Load loads a bundle from either a tar.gz archive or an extracted directory.
@@ -28,6 +28,11 @@ func (b *Bundle) Close() error {
 	return nil
 }
 
+// Root returns the bundle's root directory, inside the wrapper directory if it has one.
+func (b *Bundle) Root() string {
+	return getBundleRoot(b.ExtractPath)
+}
+
 // GetPod returns pod information by namespace and name.
 func (b *Bundle) GetPod(namespace, name string) *PodInfo {
 	for i := range b.Pods {
@@ -38,13 +43,18 @@ func (b *Bundle) GetPod(namespace, name string) *PodInfo {
 	return nil
 }
 
-// GetLogFile returns log file information by path.
+// GetLogFile returns log file information by path, kubelet logs included.
 func (b *Bundle) GetLogFile(path string) *LogFileInfo {
 	for i := range b.LogFiles {
 		if b.LogFiles[i].Path == path {
 			return &b.LogFiles[i]
 		}
 	}
+	for i := range b.KubeletLogFiles {
+		if b.KubeletLogFiles[i].Path == path {
+			return &b.KubeletLogFiles[i]
+		}
+	}
 	return nil
 }
 
@@ -53,7 +63,12 @@ func (b *Bundle) ReadLogFile(logFile *LogFileInfo) ([]byte, error) {
 	if logFile == nil {
 		return nil, fmt.Errorf("log file info is nil")
 	}
-	return os.ReadFile(logFile.Path)
+	f, err := openLogFile(logFile.Path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
 }
 
 // Summary returns a human-readable summary of the bundle.
@@ -0,0 +1,437 @@
+package bundle
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Certificate expiry states at bundle collection time (see Certificate.ExpiryStatus)
+const (
+	CertValid       = "valid"
+	CertExpiring    = "expiring"      // Expires within certExpiryWarning
+	CertExpired     = "expired"       // NotAfter before collection
+	CertNotYetValid = "not-yet-valid" // NotBefore after collection: node clock behind the issuer's
+)
+
+// Certificate chain results (see Certificate.Chain)
+const (
+	CertChainValid       = "valid"        // Signature verified against the CA (PEM certificates)
+	CertChainIssuerMatch = "issuer-match" // Issuer and key identifier match the CA; signature not in text dump
+	CertChainInvalid     = "invalid"      // Signature or key identifier does not match the CA of that name
+	CertChainUnknownCA   = "unknown-ca"   // Issuer is not a CA in the bundle (e.g. request-header-ca is not collected)
+	CertChainRoot        = "root"         // Self-signed CA
+)
+
+// Certificate formats (see Certificate.Format)
+const (
+	CertFormatPEM  = "pem"
+	CertFormatText = "text" // `openssl x509 -text -noout` output, as collected by the RKE2 log collector
+)
+
+const (
+	certExpiryWarning  = 30 * 24 * time.Hour
+	certExpiryCritical = 7 * 24 * time.Hour
+)
+
+// CertificateErrorPattern matches log lines reporting a TLS certificate error
+var CertificateErrorPattern = regexp.MustCompile(`x509: `)
+
+// certValidForRe extracts the names from `x509: certificate is valid for a, b, not c`
+var certValidForRe = regexp.MustCompile(`x509: certificate is valid for (.+?), not ([^\s"]+)`)
+
+// Certificate is a certificate file from rke2/certs
+type Certificate struct {
+	File           string // Relative to rke2/certs, e.g. "server/serving-kube-apiserver.crt"
+	Format         string // CertFormat* constant
+	Subject        string // e.g. "O=system:nodes, CN=system:node:node-1"
+	CommonName     string
+	Issuer         string
+	IssuerCN       string
+	DNSNames       []string
+	IPAddresses    []string
+	SerialNumber   string
+	NotBefore      time.Time
+	NotAfter       time.Time
+	IsCA           bool
+	ExtKeyUsage    []string // e.g. "server auth", "client auth"
+	SubjectKeyID   string   // Colon-separated hex, e.g. "3D:4D:95:..."
+	AuthorityKeyID string
+	Chain          string // CertChain* constant
+	ChainCA        string // CA file the chain was checked against, e.g. "server-ca"
+	ChainDetail    string
+
+	cert *x509.Certificate // nil for text dumps
+}
+
+// CertificateName returns the file's base name without extensions, e.g. "server-ca"
+// for "server/server-ca.nochain.crt"
+func (c Certificate) CertificateName() string {
+	name := strings.TrimSuffix(filepath.Base(c.File), ".crt")
+	return strings.TrimSuffix(name, ".nochain")
+}
+
+// ExpiryStatus returns the CertExpiry* state of the certificate at time at
+func (c Certificate) ExpiryStatus(at time.Time) string {
+	switch {
+	case at.Before(c.NotBefore):
+		return CertNotYetValid
+	case !at.Before(c.NotAfter):
+		return CertExpired
+	case c.NotAfter.Sub(at) < certExpiryWarning:
+		return CertExpiring
+	default:
+		return CertValid
+	}
+}
+
+// IsCritical reports whether the certificate is expired, not yet valid, or expires within
+// certExpiryCritical at time at
+func (c Certificate) IsCritical(at time.Time) bool {
+	status := c.ExpiryStatus(at)
+	return status == CertExpired || status == CertNotYetValid ||
+		(status == CertExpiring && c.NotAfter.Sub(at) < certExpiryCritical)
+}
+
+// ParseCertificates reads every certificate in rke2/certs/server and rke2/certs/agent and
+// checks each against the CA in the bundle that issued it (server-ca, client-ca, ...).
+// PEM files are parsed with crypto/x509; the openssl text dumps the log collector saves
+// are parsed from their text.
+func ParseCertificates(extractPath string) ([]Certificate, error) {
+	certsDir := filepath.Join(getBundleRoot(extractPath), "rke2", "certs")
+	if _, err := os.Stat(certsDir); err != nil {
+		return nil, fmt.Errorf("certs directory not found: %w", err)
+	}
+
+	var certs []Certificate
+	for _, dir := range []string{"server", "agent"} {
+		paths, _ := filepath.Glob(filepath.Join(certsDir, dir, "*.crt"))
+		sort.Strings(paths)
+		for _, path := range paths {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			cert, ok := parseCertificateFile(content)
+			if !ok {
+				continue
+			}
+			cert.File = dir + "/" + filepath.Base(path)
+			certs = append(certs, cert)
+		}
+	}
+
+	checkCertificateChains(certs)
+	return certs, nil
+}
+
+// parseCertificateFile parses the first certificate in a PEM file or openssl text dump
+func parseCertificateFile(content []byte) (Certificate, bool) {
+	if block, _ := pem.Decode(content); block != nil && block.Type == "CERTIFICATE" {
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return Certificate{}, false
+		}
+		return newCertificate(cert), true
+	}
+	if bytes.Contains(content, []byte("Certificate:")) {
+		return parseCertificateText(content)
+	}
+	return Certificate{}, false
+}
+
+// newCertificate converts a parsed x509 certificate
+func newCertificate(cert *x509.Certificate) Certificate {
+	c := Certificate{
+		Format:         CertFormatPEM,
+		Subject:        formatDN(cert.Subject),
+		CommonName:     cert.Subject.CommonName,
+		Issuer:         formatDN(cert.Issuer),
+		IssuerCN:       cert.Issuer.CommonName,
+		DNSNames:       cert.DNSNames,
+		SerialNumber:   cert.SerialNumber.String(),
+		NotBefore:      cert.NotBefore,
+		NotAfter:       cert.NotAfter,
+		IsCA:           cert.IsCA,
+		SubjectKeyID:   formatKeyID(cert.SubjectKeyId),
+		AuthorityKeyID: formatKeyID(cert.AuthorityKeyId),
+		cert:           cert,
+	}
+	for _, ip := range cert.IPAddresses {
+		c.IPAddresses = append(c.IPAddresses, ip.String())
+	}
+	for _, usage := range cert.ExtKeyUsage {
+		switch usage {
+		case x509.ExtKeyUsageServerAuth:
+			c.ExtKeyUsage = append(c.ExtKeyUsage, "server auth")
+		case x509.ExtKeyUsageClientAuth:
+			c.ExtKeyUsage = append(c.ExtKeyUsage, "client auth")
+		}
+	}
+	return c
+}
+
+// dnAttributeNames maps distinguished name attribute OIDs to their short names
+var dnAttributeNames = map[string]string{
+	"2.5.4.3":  "CN",
+	"2.5.4.6":  "C",
+	"2.5.4.7":  "L",
+	"2.5.4.8":  "ST",
+	"2.5.4.10": "O",
+	"2.5.4.11": "OU",
+}
+
+// formatDN formats a name in certificate order, as openssl prints it: "O=system:nodes, CN=..."
+func formatDN(name pkix.Name) string {
+	parts := make([]string, 0, len(name.Names))
+	for _, attr := range name.Names {
+		key := attr.Type.String()
+		if short, ok := dnAttributeNames[key]; ok {
+			key = short
+		}
+		parts = append(parts, fmt.Sprintf("%s=%v", key, attr.Value))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// formatKeyID formats a key identifier as openssl does, e.g. "3D:4D:95"
+func formatKeyID(id []byte) string {
+	parts := make([]string, len(id))
+	for i, b := range id {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
+
+// normalizeDN rewrites an openssl DN ("O = system:nodes, CN = x") as formatDN does
+func normalizeDN(dn string) string {
+	parts := strings.Split(dn, ",")
+	for i, part := range parts {
+		key, value, _ := strings.Cut(part, "=")
+		parts[i] = strings.TrimSpace(key) + "=" + strings.TrimSpace(value)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// dnCommonName returns the CN of a formatted DN
+func dnCommonName(dn string) string {
+	for _, part := range strings.Split(dn, ", ") {
+		if cn, ok := strings.CutPrefix(part, "CN="); ok {
+			return cn
+		}
+	}
+	return ""
+}
+
+// parseCertificateText parses `openssl x509 -text -noout` output.
+// Format:
+//
+//	Serial Number: 1816844791090240519 (0x1936bab620f3dc07)
+//	Issuer: CN = rke2-server-ca@1763599467
+//	    Not Before: Nov 20 00:44:27 2025 GMT
+//	    Not After : Nov 20 00:44:27 2026 GMT
+//	Subject: CN = kube-apiserver
+//	X509v3 Subject Alternative Name:
+//	    DNS:kubernetes, DNS:localhost, IP Address:127.0.0.1
+func parseCertificateText(content []byte) (Certificate, bool) {
+	c := Certificate{Format: CertFormatText}
+
+	lines := strings.Split(string(content), "\n")
+	next := func(i int) string {
+		if i+1 < len(lines) {
+			return strings.TrimSpace(lines[i+1])
+		}
+		return ""
+	}
+
+	for i, raw := range lines {
+		line := strings.TrimSpace(raw)
+		key, value, _ := strings.Cut(line, ":")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch {
+		case key == "Serial Number":
+			if value == "" {
+				// Long serials are printed as hex on the next line
+				value = next(i)
+			}
+			c.SerialNumber, _, _ = strings.Cut(value, " (")
+		case key == "Issuer":
+			c.Issuer = normalizeDN(value)
+			c.IssuerCN = dnCommonName(c.Issuer)
+		case key == "Subject":
+			c.Subject = normalizeDN(value)
+			c.CommonName = dnCommonName(c.Subject)
+		case key == "Not Before":
+			c.NotBefore = parseOpensslTime(value)
+		case key == "Not After":
+			c.NotAfter = parseOpensslTime(value)
+		case key == "X509v3 Subject Alternative Name":
+			for _, name := range strings.Split(next(i), ",") {
+				name = strings.TrimSpace(name)
+				if dns, ok := strings.CutPrefix(name, "DNS:"); ok {
+					c.DNSNames = append(c.DNSNames, dns)
+				} else if ip, ok := strings.CutPrefix(name, "IP Address:"); ok {
+					c.IPAddresses = append(c.IPAddresses, ip)
+				}
+			}
+		case key == "X509v3 Basic Constraints":
+			c.IsCA = strings.Contains(next(i), "CA:TRUE")
+		case key == "X509v3 Subject Key Identifier":
+			c.SubjectKeyID = next(i)
+		case key == "X509v3 Authority Key Identifier":
+			// Older openssl prints "keyid:3D:4D:..."
+			c.AuthorityKeyID = strings.TrimPrefix(next(i), "keyid:")
+		case key == "X509v3 Extended Key Usage":
+			usages := next(i)
+			if strings.Contains(usages, "TLS Web Server Authentication") {
+				c.ExtKeyUsage = append(c.ExtKeyUsage, "server auth")
+			}
+			if strings.Contains(usages, "TLS Web Client Authentication") {
+				c.ExtKeyUsage = append(c.ExtKeyUsage, "client auth")
+			}
+		}
+	}
+
+	if c.Subject == "" || c.NotAfter.IsZero() {
+		return c, false
+	}
+	return c, true
+}
+
+// parseOpensslTime parses openssl validity times like "Nov 20 00:44:27 2025 GMT" or "Dec  4 ..."
+func parseOpensslTime(value string) time.Time {
+	t, err := time.Parse("Jan 2 15:04:05 2006 MST", strings.Join(strings.Fields(value), " "))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// checkCertificateChains sets each certificate's chain result against the CA in the bundle
+// with its issuer's name. Self-signed CAs are roots.
+func checkCertificateChains(certs []Certificate) {
+	for i := range certs {
+		c := &certs[i]
+		if c.IsCA && c.Issuer == c.Subject {
+			c.Chain = CertChainRoot
+			c.ChainDetail = "self-signed CA"
+			continue
+		}
+
+		var ca *Certificate
+		for j := range certs {
+			if j != i && certs[j].IsCA && certs[j].Subject == c.Issuer {
+				ca = &certs[j]
+				break
+			}
+		}
+		if ca == nil {
+			// RKE2 names CAs "<name>@<unix time>": the same name with another timestamp
+			// means the CA was regenerated after this certificate was issued
+			if name, _, ok := strings.Cut(c.IssuerCN, "@"); ok {
+				for j := range certs {
+					if certs[j].IsCA && strings.HasPrefix(certs[j].CommonName, name+"@") {
+						c.Chain = CertChainInvalid
+						c.ChainCA = certs[j].CertificateName()
+						c.ChainDetail = fmt.Sprintf("issued by %s but %s is now %s: CA was regenerated, certificate not reissued",
+							c.IssuerCN, c.ChainCA, certs[j].CommonName)
+						break
+					}
+				}
+				if c.Chain != "" {
+					continue
+				}
+			}
+			c.Chain = CertChainUnknownCA
+			c.ChainDetail = fmt.Sprintf("issuer %s was not collected in the bundle", c.IssuerCN)
+			continue
+		}
+		c.ChainCA = ca.CertificateName()
+
+		switch {
+		case c.cert != nil && ca.cert != nil:
+			if err := c.cert.CheckSignatureFrom(ca.cert); err != nil {
+				c.Chain = CertChainInvalid
+				c.ChainDetail = fmt.Sprintf("signature does not verify against %s: %v", c.ChainCA, err)
+			} else {
+				c.Chain = CertChainValid
+				c.ChainDetail = "signature verified against " + c.ChainCA
+			}
+		case c.AuthorityKeyID != "" && ca.SubjectKeyID != "" && c.AuthorityKeyID != ca.SubjectKeyID:
+			c.Chain = CertChainInvalid
+			c.ChainDetail = fmt.Sprintf("authority key %s does not match %s key %s: signed by a different (rotated?) CA with the same name",
+				c.AuthorityKeyID, c.ChainCA, ca.SubjectKeyID)
+		default:
+			c.Chain = CertChainIssuerMatch
+			c.ChainDetail = fmt.Sprintf("issuer and key identifier match %s (signature not verifiable from text dump)", c.ChainCA)
+		}
+	}
+}
+
+// Certificate error kinds from x509 log messages (see CertificateError.Kind)
+const (
+	CertErrorExpired          = "expired"           // certificate has expired or is not yet valid
+	CertErrorUnknownAuthority = "unknown-authority" // certificate signed by unknown authority
+	CertErrorHostname         = "hostname"          // certificate is valid for X, not Y
+	CertErrorOther            = "other"
+)
+
+// CertificateError is an x509 error parsed from a log line
+type CertificateError struct {
+	Kind      string   // CertError* constant
+	ValidFor  []string // Names the certificate is valid for (hostname errors)
+	Requested string   // Name that was requested (hostname errors)
+}
+
+// ParseCertificateError classifies an x509 log message. Returns false if the line has none.
+func ParseCertificateError(line string) (CertificateError, bool) {
+	if !CertificateErrorPattern.MatchString(line) {
+		return CertificateError{}, false
+	}
+	switch {
+	case strings.Contains(line, "certificate has expired") || strings.Contains(line, "not yet valid"):
+		return CertificateError{Kind: CertErrorExpired}, true
+	case strings.Contains(line, "unknown authority") || strings.Contains(line, "verification error"):
+		return CertificateError{Kind: CertErrorUnknownAuthority}, true
+	}
+	if m := certValidForRe.FindStringSubmatch(line); m != nil {
+		e := CertificateError{Kind: CertErrorHostname, Requested: m[2]}
+		for _, name := range strings.Split(m[1], ",") {
+			e.ValidFor = append(e.ValidFor, strings.TrimSpace(name))
+		}
+		return e, true
+	}
+	return CertificateError{Kind: CertErrorOther}, true
+}
+
+// MatchesError reports whether a certificate is a likely cause of an x509 error at time at:
+// expired errors match expired or not-yet-valid certificates, unknown-authority errors
+// match broken chains, and hostname errors match the certificate whose SANs are listed
+func (c Certificate) MatchesError(e CertificateError, at time.Time) bool {
+	switch e.Kind {
+	case CertErrorExpired:
+		status := c.ExpiryStatus(at)
+		return status == CertExpired || status == CertNotYetValid
+	case CertErrorUnknownAuthority:
+		return c.Chain == CertChainInvalid
+	case CertErrorHostname:
+		names := append(append([]string{}, c.DNSNames...), c.IPAddresses...)
+		for _, name := range e.ValidFor {
+			if !contains(names, name) {
+				return false
+			}
+		}
+		return len(e.ValidFor) > 0
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package bundle
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+)
+
+// testCert creates a PEM certificate signed by parent (self-signed if parent is nil)
+func testCert(t *testing.T, cn string, isCA bool, notBefore, notAfter time.Time, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) ([]byte, *x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		DNSNames:              []string{"kubernetes", "localhost"},
+		IPAddresses:           []net.IP{net.ParseIP("10.43.0.1")},
+	}
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), cert, key
+}
+
+// TestParseCertificates tests PEM chain verification, regenerated CAs, and expiry states
+func TestParseCertificates(t *testing.T) {
+	root := t.TempDir()
+	collected := time.Date(2025, 12, 4, 9, 15, 57, 0, time.UTC)
+	year := 365 * 24 * time.Hour
+
+	caPEM, ca, caKey := testCert(t, "rke2-server-ca@1763599467", true, collected.Add(-year), collected.Add(10*year), nil, nil)
+	_, otherCA, otherKey := testCert(t, "rke2-server-ca@1700000000", true, collected.Add(-2*year), collected.Add(10*year), nil, nil)
+	apiserver, _, _ := testCert(t, "kube-apiserver", false, collected.Add(-year), collected.Add(year), ca, caKey)
+	expired, _, _ := testCert(t, "kube-scheduler", false, collected.Add(-2*year), collected.Add(-time.Hour), ca, caKey)
+	future, _, _ := testCert(t, "kube-proxy", false, collected.Add(2*time.Hour), collected.Add(year), ca, caKey)
+	stale, _, _ := testCert(t, "kubelet", false, collected.Add(-year), collected.Add(20*24*time.Hour), otherCA, otherKey)
+
+	writeBundleFile(t, root, "rke2/certs/server/server-ca.crt", string(caPEM))
+	writeBundleFile(t, root, "rke2/certs/server/serving-kube-apiserver.crt", string(apiserver))
+	writeBundleFile(t, root, "rke2/certs/server/client-scheduler.crt", string(expired))
+	writeBundleFile(t, root, "rke2/certs/agent/client-kube-proxy.crt", string(future))
+	writeBundleFile(t, root, "rke2/certs/agent/serving-kubelet.crt", string(stale))
+	writeBundleFile(t, root, "rke2/certs/server/client-auth-proxy.crt", `Certificate:
+    Data:
+        Version: 3 (0x2)
+        Serial Number: 610720432793877694 (0x879b6ff75c07cbe)
+        Issuer: CN = rke2-request-header-ca@1763599467
+        Validity
+            Not Before: Nov 20 00:44:27 2025 GMT
+            Not After : Dec  9 00:44:27 2025 GMT
+        Subject: O = system:masters, CN = system:auth-proxy
+        X509v3 extensions:
+            X509v3 Extended Key Usage:
+                TLS Web Client Authentication
+            X509v3 Authority Key Identifier:
+                5D:05:0E:97:4D:C5:6B:02:2E:C7:74:6A:73:59:D0:C2:D4:DA:FF:CB
+`)
+
+	certs, err := ParseCertificates(root)
+	if err != nil {
+		t.Fatalf("ParseCertificates() error = %v", err)
+	}
+	byName := make(map[string]Certificate)
+	for _, c := range certs {
+		byName[c.CertificateName()] = c
+	}
+	if len(byName) != 6 {
+		t.Fatalf("expected 6 certificates, got %d", len(byName))
+	}
+
+	tests := []struct {
+		name   string
+		chain  string
+		expiry string
+	}{
+		{"server-ca", CertChainRoot, CertValid},
+		{"serving-kube-apiserver", CertChainValid, CertValid},
+		{"client-scheduler", CertChainValid, CertExpired},
+		{"client-kube-proxy", CertChainValid, CertNotYetValid},
+		{"serving-kubelet", CertChainInvalid, CertExpiring},
+		{"client-auth-proxy", CertChainUnknownCA, CertExpiring},
+	}
+	for _, tt := range tests {
+		c := byName[tt.name]
+		if c.Chain != tt.chain {
+			t.Errorf("%s: chain = %s (%s), want %s", tt.name, c.Chain, c.ChainDetail, tt.chain)
+		}
+		if got := c.ExpiryStatus(collected); got != tt.expiry {
+			t.Errorf("%s: expiry = %s, want %s", tt.name, got, tt.expiry)
+		}
+	}
+
+	if !byName["client-auth-proxy"].IsCritical(collected) || byName["serving-kubelet"].IsCritical(collected) {
+		t.Errorf("expiring within 7 days should be critical, within 30 days not")
+	}
+	apiCert := byName["serving-kube-apiserver"]
+	if len(apiCert.DNSNames) != 2 || len(apiCert.IPAddresses) != 1 || apiCert.IPAddresses[0] != "10.43.0.1" {
+		t.Errorf("apiserver SANs: %v %v", apiCert.DNSNames, apiCert.IPAddresses)
+	}
+	proxy := byName["client-auth-proxy"]
+	if proxy.Format != CertFormatText || proxy.Subject != "O=system:masters, CN=system:auth-proxy" || proxy.CommonName != "system:auth-proxy" ||
+		proxy.SerialNumber != "610720432793877694" || len(proxy.ExtKeyUsage) != 1 {
+		t.Errorf("text dump parsed as %+v", proxy)
+	}
+}
+
+// TestParseCertificateError tests x509 log message classification and certificate matching
+func TestParseCertificateError(t *testing.T) {
+	collected := time.Date(2025, 12, 4, 9, 15, 57, 0, time.UTC)
+	expired := Certificate{NotBefore: collected.Add(-48 * time.Hour), NotAfter: collected.Add(-time.Hour), Chain: CertChainIssuerMatch}
+	serving := Certificate{NotBefore: collected.Add(-48 * time.Hour), NotAfter: collected.Add(8760 * time.Hour),
+		DNSNames: []string{"kubernetes", "localhost"}, IPAddresses: []string{"127.0.0.1"}, Chain: CertChainInvalid}
+
+	tests := []struct {
+		line       string
+		kind       string
+		expiredHit bool
+		servingHit bool
+	}{
+		{`E1204 09:10:01 reflector.go: x509: certificate has expired or is not yet valid: current time 2025-12-04T09:10:01Z is after 2025-12-04T08:15:57Z`, CertErrorExpired, true, false},
+		{`tls: failed to verify certificate: x509: certificate signed by unknown authority`, CertErrorUnknownAuthority, false, true},
+		{`Get "https://10.0.0.5:6443": x509: certificate is valid for kubernetes, localhost, 127.0.0.1, not 10.0.0.5`, CertErrorHostname, false, true},
+		{`x509: cannot validate certificate for 10.0.0.5 because it doesn't contain any IP SANs`, CertErrorOther, false, false},
+	}
+	for _, tt := range tests {
+		e, ok := ParseCertificateError(tt.line)
+		if !ok || e.Kind != tt.kind {
+			t.Errorf("ParseCertificateError(%q) = %+v, %v; want kind %s", tt.line, e, ok, tt.kind)
+			continue
+		}
+		if got := expired.MatchesError(e, collected); got != tt.expiredHit {
+			t.Errorf("%s: expired cert match = %v, want %v", tt.kind, got, tt.expiredHit)
+		}
+		if got := serving.MatchesError(e, collected); got != tt.servingHit {
+			t.Errorf("%s: serving cert match = %v, want %v", tt.kind, got, tt.servingHit)
+		}
+	}
+
+	if _, ok := ParseCertificateError("connection refused"); ok {
+		t.Errorf("line without x509 error should not parse")
+	}
+}
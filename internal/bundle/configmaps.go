@@ -0,0 +1,84 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// ParseConfigMaps parses kubectl get configmaps output from bundle
+// Format: NAMESPACE NAME DATA AGE (table output - names and key counts only),
+// or a full -o yaml / -o json list with the data
+func ParseConfigMaps(extractPath string) ([]rancher.ConfigMap, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	path := filepath.Join(bundleRoot, "rke2/kubectl/configmaps")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var configMaps []rancher.ConfigMap
+
+	if isStructuredOutput(content) {
+		// data is decoded as a node to keep the keys in file order
+		var list struct {
+			Items []struct {
+				Metadata struct {
+					Name      string `yaml:"name"`
+					Namespace string `yaml:"namespace"`
+				} `yaml:"metadata"`
+				Data       yaml.Node `yaml:"data"`
+				BinaryData yaml.Node `yaml:"binaryData"`
+			} `yaml:"items"`
+		}
+		if err := yaml.Unmarshal(content, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse configmaps: %w", err)
+		}
+
+		for _, item := range list.Items {
+			cm := rancher.ConfigMap{
+				Namespace: item.Metadata.Namespace,
+				Name:      item.Metadata.Name,
+				Data:      make(map[string]string),
+			}
+
+			for i := 0; i+1 < len(item.Data.Content); i += 2 {
+				key := item.Data.Content[i].Value
+				cm.Keys = append(cm.Keys, key)
+				cm.Data[key] = item.Data.Content[i+1].Value
+			}
+			for i := 0; i+1 < len(item.BinaryData.Content); i += 2 {
+				key := item.BinaryData.Content[i].Value
+				cm.Keys = append(cm.Keys, key)
+				cm.Data[key] = fmt.Sprintf("(binary data, %d bytes base64)", len(item.BinaryData.Content[i+1].Value))
+			}
+			cm.DataCount = len(cm.Keys)
+
+			configMaps = append(configMaps, cm)
+		}
+		return configMaps, nil
+	}
+
+	table := ParseKubectlTable(content)
+	for _, row := range table.Rows {
+		name := table.Value(row, "NAME")
+		if name == "" {
+			continue
+		}
+
+		cm := rancher.ConfigMap{
+			Namespace: table.Value(row, "NAMESPACE"),
+			Name:      name,
+			Age:       table.Value(row, "AGE"),
+		}
+		fmt.Sscanf(table.Value(row, "DATA"), "%d", &cm.DataCount)
+
+		configMaps = append(configMaps, cm)
+	}
+
+	return configMaps, nil
+}
@@ -0,0 +1,70 @@
+package bundle
+
+import "testing"
+
+// TestParseConfigMaps_YAML tests that keys keep their file order and multi-line values survive
+func TestParseConfigMaps_YAML(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "configmaps", `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: rke2-coredns-rke2-coredns
+    namespace: kube-system
+  data:
+    Corefile: |-
+      .:53 {
+          errors
+          forward . /etc/resolv.conf
+      }
+    NodeHosts: 10.0.0.1 node-1
+  binaryData:
+    logo.png: iVBORw0KGgo=
+`)
+
+	configMaps, err := ParseConfigMaps(root)
+	if err != nil {
+		t.Fatalf("ParseConfigMaps() error = %v", err)
+	}
+	if len(configMaps) != 1 {
+		t.Fatalf("expected 1 configmap, got %d", len(configMaps))
+	}
+
+	cm := configMaps[0]
+	wantKeys := []string{"Corefile", "NodeHosts", "logo.png"}
+	if len(cm.Keys) != len(wantKeys) || cm.DataCount != len(wantKeys) {
+		t.Fatalf("keys = %v (count %d), want %v", cm.Keys, cm.DataCount, wantKeys)
+	}
+	for i, key := range wantKeys {
+		if cm.Keys[i] != key {
+			t.Errorf("key %d = %q, want %q", i, cm.Keys[i], key)
+		}
+	}
+
+	wantCorefile := ".:53 {\n    errors\n    forward . /etc/resolv.conf\n}"
+	if cm.Data["Corefile"] != wantCorefile {
+		t.Errorf("Corefile = %q, want %q", cm.Data["Corefile"], wantCorefile)
+	}
+}
+
+// TestParseConfigMaps_Table tests table output, which has key counts but no data
+func TestParseConfigMaps_Table(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "configmaps", `NAMESPACE     NAME                        DATA   AGE
+kube-system   rke2-coredns-rke2-coredns   2      14d
+kube-system   kube-root-ca.crt            1      14d
+`)
+
+	configMaps, err := ParseConfigMaps(root)
+	if err != nil {
+		t.Fatalf("ParseConfigMaps() error = %v", err)
+	}
+	if len(configMaps) != 2 {
+		t.Fatalf("expected 2 configmaps, got %d", len(configMaps))
+	}
+	if cm := configMaps[0]; cm.Name != "rke2-coredns-rke2-coredns" || cm.DataCount != 2 || cm.Data != nil {
+		t.Errorf("unexpected configmap: %+v", cm)
+	}
+}
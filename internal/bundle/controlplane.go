@@ -0,0 +1,213 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// Control plane finding severities (see ControlPlaneFinding.Severity)
+const (
+	ControlPlaneDown    = "down"    // Static pod container not running
+	ControlPlaneRisky   = "risky"   // Weakens security
+	ControlPlaneNotable = "notable" // Differs from RKE2 defaults; worth knowing when debugging
+)
+
+// StaticPod is a control plane component from rke2/pod-manifests, with its container from crictl
+type StaticPod struct {
+	Component      string // metadata.labels.component, else the file name
+	File           string
+	PodName        string // <name>-<node>, as the kubelet names mirror pods
+	Namespace      string
+	Container      string
+	Image          string
+	Command        []string
+	Flags          []ControlPlaneFlag // In manifest order
+	ContainerState string             // From crictl ps -a; empty if not listed
+	Attempt        int
+	Logs           bool // rke2/podlogs has the pod's log
+	PreviousLogs   bool
+}
+
+// ControlPlaneFlag is a command-line flag: "--name=value", or "--name" with Value "true"
+type ControlPlaneFlag struct {
+	Name  string
+	Value string
+}
+
+// ControlPlaneFinding is a risky or notable control plane setting
+type ControlPlaneFinding struct {
+	Component string
+	Flag      string
+	Severity  string
+	Detail    string
+}
+
+// ControlPlane is the node's static pods from rke2/pod-manifests
+type ControlPlane struct {
+	NodeName string
+	Pods     []StaticPod
+}
+
+// staticPodManifest is the part of a static pod manifest the inspector reads
+type staticPodManifest struct {
+	Metadata struct {
+		Name      string            `yaml:"name"`
+		Namespace string            `yaml:"namespace"`
+		Labels    map[string]string `yaml:"labels"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Containers []struct {
+			Name    string   `yaml:"name"`
+			Image   string   `yaml:"image"`
+			Command []string `yaml:"command"`
+			Args    []string `yaml:"args"`
+		} `yaml:"containers"`
+	} `yaml:"spec"`
+}
+
+// ParseControlPlane parses rke2/pod-manifests/*.yaml into control plane components, matched to
+// their crictl container and pod logs. An error is returned only when the directory is missing.
+func ParseControlPlane(extractPath string) (*ControlPlane, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	dir := filepath.Join(bundleRoot, "rke2/pod-manifests")
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.yaml"))
+	sort.Strings(files)
+
+	cp := &ControlPlane{NodeName: extractNodeName(extractPath)}
+	var containers []rancher.RuntimeContainer
+	if content, err := os.ReadFile(filepath.Join(bundleRoot, "rke2/crictl/psa")); err == nil {
+		containers = parseCrictlContainers(content)
+	}
+
+	for _, path := range files {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var manifest staticPodManifest
+		if err := yaml.Unmarshal(content, &manifest); err != nil || len(manifest.Spec.Containers) == 0 {
+			continue
+		}
+		c := manifest.Spec.Containers[0]
+		pod := StaticPod{
+			Component: manifest.Metadata.Labels["component"],
+			File:      filepath.Base(path),
+			PodName:   manifest.Metadata.Name + "-" + cp.NodeName,
+			Namespace: manifest.Metadata.Namespace,
+			Container: c.Name,
+			Image:     c.Image,
+			Command:   c.Command,
+		}
+		if pod.Component == "" {
+			pod.Component = strings.TrimSuffix(pod.File, ".yaml")
+		}
+		if pod.Namespace == "" {
+			pod.Namespace = "kube-system"
+		}
+		// kubeadm-style manifests put flags after the binary in command; RKE2 uses args
+		var argv []string
+		if len(c.Command) > 1 {
+			argv = append(argv, c.Command[1:]...)
+		}
+		pod.Flags = parseControlPlaneFlags(append(argv, c.Args...))
+
+		// A restarted static pod leaves exited containers behind: prefer the latest attempt
+		for _, rc := range containers {
+			if rc.PodName == pod.PodName && rc.Name == pod.Container && (pod.ContainerState == "" || rc.Attempt > pod.Attempt) {
+				pod.ContainerState, pod.Attempt = rc.State, rc.Attempt
+			}
+		}
+		logDir := filepath.Join(bundleRoot, "rke2/podlogs", pod.Namespace+"-"+pod.PodName)
+		_, err = os.Stat(logDir)
+		pod.Logs = err == nil
+		_, err = os.Stat(logDir + "-previous")
+		pod.PreviousLogs = err == nil
+
+		cp.Pods = append(cp.Pods, pod)
+	}
+	return cp, nil
+}
+
+// parseControlPlaneFlags parses "--name=value", "--name value" and bare "--name" arguments
+func parseControlPlaneFlags(argv []string) []ControlPlaneFlag {
+	var flags []ControlPlaneFlag
+	for i := 0; i < len(argv); i++ {
+		arg := argv[i]
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !ok {
+			value = "true"
+			if i+1 < len(argv) && !strings.HasPrefix(argv[i+1], "-") {
+				value = argv[i+1]
+				i++
+			}
+		}
+		flags = append(flags, ControlPlaneFlag{Name: name, Value: value})
+	}
+	return flags
+}
+
+// Flag returns a flag's value and whether it is set
+func (p *StaticPod) Flag(name string) (string, bool) {
+	for _, f := range p.Flags {
+		if f.Name == name {
+			return f.Value, true
+		}
+	}
+	return "", false
+}
+
+// Findings flags components that are not running, audit logging off, anonymous auth on,
+// no encryption-provider-config, profiling on, and non-default pod/service CIDRs
+func (cp *ControlPlane) Findings() []ControlPlaneFinding {
+	var findings []ControlPlaneFinding
+	add := func(p StaticPod, flag, severity, detail string) {
+		findings = append(findings, ControlPlaneFinding{Component: p.Component, Flag: flag, Severity: severity, Detail: detail})
+	}
+
+	for _, p := range cp.Pods {
+		if p.ContainerState != "" && !strings.EqualFold(p.ContainerState, "Running") {
+			add(p, "", ControlPlaneDown, fmt.Sprintf("%s container is %s (attempt %d); see the %s logs", p.Container, p.ContainerState, p.Attempt, p.PodName))
+		}
+
+		switch p.Component {
+		case "kube-apiserver":
+			if path, ok := p.Flag("audit-log-path"); !ok || path == "" {
+				add(p, "audit-log-path", ControlPlaneRisky, "audit logging is off: API requests are not recorded (enable with the RKE2 CIS profile or audit-policy-file)")
+			}
+			if value, ok := p.Flag("anonymous-auth"); !ok || value != "false" {
+				add(p, "anonymous-auth", ControlPlaneRisky, "anonymous requests are allowed; unauthenticated users get the system:anonymous identity")
+			}
+			if _, ok := p.Flag("encryption-provider-config"); !ok {
+				add(p, "encryption-provider-config", ControlPlaneRisky, "secrets are stored unencrypted in etcd (RKE2 enables secrets-encryption by default)")
+			}
+			if cidr, ok := p.Flag("service-cluster-ip-range"); ok && cidr != rke2DefaultServiceCIDR {
+				add(p, "service-cluster-ip-range", ControlPlaneNotable, fmt.Sprintf("custom service CIDR %s (RKE2 default %s); the cluster DNS IP and service routes follow it", cidr, rke2DefaultServiceCIDR))
+			}
+		case "kube-controller-manager", "kube-proxy", "cloud-controller-manager":
+			if cidr, ok := p.Flag("cluster-cidr"); ok && cidr != rke2DefaultClusterCIDR {
+				add(p, "cluster-cidr", ControlPlaneNotable, fmt.Sprintf("custom cluster CIDR %s (RKE2 default %s)", cidr, rke2DefaultClusterCIDR))
+			}
+		}
+
+		switch p.Component {
+		case "kube-apiserver", "kube-controller-manager", "kube-scheduler":
+			if value, ok := p.Flag("profiling"); !ok || value != "false" {
+				add(p, "profiling", ControlPlaneRisky, "profiling is on: /debug/pprof exposes runtime internals and can be used to load the component")
+			}
+		}
+	}
+	return findings
+}
@@ -0,0 +1,100 @@
+package bundle
+
+import (
+	"testing"
+)
+
+// TestParseControlPlane tests static pod flag parsing, container/log linking and the findings
+func TestParseControlPlane(t *testing.T) {
+	root := t.TempDir()
+
+	writeBundleFile(t, root, "systeminfo/hostname", "cp1\n")
+	writeBundleFile(t, root, "rke2/pod-manifests/kube-apiserver.yaml", `apiVersion: v1
+kind: Pod
+metadata:
+  labels:
+    component: kube-apiserver
+    tier: control-plane
+  name: kube-apiserver
+  namespace: kube-system
+spec:
+  containers:
+  - args:
+    - --allow-privileged=true
+    - --anonymous-auth=true
+    - --service-cluster-ip-range=10.96.0.0/12
+    - --profiling=false
+    - --enable-admission-plugins=NodeRestriction
+    command:
+    - kube-apiserver
+    image: index.docker.io/rancher/hardened-kubernetes:v1.31.4-rke2r1-build20250115
+    name: kube-apiserver
+`)
+	writeBundleFile(t, root, "rke2/pod-manifests/kube-scheduler.yaml", `apiVersion: v1
+kind: Pod
+metadata:
+  labels:
+    component: kube-scheduler
+  name: kube-scheduler
+  namespace: kube-system
+spec:
+  containers:
+  - command:
+    - kube-scheduler
+    - --bind-address
+    - 127.0.0.1
+    - --leader-elect
+    image: index.docker.io/rancher/hardened-kubernetes:v1.31.4-rke2r1-build20250115
+    name: kube-scheduler
+`)
+	writeBundleFile(t, root, "rke2/crictl/psa", `CONTAINER           IMAGE               CREATED             STATE               NAME                ATTEMPT             POD ID              POD                       NAMESPACE
+1a2b3c4d5e6f7       a1b2c3d4e5f60       2 hours ago         Running             kube-apiserver      1                   9e8d7c6b5a401       kube-apiserver-cp1        kube-system
+0f1e2d3c4b5a6       a1b2c3d4e5f60       3 hours ago         Exited              kube-apiserver      0                   9e8d7c6b5a401       kube-apiserver-cp1        kube-system
+7a8b9c0d1e2f3       a1b2c3d4e5f60       1 minute ago        Exited              kube-scheduler      7                   5a4b3c2d1e0f9       kube-scheduler-cp1        kube-system
+`)
+	writeBundleFile(t, root, "rke2/podlogs/kube-system-kube-scheduler-cp1", "E1204 09:10:00 server.go:167] failed to get leader election lock\n")
+
+	cp, err := ParseControlPlane(root)
+	if err != nil {
+		t.Fatalf("ParseControlPlane() error = %v", err)
+	}
+	if cp.NodeName != "cp1" || len(cp.Pods) != 2 {
+		t.Fatalf("node=%q pods=%+v", cp.NodeName, cp.Pods)
+	}
+
+	api, sched := cp.Pods[0], cp.Pods[1]
+	if api.Component != "kube-apiserver" || api.PodName != "kube-apiserver-cp1" || len(api.Flags) != 5 ||
+		api.ContainerState != "Running" || api.Attempt != 1 || api.Logs {
+		t.Errorf("kube-apiserver parsed as %+v", api)
+	}
+	if v, ok := api.Flag("service-cluster-ip-range"); !ok || v != "10.96.0.0/12" {
+		t.Errorf("service-cluster-ip-range = %q, %v", v, ok)
+	}
+	if v, _ := sched.Flag("bind-address"); v != "127.0.0.1" {
+		t.Errorf("bind-address = %q, want the separate argument", v)
+	}
+	if v, _ := sched.Flag("leader-elect"); v != "true" {
+		t.Errorf("bare leader-elect = %q, want true", v)
+	}
+	if sched.ContainerState != "Exited" || sched.Attempt != 7 || !sched.Logs || sched.PreviousLogs {
+		t.Errorf("kube-scheduler parsed as %+v", sched)
+	}
+
+	want := map[string]string{
+		"kube-apiserver/audit-log-path":             ControlPlaneRisky,
+		"kube-apiserver/anonymous-auth":             ControlPlaneRisky,
+		"kube-apiserver/encryption-provider-config": ControlPlaneRisky,
+		"kube-apiserver/service-cluster-ip-range":   ControlPlaneNotable,
+		"kube-scheduler/":                           ControlPlaneDown,
+		"kube-scheduler/profiling":                  ControlPlaneRisky, // defaults to on
+	}
+	findings := cp.Findings()
+	if len(findings) != len(want) {
+		t.Errorf("got %d findings, want %d: %+v", len(findings), len(want), findings)
+	}
+	for _, f := range findings {
+		if severity := want[f.Component+"/"+f.Flag]; severity != f.Severity {
+			t.Errorf("%s %s = %s, want %q: %s", f.Component, f.Flag, f.Severity, severity, f.Detail)
+		}
+	}
+}
@@ -0,0 +1,376 @@
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// Runtime issue types found by CrossCheckRuntime
+const (
+	RuntimeIssueCrashLoop          = "crashloop"            // Latest attempt Exited after several restarts
+	RuntimeIssueMissingFromAPI     = "missing-from-api"     // Ready sandbox with no pod in kubectl output
+	RuntimeIssueMissingFromRuntime = "missing-from-runtime" // Running pod on this node with no sandbox
+)
+
+// highAttemptThreshold is the restart attempt from which an Exited container counts as crash-looping
+const highAttemptThreshold = 3
+
+// ansiEscapeRe matches terminal control sequences; crictl stats output starts with a screen clear
+var ansiEscapeRe = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+
+// RuntimeInfo is the container runtime state of the node the bundle was collected from
+type RuntimeInfo struct {
+	NodeName       string
+	RuntimeVersion string // e.g. "containerd v2.0.5-k3s2"
+	Conditions     []RuntimeCondition
+	Containers     []rancher.RuntimeContainer
+	Pods           []rancher.RuntimePod
+	Images         []rancher.RuntimeImage
+	ImageFS        *ImageFSInfo // nil if imagefsinfo was not collected
+}
+
+// RuntimeCondition is a CRI runtime condition from crictl info (RuntimeReady, NetworkReady)
+type RuntimeCondition struct {
+	Type    string
+	Status  bool
+	Reason  string
+	Message string
+}
+
+// ImageFSInfo is the image filesystem usage from crictl imagefsinfo
+type ImageFSInfo struct {
+	Mountpoint string
+	UsedBytes  int64
+	InodesUsed int64
+}
+
+// RuntimeIssue is a runtime container or pod that disagrees with the API or is crash-looping
+type RuntimeIssue struct {
+	Type      string // RuntimeIssueCrashLoop, RuntimeIssueMissingFromAPI or RuntimeIssueMissingFromRuntime
+	Namespace string
+	Pod       string
+	Container string // Empty for pod-level issues
+	Attempt   int
+	Detail    string
+}
+
+// ParseRuntime parses the crictl output under rke2/crictl: psa, pods, images, statsa,
+// imagefsinfo, info and version. Missing files are skipped; an error is returned only
+// when the crictl directory does not exist.
+func ParseRuntime(extractPath string) (*RuntimeInfo, error) {
+	crictlDir := filepath.Join(getBundleRoot(extractPath), "rke2/crictl")
+	if _, err := os.Stat(crictlDir); err != nil {
+		return nil, err
+	}
+
+	info := &RuntimeInfo{NodeName: extractNodeName(extractPath)}
+	read := func(name string) []byte {
+		content, _ := os.ReadFile(filepath.Join(crictlDir, name))
+		return content
+	}
+
+	info.Containers = parseCrictlContainers(read("psa"))
+	info.Pods = parseCrictlPods(read("pods"))
+	info.Images = parseCrictlImages(read("images"))
+	applyCrictlStats(info.Containers, read("statsa"))
+	info.ImageFS = parseImageFSInfo(read("imagefsinfo"))
+	info.Conditions = parseRuntimeConditions(read("info"))
+	info.RuntimeVersion = parseRuntimeVersion(read("version"))
+
+	return info, nil
+}
+
+// parseCrictlContainers parses crictl ps -a output
+// Format: CONTAINER IMAGE CREATED STATE NAME ATTEMPT POD ID POD [NAMESPACE]
+// Note: CREATED is "8 days ago"; NAMESPACE was added in crictl 1.26
+func parseCrictlContainers(content []byte) []rancher.RuntimeContainer {
+	if len(content) == 0 {
+		return nil
+	}
+
+	table := ParseKubectlTable(content)
+	var containers []rancher.RuntimeContainer
+	for _, row := range table.Rows {
+		id := table.Value(row, "CONTAINER")
+		if id == "" {
+			continue
+		}
+		c := rancher.RuntimeContainer{
+			ID:        id,
+			Image:     table.Value(row, "IMAGE"),
+			Created:   table.Value(row, "CREATED"),
+			State:     table.Value(row, "STATE"),
+			Name:      table.Value(row, "NAME"),
+			PodID:     table.Value(row, "POD ID"),
+			PodName:   table.Value(row, "POD"),
+			Namespace: table.Value(row, "NAMESPACE"),
+		}
+		c.Attempt, _ = strconv.Atoi(table.Value(row, "ATTEMPT"))
+		containers = append(containers, c)
+	}
+	return containers
+}
+
+// parseCrictlPods parses crictl pods output
+// Format: POD ID CREATED STATE NAME NAMESPACE ATTEMPT RUNTIME
+func parseCrictlPods(content []byte) []rancher.RuntimePod {
+	if len(content) == 0 {
+		return nil
+	}
+
+	table := ParseKubectlTable(content)
+	var pods []rancher.RuntimePod
+	for _, row := range table.Rows {
+		id := table.Value(row, "POD ID")
+		if id == "" {
+			continue
+		}
+		pod := rancher.RuntimePod{
+			ID:        id,
+			Created:   table.Value(row, "CREATED"),
+			State:     table.Value(row, "STATE"),
+			Name:      table.Value(row, "NAME"),
+			Namespace: table.Value(row, "NAMESPACE"),
+			Runtime:   table.Value(row, "RUNTIME"),
+		}
+		pod.Attempt, _ = strconv.Atoi(table.Value(row, "ATTEMPT"))
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+// parseCrictlImages parses crictl images output
+// Format: IMAGE TAG IMAGE ID SIZE
+func parseCrictlImages(content []byte) []rancher.RuntimeImage {
+	if len(content) == 0 {
+		return nil
+	}
+
+	table := ParseKubectlTable(content)
+	var images []rancher.RuntimeImage
+	for _, row := range table.Rows {
+		image := table.Value(row, "IMAGE")
+		if image == "" {
+			continue
+		}
+		images = append(images, rancher.RuntimeImage{
+			Image: image,
+			Tag:   table.Value(row, "TAG"),
+			ID:    table.Value(row, "IMAGE ID"),
+			Size:  table.Value(row, "SIZE"),
+		})
+	}
+	return images
+}
+
+// applyCrictlStats adds CPU and memory from crictl stats -a output to matching containers
+// Format: CONTAINER NAME CPU % MEM DISK INODES [SWAP]
+func applyCrictlStats(containers []rancher.RuntimeContainer, content []byte) {
+	if len(content) == 0 {
+		return
+	}
+
+	table := ParseKubectlTable([]byte(ansiEscapeRe.ReplaceAllString(string(content), "")))
+	for _, row := range table.Rows {
+		id := table.Value(row, "CONTAINER")
+		if id == "" {
+			continue
+		}
+		for i := range containers {
+			// stats and ps may print IDs of different lengths
+			if !strings.HasPrefix(containers[i].ID, id) && !strings.HasPrefix(id, containers[i].ID) {
+				continue
+			}
+			c := &containers[i]
+			c.HasStats = true
+			c.CPUPercent, _ = strconv.ParseFloat(table.Value(row, "CPU %"), 64)
+			c.Memory = table.Value(row, "MEM")
+			c.MemoryBytes = parseHumanSize(c.Memory)
+			break
+		}
+	}
+}
+
+// parseHumanSize converts crictl's decimal sizes ("29.42MB", "45.06kB", "0B") to bytes
+func parseHumanSize(size string) int64 {
+	units := []struct {
+		suffix string
+		factor float64
+	}{
+		{"TB", 1e12}, {"GB", 1e9}, {"MB", 1e6}, {"kB", 1e3}, {"B", 1},
+	}
+	for _, u := range units {
+		if strings.HasSuffix(size, u.suffix) {
+			value, err := strconv.ParseFloat(strings.TrimSuffix(size, u.suffix), 64)
+			if err != nil {
+				return 0
+			}
+			return int64(value * u.factor)
+		}
+	}
+	return 0
+}
+
+// parseImageFSInfo parses crictl imagefsinfo JSON
+func parseImageFSInfo(content []byte) *ImageFSInfo {
+	var doc struct {
+		Status struct {
+			ImageFilesystems []struct {
+				FsID struct {
+					Mountpoint string `json:"mountpoint"`
+				} `json:"fsId"`
+				UsedBytes struct {
+					Value string `json:"value"`
+				} `json:"usedBytes"`
+				InodesUsed struct {
+					Value string `json:"value"`
+				} `json:"inodesUsed"`
+			} `json:"imageFilesystems"`
+		} `json:"status"`
+	}
+	if len(content) == 0 || json.Unmarshal(content, &doc) != nil || len(doc.Status.ImageFilesystems) == 0 {
+		return nil
+	}
+
+	fs := doc.Status.ImageFilesystems[0]
+	info := &ImageFSInfo{Mountpoint: fs.FsID.Mountpoint}
+	info.UsedBytes, _ = strconv.ParseInt(fs.UsedBytes.Value, 10, 64)
+	info.InodesUsed, _ = strconv.ParseInt(fs.InodesUsed.Value, 10, 64)
+	return info
+}
+
+// parseRuntimeConditions parses status.conditions from crictl info JSON
+func parseRuntimeConditions(content []byte) []RuntimeCondition {
+	var doc struct {
+		Status struct {
+			Conditions []struct {
+				Type    string `json:"type"`
+				Status  bool   `json:"status"`
+				Reason  string `json:"reason"`
+				Message string `json:"message"`
+			} `json:"conditions"`
+		} `json:"status"`
+	}
+	if len(content) == 0 || json.Unmarshal(content, &doc) != nil {
+		return nil
+	}
+
+	var conditions []RuntimeCondition
+	for _, c := range doc.Status.Conditions {
+		conditions = append(conditions, RuntimeCondition{Type: c.Type, Status: c.Status, Reason: c.Reason, Message: c.Message})
+	}
+	return conditions
+}
+
+// parseRuntimeVersion parses crictl version output
+// Format: "RuntimeName:  containerd" and "RuntimeVersion:  v2.0.5-k3s2" lines
+func parseRuntimeVersion(content []byte) string {
+	var name, version string
+	for _, line := range strings.Split(string(content), "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "RuntimeName":
+			name = strings.TrimSpace(value)
+		case "RuntimeVersion":
+			version = strings.TrimSpace(value)
+		}
+	}
+	return strings.TrimSpace(name + " " + version)
+}
+
+// CrossCheckRuntime compares the runtime with kubectl pods:
+//   - containers whose latest attempt Exited after highAttemptThreshold restarts in a Ready sandbox
+//   - Ready sandboxes with no pod of that name in the API (kubelet did not clean up, or the API lost it)
+//   - Running pods the API places on this node that have no sandbox (kubelet/API desync)
+//
+// The API-side check needs pods listed with NODE (-o wide) and a known node name.
+func CrossCheckRuntime(info *RuntimeInfo, pods []rancher.Pod) []RuntimeIssue {
+	var issues []RuntimeIssue
+
+	readySandboxes := make(map[string]bool)
+	sandboxes := make(map[string]bool)
+	for _, sandbox := range info.Pods {
+		key := sandbox.Namespace + "/" + sandbox.Name
+		sandboxes[key] = true
+		if sandbox.State == "Ready" {
+			readySandboxes[sandbox.ID] = true
+		}
+	}
+
+	// Latest attempt per container of each sandbox
+	latest := make(map[string]rancher.RuntimeContainer)
+	var order []string
+	for _, c := range info.Containers {
+		key := c.PodID + "/" + c.Name
+		prev, seen := latest[key]
+		if !seen {
+			order = append(order, key)
+		}
+		if !seen || c.Attempt > prev.Attempt {
+			latest[key] = c
+		}
+	}
+	for _, key := range order {
+		c := latest[key]
+		if c.State == "Exited" && c.Attempt >= highAttemptThreshold && readySandboxes[c.PodID] {
+			issues = append(issues, RuntimeIssue{
+				Type:      RuntimeIssueCrashLoop,
+				Namespace: c.Namespace,
+				Pod:       c.PodName,
+				Container: c.Name,
+				Attempt:   c.Attempt,
+				Detail:    fmt.Sprintf("container %s Exited on attempt %d", c.Name, c.Attempt),
+			})
+		}
+	}
+
+	apiPods := make(map[string]bool)
+	onNode := 0
+	for _, pod := range pods {
+		apiPods[pod.NamespaceID+"/"+pod.Name] = true
+		if info.NodeName != "" && pod.NodeName == info.NodeName {
+			onNode++
+		}
+	}
+
+	if len(pods) > 0 {
+		for _, sandbox := range info.Pods {
+			if sandbox.State != "Ready" || apiPods[sandbox.Namespace+"/"+sandbox.Name] {
+				continue
+			}
+			issues = append(issues, RuntimeIssue{
+				Type:      RuntimeIssueMissingFromAPI,
+				Namespace: sandbox.Namespace,
+				Pod:       sandbox.Name,
+				Attempt:   sandbox.Attempt,
+				Detail:    fmt.Sprintf("sandbox %s is Ready in the runtime but the pod is not in the API", sandbox.ID),
+			})
+		}
+	}
+
+	if onNode > 0 && len(info.Pods) > 0 {
+		for _, pod := range pods {
+			if pod.NodeName != info.NodeName || pod.KubectlStatus != "Running" || sandboxes[pod.NamespaceID+"/"+pod.Name] {
+				continue
+			}
+			issues = append(issues, RuntimeIssue{
+				Type:      RuntimeIssueMissingFromRuntime,
+				Namespace: pod.NamespaceID,
+				Pod:       pod.Name,
+				Detail:    fmt.Sprintf("API reports the pod Running on %s but the runtime has no sandbox for it", info.NodeName),
+			})
+		}
+	}
+
+	return issues
+}
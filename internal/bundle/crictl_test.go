@@ -0,0 +1,107 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// writeBundleFile writes a file at a path relative to the bundle root
+func writeBundleFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	full := filepath.Join(root, path)
+	if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+		t.Fatalf("failed to create dir for %s: %v", path, err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+// TestParseRuntime tests crictl ps/pods/stats parsing, including the screen clear before stats
+func TestParseRuntime(t *testing.T) {
+	root := t.TempDir()
+	writeBundleFile(t, root, "systeminfo/hostname", "cp-1\n")
+	writeBundleFile(t, root, "rke2/crictl/psa", `CONTAINER           IMAGE               CREATED             STATE               NAME                ATTEMPT             POD ID              POD                         NAMESPACE
+0349a03bf13fe       eea6040304cff       8 days ago          Running             calico-typha        0                   fae52c7b54c03       calico-typha-7d9f8-x2x4z    calico-system
+4c61457b12a09       180d1ef27ac95       2 weeks ago         Exited              helm                2                   8c3f33d5ea455       helm-install-rke2-calico    kube-system
+`)
+	writeBundleFile(t, root, "rke2/crictl/pods", `POD ID              CREATED             STATE               NAME                        NAMESPACE           ATTEMPT             RUNTIME
+fae52c7b54c03       8 days ago          Ready               calico-typha-7d9f8-x2x4z    calico-system       0                   (default)
+8c3f33d5ea455       2 weeks ago         NotReady            helm-install-rke2-calico    kube-system         0                   (default)
+`)
+	writeBundleFile(t, root, "rke2/crictl/statsa", "\x1b[2J\x1b[HCONTAINER           NAME                CPU %               MEM                 DISK                INODES\n"+
+		"0349a03bf13fe       calico-typha        0.27                29.42MB             45.06kB             14\n")
+	writeBundleFile(t, root, "rke2/crictl/version", "Version:  0.1.0\nRuntimeName:  containerd\nRuntimeVersion:  v2.0.5-k3s2\n")
+	writeBundleFile(t, root, "rke2/crictl/info", `{"status": {"conditions": [{"type": "NetworkReady", "status": false, "reason": "NetworkPluginNotReady", "message": "cni plugin not initialized"}]}}`)
+
+	info, err := ParseRuntime(root)
+	if err != nil {
+		t.Fatalf("ParseRuntime() error = %v", err)
+	}
+	if info.NodeName != "cp-1" || info.RuntimeVersion != "containerd v2.0.5-k3s2" {
+		t.Errorf("node/version = %q/%q", info.NodeName, info.RuntimeVersion)
+	}
+	if len(info.Containers) != 2 || len(info.Pods) != 2 {
+		t.Fatalf("expected 2 containers and 2 pods, got %d and %d", len(info.Containers), len(info.Pods))
+	}
+
+	typha := info.Containers[0]
+	if typha.Created != "8 days ago" || typha.PodName != "calico-typha-7d9f8-x2x4z" || typha.Namespace != "calico-system" {
+		t.Errorf("unexpected container: %+v", typha)
+	}
+	if !typha.HasStats || typha.CPUPercent != 0.27 || typha.MemoryBytes != 29420000 {
+		t.Errorf("stats not applied: %+v", typha)
+	}
+	if info.Containers[1].HasStats || info.Containers[1].Attempt != 2 {
+		t.Errorf("unexpected exited container: %+v", info.Containers[1])
+	}
+	if len(info.Conditions) != 1 || info.Conditions[0].Status || info.Conditions[0].Reason != "NetworkPluginNotReady" {
+		t.Errorf("conditions = %+v", info.Conditions)
+	}
+}
+
+// TestCrossCheckRuntime tests crash-loop detection and runtime/API desync in both directions
+func TestCrossCheckRuntime(t *testing.T) {
+	info := &RuntimeInfo{
+		NodeName: "cp-1",
+		Pods: []rancher.RuntimePod{
+			{ID: "p1", State: "Ready", Name: "web-1", Namespace: "apps"},
+			{ID: "p2", State: "Ready", Name: "orphan-1", Namespace: "apps"},
+			{ID: "p3", State: "NotReady", Name: "job-1", Namespace: "apps"},
+		},
+		Containers: []rancher.RuntimeContainer{
+			{ID: "c1", State: "Exited", Name: "web", Attempt: 4, PodID: "p1", PodName: "web-1", Namespace: "apps"},
+			{ID: "c2", State: "Exited", Name: "web", Attempt: 5, PodID: "p1", PodName: "web-1", Namespace: "apps"},
+			{ID: "c3", State: "Running", Name: "sidecar", Attempt: 6, PodID: "p1", PodName: "web-1", Namespace: "apps"},
+			{ID: "c4", State: "Exited", Name: "job", Attempt: 3, PodID: "p3", PodName: "job-1", Namespace: "apps"},
+		},
+	}
+	pods := []rancher.Pod{
+		{Name: "web-1", NamespaceID: "apps", NodeName: "cp-1", KubectlStatus: "CrashLoopBackOff"},
+		{Name: "job-1", NamespaceID: "apps", NodeName: "cp-1", KubectlStatus: "Completed"},
+		{Name: "api-1", NamespaceID: "apps", NodeName: "cp-1", KubectlStatus: "Running"},
+		{Name: "api-2", NamespaceID: "apps", NodeName: "worker-1", KubectlStatus: "Running"},
+	}
+
+	issues := CrossCheckRuntime(info, pods)
+
+	want := []struct{ typ, pod string }{
+		{RuntimeIssueCrashLoop, "web-1"},
+		{RuntimeIssueMissingFromAPI, "orphan-1"},
+		{RuntimeIssueMissingFromRuntime, "api-1"},
+	}
+	if len(issues) != len(want) {
+		t.Fatalf("expected %d issues, got %d: %+v", len(want), len(issues), issues)
+	}
+	for i, w := range want {
+		if issues[i].Type != w.typ || issues[i].Pod != w.pod {
+			t.Errorf("issue %d = %s %s, want %s %s", i, issues[i].Type, issues[i].Pod, w.typ, w.pod)
+		}
+	}
+	if issues[0].Attempt != 5 {
+		t.Errorf("crashloop should report the latest attempt, got %d", issues[0].Attempt)
+	}
+}
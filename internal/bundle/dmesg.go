@@ -0,0 +1,297 @@
+package bundle
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// Kernel event types (see KernelEvent.Type)
+const (
+	KernelEventOOMKill       = "oom-kill"
+	KernelEventHungTask      = "hung-task"
+	KernelEventIOError       = "io-error"
+	KernelEventConntrackFull = "conntrack-full"
+	KernelEventSegfault      = "segfault"
+)
+
+// OOM kill constraints: a container hitting its memory cgroup limit, or the whole node
+// running out of memory
+const (
+	OOMConstraintMemcg = "memcg limit"
+	OOMConstraintNode  = "node out of memory"
+)
+
+// KernelEvent is a dmesg line (or group of lines, for OOM kills) worth surfacing
+type KernelEvent struct {
+	Type    string
+	Time    time.Time // Wall-clock time; zero if it could not be derived
+	Uptime  float64   // Seconds since boot; -1 for dmesg -T output
+	Process string    // Victim, hung task or faulting process
+	PID     int
+	Device  string // Block device for I/O errors
+	Message string // The dmesg line, without its timestamp
+	Lines   []string
+
+	// OOM kill attribution
+	Constraint  string // OOMConstraintMemcg or OOMConstraintNode
+	Trigger     string // Process that invoked the OOM killer
+	MemcgPath   string // task_memcg cgroup path
+	AnonRSS     int64  // KiB
+	ContainerID string // Full container ID from the cgroup path
+	PodUID      string
+	Namespace   string // From crictl, when the container is still listed
+	Pod         string
+	Container   string
+}
+
+// KernelLog is the bundle node's dmesg with the events found in it
+type KernelLog struct {
+	NodeName string
+	Boot     time.Time // Derived from date and uptime; zero if unknown
+	Lines    int
+	Events   []KernelEvent
+}
+
+var (
+	// Format: "[ 1234.567890] msg" (raw) or "[Thu Nov 20 00:42:53 2025] msg" (dmesg -T)
+	dmesgLineRe   = regexp.MustCompile(`^\[\s*([^\]]+)\]\s?(.*)$`)
+	oomInvokedRe  = regexp.MustCompile(`^(.+?) invoked oom-killer:`)
+	oomKilledRe   = regexp.MustCompile(`(Memory cgroup out of memory|Out of memory): Killed process (\d+) \(([^)]*)\)(?:.*anon-rss:(\d+)kB)?`)
+	hungTaskRe    = regexp.MustCompile(`task (.+):(\d+) blocked for more than \d+ seconds`)
+	segfaultRe    = regexp.MustCompile(`^(.+?)\[(\d+)\]: segfault at`)
+	ioDeviceRe    = regexp.MustCompile(`(?:dev(?:ice)?|XFS) \(?([\w.-]+?)\)?[,:) ]`)
+	podUIDRe      = regexp.MustCompile(`pod([0-9a-f]{8}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{4}[-_][0-9a-f]{12})`)
+	containerIDRe = regexp.MustCompile(`([0-9a-f]{64})(?:\.scope)?$`)
+)
+
+// ParseKernelEvents parses systeminfo/dmesg into OOM kills, hung tasks, disk I/O errors,
+// conntrack table full and segfault events. Raw timestamps are converted to wall-clock time
+// using systeminfo/uptime and systeminfo/date; OOM victims are attributed to pods through
+// their cgroup path and the crictl container list.
+func ParseKernelEvents(extractPath string) (*KernelLog, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	content, err := os.ReadFile(filepath.Join(bundleRoot, "systeminfo", "dmesg"))
+	if err != nil {
+		return nil, err
+	}
+
+	log := &KernelLog{NodeName: extractNodeName(extractPath)}
+	if collected := bundleCollectedAt(extractPath); !collected.IsZero() {
+		if data, err := os.ReadFile(filepath.Join(bundleRoot, "systeminfo", "uptime")); err == nil {
+			uptime, _, _, _ := parseUptime(strings.TrimSpace(string(data)))
+			if d := uptimeDuration(uptime); d > 0 {
+				log.Boot = collected.Add(-d)
+			}
+		}
+	}
+
+	var oom *KernelEvent // OOM kill being assembled from its "invoked oom-killer" line onwards
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		log.Lines++
+
+		stamp, msg := log.parseTimestamp(line)
+		event := KernelEvent{Time: stamp.time, Uptime: stamp.uptime, Message: msg, Lines: []string{line}}
+
+		switch {
+		case strings.Contains(msg, "invoked oom-killer"):
+			event.Type = KernelEventOOMKill
+			if m := oomInvokedRe.FindStringSubmatch(msg); m != nil {
+				event.Trigger = m[1]
+			}
+			oom = &event
+
+		case strings.HasPrefix(msg, "oom-kill:"):
+			// Format: oom-kill:constraint=CONSTRAINT_MEMCG,nodemask=(null),cpuset=...,mems_allowed=0,
+			//   oom_memcg=/kubepods.slice/...,task_memcg=/kubepods.slice/.../cri-containerd-<id>.scope,task=java,pid=1234,uid=0
+			if oom == nil {
+				oom = &event
+				oom.Type = KernelEventOOMKill
+			} else {
+				oom.Lines = append(oom.Lines, line)
+			}
+			for _, field := range strings.Split(strings.TrimPrefix(msg, "oom-kill:"), ",") {
+				key, value, _ := strings.Cut(field, "=")
+				switch key {
+				case "constraint":
+					if value == "CONSTRAINT_MEMCG" {
+						oom.Constraint = OOMConstraintMemcg
+					} else {
+						oom.Constraint = OOMConstraintNode
+					}
+				case "task_memcg":
+					oom.MemcgPath = value
+				case "task":
+					oom.Process = value
+				case "pid":
+					oom.PID, _ = strconv.Atoi(value)
+				}
+			}
+
+		case strings.Contains(msg, "Killed process"):
+			m := oomKilledRe.FindStringSubmatch(msg)
+			if m == nil {
+				continue
+			}
+			if oom == nil {
+				oom = &event
+				oom.Type = KernelEventOOMKill
+			} else {
+				oom.Lines = append(oom.Lines, line)
+			}
+			oom.PID, _ = strconv.Atoi(m[2])
+			oom.Process = m[3]
+			oom.AnonRSS, _ = strconv.ParseInt(m[4], 10, 64)
+			if oom.Constraint == "" {
+				oom.Constraint = OOMConstraintNode
+				if m[1] == "Memory cgroup out of memory" {
+					oom.Constraint = OOMConstraintMemcg
+				}
+			}
+			oom.Message = msg
+			log.Events = append(log.Events, *oom)
+			oom = nil
+
+		case strings.Contains(msg, "blocked for more than"):
+			event.Type = KernelEventHungTask
+			if m := hungTaskRe.FindStringSubmatch(msg); m != nil {
+				event.Process = m[1]
+				event.PID, _ = strconv.Atoi(m[2])
+			}
+			log.Events = append(log.Events, event)
+
+		case strings.Contains(msg, "I/O error") || strings.Contains(msg, "EXT4-fs error"):
+			event.Type = KernelEventIOError
+			if m := ioDeviceRe.FindStringSubmatch(msg); m != nil {
+				event.Device = m[1]
+			}
+			log.Events = append(log.Events, event)
+
+		case strings.Contains(msg, "nf_conntrack: table full"):
+			event.Type = KernelEventConntrackFull
+			log.Events = append(log.Events, event)
+
+		case strings.Contains(msg, "segfault at"):
+			event.Type = KernelEventSegfault
+			if m := segfaultRe.FindStringSubmatch(msg); m != nil {
+				event.Process = m[1]
+				event.PID, _ = strconv.Atoi(m[2])
+			}
+			log.Events = append(log.Events, event)
+
+		default:
+			// Older kernels name the cgroups on their own line:
+			// "Task in /kubepods/burstable/pod<uid>/<id> killed as a result of limit of /kubepods/burstable/pod<uid>"
+			if oom != nil && strings.HasPrefix(msg, "Task in ") {
+				path, _, _ := strings.Cut(strings.TrimPrefix(msg, "Task in "), " ")
+				oom.MemcgPath = path
+				oom.Lines = append(oom.Lines, line)
+			}
+		}
+	}
+
+	var containers []rancher.RuntimeContainer
+	if content, err := os.ReadFile(filepath.Join(bundleRoot, "rke2/crictl/psa")); err == nil {
+		containers = parseCrictlContainers(content)
+	}
+	for i := range log.Events {
+		if log.Events[i].Type == KernelEventOOMKill {
+			attributeOOMKill(&log.Events[i], containers)
+		}
+	}
+
+	return log, nil
+}
+
+// kernelTimestamp is a dmesg line's time since boot and wall-clock time
+type kernelTimestamp struct {
+	uptime float64
+	time   time.Time
+}
+
+// parseTimestamp splits a dmesg line into its timestamp and message. Raw timestamps are
+// seconds since boot; dmesg -T timestamps are already wall-clock.
+func (k *KernelLog) parseTimestamp(line string) (kernelTimestamp, string) {
+	ts := kernelTimestamp{uptime: -1}
+	m := dmesgLineRe.FindStringSubmatch(line)
+	if m == nil {
+		return ts, line
+	}
+	if secs, err := strconv.ParseFloat(strings.TrimSpace(m[1]), 64); err == nil {
+		ts.uptime = secs
+		if !k.Boot.IsZero() {
+			ts.time = k.Boot.Add(time.Duration(secs * float64(time.Second)))
+		}
+		return ts, m[2]
+	}
+	if t, err := time.Parse(time.ANSIC, m[1]); err == nil {
+		ts.time = t
+		return ts, m[2]
+	}
+	return ts, line
+}
+
+// uptimeDuration converts uptime's "14 days, 8:33", "3:02" or "1 day, 45 min" into a duration
+func uptimeDuration(uptime string) time.Duration {
+	var d time.Duration
+	for _, part := range strings.Split(uptime, ",") {
+		fields := strings.Fields(part)
+		switch {
+		case len(fields) == 2 && strings.HasPrefix(fields[1], "day"):
+			days, _ := strconv.Atoi(fields[0])
+			d += time.Duration(days) * 24 * time.Hour
+		case len(fields) == 2 && strings.HasPrefix(fields[1], "min"):
+			mins, _ := strconv.Atoi(fields[0])
+			d += time.Duration(mins) * time.Minute
+		case len(fields) == 1:
+			hours, mins, ok := strings.Cut(fields[0], ":")
+			if !ok {
+				continue
+			}
+			h, _ := strconv.Atoi(hours)
+			m, _ := strconv.Atoi(mins)
+			d += time.Duration(h)*time.Hour + time.Duration(m)*time.Minute
+		}
+	}
+	return d
+}
+
+// attributeOOMKill maps an OOM victim's cgroup path to its container and pod. The container ID
+// is matched against crictl ps -a, whose CONTAINER column is a truncated ID prefix; when the
+// container is no longer listed only the pod UID from the path is kept.
+// Format (systemd driver): /kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod<uid_with_underscores>.slice/cri-containerd-<id>.scope
+// Format (cgroupfs driver): /kubepods/burstable/pod<uid>/<id>
+func attributeOOMKill(event *KernelEvent, containers []rancher.RuntimeContainer) {
+	if event.MemcgPath == "" {
+		return
+	}
+	if m := podUIDRe.FindStringSubmatch(event.MemcgPath); m != nil {
+		event.PodUID = strings.ReplaceAll(m[1], "_", "-")
+	}
+	m := containerIDRe.FindStringSubmatch(event.MemcgPath)
+	if m == nil {
+		return
+	}
+	event.ContainerID = m[1]
+	for _, c := range containers {
+		if c.ID != "" && strings.HasPrefix(event.ContainerID, c.ID) {
+			event.Namespace = c.Namespace
+			event.Pod = c.PodName
+			event.Container = c.Name
+			return
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package bundle
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseKernelEvents tests dmesg event extraction, wall-clock conversion and OOM attribution
+func TestParseKernelEvents(t *testing.T) {
+	root := t.TempDir()
+
+	writeBundleFile(t, root, "systeminfo/date", "Thu Dec  4 09:15:58 UTC 2025\n")
+	writeBundleFile(t, root, "systeminfo/uptime", " 09:15:58 up 2 days,  1:00,  1 user,  load average: 0.95, 0.91, 0.94\n")
+	writeBundleFile(t, root, "rke2/crictl/psa", `CONTAINER           IMAGE               CREATED             STATE               NAME                ATTEMPT             POD ID              POD                       NAMESPACE
+4f2a9c1be7d30       a1b2c3d4e5f60       2 hours ago         Running             rancher             3                   9e8d7c6b5a401       rancher-7d9f8b6c5-xyz12   cattle-system
+`)
+	writeBundleFile(t, root, "systeminfo/dmesg", `[    0.000000] Linux version 5.15.0-113-generic
+[ 3600.100000] java invoked oom-killer: gfp_mask=0xcc0(GFP_KERNEL), order=0, oom_score_adj=999
+[ 3600.100100] oom-kill:constraint=CONSTRAINT_MEMCG,nodemask=(null),cpuset=cri-containerd-4f2a9c1be7d30aa.scope,mems_allowed=0,oom_memcg=/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod0b3c9f4e_1d2a_4c5b_8e7f_112233445566.slice,task_memcg=/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod0b3c9f4e_1d2a_4c5b_8e7f_112233445566.slice/cri-containerd-4f2a9c1be7d30aa1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f6071829.scope,task=java,pid=4242,uid=0
+[ 3600.100200] Memory cgroup out of memory: Killed process 4242 (java) total-vm:4194304kB, anon-rss:1048576kB, file-rss:0kB, shmem-rss:0kB, UID:0 pgtables:2500kB oom_score_adj:999
+[ 7200.000000] etcd invoked oom-killer: gfp_mask=0x140cca(GFP_HIGHUSER_MOVABLE|__GFP_COMP), order=0, oom_score_adj=-997
+[ 7200.000100] Task in /kubepods/besteffort/pod5f6e7d8c-9b0a-4c1d-8e2f-a0b1c2d3e4f5/0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef killed as a result of limit of /kubepods
+[ 7200.000200] Out of memory: Killed process 777 (stress) total-vm:100kB, anon-rss:2048kB, file-rss:0kB
+[ 8000.500000] INFO: task jbd2/vdb1-8:312 blocked for more than 120 seconds.
+[ 8001.000000] blk_update_request: I/O error, dev vdb, sector 2048 op 0x1:(WRITE) flags 0x800 phys_seg 1 prio class 0
+[ 8002.000000] EXT4-fs error (device vdb1): ext4_find_entry:1455: inode #2: comm ls: reading directory lblock 0
+[ 9000.000000] nf_conntrack: nf_conntrack: table full, dropping packet
+[ 9100.000000] calico-node[5150]: segfault at 0 ip 00007f00 sp 00007ffd error 4 in libc.so.6[7f00+195000]
+`)
+
+	log, err := ParseKernelEvents(root)
+	if err != nil {
+		t.Fatalf("ParseKernelEvents() error = %v", err)
+	}
+
+	wantBoot := time.Date(2025, 12, 2, 8, 15, 58, 0, time.UTC)
+	if !log.Boot.Equal(wantBoot) || log.Lines != 12 {
+		t.Errorf("boot = %v (want %v), lines = %d", log.Boot, wantBoot, log.Lines)
+	}
+
+	wantTypes := []string{KernelEventOOMKill, KernelEventOOMKill, KernelEventHungTask, KernelEventIOError,
+		KernelEventIOError, KernelEventConntrackFull, KernelEventSegfault}
+	if len(log.Events) != len(wantTypes) {
+		t.Fatalf("got %d events, want %d: %+v", len(log.Events), len(wantTypes), log.Events)
+	}
+	for i, want := range wantTypes {
+		if log.Events[i].Type != want {
+			t.Errorf("event %d type = %s, want %s", i, log.Events[i].Type, want)
+		}
+	}
+
+	memcg := log.Events[0]
+	if memcg.Constraint != OOMConstraintMemcg || memcg.Process != "java" || memcg.PID != 4242 || memcg.AnonRSS != 1048576 ||
+		memcg.Trigger != "java" || len(memcg.Lines) != 3 {
+		t.Errorf("memcg OOM kill parsed as %+v", memcg)
+	}
+	if memcg.Namespace != "cattle-system" || memcg.Pod != "rancher-7d9f8b6c5-xyz12" || memcg.Container != "rancher" ||
+		memcg.PodUID != "0b3c9f4e-1d2a-4c5b-8e7f-112233445566" {
+		t.Errorf("memcg OOM kill attributed to %s/%s/%s (pod %s)", memcg.Namespace, memcg.Pod, memcg.Container, memcg.PodUID)
+	}
+	if want := wantBoot.Add(time.Hour + 100*time.Millisecond); memcg.Time.Sub(want).Abs() > time.Millisecond {
+		t.Errorf("memcg OOM kill time = %v, want %v", memcg.Time, want)
+	}
+
+	node := log.Events[1]
+	if node.Constraint != OOMConstraintNode || node.Process != "stress" || node.Pod != "" ||
+		node.PodUID != "5f6e7d8c-9b0a-4c1d-8e2f-a0b1c2d3e4f5" || len(node.ContainerID) != 64 {
+		t.Errorf("node OOM kill parsed as %+v", node)
+	}
+
+	if hung := log.Events[2]; hung.Process != "jbd2/vdb1-8" || hung.PID != 312 {
+		t.Errorf("hung task parsed as %+v", hung)
+	}
+	if log.Events[3].Device != "vdb" || log.Events[4].Device != "vdb1" {
+		t.Errorf("I/O error devices = %q, %q", log.Events[3].Device, log.Events[4].Device)
+	}
+	if segv := log.Events[6]; segv.Process != "calico-node" || segv.PID != 5150 {
+		t.Errorf("segfault parsed as %+v", segv)
+	}
+}
+
+// TestParseKernelEventsHumanTimestamps tests dmesg -T output, which is already wall-clock
+func TestParseKernelEventsHumanTimestamps(t *testing.T) {
+	root := t.TempDir()
+	writeBundleFile(t, root, "systeminfo/dmesg", "[Thu Nov 20 00:42:53 2025] Linux version 5.15.0\n[Mon Dec  1 10:00:00 2025] nf_conntrack: table full, dropping packet\n")
+
+	log, err := ParseKernelEvents(root)
+	if err != nil {
+		t.Fatalf("ParseKernelEvents() error = %v", err)
+	}
+	if len(log.Events) != 1 {
+		t.Fatalf("got %d events, want 1", len(log.Events))
+	}
+	e := log.Events[0]
+	if want := time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC); !e.Time.Equal(want) || e.Uptime != -1 {
+		t.Errorf("time = %v uptime = %v, want %v and -1", e.Time, e.Uptime, want)
+	}
+	if e.Message != "nf_conntrack: table full, dropping packet" {
+		t.Errorf("message = %q", e.Message)
+	}
+}
@@ -1,9 +1,13 @@
 package bundle
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // EtcdHealthInfo contains parsed etcd health information
@@ -60,3 +64,607 @@ func ParseEtcdHealth(extractPath string) (*EtcdHealthInfo, error) {
 
 	return health, nil
 }
+
+// Etcd issue types (see EtcdIssue.Type)
+const (
+	EtcdIssueNoLeader      = "no-leader"
+	EtcdIssueLearner       = "learner"
+	EtcdIssueMissingMember = "missing-member"
+	EtcdIssueFragmentation = "fragmentation"
+	EtcdIssueNoSpace       = "nospace-risk"
+	EtcdIssueNoSnapshot    = "no-snapshot"
+)
+
+const (
+	// etcdDefaultQuotaBytes is etcd's --quota-backend-bytes default, used when metrics are missing
+	etcdDefaultQuotaBytes = 2 * 1024 * 1024 * 1024
+
+	// A DB whose free pages exceed this fraction is worth defragmenting, once it is large
+	// enough for the wasted space to matter
+	etcdFragmentationRatio    = 0.5
+	etcdFragmentationMinBytes = 100 * 1000 * 1000
+
+	// DB size relative to quota at which writes are at risk of the NOSPACE alarm
+	etcdQuotaWarnRatio     = 0.8
+	etcdQuotaCriticalRatio = 0.95
+
+	// etcdSnapshotMaxAge is how old the newest snapshot may be before it is not "recent" when
+	// the snapshot schedule's interval is unknown. RKE2 snapshots every 12 hours by default;
+	// otherwise two scheduled intervals are allowed (see SnapshotMaxAge).
+	etcdSnapshotMaxAge = 24 * time.Hour
+)
+
+// EtcdMember is an etcd member from memberlist, with endpoint status and health when collected
+type EtcdMember struct {
+	ID         string
+	Name       string // Empty for members that have not started
+	Status     string // "started" or "unstarted"
+	PeerURLs   []string
+	ClientURLs []string
+	IsLearner  bool
+
+	// From endpointstatus (HasStatus is false if the member's endpoint was not queried)
+	HasStatus        bool
+	Endpoint         string
+	Version          string
+	DBSize           int64
+	DBSizeInUse      int64 // 0 if unknown
+	IsLeader         bool
+	RaftTerm         uint64
+	RaftIndex        uint64
+	RaftAppliedIndex uint64
+	Errors           string
+
+	// From endpointhealth
+	HasHealth   bool
+	Healthy     bool
+	Latency     time.Duration
+	HealthError string
+}
+
+// Fragmentation returns the fraction of the DB file that is free pages (0 if in-use size is unknown)
+func (m EtcdMember) Fragmentation() float64 {
+	if m.DBSize <= 0 || m.DBSizeInUse <= 0 || m.DBSizeInUse > m.DBSize {
+		return 0
+	}
+	return 1 - float64(m.DBSizeInUse)/float64(m.DBSize)
+}
+
+// EtcdSnapshot is an etcd snapshot file on the node
+type EtcdSnapshot struct {
+	Name string
+	Size int64
+	Time time.Time
+}
+
+// EtcdStatus is the etcd cluster as seen from the bundle's node
+type EtcdStatus struct {
+	Members            []EtcdMember
+	EtcdNodes          []string // kubectl nodes with the etcd role
+	QuotaBytes         int64
+	QuotaFromMetrics   bool           // false if QuotaBytes is etcd's default
+	Snapshots          []EtcdSnapshot // Newest first
+	SnapshotsCollected bool
+	SnapshotSchedule   string // Effective etcd-snapshot-schedule-cron from 50-rancher.yaml
+	SnapshotRetention  int
+	SnapshotsDisabled  bool // etcd-disable-snapshots
+	CollectedAt        time.Time
+}
+
+// EtcdIssue is a problem found in the etcd status
+type EtcdIssue struct {
+	Type     string
+	Critical bool
+	Member   string // Member or node name, empty for cluster-wide issues
+	Detail   string
+}
+
+// Leader returns the member reporting itself as leader, or nil
+func (s *EtcdStatus) Leader() *EtcdMember {
+	for i := range s.Members {
+		if s.Members[i].IsLeader {
+			return &s.Members[i]
+		}
+	}
+	return nil
+}
+
+// QuotaRatio returns a member's DB size relative to the backend quota
+func (s *EtcdStatus) QuotaRatio(m EtcdMember) float64 {
+	if s.QuotaBytes <= 0 {
+		return 0
+	}
+	return float64(m.DBSize) / float64(s.QuotaBytes)
+}
+
+// LatestSnapshot returns the newest snapshot, or nil
+func (s *EtcdStatus) LatestSnapshot() *EtcdSnapshot {
+	if len(s.Snapshots) == 0 {
+		return nil
+	}
+	return &s.Snapshots[0]
+}
+
+// SnapshotMaxAge returns how old the newest snapshot may be: two intervals of the snapshot
+// schedule, or etcdSnapshotMaxAge if the schedule's interval cannot be worked out
+func (s *EtcdStatus) SnapshotMaxAge() time.Duration {
+	if interval := cronInterval(s.SnapshotSchedule); interval > 0 {
+		return 2 * interval
+	}
+	return etcdSnapshotMaxAge
+}
+
+// cronInterval returns the interval of a cron schedule that runs every N minutes or hours,
+// or at a fixed list of hours each day. Returns 0 for anything else (day/month/weekday fields).
+// Format: "0 */5 * * *", "*/30 * * * *", "0 0,12 * * *"
+func cronInterval(schedule string) time.Duration {
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 || fields[2] != "*" || fields[3] != "*" || fields[4] != "*" {
+		return 0
+	}
+	every := func(field string, unit time.Duration) time.Duration {
+		switch {
+		case field == "*":
+			return unit
+		case strings.HasPrefix(field, "*/"):
+			if n, err := strconv.Atoi(field[2:]); err == nil && n > 0 {
+				return time.Duration(n) * unit
+			}
+		}
+		return 0
+	}
+	minute, hour := fields[0], fields[1]
+	if hour == "*" {
+		if interval := every(minute, time.Minute); interval > 0 {
+			return interval
+		}
+		return time.Hour / time.Duration(len(strings.Split(minute, ",")))
+	}
+	if interval := every(hour, time.Hour); interval > 0 {
+		return interval
+	}
+	return 24 * time.Hour / time.Duration(len(strings.Split(hour, ",")))
+}
+
+// Issues returns learners, missing members, heavy fragmentation, NOSPACE risk,
+// missing leader and stale snapshots
+func (s *EtcdStatus) Issues() []EtcdIssue {
+	var issues []EtcdIssue
+
+	withStatus := 0
+	for _, m := range s.Members {
+		if m.HasStatus {
+			withStatus++
+		}
+	}
+	if withStatus > 0 && s.Leader() == nil {
+		issues = append(issues, EtcdIssue{
+			Type:     EtcdIssueNoLeader,
+			Critical: true,
+			Detail:   fmt.Sprintf("none of the %d queried endpoints reports itself as leader", withStatus),
+		})
+	}
+
+	for _, m := range s.Members {
+		name := m.Name
+		if name == "" {
+			name = m.ID
+		}
+
+		if m.IsLearner {
+			issues = append(issues, EtcdIssue{
+				Type:   EtcdIssueLearner,
+				Member: name,
+				Detail: fmt.Sprintf("member %s is a non-voting learner; it should be promoted once caught up", name),
+			})
+		}
+		if m.Status == "unstarted" {
+			issues = append(issues, EtcdIssue{
+				Type:   EtcdIssueMissingMember,
+				Member: name,
+				Detail: fmt.Sprintf("member %s (%s) was added but never started", m.ID, strings.Join(m.PeerURLs, ",")),
+			})
+		}
+		if m.Errors != "" {
+			issues = append(issues, EtcdIssue{
+				Type:   EtcdIssueMissingMember,
+				Member: name,
+				Detail: fmt.Sprintf("member %s reports errors: %s", name, m.Errors),
+			})
+		}
+
+		if frag := m.Fragmentation(); frag >= etcdFragmentationRatio && m.DBSize >= etcdFragmentationMinBytes {
+			issues = append(issues, EtcdIssue{
+				Type:   EtcdIssueFragmentation,
+				Member: name,
+				Detail: fmt.Sprintf("%s DB is %s but only %s in use (%.0f%% fragmented); defragment to reclaim space",
+					name, formatBytes(m.DBSize), formatBytes(m.DBSizeInUse), frag*100),
+			})
+		}
+
+		if ratio := s.QuotaRatio(m); ratio >= etcdQuotaWarnRatio {
+			issues = append(issues, EtcdIssue{
+				Type:     EtcdIssueNoSpace,
+				Critical: ratio >= etcdQuotaCriticalRatio,
+				Member:   name,
+				Detail: fmt.Sprintf("%s DB is %s, %.0f%% of the %s quota; writes stop with a NOSPACE alarm at 100%%",
+					name, formatBytes(m.DBSize), ratio*100, formatBytes(s.QuotaBytes)),
+			})
+		}
+	}
+
+	for _, node := range s.EtcdNodes {
+		if s.memberForNode(node) == nil {
+			issues = append(issues, EtcdIssue{
+				Type:   EtcdIssueMissingMember,
+				Member: node,
+				Detail: fmt.Sprintf("node %s has the etcd role but is not in the member list", node),
+			})
+		}
+	}
+
+	if s.SnapshotsCollected {
+		collected := s.CollectedAt
+		if collected.IsZero() {
+			collected = time.Now()
+		}
+		latest := s.LatestSnapshot()
+		switch {
+		case latest == nil && s.SnapshotsDisabled:
+			issues = append(issues, EtcdIssue{
+				Type:   EtcdIssueNoSnapshot,
+				Detail: "no etcd snapshots: etcd-disable-snapshots is set in 50-rancher.yaml",
+			})
+		case latest == nil:
+			issues = append(issues, EtcdIssue{
+				Type:   EtcdIssueNoSnapshot,
+				Detail: fmt.Sprintf("no etcd snapshots in /var/lib/rancher/rke2/server/db/snapshots (schedule %q)", s.SnapshotSchedule),
+			})
+		default:
+			if age := collected.Sub(latest.Time); age > s.SnapshotMaxAge() {
+				issues = append(issues, EtcdIssue{
+					Type:   EtcdIssueNoSnapshot,
+					Detail: fmt.Sprintf("newest snapshot %s is %s old; schedule %q should have taken one within %s", latest.Name, formatKubectlAge(age), s.SnapshotSchedule, formatKubectlAge(s.SnapshotMaxAge())),
+				})
+			}
+		}
+	}
+
+	return issues
+}
+
+// memberForNode returns the member for a node. RKE2 names members <node>-<8 hex chars>.
+func (s *EtcdStatus) memberForNode(node string) *EtcdMember {
+	for i := range s.Members {
+		name := s.Members[i].Name
+		if name == node || strings.HasPrefix(name, node+"-") {
+			return &s.Members[i]
+		}
+	}
+	return nil
+}
+
+// ParseEtcdStatus parses memberlist, endpointstatus, endpointhealth, findserverdbsnapshots
+// and the etcd metrics scrape into the members' status. Returns an error only if the bundle
+// has no etcd directory.
+func ParseEtcdStatus(extractPath string) (*EtcdStatus, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	etcdDir := filepath.Join(bundleRoot, "etcd")
+	if _, err := os.Stat(etcdDir); err != nil {
+		return nil, err
+	}
+
+	status := &EtcdStatus{
+		QuotaBytes:  etcdDefaultQuotaBytes,
+		CollectedAt: bundleCollectedAt(extractPath),
+	}
+
+	if content, err := os.ReadFile(filepath.Join(etcdDir, "memberlist")); err == nil {
+		status.Members = parseEtcdMemberList(content)
+	}
+	if content, err := os.ReadFile(filepath.Join(etcdDir, "endpointstatus")); err == nil {
+		for _, s := range parseEtcdEndpointStatus(content) {
+			status.applyEndpointStatus(s)
+		}
+	}
+	if content, err := os.ReadFile(filepath.Join(etcdDir, "endpointhealth")); err == nil {
+		status.applyEndpointHealth(content)
+	}
+
+	// The metrics scrape is from the local member: quota, exact DB size, and in-use size
+	// when endpoint status (etcdctl < 3.6) does not report it
+	if metrics, _ := filepath.Glob(filepath.Join(etcdDir, "etcd-metrics-*")); len(metrics) > 0 {
+		if content, err := os.ReadFile(metrics[0]); err == nil {
+			families := ParsePrometheusText(content)
+			if quota, ok := metricValue(families, "etcd_server_quota_backend_bytes"); ok && quota > 0 {
+				status.QuotaBytes = int64(quota)
+				status.QuotaFromMetrics = true
+			}
+			if local := status.memberForNode(extractNodeName(extractPath)); local != nil {
+				// Exact bytes, where endpoint status rounds to "50 MB"
+				if total, ok := metricValue(families, "etcd_mvcc_db_total_size_in_bytes"); ok && total > 0 {
+					local.DBSize = int64(total)
+				}
+				if inUse, ok := metricValue(families, "etcd_mvcc_db_total_size_in_use_in_bytes"); ok && local.DBSizeInUse == 0 {
+					local.DBSizeInUse = int64(inUse)
+				}
+			}
+		}
+	}
+
+	config := loadRKE2Config(extractPath)
+	status.SnapshotSchedule, status.SnapshotRetention, status.SnapshotsDisabled = config.SnapshotSchedule, config.SnapshotRetention, config.SnapshotsDisabled
+	if content, err := os.ReadFile(filepath.Join(etcdDir, "findserverdbsnapshots")); err == nil {
+		status.SnapshotsCollected = true
+		status.Snapshots = parseEtcdSnapshots(content, status.CollectedAt)
+	}
+
+	if content, err := os.ReadFile(filepath.Join(bundleRoot, "rke2/kubectl/nodes")); err == nil {
+		table := ParseKubectlTable(content)
+		for _, row := range table.Rows {
+			if contains(strings.Split(table.Value(row, "ROLES"), ","), "etcd") {
+				status.EtcdNodes = append(status.EtcdNodes, table.Value(row, "NAME"))
+			}
+		}
+	}
+
+	return status, nil
+}
+
+// parseEtcdMemberList parses etcdctl member list output
+// Format: ID, STATUS, NAME, PEER ADDRS, CLIENT ADDRS, IS LEARNER (simple or -w table)
+func parseEtcdMemberList(content []byte) []EtcdMember {
+	var members []EtcdMember
+
+	for _, fields := range etcdctlRows(content) {
+		if len(fields) < 6 {
+			continue
+		}
+		// Multiple URLs are comma-separated too; peer URLs come before client URLs
+		urls := fields[3 : len(fields)-1]
+		if len(fields) == 6 {
+			urls = append(strings.Split(fields[3], ","), strings.Split(fields[4], ",")...)
+		}
+		half := len(urls) / 2
+
+		members = append(members, EtcdMember{
+			ID:         fields[0],
+			Status:     fields[1],
+			Name:       fields[2],
+			PeerURLs:   trimAll(urls[:half]),
+			ClientURLs: trimAll(urls[half:]),
+			IsLearner:  fields[len(fields)-1] == "true",
+		})
+	}
+
+	return members
+}
+
+// etcdEndpointStatus is one row of etcdctl endpoint status
+type etcdEndpointStatus struct {
+	Endpoint, ID, Version, Errors         string
+	DBSize, DBSizeInUse                   int64
+	IsLeader, IsLearner                   bool
+	RaftTerm, RaftIndex, RaftAppliedIndex uint64
+}
+
+// parseEtcdEndpointStatus parses etcdctl endpoint status output. The -w table header names
+// the columns; simple output is positional (etcdctl 3.5, or 3.6 with STORAGE VERSION and
+// IN USE after VERSION).
+func parseEtcdEndpointStatus(content []byte) []etcdEndpointStatus {
+	columns := []string{"ENDPOINT", "ID", "VERSION", "DB SIZE", "IS LEADER", "IS LEARNER", "RAFT TERM", "RAFT INDEX", "RAFT APPLIED INDEX", "ERRORS"}
+	columns36 := []string{"ENDPOINT", "ID", "VERSION", "STORAGE VERSION", "DB SIZE", "IN USE", "IS LEADER", "IS LEARNER", "RAFT TERM", "RAFT INDEX", "RAFT APPLIED INDEX", "ERRORS"}
+
+	var statuses []etcdEndpointStatus
+	var header []string
+	for _, fields := range etcdctlRows(content) {
+		if len(fields) > 0 && fields[0] == "ENDPOINT" {
+			header = fields
+			continue
+		}
+
+		cols := header
+		if cols == nil {
+			cols = columns
+			if len(fields) >= len(columns36) {
+				cols = columns36
+			}
+		}
+		value := func(names ...string) string {
+			for i, c := range cols {
+				if contains(names, c) && i < len(fields) {
+					return fields[i]
+				}
+			}
+			return ""
+		}
+		if len(fields) < 4 {
+			continue
+		}
+
+		s := etcdEndpointStatus{
+			Endpoint:    value("ENDPOINT"),
+			ID:          value("ID"),
+			Version:     value("VERSION"),
+			Errors:      value("ERRORS"),
+			DBSize:      parseHumanSize(strings.ReplaceAll(value("DB SIZE"), " ", "")),
+			DBSizeInUse: parseHumanSize(strings.ReplaceAll(value("IN USE", "DB SIZE IN USE"), " ", "")),
+			IsLeader:    value("IS LEADER") == "true",
+			IsLearner:   value("IS LEARNER") == "true",
+		}
+		s.RaftTerm, _ = strconv.ParseUint(value("RAFT TERM"), 10, 64)
+		s.RaftIndex, _ = strconv.ParseUint(value("RAFT INDEX"), 10, 64)
+		s.RaftAppliedIndex, _ = strconv.ParseUint(value("RAFT APPLIED INDEX"), 10, 64)
+		statuses = append(statuses, s)
+	}
+
+	return statuses
+}
+
+// applyEndpointStatus merges an endpoint status row into its member (by ID, else client URL),
+// adding a member if memberlist was not collected
+func (s *EtcdStatus) applyEndpointStatus(es etcdEndpointStatus) {
+	var m *EtcdMember
+	for i := range s.Members {
+		if s.Members[i].ID == es.ID || contains(s.Members[i].ClientURLs, es.Endpoint) {
+			m = &s.Members[i]
+			break
+		}
+	}
+	if m == nil {
+		s.Members = append(s.Members, EtcdMember{ID: es.ID, Status: "started", ClientURLs: []string{es.Endpoint}, IsLearner: es.IsLearner})
+		m = &s.Members[len(s.Members)-1]
+	}
+
+	m.HasStatus = true
+	m.Endpoint = es.Endpoint
+	m.Version = es.Version
+	m.DBSize = es.DBSize
+	m.DBSizeInUse = es.DBSizeInUse
+	m.IsLeader = es.IsLeader
+	m.RaftTerm = es.RaftTerm
+	m.RaftIndex = es.RaftIndex
+	m.RaftAppliedIndex = es.RaftAppliedIndex
+	m.Errors = es.Errors
+}
+
+// applyEndpointHealth parses etcdctl endpoint health output into members by client URL
+// Format: "<endpoint> is healthy: successfully committed proposal: took = 7.9ms" or
+// "<endpoint> is unhealthy: failed to commit proposal: <error>" (or -w table)
+func (s *EtcdStatus) applyEndpointHealth(content []byte) {
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+
+		var endpoint, took, errText string
+		healthy := false
+		if strings.HasPrefix(line, "|") {
+			fields := splitEtcdctlTableRow(line)
+			if len(fields) < 3 || fields[0] == "ENDPOINT" {
+				continue
+			}
+			endpoint, healthy, took = fields[0], fields[1] == "true", fields[2]
+			if len(fields) > 3 {
+				errText = fields[3]
+			}
+		} else if ep, rest, found := strings.Cut(line, " is healthy"); found {
+			endpoint, healthy = ep, true
+			if _, t, ok := strings.Cut(rest, "took = "); ok {
+				took = t
+			}
+		} else if ep, rest, found := strings.Cut(line, " is unhealthy"); found {
+			endpoint = ep
+			errText = strings.TrimSpace(strings.TrimPrefix(rest, ":"))
+		} else {
+			continue
+		}
+
+		for i := range s.Members {
+			m := &s.Members[i]
+			if m.Endpoint == endpoint || contains(m.ClientURLs, endpoint) {
+				m.HasHealth = true
+				m.Healthy = healthy
+				m.Latency, _ = time.ParseDuration(strings.TrimSpace(took))
+				m.HealthError = errText
+			}
+		}
+	}
+}
+
+// parseEtcdSnapshots parses `ls -l` of the snapshots directory, newest first. RKE2 snapshot
+// names end in a Unix timestamp; otherwise the ls date is used (with the year of collection).
+func parseEtcdSnapshots(content []byte, collectedAt time.Time) []EtcdSnapshot {
+	if collectedAt.IsZero() {
+		collectedAt = time.Now()
+	}
+
+	var snapshots []EtcdSnapshot
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 9 || !strings.HasPrefix(fields[0], "-") {
+			continue
+		}
+
+		snap := EtcdSnapshot{Name: filepath.Base(fields[len(fields)-1])}
+		snap.Size, _ = strconv.ParseInt(fields[4], 10, 64)
+
+		if i := strings.LastIndex(snap.Name, "-"); i >= 0 {
+			if unix, err := strconv.ParseInt(snap.Name[i+1:], 10, 64); err == nil && unix > 1e9 {
+				snap.Time = time.Unix(unix, 0).UTC()
+			}
+		}
+		if snap.Time.IsZero() {
+			snap.Time = parseLsTime(fields[5], fields[6], fields[7], collectedAt)
+		}
+
+		snapshots = append(snapshots, snap)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Time.After(snapshots[j].Time) })
+	return snapshots
+}
+
+// parseLsTime parses an ls -l date ("Dec  4 05:00" for recent files, "Dec  4 2024" otherwise)
+func parseLsTime(month, day, timeOrYear string, collectedAt time.Time) time.Time {
+	if strings.Contains(timeOrYear, ":") {
+		t, err := time.Parse("Jan 2 15:04 2006", fmt.Sprintf("%s %s %s %d", month, day, timeOrYear, collectedAt.Year()))
+		if err != nil {
+			return time.Time{}
+		}
+		// Dates later than collection are from the previous year
+		if t.After(collectedAt.Add(24 * time.Hour)) {
+			t = t.AddDate(-1, 0, 0)
+		}
+		return t
+	}
+	t, err := time.Parse("Jan 2 2006", fmt.Sprintf("%s %s %s", month, day, timeOrYear))
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// etcdctlRows splits etcdctl simple ("a, b, c") or -w table ("| a | b | c |") output into
+// fields, skipping table borders and blank lines
+func etcdctlRows(content []byte) [][]string {
+	var rows [][]string
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "+") {
+			continue
+		}
+		if strings.HasPrefix(line, "|") {
+			rows = append(rows, splitEtcdctlTableRow(line))
+			continue
+		}
+		rows = append(rows, trimAll(strings.Split(line, ",")))
+	}
+	return rows
+}
+
+// splitEtcdctlTableRow splits "| a | b |" into trimmed cells
+func splitEtcdctlTableRow(line string) []string {
+	return trimAll(strings.Split(strings.Trim(line, "|"), "|"))
+}
+
+// trimAll trims surrounding spaces from every value
+func trimAll(values []string) []string {
+	trimmed := make([]string, len(values))
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+	return trimmed
+}
+
+// formatBytes formats a byte count with binary units, e.g. "2.0 GiB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,77 @@
+package bundle
+
+import (
+	"testing"
+	"time"
+)
+
+// TestParseEtcdStatus tests member/status/health merging and the etcd issue checks
+func TestParseEtcdStatus(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "nodes", `NAME   STATUS   ROLES                       AGE   VERSION
+cp-1   Ready    control-plane,etcd,master   14d   v1.32.7+rke2r1
+cp-2   Ready    control-plane,etcd,master   14d   v1.32.7+rke2r1
+cp-3   Ready    control-plane,etcd,master   2h    v1.32.7+rke2r1
+wk-1   Ready    worker                      14d   v1.32.7+rke2r1
+`)
+	writeBundleFile(t, root, "etcd/memberlist", `1a, started, cp-1-0f3a9c21, https://10.0.0.1:2380, https://10.0.0.1:2379, false
+2b, started, cp-2-77d1e0aa, https://10.0.0.2:2380, https://10.0.0.2:2379, true
+`)
+	writeBundleFile(t, root, "etcd/endpointstatus", `+------------------------+----+---------+---------+-----------+------------+-----------+------------+--------------------+--------+
+|        ENDPOINT        | ID | VERSION | DB SIZE | IS LEADER | IS LEARNER | RAFT TERM | RAFT INDEX | RAFT APPLIED INDEX | ERRORS |
++------------------------+----+---------+---------+-----------+------------+-----------+------------+--------------------+--------+
+| https://10.0.0.1:2379  | 1a |  3.5.21 |  1.9 GB |      true |      false |         4 |     901234 |             901234 |        |
+| https://10.0.0.2:2379  | 2b |  3.5.21 |   20 MB |     false |       true |         4 |     900001 |             900001 |        |
++------------------------+----+---------+---------+-----------+------------+-----------+------------+--------------------+--------+
+`)
+	writeBundleFile(t, root, "etcd/endpointhealth", `https://10.0.0.1:2379 is healthy: successfully committed proposal: took = 12.5ms
+https://10.0.0.2:2379 is unhealthy: failed to commit proposal: context deadline exceeded
+`)
+	writeBundleFile(t, root, "etcd/etcd-metrics-127.0.0.1:2381.txt", `# TYPE etcd_server_quota_backend_bytes gauge
+etcd_server_quota_backend_bytes 2.147483648e+09
+etcd_mvcc_db_total_size_in_use_in_bytes 4e+08
+`)
+	writeBundleFile(t, root, "etcd/findserverdbsnapshots", `-rw------- 1 root root 50425888 Dec  1 05:00 /var/lib/rancher/rke2/server/db/snapshots/etcd-snapshot-cp-1-1764565205
+-rw------- 1 root root 50425888 Nov 30 17:00 /var/lib/rancher/rke2/server/db/snapshots/on-demand-cp-1
+`)
+	writeBundleFile(t, root, "systeminfo/hostname", "cp-1\n")
+
+	status, err := ParseEtcdStatus(root)
+	if err != nil {
+		t.Fatalf("ParseEtcdStatus() error = %v", err)
+	}
+	status.CollectedAt = time.Date(2025, 12, 4, 9, 15, 0, 0, time.UTC)
+
+	if len(status.Members) != 2 || !status.QuotaFromMetrics {
+		t.Fatalf("expected 2 members and metrics quota, got %+v", status)
+	}
+	leader := status.Leader()
+	if leader == nil || leader.Name != "cp-1-0f3a9c21" || leader.DBSize != 1900000000 || leader.DBSizeInUse != 400000000 || leader.Latency != 12500*time.Microsecond {
+		t.Errorf("unexpected leader %+v", leader)
+	}
+	if m := status.Members[1]; !m.HasHealth || m.Healthy || m.HealthError == "" || !m.IsLearner {
+		t.Errorf("unexpected second member %+v", m)
+	}
+	if latest := status.LatestSnapshot(); latest == nil || !latest.Time.Equal(time.Unix(1764565205, 0)) {
+		t.Errorf("unexpected latest snapshot %+v", latest)
+	}
+
+	got := make(map[string]EtcdIssue)
+	for _, issue := range status.Issues() {
+		got[issue.Type] = issue
+	}
+	for _, typ := range []string{EtcdIssueLearner, EtcdIssueMissingMember, EtcdIssueFragmentation, EtcdIssueNoSpace, EtcdIssueNoSnapshot} {
+		if _, ok := got[typ]; !ok {
+			t.Errorf("expected %s issue, got %+v", typ, status.Issues())
+		}
+	}
+	if got[EtcdIssueMissingMember].Member != "cp-3" {
+		t.Errorf("missing member = %q, want cp-3", got[EtcdIssueMissingMember].Member)
+	}
+	if got[EtcdIssueNoSpace].Critical {
+		t.Errorf("88%% of quota should be a warning, not critical")
+	}
+	if _, ok := got[EtcdIssueNoLeader]; ok {
+		t.Errorf("unexpected no-leader issue")
+	}
+}
@@ -0,0 +1,269 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// knownChartWorkloads lists workloads installed by RKE2 add-on charts that are not
+// named after the chart (e.g. the Calico operator deploys into its own namespaces)
+var knownChartWorkloads = map[string][]string{
+	"rke2-calico": {
+		"tigera-operator/tigera-operator",
+		"calico-system/calico-node",
+		"calico-system/calico-kube-controllers",
+		"calico-system/calico-typha",
+	},
+	"rke2-cilium": {
+		"kube-system/cilium",
+		"kube-system/cilium-operator",
+	},
+}
+
+// HelmChartInfo correlates a HelmChart with its helm-install job, the job's pods
+// and the workloads the chart deploys
+type HelmChartInfo struct {
+	Chart        rancher.HelmChart
+	Job          *rancher.Job  // nil if the job is not in the bundle (e.g. already cleaned up)
+	InstallPods  []rancher.Pod // Pods created by the helm-install job
+	Workloads    []WorkloadInfo
+	WorkloadPods []string // Pods belonging to Workloads
+}
+
+// JobFailed reports whether the helm-install job failed or is failing to complete
+func (h *HelmChartInfo) JobFailed() bool {
+	if h.Job != nil {
+		switch h.Job.Status {
+		case "Complete":
+			return false
+		case "Failed":
+			return true
+		}
+		if h.Job.Status == "" && isCompletedCount(h.Job.Completions) {
+			return false
+		}
+	}
+
+	// Running (or unknown) job: failing if its pods are erroring
+	for _, pod := range h.InstallPods {
+		status := strings.ToLower(pod.KubectlStatus)
+		if strings.Contains(status, "error") || strings.Contains(status, "crashloopbackoff") ||
+			strings.Contains(status, "backoff") || strings.Contains(status, "failed") {
+			return true
+		}
+	}
+	return false
+}
+
+// UnhealthyWorkloads returns the chart's workloads that have fewer ready replicas than desired
+func (h *HelmChartInfo) UnhealthyWorkloads() []WorkloadInfo {
+	var unhealthy []WorkloadInfo
+	for _, w := range h.Workloads {
+		if !w.Healthy() {
+			unhealthy = append(unhealthy, w)
+		}
+	}
+	return unhealthy
+}
+
+// Problem returns a short description of what is wrong with the chart, or "" if healthy
+func (h *HelmChartInfo) Problem() string {
+	switch {
+	case h.Chart.Failed:
+		return "install failed"
+	case h.JobFailed():
+		status := "failing"
+		if len(h.InstallPods) > 0 {
+			status = h.InstallPods[len(h.InstallPods)-1].KubectlStatus
+		}
+		return fmt.Sprintf("helm-install job %s", status)
+	}
+
+	if unhealthy := h.UnhealthyWorkloads(); len(unhealthy) > 0 {
+		return fmt.Sprintf("%d/%d workloads not ready", len(unhealthy), len(h.Workloads))
+	}
+	return ""
+}
+
+// ParseHelmCharts parses kubectl get helmcharts output from bundle
+// Format: NAMESPACE NAME REPO CHART VERSION TARGETNAMESPACE BOOTSTRAP FAILED JOB (table output),
+// or a full -o yaml / -o json list
+func ParseHelmCharts(extractPath string) ([]rancher.HelmChart, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	path := filepath.Join(bundleRoot, "rke2/kubectl/helmcharts")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var charts []rancher.HelmChart
+
+	if isStructuredOutput(content) {
+		var list struct {
+			Items []struct {
+				Metadata struct {
+					Name      string `yaml:"name"`
+					Namespace string `yaml:"namespace"`
+				} `yaml:"metadata"`
+				Spec struct {
+					Repo            string `yaml:"repo"`
+					Chart           string `yaml:"chart"`
+					Version         string `yaml:"version"`
+					TargetNamespace string `yaml:"targetNamespace"`
+					Bootstrap       bool   `yaml:"bootstrap"`
+				} `yaml:"spec"`
+				Status struct {
+					JobName    string `yaml:"jobName"`
+					Conditions []struct {
+						Type   string `yaml:"type"`
+						Status string `yaml:"status"`
+					} `yaml:"conditions"`
+				} `yaml:"status"`
+			} `yaml:"items"`
+		}
+		if err := yaml.Unmarshal(content, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse helmcharts: %w", err)
+		}
+
+		for _, item := range list.Items {
+			chart := rancher.HelmChart{
+				Namespace:       item.Metadata.Namespace,
+				Name:            item.Metadata.Name,
+				Repo:            item.Spec.Repo,
+				Chart:           item.Spec.Chart,
+				Version:         item.Spec.Version,
+				TargetNamespace: item.Spec.TargetNamespace,
+				Bootstrap:       item.Spec.Bootstrap,
+				JobName:         item.Status.JobName,
+			}
+			for _, cond := range item.Status.Conditions {
+				if cond.Type == "Failed" && cond.Status == "True" {
+					chart.Failed = true
+				}
+			}
+			charts = append(charts, chart)
+		}
+		return charts, nil
+	}
+
+	table := ParseKubectlTable(content)
+	for _, row := range table.Rows {
+		name := table.Value(row, "NAME")
+		if name == "" {
+			continue
+		}
+
+		charts = append(charts, rancher.HelmChart{
+			Namespace:       table.Value(row, "NAMESPACE"),
+			Name:            name,
+			Repo:            table.Value(row, "REPO"),
+			Chart:           table.Value(row, "CHART"),
+			Version:         table.Value(row, "VERSION"),
+			TargetNamespace: table.Value(row, "TARGETNAMESPACE"),
+			Bootstrap:       strings.EqualFold(table.Value(row, "BOOTSTRAP"), "true"),
+			Failed:          strings.EqualFold(table.Value(row, "FAILED"), "true"),
+			JobName:         table.Value(row, "JOB"),
+		})
+	}
+
+	return charts, nil
+}
+
+// AnalyzeHelmCharts correlates each HelmChart with its helm-install job and pods
+// and with the workloads it deploys
+func AnalyzeHelmCharts(extractPath string) ([]HelmChartInfo, error) {
+	charts, err := ParseHelmCharts(extractPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Missing jobs/pods/workloads files just mean less can be correlated
+	jobs, _ := ParseJobs(extractPath)
+	pods, _ := ParsePods(extractPath)
+	workloads, _ := ParseWorkloads(extractPath)
+
+	jobMap := make(map[string]rancher.Job)
+	for _, job := range jobs {
+		jobMap[job.Namespace+"/"+job.Name] = job
+	}
+
+	infos := make([]HelmChartInfo, len(charts))
+	for i, chart := range charts {
+		if chart.JobName == "" {
+			chart.JobName = "helm-install-" + chart.Name
+		}
+		infos[i].Chart = chart
+
+		if job, ok := jobMap[chart.Namespace+"/"+chart.JobName]; ok {
+			infos[i].Job = &job
+		}
+		for _, pod := range pods {
+			if pod.NamespaceID == chart.Namespace && isGeneratedName(pod.Name, chart.JobName, 0) {
+				infos[i].InstallPods = append(infos[i].InstallPods, pod)
+			}
+		}
+	}
+
+	for _, w := range workloads {
+		idx := chartForWorkload(infos, w)
+		if idx < 0 {
+			continue
+		}
+		infos[idx].Workloads = append(infos[idx].Workloads, w)
+		infos[idx].WorkloadPods = append(infos[idx].WorkloadPods, w.PodsOf(pods)...)
+	}
+
+	return infos, nil
+}
+
+// chartForWorkload returns the index of the chart that deployed a workload, or -1.
+// Workloads are matched by name prefix in the chart's target namespace; the longest
+// chart name wins so "rke2-snapshot-controller-crd" does not claim the controller.
+func chartForWorkload(infos []HelmChartInfo, w WorkloadInfo) int {
+	best := -1
+	for i := range infos {
+		chart := infos[i].Chart
+
+		for _, known := range knownChartWorkloads[chart.Name] {
+			if known == w.Namespace+"/"+w.Name {
+				return i
+			}
+		}
+
+		targetNS := chart.TargetNamespace
+		if targetNS == "" {
+			targetNS = chart.Namespace
+		}
+		if w.Namespace != targetNS {
+			continue
+		}
+		if w.Name == chart.Name || strings.HasPrefix(w.Name, chart.Name+"-") {
+			if best < 0 || len(chart.Name) > len(infos[best].Chart.Name) {
+				best = i
+			}
+		}
+	}
+	return best
+}
+
+// isGeneratedName reports whether name is prefix followed by generated suffixes,
+// e.g. "helm-install-rke2-calico-chl9q" for prefix "helm-install-rke2-calico" but not
+// "helm-install-rke2-calico-crd-jw7s5". extraDashes allows additional generated segments.
+func isGeneratedName(name, prefix string, extraDashes int) bool {
+	if !strings.HasPrefix(name, prefix+"-") {
+		return false
+	}
+	return strings.Count(name[len(prefix)+1:], "-") == extraDashes
+}
+
+// isCompletedCount checks a kubectl COMPLETIONS value like "1/1"
+func isCompletedCount(completions string) bool {
+	parts := strings.Split(completions, "/")
+	return len(parts) == 2 && parts[0] == parts[1]
+}
@@ -0,0 +1,71 @@
+package bundle
+
+import "testing"
+
+// TestAnalyzeHelmCharts_FailedInstall tests correlation of a failing helm-install job
+// with its pods and the workloads of each chart
+func TestAnalyzeHelmCharts_FailedInstall(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "helmcharts", `NAMESPACE     NAME                           REPO   CHART   VERSION   TARGETNAMESPACE   BOOTSTRAP   FAILED   JOB
+kube-system   rke2-canal                                                                true        False    helm-install-rke2-canal
+kube-system   rke2-coredns                                                              true        False    helm-install-rke2-coredns
+kube-system   rke2-snapshot-controller                                                              False    helm-install-rke2-snapshot-controller
+kube-system   rke2-snapshot-controller-crd                                                          False    helm-install-rke2-snapshot-controller-crd
+`)
+	writeKubectlFile(t, root, "jobs", `NAMESPACE     NAME                                        STATUS     COMPLETIONS   DURATION   AGE
+kube-system   helm-install-rke2-canal                     Running    0/1           14d        14d
+kube-system   helm-install-rke2-coredns                   Complete   1/1           18s        14d
+kube-system   helm-install-rke2-snapshot-controller       Complete   1/1           4m21s      14d
+kube-system   helm-install-rke2-snapshot-controller-crd   Complete   1/1           4m20s      14d
+`)
+	writeKubectlFile(t, root, "pods", `NAMESPACE     NAME                                              READY   STATUS      RESTARTS   AGE   IP            NODE     NOMINATED NODE   READINESS GATES
+kube-system   helm-install-rke2-canal-x7k2p                     0/1     Error       5          14d   10.0.0.1      node-1   <none>           <none>
+kube-system   helm-install-rke2-coredns-tbcgq                   0/1     Completed   0          14d   10.0.0.1      node-1   <none>           <none>
+kube-system   rke2-coredns-rke2-coredns-7b4f9d8c6-abcde         1/1     Running     0          14d   10.42.0.5     node-1   <none>           <none>
+kube-system   rke2-snapshot-controller-58dbcfd956-fhv8x         1/1     Running     0          14d   10.42.0.6     node-1   <none>           <none>
+`)
+	writeKubectlFile(t, root, "deployments", `NAMESPACE     NAME                        READY   UP-TO-DATE   AVAILABLE   AGE
+kube-system   rke2-coredns-rke2-coredns   1/2     2            1           14d
+kube-system   rke2-snapshot-controller    1/1     1            1           14d
+`)
+	writeKubectlFile(t, root, "daemonsets", `NAMESPACE     NAME         DESIRED   CURRENT   READY   UP-TO-DATE   AVAILABLE   NODE SELECTOR            AGE
+kube-system   rke2-canal   3         3         0       3            0           kubernetes.io/os=linux   14d
+`)
+
+	infos, err := AnalyzeHelmCharts(root)
+	if err != nil {
+		t.Fatalf("AnalyzeHelmCharts() error = %v", err)
+	}
+	if len(infos) != 4 {
+		t.Fatalf("expected 4 charts, got %d", len(infos))
+	}
+
+	byName := map[string]*HelmChartInfo{}
+	for i := range infos {
+		byName[infos[i].Chart.Name] = &infos[i]
+	}
+
+	canal := byName["rke2-canal"]
+	if !canal.JobFailed() || canal.Problem() != "helm-install job Error" {
+		t.Errorf("rke2-canal: JobFailed=%v Problem=%q", canal.JobFailed(), canal.Problem())
+	}
+	if len(canal.InstallPods) != 1 || len(canal.Workloads) != 1 || canal.Workloads[0].Ready != 0 {
+		t.Errorf("rke2-canal: unexpected pods %v / workloads %v", canal.InstallPods, canal.Workloads)
+	}
+
+	coredns := byName["rke2-coredns"]
+	if coredns.JobFailed() || coredns.Problem() != "1/1 workloads not ready" {
+		t.Errorf("rke2-coredns: JobFailed=%v Problem=%q", coredns.JobFailed(), coredns.Problem())
+	}
+	if len(coredns.WorkloadPods) != 1 {
+		t.Errorf("rke2-coredns: expected 1 workload pod, got %v", coredns.WorkloadPods)
+	}
+
+	// The controller deployment belongs to rke2-snapshot-controller, not the -crd chart
+	if len(byName["rke2-snapshot-controller"].Workloads) != 1 || len(byName["rke2-snapshot-controller-crd"].Workloads) != 0 {
+		t.Errorf("snapshot-controller workloads assigned to the wrong chart")
+	}
+	if byName["rke2-snapshot-controller"].Problem() != "" {
+		t.Errorf("rke2-snapshot-controller should be healthy")
+	}
+}
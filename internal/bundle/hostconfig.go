@@ -0,0 +1,352 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Host config check results (see HostCheck.Status)
+const (
+	HostCheckPass = "pass"
+	HostCheckWarn = "warn"
+	HostCheckFail = "fail"
+	HostCheckSkip = "skip" // Input not collected in the bundle
+)
+
+// Host config check categories (see HostCheck.Category)
+const (
+	HostCategorySysctl   = "sysctl"
+	HostCategoryModule   = "module"
+	HostCategoryPackage  = "package"
+	HostCategorySecurity = "security"
+)
+
+// hostSysctlRule is a kernel parameter RKE2 or common workloads depend on. A rule passes when the
+// value equals want, or is at least want when atLeast is set; otherwise it reports failStatus.
+type hostSysctlRule struct {
+	key        string
+	want       int64
+	atLeast    bool
+	failStatus string
+	cisOnly    bool // Only enforced with protect-kernel-defaults or a CIS profile
+	reason     string
+}
+
+// hostSysctlRules is the embedded sysctl rule set
+var hostSysctlRules = []hostSysctlRule{
+	{key: "net.ipv4.ip_forward", want: 1, failStatus: HostCheckFail,
+		reason: "pod traffic is routed through the host; with forwarding off, pods cannot reach other nodes or services"},
+	{key: "net.bridge.bridge-nf-call-iptables", want: 1, failStatus: HostCheckFail,
+		reason: "bridged pod traffic must pass through iptables for kube-proxy services and network policies"},
+	{key: "net.bridge.bridge-nf-call-ip6tables", want: 1, failStatus: HostCheckWarn,
+		reason: "IPv6 bridged traffic bypasses ip6tables, breaking IPv6 services and policies"},
+	{key: "fs.inotify.max_user_instances", want: 8192, atLeast: true, failStatus: HostCheckWarn,
+		reason: "kubelet, log shippers and operators each hold inotify instances; the default 128 causes \"too many open files\""},
+	{key: "fs.inotify.max_user_watches", want: 524288, atLeast: true, failStatus: HostCheckWarn,
+		reason: "file watchers (kubelet, log tailing, config reloaders) fail with \"no space left on device\" when exhausted"},
+	{key: "vm.max_map_count", want: 262144, atLeast: true, failStatus: HostCheckWarn,
+		reason: "Elasticsearch/OpenSearch (e.g. rancher-logging) refuse to start below 262144"},
+	{key: "vm.overcommit_memory", want: 1, failStatus: HostCheckFail, cisOnly: true,
+		reason: "kubelet refuses to start with protect-kernel-defaults when this differs from its expected value"},
+	{key: "vm.panic_on_oom", want: 0, failStatus: HostCheckFail, cisOnly: true,
+		reason: "kubelet refuses to start with protect-kernel-defaults when this differs from its expected value"},
+	{key: "kernel.panic", want: 10, failStatus: HostCheckFail, cisOnly: true,
+		reason: "kubelet refuses to start with protect-kernel-defaults when this differs from its expected value"},
+	{key: "kernel.panic_on_oops", want: 1, failStatus: HostCheckFail, cisOnly: true,
+		reason: "kubelet refuses to start with protect-kernel-defaults when this differs from its expected value"},
+}
+
+// hostRequiredModules are kernel modules RKE2 needs, with the reason they are needed
+var hostRequiredModules = []struct {
+	name   string
+	reason string
+}{
+	{"br_netfilter", "provides the net.bridge.bridge-nf-call-* sysctls; without it bridged pod traffic skips iptables"},
+	{"overlay", "containerd's overlayfs snapshotter needs it to create container root filesystems"},
+}
+
+// hostConflictingServices are firewalls and network managers that interfere with RKE2
+var hostConflictingServices = []struct {
+	unit       string
+	packages   []string
+	failStatus string
+	reason     string
+}{
+	{"firewalld.service", []string{"firewalld"}, HostCheckFail,
+		"firewalld rewrites iptables/nftables rules and blocks CNI traffic; RKE2 requires it disabled"},
+	{"nm-cloud-setup.service", []string{"NetworkManager-cloud-setup"}, HostCheckFail,
+		"nm-cloud-setup modifies routing tables and breaks CNI routes; RKE2 requires it disabled"},
+	{"ufw.service", []string{"ufw"}, HostCheckWarn,
+		"ufw inserts iptables rules ahead of the CNI's; an active ruleset must allow the RKE2 ports and pod/service CIDRs"},
+}
+
+// HostConfig is the bundle node's kernel parameters, modules, packages and security modules
+type HostConfig struct {
+	NodeName          string
+	OS                string // PRETTY_NAME from os-release
+	Kernel            string // uname -r
+	Sysctls           map[string]string
+	Modules           map[string]bool
+	Packages          map[string]string // Package name → version (dpkg or rpm)
+	Units             []SystemdUnit
+	UFWStatus         string // "active" or "inactive"; empty if not collected
+	UFWRules          int
+	AppArmor          string // "enabled", "disabled" or empty if not collected
+	AppArmorLoaded    int    // Profiles loaded
+	AppArmorEnforcing int    // Profiles in enforce mode
+	OverlayMounts     bool   // An overlay filesystem is mounted (overlay built into the kernel)
+	ProtectKernel     bool   // protect-kernel-defaults or a CIS profile in 50-rancher.yaml
+}
+
+// HostCheck is the result of one host config rule
+type HostCheck struct {
+	Category string
+	Name     string
+	Status   string
+	Expected string
+	Actual   string
+	Detail   string
+}
+
+// ParseHostConfig parses systeminfo/sysctla, lsmod, osrelease, uname, packages-dpkg (or
+// packages-rpm), systemd-units, ubuntu-ufw and ubuntu-apparmorstatus. Missing files leave
+// their fields empty; an error is returned only when systeminfo does not exist.
+func ParseHostConfig(extractPath string) (*HostConfig, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	dir := filepath.Join(bundleRoot, "systeminfo")
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+		return string(content)
+	}
+
+	h := &HostConfig{
+		NodeName: extractNodeName(extractPath),
+		Sysctls:  make(map[string]string),
+		Packages: make(map[string]string),
+		Units:    parseSystemdUnits(read("systemd-units")),
+	}
+
+	// Format: key = value
+	for _, line := range strings.Split(read("sysctla"), "\n") {
+		if key, value, ok := strings.Cut(line, " = "); ok {
+			h.Sysctls[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+
+	// Format: Module Size "Used by" header, then one module per line
+	if lsmod := read("lsmod"); lsmod != "" {
+		h.Modules = make(map[string]bool)
+		for _, line := range strings.Split(lsmod, "\n")[1:] {
+			if fields := strings.Fields(line); len(fields) > 0 {
+				h.Modules[fields[0]] = true
+			}
+		}
+	}
+
+	// osrelease holds lsb-release then os-release: DISTRIB_ID=Ubuntu ... PRETTY_NAME="Ubuntu 22.04.4 LTS"
+	for _, line := range strings.Split(read("osrelease"), "\n") {
+		if value, ok := strings.CutPrefix(line, "PRETTY_NAME="); ok {
+			h.OS = strings.Trim(value, `"`)
+		}
+	}
+	// Format: Linux <hostname> <release> #<build> ...
+	if fields := strings.Fields(read("uname")); len(fields) >= 3 {
+		h.Kernel = fields[2]
+	}
+
+	// Format (dpkg -l): ii  name[:arch]  version  arch  description
+	for _, line := range strings.Split(read("packages-dpkg"), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && fields[0] == "ii" {
+			name, _, _ := strings.Cut(fields[1], ":")
+			h.Packages[name] = fields[2]
+		}
+	}
+	// Format (rpm -qa): name-version-release.arch
+	for _, line := range strings.Split(read("packages-rpm"), "\n") {
+		line = strings.TrimSpace(line)
+		parts := strings.Split(line, "-")
+		if len(parts) >= 3 {
+			h.Packages[strings.Join(parts[:len(parts)-2], "-")] = strings.Join(parts[len(parts)-2:], "-")
+		}
+	}
+
+	// Format: "Status: active", then "To Action From", a "--" underline and one line per rule
+	if ufw := read("ubuntu-ufw"); ufw != "" {
+		rules := false
+		for _, line := range strings.Split(ufw, "\n") {
+			switch {
+			case strings.HasPrefix(line, "Status:"):
+				h.UFWStatus = strings.TrimSpace(strings.TrimPrefix(line, "Status:"))
+			case strings.HasPrefix(line, "--"):
+				rules = true
+			case rules && strings.TrimSpace(line) != "":
+				h.UFWRules++
+			}
+		}
+	}
+
+	// Format: "apparmor module is loaded.", "42 profiles are loaded.", "42 profiles are in enforce mode."
+	if status := read("ubuntu-apparmorstatus"); status != "" {
+		h.AppArmor = "disabled"
+		for _, line := range strings.Split(status, "\n") {
+			fields := strings.Fields(line)
+			switch {
+			case strings.HasPrefix(line, "apparmor module is loaded"):
+				h.AppArmor = "enabled"
+			case len(fields) >= 4 && strings.HasSuffix(line, "profiles are loaded."):
+				h.AppArmorLoaded, _ = strconv.Atoi(fields[0])
+			case len(fields) >= 4 && strings.HasSuffix(line, "profiles are in enforce mode."):
+				h.AppArmorEnforcing, _ = strconv.Atoi(fields[0])
+			}
+		}
+	}
+
+	for _, line := range strings.Split(read("mount"), "\n") {
+		if strings.Contains(line, " type overlay ") {
+			h.OverlayMounts = true
+			break
+		}
+	}
+
+	h.ProtectKernel = rke2ConfigBool(extractPath, "protect-kernel-defaults") ||
+		strings.HasPrefix(rke2ConfigString(extractPath, "profile"), "cis")
+
+	return h, nil
+}
+
+// Unit returns the named systemd unit, or nil if it is not loaded
+func (h *HostConfig) Unit(name string) *SystemdUnit {
+	for i := range h.Units {
+		if h.Units[i].Name == name {
+			return &h.Units[i]
+		}
+	}
+	return nil
+}
+
+// Lint evaluates the embedded rule set: sysctls, required kernel modules, conflicting
+// firewall/network services and AppArmor
+func (h *HostConfig) Lint() []HostCheck {
+	var checks []HostCheck
+
+	for _, rule := range hostSysctlRules {
+		if rule.cisOnly && !h.ProtectKernel {
+			continue
+		}
+		expected := fmt.Sprintf("= %d", rule.want)
+		if rule.atLeast {
+			expected = fmt.Sprintf("≥ %d", rule.want)
+		}
+		check := HostCheck{Category: HostCategorySysctl, Name: rule.key, Expected: expected, Detail: rule.reason}
+		value, ok := h.Sysctls[rule.key]
+		switch {
+		case len(h.Sysctls) == 0:
+			check.Status = HostCheckSkip
+			check.Actual = "not collected"
+		case !ok:
+			check.Status = rule.failStatus
+			check.Actual = "missing"
+			if strings.HasPrefix(rule.key, "net.bridge.") {
+				check.Detail = "br_netfilter is not loaded, so the sysctl does not exist; " + rule.reason
+			}
+		default:
+			check.Actual = value
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err == nil && (n == rule.want || (rule.atLeast && n > rule.want)) {
+				check.Status = HostCheckPass
+			} else {
+				check.Status = rule.failStatus
+			}
+		}
+		checks = append(checks, check)
+	}
+
+	for _, module := range hostRequiredModules {
+		check := HostCheck{Category: HostCategoryModule, Name: module.name, Expected: "loaded", Detail: module.reason}
+		switch {
+		case h.Modules == nil:
+			check.Status = HostCheckSkip
+			check.Actual = "not collected"
+		case h.Modules[module.name]:
+			check.Status = HostCheckPass
+			check.Actual = "loaded"
+		case module.name == "br_netfilter" && h.Sysctls["net.bridge.bridge-nf-call-iptables"] != "":
+			check.Status = HostCheckPass
+			check.Actual = "built in"
+		case module.name == "overlay" && h.OverlayMounts:
+			check.Status = HostCheckPass
+			check.Actual = "built in"
+		default:
+			check.Status = HostCheckFail
+			check.Actual = "not loaded"
+		}
+		checks = append(checks, check)
+	}
+
+	for _, svc := range hostConflictingServices {
+		name := strings.TrimSuffix(svc.unit, ".service")
+		check := HostCheck{Category: HostCategoryPackage, Name: name, Expected: "not running", Detail: svc.reason}
+		installed := ""
+		for _, pkg := range svc.packages {
+			if version, ok := h.Packages[pkg]; ok {
+				installed = pkg + " " + version
+			}
+		}
+		unit := h.Unit(svc.unit)
+		switch {
+		case unit == nil && installed == "":
+			check.Status = HostCheckPass
+			check.Actual = "not installed"
+		case unit == nil || unit.Active != "active":
+			check.Status = HostCheckPass
+			check.Actual = "installed, inactive"
+		case name == "ufw" && h.UFWStatus != "":
+			// ufw.service is a oneshot that stays "active (exited)"; the firewall state is in ufw status
+			check.Actual = fmt.Sprintf("service active, firewall %s", h.UFWStatus)
+			check.Status = HostCheckPass
+			if h.UFWStatus == "active" {
+				check.Status = svc.failStatus
+				check.Actual += fmt.Sprintf(", %d rules", h.UFWRules)
+			}
+		default:
+			check.Status = svc.failStatus
+			check.Actual = fmt.Sprintf("%s (%s)", unit.Active, unit.Sub)
+		}
+		if installed != "" {
+			check.Detail += " (package " + installed + ")"
+		}
+		checks = append(checks, check)
+	}
+
+	check := HostCheck{Category: HostCategorySecurity, Name: "AppArmor", Expected: "parser installed if enabled"}
+	_, parser := h.Packages["apparmor"]
+	switch {
+	case h.AppArmor == "":
+		check.Status = HostCheckSkip
+		check.Actual = "not collected"
+	case h.AppArmor == "disabled":
+		check.Status = HostCheckPass
+		check.Actual = "disabled"
+	case !parser && len(h.Packages) > 0:
+		check.Status = HostCheckFail
+		check.Actual = "enabled, apparmor package missing"
+		check.Detail = "containerd loads its cri-containerd.apparmor.d profile with apparmor_parser; without it containers fail to start"
+	default:
+		check.Status = HostCheckPass
+		check.Actual = fmt.Sprintf("enabled, %d profiles (%d enforcing)", h.AppArmorLoaded, h.AppArmorEnforcing)
+		check.Detail = "containerd applies its cri-containerd.apparmor.d profile to containers"
+	}
+	checks = append(checks, check)
+
+	return checks
+}
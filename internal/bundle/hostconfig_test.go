@@ -0,0 +1,86 @@
+package bundle
+
+import (
+	"testing"
+)
+
+// TestParseHostConfig tests host config parsing and the embedded lint rules
+func TestParseHostConfig(t *testing.T) {
+	root := t.TempDir()
+
+	writeBundleFile(t, root, "rke2/50-rancher.yaml", "{\n  \"protect-kernel-defaults\": true,\n  \"cni\": \"calico\",\n}\n")
+	writeBundleFile(t, root, "systeminfo/sysctla", `fs.inotify.max_user_instances = 8192
+fs.inotify.max_user_watches = 30217
+kernel.panic = 0
+kernel.panic_on_oops = 1
+net.ipv4.ip_forward = 0
+vm.max_map_count = 262144
+vm.overcommit_memory = 1
+vm.panic_on_oom = 0
+`)
+	writeBundleFile(t, root, "systeminfo/lsmod", "Module                  Size  Used by\nvxlan                  86016  0\n")
+	writeBundleFile(t, root, "systeminfo/mount", "overlay on /run/k3s/containerd/rootfs type overlay (rw,relatime)\n")
+	writeBundleFile(t, root, "systeminfo/osrelease", "DISTRIB_ID=Ubuntu\nPRETTY_NAME=\"Ubuntu 22.04.4 LTS\"\n")
+	writeBundleFile(t, root, "systeminfo/uname", "Linux node1 5.15.0-113-generic #123-Ubuntu SMP Mon Jun 10 08:16:17 UTC 2024 x86_64 GNU/Linux\n")
+	writeBundleFile(t, root, "systeminfo/packages-dpkg", `||/ Name                             Version                                 Architecture Description
++++-================================-=======================================-============-=========
+ii  libapparmor1:amd64               3.0.4-2ubuntu2.4                        amd64        changehat AppArmor library
+ii  ufw                              0.36.1-4ubuntu0.1                       all          program for managing a Netfilter firewall
+rc  firewalld                        1.1.1-1ubuntu1                          all          dynamically managed firewall
+`)
+	writeBundleFile(t, root, "systeminfo/systemd-units", `  UNIT                      LOAD   ACTIVE SUB       DESCRIPTION
+  ufw.service               loaded active exited    Uncomplicated firewall
+● rke2-server.service       loaded failed failed    Rancher Kubernetes Engine v2 (server)
+
+LOAD   = Reflects whether the unit definition was properly loaded.
+`)
+	writeBundleFile(t, root, "systeminfo/ubuntu-ufw", `Status: active
+
+To                         Action      From
+--                         ------      ----
+22/tcp                     ALLOW       Anywhere
+6443/tcp                   ALLOW       Anywhere
+`)
+	writeBundleFile(t, root, "systeminfo/ubuntu-apparmorstatus", "apparmor module is loaded.\n42 profiles are loaded.\n40 profiles are in enforce mode.\n")
+
+	host, err := ParseHostConfig(root)
+	if err != nil {
+		t.Fatalf("ParseHostConfig() error = %v", err)
+	}
+
+	if host.OS != "Ubuntu 22.04.4 LTS" || host.Kernel != "5.15.0-113-generic" || !host.ProtectKernel {
+		t.Errorf("os=%q kernel=%q protect-kernel-defaults=%v", host.OS, host.Kernel, host.ProtectKernel)
+	}
+	if len(host.Units) != 2 || host.Units[1].Name != "rke2-server.service" || host.Units[1].Active != "failed" {
+		t.Errorf("systemd units parsed as %+v", host.Units)
+	}
+	if _, ok := host.Packages["firewalld"]; ok || host.Packages["libapparmor1"] != "3.0.4-2ubuntu2.4" {
+		t.Errorf("packages parsed as %+v", host.Packages)
+	}
+	if host.UFWStatus != "active" || host.UFWRules != 2 || host.AppArmorEnforcing != 40 {
+		t.Errorf("ufw=%s rules=%d apparmor enforcing=%d", host.UFWStatus, host.UFWRules, host.AppArmorEnforcing)
+	}
+
+	want := map[string]string{
+		"net.ipv4.ip_forward":                HostCheckFail,
+		"net.bridge.bridge-nf-call-iptables": HostCheckFail, // br_netfilter not loaded
+		"fs.inotify.max_user_instances":      HostCheckPass,
+		"fs.inotify.max_user_watches":        HostCheckWarn,
+		"vm.max_map_count":                   HostCheckPass,
+		"kernel.panic":                       HostCheckFail, // protect-kernel-defaults
+		"br_netfilter":                       HostCheckFail,
+		"overlay":                            HostCheckPass, // built in, overlay mounts exist
+		"firewalld":                          HostCheckPass,
+		"ufw":                                HostCheckWarn,
+		"AppArmor":                           HostCheckFail, // enabled without the apparmor package
+	}
+	got := make(map[string]HostCheck)
+	for _, c := range host.Lint() {
+		got[c.Name] = c
+	}
+	for name, wantStatus := range want {
+		if got[name].Status != wantStatus {
+			t.Errorf("%s = %s (actual %s), want %s", name, got[name].Status, got[name].Actual, wantStatus)
+		}
+	}
+}
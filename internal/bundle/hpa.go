@@ -0,0 +1,340 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// unknownMetric is how kubectl and the HPA controller show a metric that could not be fetched
+const unknownMetric = "<unknown>"
+
+// HPAInfo links a HorizontalPodAutoscaler to its scale target
+type HPAInfo struct {
+	rancher.HorizontalPodAutoscaler
+	Target        *WorkloadInfo // nil if the scale target is not in the bundle
+	TargetChecked bool          // The target's kind was collected, so a nil Target means it does not exist
+}
+
+// PinnedAtMax reports whether the HPA is running at maxReplicas and cannot scale further.
+// HPAs with minReplicas == maxReplicas are a fixed size by design and are not reported.
+func (h *HPAInfo) PinnedAtMax() bool {
+	return h.MaxReplicas > h.MinReplicas && h.Replicas >= h.MaxReplicas
+}
+
+// TargetMissing reports whether the scale target does not exist in the bundle
+func (h *HPAInfo) TargetMissing() bool {
+	return h.TargetChecked && h.Target == nil
+}
+
+// UnknownMetrics returns the metrics whose current value could not be fetched
+func (h *HPAInfo) UnknownMetrics() []rancher.HPAMetric {
+	var unknown []rancher.HPAMetric
+	for _, m := range h.Metrics {
+		if m.Current == unknownMetric || m.Current == "" {
+			unknown = append(unknown, m)
+		}
+	}
+	return unknown
+}
+
+// MetricsAPIServices returns the aggregated APIServices serving the HPA's unknown metrics,
+// e.g. "v1beta1.metrics.k8s.io" (metrics-server) for cpu/memory
+func (h *HPAInfo) MetricsAPIServices() []string {
+	var names []string
+	for _, m := range h.UnknownMetrics() {
+		name := metricsAPIService(m)
+		if !contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Problems returns short descriptions of what is wrong with the HPA, empty if healthy
+func (h *HPAInfo) Problems() []string {
+	var problems []string
+	if h.TargetMissing() {
+		problems = append(problems, fmt.Sprintf("scale target %s/%s not found", h.TargetKind, h.TargetName))
+	}
+	if unknown := h.UnknownMetrics(); len(unknown) > 0 {
+		var names []string
+		for _, m := range unknown {
+			names = append(names, metricLabel(m))
+		}
+		problems = append(problems, fmt.Sprintf("%s metrics (%s)", unknownMetric, strings.Join(names, ", ")))
+	}
+	if h.PinnedAtMax() {
+		problems = append(problems, fmt.Sprintf("pinned at maxReplicas (%d/%d)", h.Replicas, h.MaxReplicas))
+	}
+	return problems
+}
+
+// metricsAPIService returns the APIService that serves a metric type:
+// resource metrics come from metrics-server, others from a custom/external metrics adapter
+func metricsAPIService(m rancher.HPAMetric) string {
+	switch m.Type {
+	case "Resource", "ContainerResource":
+		return "v1beta1.metrics.k8s.io"
+	case "External":
+		return "v1beta1.external.metrics.k8s.io"
+	case "Pods", "Object":
+		return "v1beta1.custom.metrics.k8s.io"
+	}
+	// Table output: named cpu/memory or an unnamed utilization target are resource metrics
+	if m.Name == "cpu" || m.Name == "memory" || (m.Name == "" && strings.HasSuffix(m.Target, "%")) {
+		return "v1beta1.metrics.k8s.io"
+	}
+	return "v1beta1.custom.metrics.k8s.io"
+}
+
+// metricLabel returns the metric name, or its target for unnamed metrics in table output
+func metricLabel(m rancher.HPAMetric) string {
+	if m.Name != "" {
+		return m.Name
+	}
+	return "target " + m.Target
+}
+
+// hpaMetricValue is a metric target or current value in autoscaling/v2
+type hpaMetricValue struct {
+	AverageUtilization *int   `yaml:"averageUtilization"`
+	AverageValue       string `yaml:"averageValue"`
+	Value              string `yaml:"value"`
+}
+
+// String formats the value the way kubectl get hpa does
+func (v hpaMetricValue) String() string {
+	switch {
+	case v.AverageUtilization != nil:
+		return fmt.Sprintf("%d%%", *v.AverageUtilization)
+	case v.AverageValue != "":
+		return v.AverageValue
+	default:
+		return v.Value
+	}
+}
+
+// hpaMetricSource is the type-specific part of an autoscaling/v2 metric
+type hpaMetricSource struct {
+	Name   string `yaml:"name"` // Resource and ContainerResource
+	Metric struct {
+		Name string `yaml:"name"`
+	} `yaml:"metric"` // Pods, Object and External
+	Target  hpaMetricValue `yaml:"target"`
+	Current hpaMetricValue `yaml:"current"`
+}
+
+// hpaMetricSpec is an autoscaling/v2 metric in spec.metrics or status.currentMetrics
+type hpaMetricSpec struct {
+	Type              string           `yaml:"type"`
+	Resource          *hpaMetricSource `yaml:"resource"`
+	ContainerResource *hpaMetricSource `yaml:"containerResource"`
+	Pods              *hpaMetricSource `yaml:"pods"`
+	Object            *hpaMetricSource `yaml:"object"`
+	External          *hpaMetricSource `yaml:"external"`
+}
+
+// source returns the populated type-specific section and the metric name
+func (m hpaMetricSpec) source() (*hpaMetricSource, string) {
+	for _, src := range []*hpaMetricSource{m.Resource, m.ContainerResource, m.Pods, m.Object, m.External} {
+		if src == nil {
+			continue
+		}
+		if src.Name != "" {
+			return src, src.Name
+		}
+		return src, src.Metric.Name
+	}
+	return nil, ""
+}
+
+// ParseHPAs parses kubectl get hpa output from bundle
+// Format: NAMESPACE NAME REFERENCE TARGETS MINPODS MAXPODS REPLICAS AGE (table output),
+// or a full -o yaml / -o json list (autoscaling/v2, or v1 CPU utilization fields)
+// Note: TARGETS is "45%/80%", "cpu: 45%/80%, memory: <unknown>/70%" or "<none>"
+func ParseHPAs(extractPath string) ([]rancher.HorizontalPodAutoscaler, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	path := filepath.Join(bundleRoot, "rke2/kubectl/hpa")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var hpas []rancher.HorizontalPodAutoscaler
+
+	if isStructuredOutput(content) {
+		var list struct {
+			Items []struct {
+				Metadata struct {
+					Name      string `yaml:"name"`
+					Namespace string `yaml:"namespace"`
+				} `yaml:"metadata"`
+				Spec struct {
+					ScaleTargetRef struct {
+						Kind string `yaml:"kind"`
+						Name string `yaml:"name"`
+					} `yaml:"scaleTargetRef"`
+					MinReplicas                    *int            `yaml:"minReplicas"`
+					MaxReplicas                    int             `yaml:"maxReplicas"`
+					Metrics                        []hpaMetricSpec `yaml:"metrics"`
+					TargetCPUUtilizationPercentage *int            `yaml:"targetCPUUtilizationPercentage"`
+				} `yaml:"spec"`
+				Status struct {
+					CurrentReplicas                 int                    `yaml:"currentReplicas"`
+					CurrentMetrics                  []hpaMetricSpec        `yaml:"currentMetrics"`
+					CurrentCPUUtilizationPercentage *int                   `yaml:"currentCPUUtilizationPercentage"`
+					Conditions                      []rancher.HPACondition `yaml:"conditions"`
+				} `yaml:"status"`
+			} `yaml:"items"`
+		}
+		if err := yaml.Unmarshal(content, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse hpa: %w", err)
+		}
+
+		for _, item := range list.Items {
+			hpa := rancher.HorizontalPodAutoscaler{
+				Namespace:   item.Metadata.Namespace,
+				Name:        item.Metadata.Name,
+				TargetKind:  item.Spec.ScaleTargetRef.Kind,
+				TargetName:  item.Spec.ScaleTargetRef.Name,
+				MinReplicas: 1, // API default
+				MaxReplicas: item.Spec.MaxReplicas,
+				Replicas:    item.Status.CurrentReplicas,
+				Conditions:  item.Status.Conditions,
+			}
+			if item.Spec.MinReplicas != nil {
+				hpa.MinReplicas = *item.Spec.MinReplicas
+			}
+
+			for _, spec := range item.Spec.Metrics {
+				src, name := spec.source()
+				if src == nil {
+					continue
+				}
+				metric := rancher.HPAMetric{Type: spec.Type, Name: name, Target: src.Target.String(), Current: unknownMetric}
+				for _, status := range item.Status.CurrentMetrics {
+					if cur, curName := status.source(); cur != nil && status.Type == spec.Type && curName == name {
+						if value := cur.Current.String(); value != "" {
+							metric.Current = value
+						}
+					}
+				}
+				hpa.Metrics = append(hpa.Metrics, metric)
+			}
+
+			// autoscaling/v1 only has CPU utilization
+			if len(hpa.Metrics) == 0 && item.Spec.TargetCPUUtilizationPercentage != nil {
+				metric := rancher.HPAMetric{
+					Type:    "Resource",
+					Name:    "cpu",
+					Target:  fmt.Sprintf("%d%%", *item.Spec.TargetCPUUtilizationPercentage),
+					Current: unknownMetric,
+				}
+				if item.Status.CurrentCPUUtilizationPercentage != nil {
+					metric.Current = fmt.Sprintf("%d%%", *item.Status.CurrentCPUUtilizationPercentage)
+				}
+				hpa.Metrics = append(hpa.Metrics, metric)
+			}
+
+			hpas = append(hpas, hpa)
+		}
+		return hpas, nil
+	}
+
+	table := ParseKubectlTable(content)
+	for _, row := range table.Rows {
+		name := table.Value(row, "NAME")
+		if name == "" {
+			continue
+		}
+
+		hpa := rancher.HorizontalPodAutoscaler{
+			Namespace: table.Value(row, "NAMESPACE"),
+			Name:      name,
+			Metrics:   parseHPATargets(table.Value(row, "TARGETS")),
+			Age:       table.Value(row, "AGE"),
+		}
+		if kind, target, ok := strings.Cut(table.Value(row, "REFERENCE"), "/"); ok {
+			hpa.TargetKind = kind
+			hpa.TargetName = target
+		}
+		fmt.Sscanf(table.Value(row, "MINPODS"), "%d", &hpa.MinReplicas)
+		fmt.Sscanf(table.Value(row, "MAXPODS"), "%d", &hpa.MaxReplicas)
+		fmt.Sscanf(table.Value(row, "REPLICAS"), "%d", &hpa.Replicas)
+
+		hpas = append(hpas, hpa)
+	}
+
+	return hpas, nil
+}
+
+// parseHPATargets parses the TARGETS column of kubectl get hpa.
+// Older kubectl prints "45%/80%, <unknown>/70%"; newer prefixes the metric name
+// ("cpu: 45%/80%"). A trailing " + 2 more..." summary is dropped.
+func parseHPATargets(cell string) []rancher.HPAMetric {
+	if i := strings.Index(cell, " + "); i >= 0 {
+		cell = cell[:i]
+	}
+
+	var metrics []rancher.HPAMetric
+	for _, part := range strings.Split(cell, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" || part == "<none>" {
+			continue
+		}
+
+		var metric rancher.HPAMetric
+		if name, value, ok := strings.Cut(part, ": "); ok {
+			metric.Name = name
+			part = value
+		}
+		current, target, ok := strings.Cut(part, "/")
+		if !ok {
+			continue
+		}
+		metric.Current = current
+		metric.Target = target
+		metrics = append(metrics, metric)
+	}
+	return metrics
+}
+
+// AnalyzeHPAs links every HPA to the Deployment/StatefulSet/DaemonSet it scales
+func AnalyzeHPAs(extractPath string) ([]HPAInfo, error) {
+	hpas, err := ParseHPAs(extractPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Workload files might be missing; the target can only be reported missing
+	// when its kind was collected
+	workloads, _ := ParseWorkloads(extractPath)
+	bundleRoot := getBundleRoot(extractPath)
+	collected := make(map[string]bool)
+	for _, kind := range []string{"Deployment", "DaemonSet", "StatefulSet"} {
+		if _, err := os.Stat(filepath.Join(bundleRoot, "rke2/kubectl", strings.ToLower(kind)+"s")); err == nil {
+			collected[kind] = true
+		}
+	}
+
+	infos := make([]HPAInfo, len(hpas))
+	for i, hpa := range hpas {
+		infos[i].HorizontalPodAutoscaler = hpa
+		infos[i].TargetChecked = collected[hpa.TargetKind]
+		for j := range workloads {
+			w := &workloads[j]
+			if w.Kind == hpa.TargetKind && w.Namespace == hpa.Namespace && w.Name == hpa.TargetName {
+				infos[i].Target = w
+				break
+			}
+		}
+	}
+
+	return infos, nil
+}
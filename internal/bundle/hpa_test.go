@@ -0,0 +1,135 @@
+package bundle
+
+import "testing"
+
+// TestAnalyzeHPAs_Table tests TARGETS parsing, target linking and the problems flagged per HPA
+func TestAnalyzeHPAs_Table(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "deployments", `NAMESPACE   NAME     READY   UP-TO-DATE   AVAILABLE   AGE
+apps        web      10/10   10           10          30d
+apps        worker   2/2     2            2           30d
+`)
+	writeKubectlFile(t, root, "hpa", `NAMESPACE   NAME     REFERENCE               TARGETS                                MINPODS   MAXPODS   REPLICAS   AGE
+apps        web      Deployment/web          cpu: 95%/80%, memory: 40%/70%          2         10        10         30d
+apps        worker   Deployment/worker       <unknown>/60%                          2         6         2          30d
+apps        old      Deployment/old-api      <none>                                 1         3         0          90d
+apps        fixed    Deployment/worker       30%/80% + 1 more...                    2         2         2          30d
+`)
+
+	hpas, err := AnalyzeHPAs(root)
+	if err != nil {
+		t.Fatalf("AnalyzeHPAs() error = %v", err)
+	}
+	if len(hpas) != 4 {
+		t.Fatalf("expected 4 hpas, got %d", len(hpas))
+	}
+
+	web := hpas[0]
+	if len(web.Metrics) != 2 || web.Metrics[1].Name != "memory" || web.Metrics[0].Current != "95%" || web.Metrics[0].Target != "80%" {
+		t.Errorf("web metrics = %+v", web.Metrics)
+	}
+	if web.Target == nil || !web.PinnedAtMax() || len(web.Problems()) != 1 {
+		t.Errorf("web: expected linked target pinned at max, problems = %v", web.Problems())
+	}
+
+	worker := hpas[1]
+	if len(worker.UnknownMetrics()) != 1 || worker.PinnedAtMax() {
+		t.Errorf("worker: expected 1 unknown metric, got %+v", worker.Metrics)
+	}
+	if apis := worker.MetricsAPIServices(); len(apis) != 1 || apis[0] != "v1beta1.metrics.k8s.io" {
+		t.Errorf("worker: metrics APIServices = %v", apis)
+	}
+
+	old := hpas[2]
+	if !old.TargetMissing() || len(old.Metrics) != 0 {
+		t.Errorf("old: expected missing target and no metrics, got %+v", old)
+	}
+
+	// minReplicas == maxReplicas is a fixed size, not a scaling limit
+	fixed := hpas[3]
+	if fixed.PinnedAtMax() || len(fixed.Metrics) != 1 || len(fixed.Problems()) != 0 {
+		t.Errorf("fixed: unexpected problems %v (metrics %+v)", fixed.Problems(), fixed.Metrics)
+	}
+}
+
+// TestParseHPAs_YAML tests autoscaling/v2 metrics, with a metric missing from currentMetrics
+func TestParseHPAs_YAML(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "hpa", `apiVersion: v1
+kind: List
+items:
+- apiVersion: autoscaling/v2
+  kind: HorizontalPodAutoscaler
+  metadata:
+    name: web
+    namespace: apps
+  spec:
+    scaleTargetRef:
+      apiVersion: apps/v1
+      kind: Deployment
+      name: web
+    maxReplicas: 5
+    metrics:
+    - type: Resource
+      resource:
+        name: cpu
+        target:
+          type: Utilization
+          averageUtilization: 80
+    - type: Pods
+      pods:
+        metric:
+          name: http_requests
+        target:
+          type: AverageValue
+          averageValue: "100"
+  status:
+    currentReplicas: 1
+    currentMetrics:
+    - type: Resource
+      resource:
+        name: cpu
+        current:
+          averageUtilization: 12
+          averageValue: 6m
+    conditions:
+    - type: ScalingActive
+      status: "False"
+      reason: FailedGetPodsMetric
+      message: 'unable to fetch metrics from custom metrics API'
+`)
+
+	hpas, err := ParseHPAs(root)
+	if err != nil {
+		t.Fatalf("ParseHPAs() error = %v", err)
+	}
+	if len(hpas) != 1 {
+		t.Fatalf("expected 1 hpa, got %d", len(hpas))
+	}
+
+	hpa := hpas[0]
+	if hpa.MinReplicas != 1 || hpa.MaxReplicas != 5 || hpa.TargetKind != "Deployment" || hpa.TargetName != "web" {
+		t.Errorf("unexpected hpa: %+v", hpa)
+	}
+	want := []struct{ name, current, target string }{
+		{"cpu", "12%", "80%"},
+		{"http_requests", unknownMetric, "100"},
+	}
+	if len(hpa.Metrics) != len(want) {
+		t.Fatalf("metrics = %+v", hpa.Metrics)
+	}
+	for i, w := range want {
+		m := hpa.Metrics[i]
+		if m.Name != w.name || m.Current != w.current || m.Target != w.target {
+			t.Errorf("metric %d = %+v, want %s %s/%s", i, m, w.name, w.current, w.target)
+		}
+	}
+	if len(hpa.Conditions) != 1 || hpa.Conditions[0].Reason != "FailedGetPodsMetric" {
+		t.Errorf("conditions = %+v", hpa.Conditions)
+	}
+
+	info := HPAInfo{HorizontalPodAutoscaler: hpa}
+	if apis := info.MetricsAPIServices(); len(apis) != 1 || apis[0] != "v1beta1.custom.metrics.k8s.io" {
+		t.Errorf("metrics APIServices = %v", apis)
+	}
+}
@@ -0,0 +1,313 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// Pull failure classes (see PullFailure.Class)
+const (
+	PullFailureNotFound = "not-found" // Image, tag or repository does not exist
+	PullFailureAuth     = "auth"      // Registry rejected credentials or requires them
+	PullFailureTLS      = "tls"       // Certificate not trusted, expired or for another host
+	PullFailureTimeout  = "timeout"   // Registry unreachable: timeouts, refused connections, DNS
+	PullFailureUnknown  = "unknown"   // Message did not match a known cause (or no event)
+)
+
+// defaultRegistry is where unqualified image names are pulled from
+const defaultRegistry = "docker.io"
+
+// pullImageRe extracts the image from kubelet messages like `Failed to pull image "x": ...`
+var pullImageRe = regexp.MustCompile(`(?:pull|pulling) image "([^"]+)"`)
+
+// pullFailurePatterns maps lowercase message fragments to a class, checked in order.
+// TLS comes first as x509 errors often mention the host and "dial". Not-found comes before
+// auth: Docker Hub answers "pull access denied, repository does not exist or may require
+// authorization" for repositories that do not exist.
+var pullFailurePatterns = []struct {
+	class     string
+	fragments []string
+}{
+	{PullFailureTLS, []string{"x509:", "tls:", "certificate signed by unknown authority", "certificate has expired", "certificate is valid for"}},
+	{PullFailureNotFound, []string{"not found", "manifest unknown", "repository does not exist", "name unknown"}},
+	{PullFailureAuth, []string{"unauthorized", "authentication required", "403 forbidden", "denied", "insufficient_scope", "no basic auth credentials"}},
+	{PullFailureTimeout, []string{"i/o timeout", "timeout", "deadline exceeded", "connection refused", "no such host", "network is unreachable", "connection reset"}},
+}
+
+// ImageRef is a container image reference split into its parts
+type ImageRef struct {
+	Registry   string // e.g. "docker.io", "registry.example.com:5000"
+	Repository string // e.g. "rancher/hardened-coredns", "library/nginx"
+	Tag        string // Empty if the image is pinned by digest only
+	Digest     string // e.g. "sha256:..."
+}
+
+// String returns the normalized reference, e.g. "docker.io/library/nginx:latest"
+func (r ImageRef) String() string {
+	s := r.Registry + "/" + r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// ParseImageRef splits an image reference the way the container runtime resolves it:
+// a first component with a "." or ":" (or "localhost") is a registry, otherwise docker.io;
+// single-name Docker Hub images live under "library/"; a missing tag means "latest".
+func ParseImageRef(image string) ImageRef {
+	var ref ImageRef
+
+	name := image
+	if at := strings.Index(name, "@"); at >= 0 {
+		ref.Digest = name[at+1:]
+		name = name[:at]
+	}
+	// A tag colon comes after the last slash (a registry port comes before it)
+	if colon := strings.LastIndex(name, ":"); colon > strings.LastIndex(name, "/") {
+		ref.Tag = name[colon+1:]
+		name = name[:colon]
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = "latest"
+	}
+
+	first, rest, found := strings.Cut(name, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry = first
+		ref.Repository = rest
+	} else {
+		ref.Registry = defaultRegistry
+		ref.Repository = name
+	}
+	if ref.Registry == defaultRegistry && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+
+	return ref
+}
+
+// PullFailure is an image pull error reported by kubelet for a pod
+type PullFailure struct {
+	Namespace string
+	Pod       string
+	Image     string
+	Class     string // PullFailure* constant
+	Message   string // Kubelet message, e.g. "Failed to pull image ...: 401 Unauthorized"
+	Count     int    // Event count (0 if only known from the pod status)
+}
+
+// ClassifyPullFailure returns the PullFailure* class of a kubelet pull error message
+func ClassifyPullFailure(message string) string {
+	lower := strings.ToLower(message)
+	for _, p := range pullFailurePatterns {
+		for _, fragment := range p.fragments {
+			if strings.Contains(lower, fragment) {
+				return p.class
+			}
+		}
+	}
+	return PullFailureUnknown
+}
+
+// ParsePullFailures finds image pull errors in events ("Failed to pull image" messages),
+// one per pod and image, plus pods in ErrImagePull/ImagePullBackOff with no such event
+func ParsePullFailures(events []rancher.Event, pods []rancher.Pod) []PullFailure {
+	var failures []PullFailure
+	seen := make(map[string]int) // namespace/pod/image → index in failures
+	podsWithFailure := make(map[string]bool)
+
+	for _, e := range events {
+		if e.ObjectKind != "pod" || !strings.Contains(e.Message, "Failed to pull image") {
+			continue
+		}
+		// Drop anything the events parser folded into the message ahead of kubelet's text
+		message := e.Message[strings.Index(e.Message, "Failed to pull image"):]
+		image := ""
+		if m := pullImageRe.FindStringSubmatch(message); m != nil {
+			image = m[1]
+		}
+
+		key := e.Namespace + "/" + e.PodName + "/" + image
+		if i, ok := seen[key]; ok {
+			failures[i].Count += e.Count
+			// Keep the most specific cause when a pod reports several messages
+			if failures[i].Class == PullFailureUnknown {
+				failures[i].Class = ClassifyPullFailure(message)
+				failures[i].Message = message
+			}
+			continue
+		}
+
+		seen[key] = len(failures)
+		podsWithFailure[e.Namespace+"/"+e.PodName] = true
+		failures = append(failures, PullFailure{
+			Namespace: e.Namespace,
+			Pod:       e.PodName,
+			Image:     image,
+			Class:     ClassifyPullFailure(message),
+			Message:   message,
+			Count:     e.Count,
+		})
+	}
+
+	for _, pod := range pods {
+		status := strings.ToLower(pod.KubectlStatus)
+		if !strings.Contains(status, "imagepullbackoff") && !strings.Contains(status, "errimagepull") {
+			continue
+		}
+		if podsWithFailure[pod.NamespaceID+"/"+pod.Name] {
+			continue
+		}
+		failures = append(failures, PullFailure{
+			Namespace: pod.NamespaceID,
+			Pod:       pod.Name,
+			Class:     PullFailureUnknown,
+			Message:   fmt.Sprintf("Pod status %s (no pull event in bundle)", pod.KubectlStatus),
+		})
+	}
+
+	return failures
+}
+
+// ImageInfo is one image in use by workloads or present on the node
+type ImageInfo struct {
+	Ref                ImageRef
+	Image              string   // As referenced by workloads, or registry/repository:tag from crictl
+	ID                 string   // crictl IMAGE ID, empty if not on this node
+	Size               string   // crictl SIZE, empty if not on this node
+	OnNode             bool     // Present in crictl images
+	RunningOnNode      bool     // Used by a crictl container (by image ID)
+	UsedBy             []string // Workloads referencing it, e.g. "Deployment kube-system/coredns"
+	Latest             bool     // Tagged (or defaulting to) :latest
+	UnexpectedRegistry bool
+	PullFailures       []PullFailure
+}
+
+// Unused reports whether the image is on the node but no workload or container uses it.
+// The sandbox (pause) and rke2-runtime images are used by RKE2 itself and never count.
+func (i ImageInfo) Unused() bool {
+	if !i.OnNode || i.RunningOnNode || len(i.UsedBy) > 0 {
+		return false
+	}
+	repo := i.Ref.Repository
+	return !strings.HasSuffix(repo, "pause") && !strings.HasSuffix(repo, "/rke2-runtime")
+}
+
+// ImageInventory combines images from workloads, the node and pull failures
+type ImageInventory struct {
+	ExpectedRegistries []string
+	Images             []ImageInfo
+	PullFailures       []PullFailure
+}
+
+// BuildImageInventory lists every image referenced by Deployments, DaemonSets, StatefulSets
+// and Jobs (-o wide IMAGES column) or present in crictl images, flagging :latest tags,
+// unused node images and registries other than the expected ones. Pull failures from
+// events are attached to their images.
+func BuildImageInventory(extractPath string) (*ImageInventory, error) {
+	workloads, _ := ParseWorkloads(extractPath)
+	inv := &ImageInventory{ExpectedRegistries: expectedRegistries(extractPath, workloads)}
+
+	byKey := make(map[string]int) // normalized reference → index in inv.Images
+	add := func(image string) *ImageInfo {
+		ref := ParseImageRef(image)
+		key := ref.String()
+		if i, ok := byKey[key]; ok {
+			return &inv.Images[i]
+		}
+		byKey[key] = len(inv.Images)
+		inv.Images = append(inv.Images, ImageInfo{
+			Ref:                ref,
+			Image:              image,
+			Latest:             ref.Tag == "latest",
+			UnexpectedRegistry: !contains(inv.ExpectedRegistries, ref.Registry),
+		})
+		return &inv.Images[len(inv.Images)-1]
+	}
+
+	for _, w := range workloads {
+		for _, image := range w.Images {
+			info := add(image)
+			info.UsedBy = append(info.UsedBy, fmt.Sprintf("%s %s/%s", w.Kind, w.Namespace, w.Name))
+		}
+	}
+	jobs, _ := ParseJobs(extractPath)
+	for _, job := range jobs {
+		for _, image := range job.Images {
+			info := add(image)
+			info.UsedBy = append(info.UsedBy, fmt.Sprintf("Job %s/%s", job.Namespace, job.Name))
+		}
+	}
+
+	crictlDir := filepath.Join(getBundleRoot(extractPath), "rke2/crictl")
+	imagesContent, _ := os.ReadFile(filepath.Join(crictlDir, "images"))
+	psaContent, _ := os.ReadFile(filepath.Join(crictlDir, "psa"))
+	containers := parseCrictlContainers(psaContent)
+	for _, img := range parseCrictlImages(imagesContent) {
+		image := img.Image
+		if img.Tag != "" && img.Tag != "<none>" {
+			image += ":" + img.Tag
+		}
+		info := add(image)
+		info.OnNode = true
+		info.ID = img.ID
+		info.Size = img.Size
+		for _, c := range containers {
+			if c.Image != "" && strings.HasPrefix(img.ID, c.Image) {
+				info.RunningOnNode = true
+				break
+			}
+		}
+	}
+
+	events, _ := ParseEvents(extractPath)
+	pods, _ := ParsePods(extractPath)
+	inv.PullFailures = ParsePullFailures(events, pods)
+	for _, f := range inv.PullFailures {
+		if f.Image == "" {
+			continue
+		}
+		info := add(f.Image)
+		info.PullFailures = append(info.PullFailures, f)
+	}
+
+	if len(inv.Images) == 0 && len(inv.PullFailures) == 0 {
+		return nil, fmt.Errorf("no workload, crictl or event image data in bundle")
+	}
+
+	sort.SliceStable(inv.Images, func(i, j int) bool {
+		return inv.Images[i].Ref.String() < inv.Images[j].Ref.String()
+	})
+	return inv, nil
+}
+
+// expectedRegistries returns the registries images should come from: the RKE2
+// system-default-registry when configured (air-gapped and mirrored setups), else docker.io
+// plus the registries the kube-system workloads already use (quay.io, registry.k8s.io, ...)
+func expectedRegistries(extractPath string, workloads []WorkloadInfo) []string {
+	if registry := rke2ConfigString(extractPath, "system-default-registry"); registry != "" {
+		return []string{registry}
+	}
+	registries := []string{defaultRegistry}
+	for _, w := range workloads {
+		if w.Namespace != "kube-system" {
+			continue
+		}
+		for _, image := range w.Images {
+			if registry := ParseImageRef(image).Registry; !contains(registries, registry) {
+				registries = append(registries, registry)
+			}
+		}
+	}
+	sort.Strings(registries[1:])
+	return registries
+}
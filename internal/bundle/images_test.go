@@ -0,0 +1,132 @@
+package bundle
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseImageRef tests registry, Docker Hub library and tag/digest resolution
+func TestParseImageRef(t *testing.T) {
+	tests := []struct {
+		image string
+		want  string
+	}{
+		{"nginx", "docker.io/library/nginx:latest"},
+		{"rancher/shell:v0.5.0", "docker.io/rancher/shell:v0.5.0"},
+		{"docker.io/rancher/mirrored-pause:3.6", "docker.io/rancher/mirrored-pause:3.6"},
+		{"registry.example.com:5000/team/app", "registry.example.com:5000/team/app:latest"},
+		{"localhost/app:dev", "localhost/app:dev"},
+		{"quay.io/jetstack/cert-manager@sha256:abc", "quay.io/jetstack/cert-manager@sha256:abc"},
+	}
+
+	for _, tt := range tests {
+		if got := ParseImageRef(tt.image).String(); got != tt.want {
+			t.Errorf("ParseImageRef(%q) = %q, want %q", tt.image, got, tt.want)
+		}
+	}
+}
+
+// TestClassifyPullFailure tests classification of containerd pull errors
+func TestClassifyPullFailure(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{`Failed to pull image "nonexistent/invalid:tag": failed to resolve reference "docker.io/nonexistent/invalid:tag": pull access denied, repository does not exist or may require authorization: server message: insufficient_scope: authorization failed`, PullFailureNotFound},
+		{`Failed to pull image "reg.example.com/app:v1": failed to resolve reference "reg.example.com/app:v1": reg.example.com/app:v1: not found`, PullFailureNotFound},
+		{`Failed to pull image "reg.example.com/app:v1": failed to authorize: failed to fetch oauth token: unexpected status: 401 Unauthorized`, PullFailureAuth},
+		{`Failed to pull image "reg.example.com/app:v1": failed to do request: Head "https://reg.example.com/v2/app/manifests/v1": tls: failed to verify certificate: x509: certificate signed by unknown authority`, PullFailureTLS},
+		{`Failed to pull image "reg.example.com/app:v1": failed to do request: Head "https://reg.example.com/v2/": dial tcp 10.0.0.5:443: i/o timeout`, PullFailureTimeout},
+		{`Failed to pull image "reg.example.com/app:v1": rpc error: code = Canceled`, PullFailureUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyPullFailure(tt.message); got != tt.want {
+			t.Errorf("ClassifyPullFailure(%q) = %q, want %q", tt.message, got, tt.want)
+		}
+	}
+}
+
+// TestBuildImageInventory tests merging workload, crictl and event images and their flags
+func TestBuildImageInventory(t *testing.T) {
+	root := t.TempDir()
+	writeBundleFile(t, root, "rke2/50-rancher.yaml", `{
+  "cni": "calico",
+  "system-default-registry": "registry.example.com",
+}`)
+	writeKubectlFile(t, root, "deployments", `NAMESPACE     NAME      READY   UP-TO-DATE   AVAILABLE   AGE   CONTAINERS   IMAGES                                        SELECTOR
+kube-system   coredns   2/2     2            2           14d   coredns      registry.example.com/rancher/coredns:v1.12    k8s-app=dns
+apps          web       0/1     1            0           1h    web          nginx                                          app=web
+`)
+	writeKubectlFile(t, root, "jobs", `NAMESPACE   NAME      STATUS    COMPLETIONS   DURATION   AGE   CONTAINERS   IMAGES                    SELECTOR
+apps        migrate   Running   0/1           5m         5m    migrate      quay.io/acme/migrate:v2   controller-uid=1
+`)
+	writeBundleFile(t, root, "rke2/crictl/images", `IMAGE                                   TAG       IMAGE ID        SIZE
+registry.example.com/rancher/coredns    v1.12     e99a979ee1696   29.2MB
+registry.example.com/rancher/shell      v0.5.0    565991da1027b   110MB
+registry.example.com/rancher/pause      3.6       6270bb605e12e   301kB
+`)
+	writeKubectlFile(t, root, "events", `NAMESPACE   LAST SEEN   TYPE      REASON   OBJECT                      SUBOBJECT                 SOURCE            MESSAGE                                                                                         FIRST SEEN   COUNT   NAME
+apps        1m          Warning   Failed   pod/migrate-x7k2p           spec.containers{migrate}  kubelet, node-1   Failed to pull image "quay.io/acme/migrate:v2": failed to resolve reference: 401 Unauthorized   5m           4       migrate-x7k2p.1
+`)
+
+	inv, err := BuildImageInventory(root)
+	if err != nil {
+		t.Fatalf("BuildImageInventory() error = %v", err)
+	}
+	if len(inv.ExpectedRegistries) != 1 || inv.ExpectedRegistries[0] != "registry.example.com" {
+		t.Errorf("expected registries = %v", inv.ExpectedRegistries)
+	}
+
+	byRef := make(map[string]ImageInfo)
+	for _, img := range inv.Images {
+		byRef[img.Ref.String()] = img
+	}
+	if len(byRef) != 5 {
+		t.Fatalf("expected 5 images, got %d: %v", len(byRef), inv.Images)
+	}
+
+	if coredns := byRef["registry.example.com/rancher/coredns:v1.12"]; !coredns.OnNode || len(coredns.UsedBy) != 1 || coredns.UnexpectedRegistry || coredns.Unused() {
+		t.Errorf("coredns: unexpected %+v", coredns)
+	}
+	if web := byRef["docker.io/library/nginx:latest"]; !web.Latest || !web.UnexpectedRegistry || web.OnNode {
+		t.Errorf("nginx: unexpected %+v", web)
+	}
+	if shell := byRef["registry.example.com/rancher/shell:v0.5.0"]; !shell.Unused() {
+		t.Errorf("shell should be unused: %+v", shell)
+	}
+	if pause := byRef["registry.example.com/rancher/pause:3.6"]; pause.Unused() {
+		t.Errorf("pause image should never count as unused")
+	}
+
+	migrate := byRef["quay.io/acme/migrate:v2"]
+	if len(migrate.PullFailures) != 1 || migrate.PullFailures[0].Class != PullFailureAuth || migrate.PullFailures[0].Pod != "migrate-x7k2p" {
+		t.Errorf("migrate: unexpected pull failures %+v", migrate.PullFailures)
+	}
+}
+
+// TestExpectedRegistriesFromKubeSystem tests that without a system-default-registry the
+// registries of kube-system images are expected too
+func TestExpectedRegistriesFromKubeSystem(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "daemonsets", `NAMESPACE     NAME      DESIRED   CURRENT   READY   UP-TO-DATE   AVAILABLE   NODE SELECTOR   AGE   CONTAINERS   IMAGES                                  SELECTOR
+kube-system   cilium    3         3         3       3            3           <none>          14d   cilium       quay.io/cilium/cilium:v1.16             k8s-app=cilium
+kube-system   ingress   3         3         3       3            3           <none>          14d   controller   registry.k8s.io/ingress-nginx/controller:v1.11   app=ingress
+apps          agent     3         3         3       3            3           <none>          1h    agent        ghcr.io/acme/agent:v1                   app=agent
+apps          proxy     3         3         3       3            3           <none>          1h    proxy        quay.io/acme/proxy:v2                   app=proxy
+`)
+
+	inv, err := BuildImageInventory(root)
+	if err != nil {
+		t.Fatalf("BuildImageInventory() error = %v", err)
+	}
+	want := []string{"docker.io", "quay.io", "registry.k8s.io"}
+	if strings.Join(inv.ExpectedRegistries, ",") != strings.Join(want, ",") {
+		t.Errorf("expected registries = %v, want %v", inv.ExpectedRegistries, want)
+	}
+	for _, img := range inv.Images {
+		if img.UnexpectedRegistry != (img.Ref.Registry == "ghcr.io") {
+			t.Errorf("%s: UnexpectedRegistry = %v", img.Ref, img.UnexpectedRegistry)
+		}
+	}
+}
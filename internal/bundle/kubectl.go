@@ -131,6 +131,7 @@ func ParseDeployments(extractPath string) ([]rancher.Deployment, error) {
 }
 
 // ParseServices parses kubectl get services output from bundle
+// Format: NAMESPACE NAME TYPE CLUSTER-IP EXTERNAL-IP PORT(S) AGE SELECTOR
 func ParseServices(extractPath string) ([]rancher.Service, error) {
 	// FIX BUG-003: Use getBundleRoot() to handle wrapper directories
 	bundleRoot := getBundleRoot(extractPath)
@@ -160,6 +161,18 @@ func ParseServices(extractPath string) ([]rancher.Service, error) {
 		// externalIP := fields[4]
 		portsStr := fields[5]
 
+		// Parse selector: "app=rancher-webhook,release=x" (only present with -o wide)
+		var selector map[string]string
+		if len(fields) >= 8 && fields[7] != "<none>" {
+			selector = make(map[string]string)
+			for _, pair := range strings.Split(fields[7], ",") {
+				kv := strings.SplitN(pair, "=", 2)
+				if len(kv) == 2 {
+					selector[kv[0]] = kv[1]
+				}
+			}
+		}
+
 		// Parse ports: "5473/TCP" or "9093/TCP,9094/TCP,9094/UDP"
 		var ports []rancher.ServicePort
 		for _, portStr := range strings.Split(portsStr, ",") {
@@ -184,6 +197,7 @@ func ParseServices(extractPath string) ([]rancher.Service, error) {
 			ClusterIP:   clusterIP,
 			Kind:        serviceType,
 			Ports:       ports,
+			Selector:    selector,
 			Created:     time.Now(), // Not in kubectl output
 		})
 	}
@@ -264,6 +278,42 @@ func parseKubectlAge(ageStr string) time.Time {
 	}
 }
 
+// kubectlAgeDuration converts a kubectl age like "14d", "3d4h" or "5m30s" to a duration.
+// Returns false for "<invalid>" or unparseable values.
+func kubectlAgeDuration(age string) (time.Duration, bool) {
+	units := map[byte]time.Duration{
+		'y': 365 * 24 * time.Hour,
+		'd': 24 * time.Hour,
+		'h': time.Hour,
+		'm': time.Minute,
+		's': time.Second,
+	}
+
+	var total time.Duration
+	value := -1
+	for i := 0; i < len(age); i++ {
+		c := age[i]
+		if c >= '0' && c <= '9' {
+			if value < 0 {
+				value = 0
+			}
+			value = value*10 + int(c-'0')
+			continue
+		}
+		unit, ok := units[c]
+		if !ok || value < 0 {
+			return 0, false
+		}
+		total += time.Duration(value) * unit
+		value = -1
+	}
+
+	if value >= 0 || age == "" {
+		return 0, false // Trailing number without a unit, or empty
+	}
+	return total, true
+}
+
 // ParsePods parses kubectl get pods output from bundle
 // Format: NAMESPACE NAME READY STATUS RESTARTS AGE IP NODE NOMINATED_NODE READINESS_GATES
 // Note: RESTARTS field can be "8" or "8 (4m53s ago)" - we need to handle variable field count
@@ -300,8 +350,12 @@ func ParsePods(extractPath string) ([]rancher.Pod, error) {
 		var node string
 		var restarts int
 
-		// Parse restart count from field[4]
+		// Parse restart count from field[4], and the last restart's age from "(4m53s ago)"
 		fmt.Sscanf(fields[4], "%d", &restarts)
+		lastRestart := ""
+		if len(fields) > 6 && strings.HasPrefix(fields[5], "(") && fields[6] == "ago)" {
+			lastRestart = strings.TrimPrefix(fields[5], "(")
+		}
 
 		// Find the IP field (starts with numbers and dots, or is IPv6)
 		// IP is always before NODE
@@ -353,6 +407,7 @@ func ParsePods(extractPath string) ([]rancher.Pod, error) {
 			KubectlIP:             ip,
 			KubectlReadinessGates: readinessGates,
 			KubectlRestarts:       restarts,
+			KubectlLastRestart:    lastRestart,
 		})
 	}
 
@@ -518,6 +573,184 @@ func ParseDaemonSets(extractPath string) ([]DaemonSetInfo, error) {
 	return daemonsets, nil
 }
 
+// ParseEndpoints parses kubectl get endpoints output from bundle
+// Format: NAMESPACE NAME ENDPOINTS AGE
+// Note: ENDPOINTS is a comma-separated "ip:port" list, "<none>", or truncated with "+ N more..."
+func ParseEndpoints(extractPath string) ([]rancher.Endpoints, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	path := filepath.Join(bundleRoot, "rke2/kubectl/endpoints")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(content), "\n")
+	var endpoints []rancher.Endpoints
+
+	for i, line := range lines {
+		if i == 0 || strings.TrimSpace(line) == "" {
+			continue // Skip header and empty lines
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+
+		ep := rancher.Endpoints{
+			Namespace: fields[0],
+			Name:      fields[1],
+		}
+
+		if fields[2] != "<none>" {
+			for _, addr := range strings.Split(fields[2], ",") {
+				if addr != "" {
+					ep.Addresses = append(ep.Addresses, addr)
+				}
+			}
+		}
+
+		endpoints = append(endpoints, ep)
+	}
+
+	return endpoints, nil
+}
+
+// ParseJobs parses kubectl get jobs output from bundle
+// Format: NAMESPACE NAME [STATUS] COMPLETIONS DURATION AGE [CONTAINERS IMAGES SELECTOR]
+// Note: STATUS is only printed by kubectl 1.28+
+func ParseJobs(extractPath string) ([]rancher.Job, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	path := filepath.Join(bundleRoot, "rke2/kubectl/jobs")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	table := ParseKubectlTable(content)
+	var jobs []rancher.Job
+
+	for _, row := range table.Rows {
+		name := table.Value(row, "NAME")
+		if name == "" {
+			continue
+		}
+
+		jobs = append(jobs, rancher.Job{
+			Namespace:   table.Value(row, "NAMESPACE"),
+			Name:        name,
+			Status:      table.Value(row, "STATUS"),
+			Completions: table.Value(row, "COMPLETIONS"),
+			Duration:    table.Value(row, "DURATION"),
+			Age:         table.Value(row, "AGE"),
+			Images:      splitList(table.Value(row, "IMAGES")),
+		})
+	}
+
+	return jobs, nil
+}
+
+// ParseWorkloads parses deployments, daemonsets and statefulsets into a common ready/desired form
+// Format: deployments/statefulsets READY "n/m"; daemonsets DESIRED and READY columns;
+// IMAGES and SELECTOR (-o wide) when present
+func ParseWorkloads(extractPath string) ([]WorkloadInfo, error) {
+	bundleRoot := getBundleRoot(extractPath)
+
+	var workloads []WorkloadInfo
+	found := false
+
+	for _, kind := range []string{"Deployment", "DaemonSet", "StatefulSet"} {
+		path := filepath.Join(bundleRoot, "rke2/kubectl", strings.ToLower(kind)+"s")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		found = true
+
+		table := ParseKubectlTable(content)
+		for _, row := range table.Rows {
+			w := WorkloadInfo{
+				Kind:      kind,
+				Namespace: table.Value(row, "NAMESPACE"),
+				Name:      table.Value(row, "NAME"),
+			}
+			if w.Name == "" {
+				continue
+			}
+
+			w.Selector = parseLabelSelector(table.Value(row, "SELECTOR"))
+			w.Images = splitList(table.Value(row, "IMAGES"))
+
+			if kind == "DaemonSet" {
+				fmt.Sscanf(table.Value(row, "READY"), "%d", &w.Ready)
+				fmt.Sscanf(table.Value(row, "DESIRED"), "%d", &w.Desired)
+			} else {
+				fmt.Sscanf(table.Value(row, "READY"), "%d/%d", &w.Ready, &w.Desired)
+			}
+
+			workloads = append(workloads, w)
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no workloads found in bundle")
+	}
+
+	return workloads, nil
+}
+
+// WorkloadInfo contains the ready state of a Deployment, DaemonSet or StatefulSet
+type WorkloadInfo struct {
+	Kind      string // "Deployment", "DaemonSet" or "StatefulSet"
+	Namespace string
+	Name      string
+	Ready     int
+	Desired   int
+	Selector  map[string]string // Pod label selector (matchLabels only); nil if not collected
+	Images    []string          // Container images (-o wide only)
+}
+
+// Healthy reports whether all desired replicas are ready
+func (w WorkloadInfo) Healthy() bool {
+	return w.Ready >= w.Desired
+}
+
+// PodsOf returns the names of pods created by the workload.
+// Deployment pods are <name>-<replicaset hash>-<suffix>; DaemonSet/StatefulSet pods <name>-<suffix>.
+func (w WorkloadInfo) PodsOf(pods []rancher.Pod) []string {
+	extraDashes := 0
+	if w.Kind == "Deployment" {
+		extraDashes = 1
+	}
+
+	var names []string
+	for _, pod := range pods {
+		if pod.NamespaceID == w.Namespace && isGeneratedName(pod.Name, w.Name, extraDashes) {
+			names = append(names, pod.Name)
+		}
+	}
+	return names
+}
+
+// parseLabelSelector parses a kubectl selector cell like "app=x,tier=web".
+// Set-based requirements ("app in (a,b)", "!key") cannot be expressed as matchLabels;
+// the selector is returned as nil so callers treat it as unresolvable.
+func parseLabelSelector(cell string) map[string]string {
+	if cell == "" || cell == "<none>" {
+		return nil
+	}
+
+	selector := make(map[string]string)
+	for _, part := range strings.Split(cell, ",") {
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 || strings.ContainsAny(kv[0], " !()") || strings.HasSuffix(kv[0], "!") {
+			return nil
+		}
+		selector[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return selector
+}
+
 // NodeInfo contains parsed node information
 type NodeInfo struct {
 	Name   string
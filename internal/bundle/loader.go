@@ -184,6 +184,7 @@ func loadFromExtractedPath(extractPath, originalPath string, size int64, opts Im
 		}
 		logFiles = []LogFileInfo{} // Empty slice
 	}
+	kubeletLogFiles, _ := InventoryKubeletLogFiles(extractPath)
 
 	// Parse kubectl resources (all optional)
 	crds, _ := ParseCRDs(extractPath)
@@ -218,19 +219,20 @@ func loadFromExtractedPath(extractPath, originalPath string, size int64, opts Im
 
 	// Create bundle
 	bundle := &Bundle{
-		Path:        originalPath,
-		ExtractPath: extractPath,
-		Manifest:    manifest,
-		Pods:        pods,
-		LogFiles:    logFiles,
-		CRDs:        crdsI,
-		Deployments: deploymentsI,
-		Services:    servicesI,
-		Namespaces:  namespacesI,
-		Events:      eventsI,
-		Loaded:      true,
-		Size:        size,
-		IsTemporary: false, // Bundles are already extracted, never temporary
+		Path:            originalPath,
+		ExtractPath:     extractPath,
+		Manifest:        manifest,
+		Pods:            pods,
+		LogFiles:        logFiles,
+		KubeletLogFiles: kubeletLogFiles,
+		CRDs:            crdsI,
+		Deployments:     deploymentsI,
+		Services:        servicesI,
+		Namespaces:      namespacesI,
+		Events:          eventsI,
+		Loaded:          true,
+		Size:            size,
+		IsTemporary:     false, // Bundles are already extracted, never temporary
 	}
 
 	return bundle, nil
@@ -0,0 +1,188 @@
+package bundle
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// maxSearchLineLength caps how much of a matching line is kept in a LogMatchInfo
+const maxSearchLineLength = 300
+
+// LineMatcher selects log lines for SearchLogs; *regexp.Regexp implements it
+type LineMatcher interface {
+	MatchString(s string) bool
+}
+
+// LogMatchInfo contains a single log line matched by SearchLogs
+type LogMatchInfo struct {
+	// Source is a short label for the log: "namespace/pod" for pod logs, file name otherwise
+	Source string
+
+	// Type is the log type of the file the line came from
+	Type LogType
+
+	// Namespace and PodName are set for pod logs only
+	Namespace string
+	PodName   string
+
+	// Path is the full path to the log file
+	Path string
+
+	// LineNumber is the 1-based line number within the file
+	LineNumber int
+
+	// Line is the matched line, truncated to maxSearchLineLength
+	Line string
+}
+
+// SearchLogs scans every inventoried log file (pod, system and journald logs) for lines
+// matching pattern. Scanning stops once maxMatches lines have been collected (0 = unlimited).
+// Unreadable files are skipped rather than failing the whole search.
+func (b *Bundle) SearchLogs(pattern LineMatcher, maxMatches int) []LogMatchInfo {
+	search := &LogSearch{Pattern: pattern, MaxMatches: maxMatches}
+	b.ScanLogs(search)
+	return search.Matches
+}
+
+// LogSearch is one of the searches run together by ScanLogs
+type LogSearch struct {
+	Pattern    LineMatcher
+	MaxMatches int                     // 0 = unlimited
+	Files      func(*LogFileInfo) bool // Log files to search, nil for all of them
+
+	// Matches is filled in by ScanLogs, in file and line order
+	Matches []LogMatchInfo
+}
+
+// done reports whether the search has collected all the matches it wants
+func (s *LogSearch) done() bool {
+	return s.MaxMatches > 0 && len(s.Matches) >= s.MaxMatches
+}
+
+// ScanLogs runs several searches in one pass over the inventoried log files: each file is
+// read once, for the searches that want it and still need matches. Unreadable files are
+// skipped rather than failing the whole scan.
+func (b *Bundle) ScanLogs(searches ...*LogSearch) {
+	var active []*LogSearch
+	for i := range b.LogFiles {
+		logFile := &b.LogFiles[i]
+		active = active[:0]
+		for _, s := range searches {
+			if !s.done() && (s.Files == nil || s.Files(logFile)) {
+				active = append(active, s)
+			}
+		}
+		if len(active) > 0 {
+			scanLogFile(logFile, active)
+		}
+	}
+}
+
+// scanLogFile adds the lines of a log file matching each search to its matches
+func scanLogFile(logFile *LogFileInfo, searches []*LogSearch) {
+	f, err := openLogFile(logFile.Path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	source := logFile.Source()
+
+	scanner := bufio.NewScanner(f)
+	// Pod logs can contain very long JSON lines - allow up to 1MB per line
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	lineNum := 0
+	pending := len(searches)
+	for pending > 0 && scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		for _, s := range searches {
+			if s.done() || !s.Pattern.MatchString(line) {
+				continue
+			}
+
+			text := line
+			if len(text) > maxSearchLineLength {
+				text = text[:maxSearchLineLength-3] + "..."
+			}
+
+			s.Matches = append(s.Matches, LogMatchInfo{
+				Source:     source,
+				Type:       logFile.Type,
+				Namespace:  logFile.Namespace,
+				PodName:    logFile.PodName,
+				Path:       logFile.Path,
+				LineNumber: lineNum,
+				Line:       text,
+			})
+			if s.done() {
+				pending--
+			}
+		}
+	}
+}
+
+// LogPattern is a regexp guarded by literal strings: only lines containing one of them are
+// run through the regexp. On large logs a strings.Contains prefilter is much cheaper than an
+// unanchored alternation, which the regexp engine tries at every byte.
+type LogPattern struct {
+	literals []string
+	re       *regexp.Regexp
+}
+
+// NewLogPattern compiles expr, every match of which must contain one of literals
+func NewLogPattern(expr string, literals ...string) *LogPattern {
+	return &LogPattern{literals: literals, re: regexp.MustCompile(expr)}
+}
+
+// MatchString reports whether line contains one of the literals and matches the regexp
+func (p *LogPattern) MatchString(line string) bool {
+	for _, lit := range p.literals {
+		if strings.Contains(line, lit) {
+			return p.re.MatchString(line)
+		}
+	}
+	return false
+}
+
+// Source returns a short label for the log file: "namespace/pod" for pod logs, file name otherwise
+func (l *LogFileInfo) Source() string {
+	if l.Type == LogTypePod {
+		return l.Namespace + "/" + l.PodName
+	}
+	return filepath.Base(l.Path)
+}
+
+// gzipFile closes both the gzip reader and the file underneath it
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g gzipFile) Close() error {
+	g.Reader.Close()
+	return g.file.Close()
+}
+
+// openLogFile opens a log file for reading, decompressing rotated .gz logs
+func openLogFile(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return f, nil
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return gzipFile{Reader: zr, file: f}, nil
+}
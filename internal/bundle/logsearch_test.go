@@ -0,0 +1,70 @@
+package bundle
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// TestScanLogs tests running searches with their own file filters and caps in one pass
+func TestScanLogs(t *testing.T) {
+	root := t.TempDir()
+	writeBundleFile(t, root, "rke2/podlogs/kube-system-kube-apiserver-node-1", `E1204 09:00:00.000000 1 controller.go:113] loading OpenAPI spec for "v1beta1.metrics.k8s.io" failed with: failing or missing response from https://10.42.0.9:10250/apis/metrics.k8s.io/v1beta1
+E1204 09:00:01.000000 1 available_controller.go:456] v1beta1.metrics.k8s.io failed with: failing or missing response from https://10.42.0.9:10250
+`)
+	writeBundleFile(t, root, "rke2/podlogs/kube-system-rke2-metrics-server-abc", `E1204 09:00:02.000000 1 scraper.go:140] "Failed to scrape node" err="x509: certificate has expired"
+`)
+	writeBundleFile(t, root, "rke2/podlogs/apps-web-1", `error: unable to retrieve the complete list of server APIs: metrics.k8s.io/v1beta1
+`)
+	writeBundleFile(t, root, "journald/rke2-server", `Dec  4 09:00:03 node-1 rke2[1]: E1204 09:00:03 memcache.go:287] couldn't get resource list for metrics.k8s.io/v1beta1: the server is currently unable to handle the request, FailedDiscoveryCheck
+`)
+
+	logFiles, err := InventoryLogFiles(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &Bundle{ExtractPath: root, LogFiles: logFiles}
+
+	infos := []APIServiceInfo{{
+		APIService:      rancher.APIService{Name: "v1beta1.metrics.k8s.io", ServiceNamespace: "kube-system", ServiceName: "rke2-metrics-server"},
+		ServiceBackends: ServiceBackends{BackendPods: []string{"rke2-metrics-server-abc"}},
+	}}
+	apiServices := &LogSearch{Pattern: APIServiceFailurePattern, Files: APIServiceLogFiles(infos)}
+	certificates := &LogSearch{Pattern: CertificateErrorPattern}
+	firstError := &LogSearch{Pattern: regexp.MustCompile(`^E1204`), MaxMatches: 1}
+	b.ScanLogs(apiServices, certificates, firstError)
+
+	// apps/web-1 is neither a control plane nor a backend pod, so its discovery error is skipped
+	var sources []string
+	for _, m := range apiServices.Matches {
+		sources = append(sources, m.Source)
+	}
+	want := []string{"kube-system/kube-apiserver-node-1", "kube-system/kube-apiserver-node-1", "rke2-server"}
+	if len(sources) != len(want) {
+		t.Fatalf("APIService matches from %v, want %v", sources, want)
+	}
+	for i := range want {
+		if sources[i] != want[i] {
+			t.Errorf("APIService match %d from %s, want %s", i, sources[i], want[i])
+		}
+	}
+
+	if len(certificates.Matches) != 1 || certificates.Matches[0].PodName != "rke2-metrics-server-abc" {
+		t.Errorf("certificate matches = %+v", certificates.Matches)
+	}
+	if len(firstError.Matches) != 1 || firstError.Matches[0].LineNumber != 1 {
+		t.Errorf("capped search matches = %+v, want the first line only", firstError.Matches)
+	}
+}
+
+// TestLogPattern tests that the regexp only matches lines passing the literal prefilter
+func TestLogPattern(t *testing.T) {
+	p := NewLogPattern(`dial tcp [^ ]+: i/o timeout`, "i/o timeout")
+	if !p.MatchString("dial tcp 10.43.0.1:443: i/o timeout") {
+		t.Error("expected a match")
+	}
+	if p.MatchString("dial tcp 10.43.0.1:443: connection refused") || p.MatchString("read: i/o timeout") {
+		t.Error("expected no match")
+	}
+}
@@ -24,6 +24,10 @@ func ParseManifest(extractPath string) (*BundleManifest, error) {
 	// Extract node name from directory structure or filename
 	manifest.NodeName = extractNodeName(extractPath)
 
+	if collected := bundleCollectedAt(extractPath); !collected.IsZero() {
+		manifest.CollectedAt = collected
+	}
+
 	// Count files and calculate total size
 	fileCount, totalSize, err := calculateBundleStats(extractPath)
 	if err != nil {
@@ -113,6 +117,28 @@ func extractNodeName(extractPath string) string {
 	return baseName
 }
 
+// bundleCollectedAt parses the collection time from the bundle directory name
+// (<nodename>-2025-11-27_04_19_09), falling back to systeminfo/date; returns the
+// zero time if neither is usable
+func bundleCollectedAt(extractPath string) time.Time {
+	bundleRoot := getBundleRoot(extractPath)
+	baseName := filepath.Base(bundleRoot)
+	const layout = "2006-01-02_15_04_05"
+	if len(baseName) >= len(layout) {
+		if t, err := time.Parse(layout, baseName[len(baseName)-len(layout):]); err == nil {
+			return t
+		}
+	}
+
+	// Format: Thu Dec  4 09:15:58 UTC 2025
+	if data, err := os.ReadFile(filepath.Join(bundleRoot, "systeminfo", "date")); err == nil {
+		if t, err := time.Parse(time.UnixDate, strings.TrimSpace(string(data))); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
 // parseRKE2Version attempts to read the RKE2 version from the bundle.
 func parseRKE2Version(extractPath string) string {
 	bundleRoot := getBundleRoot(extractPath)
@@ -353,5 +379,54 @@ func InventoryLogFiles(extractPath string) ([]LogFileInfo, error) {
 		}
 	}
 
+	// Scan journald unit logs (rke2-server, rke2-agent, rancher-system-agent, ...)
+	journaldDir := filepath.Join(bundleRoot, "journald")
+	if stat, err := os.Stat(journaldDir); err == nil && stat.IsDir() {
+		err := filepath.Walk(journaldDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+
+			logInfo := LogFileInfo{
+				Path: path,
+				Type: LogTypeJournald,
+				Size: info.Size(),
+			}
+			logFiles = append(logFiles, logInfo)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return logFiles, nil
+}
+
+// InventoryKubeletLogFiles scans rke2/agent-logs for kubelet logs. They are kept out of
+// InventoryLogFiles: the rotated files are large and gzipped, so only the timeline reads them.
+func InventoryKubeletLogFiles(extractPath string) ([]LogFileInfo, error) {
+	var logFiles []LogFileInfo
+
+	agentLogsDir := filepath.Join(getBundleRoot(extractPath), "rke2", "agent-logs")
+	if stat, err := os.Stat(agentLogsDir); err == nil && stat.IsDir() {
+		err := filepath.Walk(agentLogsDir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+
+			logInfo := LogFileInfo{
+				Path: path,
+				Type: LogTypeKubelet,
+				Size: info.Size(),
+			}
+			logFiles = append(logFiles, logInfo)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return logFiles, nil
 }
@@ -0,0 +1,496 @@
+package bundle
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Metric types from "# TYPE" lines; samples with no TYPE line are untyped
+const (
+	MetricTypeCounter   = "counter"
+	MetricTypeGauge     = "gauge"
+	MetricTypeHistogram = "histogram"
+	MetricTypeSummary   = "summary"
+	MetricTypeUntyped   = "untyped"
+)
+
+// Metric check results (see MetricCheck.Status)
+const (
+	MetricCheckOK       = "ok"
+	MetricCheckWarning  = "warning"
+	MetricCheckCritical = "critical"
+)
+
+// etcd metric thresholds, from the etcd tuning and hardware guides
+const (
+	etcdWALFsyncP99Warn          = 0.010 // 10ms: disk too slow for etcd
+	etcdWALFsyncP99Critical      = 0.100 // 100ms: a heartbeat interval, causes elections
+	etcdBackendCommitP99Warn     = 0.025 // 25ms
+	etcdBackendCommitP99Critical = 0.250
+	etcdLeaderChangesWarn        = 3    // Includes the initial election
+	etcdSlowApplyRatioWarn       = 0.01 // 1% of applied proposals took over 100ms
+)
+
+// MetricSample is one line of Prometheus text output, e.g. `etcd_server_has_leader 1`
+type MetricSample struct {
+	Name   string // Sample name, including _bucket/_sum/_count for histograms and summaries
+	Labels map[string]string
+	Value  float64
+}
+
+// MetricFamily groups the samples of one metric under its HELP and TYPE
+type MetricFamily struct {
+	Name    string
+	Help    string
+	Type    string // MetricType* constant
+	Samples []MetricSample
+}
+
+// HistogramBucket is a cumulative histogram bucket: Count observations <= UpperBound
+type HistogramBucket struct {
+	UpperBound float64
+	Count      float64
+}
+
+// Histogram is one label set of a histogram family
+type Histogram struct {
+	Labels  map[string]string // Without "le"
+	Buckets []HistogramBucket // Sorted by UpperBound, ending with +Inf
+	Sum     float64
+	Count   float64
+}
+
+// MetricsFile is a parsed metrics scrape saved in the bundle
+type MetricsFile struct {
+	Component string // e.g. "etcd"
+	Path      string // Relative to the bundle root
+	Families  []MetricFamily
+	Checks    []MetricCheck
+}
+
+// MetricCheck is the result of a built-in check against a component's metrics
+type MetricCheck struct {
+	Name   string // e.g. "WAL fsync p99"
+	Metric string // Metric family checked
+	Status string // MetricCheck* constant
+	Value  string // Observed value, formatted, e.g. "4.2ms"
+	Detail string
+}
+
+// metricsFiles lists the scrapes collected by the bundle, by component. Other components'
+// scrapes are parsed and checked the same way once added here.
+var metricsFiles = []struct {
+	component string
+	pattern   string
+	checks    func([]MetricFamily) []MetricCheck
+}{
+	{"etcd", "etcd/etcd-metrics-*", EtcdMetricChecks},
+}
+
+// ParseMetricsFiles parses every known metrics scrape in the bundle and runs the
+// component's built-in checks
+func ParseMetricsFiles(extractPath string) ([]MetricsFile, error) {
+	bundleRoot := getBundleRoot(extractPath)
+
+	var files []MetricsFile
+	for _, source := range metricsFiles {
+		paths, _ := filepath.Glob(filepath.Join(bundleRoot, source.pattern))
+		sort.Strings(paths)
+		for _, path := range paths {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			rel, _ := filepath.Rel(bundleRoot, path)
+			file := MetricsFile{
+				Component: source.component,
+				Path:      rel,
+				Families:  ParsePrometheusText(content),
+			}
+			if source.checks != nil {
+				file.Checks = source.checks(file.Families)
+			}
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
+
+// ParsePrometheusText parses the Prometheus text exposition format. Samples are grouped
+// into families by their "# TYPE" line, with histogram and summary _bucket/_sum/_count
+// samples under the base name. Malformed lines (e.g. a truncated scrape) are skipped.
+func ParsePrometheusText(content []byte) []MetricFamily {
+	var families []MetricFamily
+	byName := make(map[string]int) // family name → index in families
+
+	family := func(name string) *MetricFamily {
+		if i, ok := byName[name]; ok {
+			return &families[i]
+		}
+		byName[name] = len(families)
+		families = append(families, MetricFamily{Name: name, Type: MetricTypeUntyped})
+		return &families[len(families)-1]
+	}
+
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "#") {
+			// Format: # HELP <name> <text> / # TYPE <name> <type>
+			fields := strings.SplitN(strings.TrimSpace(line[1:]), " ", 3)
+			if len(fields) < 3 {
+				continue
+			}
+			switch fields[0] {
+			case "HELP":
+				family(fields[1]).Help = fields[2]
+			case "TYPE":
+				family(fields[1]).Type = strings.TrimSpace(fields[2])
+			}
+			continue
+		}
+
+		sample, ok := parseMetricSample(line)
+		if !ok {
+			continue
+		}
+		name := sample.Name
+		for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+			base := strings.TrimSuffix(sample.Name, suffix)
+			if base == sample.Name {
+				continue
+			}
+			if i, ok := byName[base]; ok && (families[i].Type == MetricTypeHistogram || families[i].Type == MetricTypeSummary) {
+				name = base
+				break
+			}
+		}
+		f := family(name)
+		f.Samples = append(f.Samples, sample)
+	}
+
+	return families
+}
+
+// parseMetricSample parses `name{label="value",...} value [timestamp]`
+func parseMetricSample(line string) (MetricSample, bool) {
+	sample := MetricSample{Labels: map[string]string{}}
+
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return sample, false
+	}
+	sample.Name = line[:end]
+	rest := line[end:]
+
+	if strings.HasPrefix(rest, "{") {
+		labels, n, ok := parseMetricLabels(rest)
+		if !ok {
+			return sample, false
+		}
+		sample.Labels = labels
+		rest = rest[n:]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return sample, false
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return sample, false
+	}
+	sample.Value = value
+	return sample, true
+}
+
+// parseMetricLabels parses a `{a="x",b="y"}` label block, returning the labels and the
+// number of bytes consumed. Label values may contain escaped quotes, backslashes and newlines.
+func parseMetricLabels(s string) (map[string]string, int, bool) {
+	labels := make(map[string]string)
+	i := 1 // Skip '{'
+	for {
+		for i < len(s) && (s[i] == ' ' || s[i] == ',') {
+			i++
+		}
+		if i >= len(s) {
+			return nil, 0, false
+		}
+		if s[i] == '}' {
+			return labels, i + 1, true
+		}
+
+		eq := strings.IndexByte(s[i:], '=')
+		if eq < 0 {
+			return nil, 0, false
+		}
+		name := strings.TrimSpace(s[i : i+eq])
+		i += eq + 1
+		if i >= len(s) || s[i] != '"' {
+			return nil, 0, false
+		}
+		i++
+
+		var value strings.Builder
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			value.WriteByte(s[i])
+		}
+		if i >= len(s) {
+			return nil, 0, false
+		}
+		i++ // Closing quote
+		labels[name] = value.String()
+	}
+}
+
+// FindMetricFamily returns the family with the given name, or nil
+func FindMetricFamily(families []MetricFamily, name string) *MetricFamily {
+	for i := range families {
+		if families[i].Name == name {
+			return &families[i]
+		}
+	}
+	return nil
+}
+
+// metricValue returns the sum of a counter or gauge's samples across label sets
+func metricValue(families []MetricFamily, name string) (float64, bool) {
+	f := FindMetricFamily(families, name)
+	if f == nil || len(f.Samples) == 0 {
+		return 0, false
+	}
+	total := 0.0
+	for _, s := range f.Samples {
+		total += s.Value
+	}
+	return total, true
+}
+
+// MetricLabelsKey returns a stable key for a label set, e.g. `op="Txn",success="true"`
+func MetricLabelsKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%q", name, labels[name])
+	}
+	return strings.Join(parts, ",")
+}
+
+// Histograms returns one Histogram per label set of a histogram family, in scrape order
+func (f MetricFamily) Histograms() []Histogram {
+	if f.Type != MetricTypeHistogram {
+		return nil
+	}
+
+	var histograms []Histogram
+	byKey := make(map[string]int)
+	for _, s := range f.Samples {
+		labels := make(map[string]string, len(s.Labels))
+		for k, v := range s.Labels {
+			if k != "le" {
+				labels[k] = v
+			}
+		}
+		key := MetricLabelsKey(labels)
+		i, ok := byKey[key]
+		if !ok {
+			i = len(histograms)
+			byKey[key] = i
+			histograms = append(histograms, Histogram{Labels: labels})
+		}
+		h := &histograms[i]
+
+		switch s.Name {
+		case f.Name + "_bucket":
+			bound, err := strconv.ParseFloat(s.Labels["le"], 64)
+			if err == nil {
+				h.Buckets = append(h.Buckets, HistogramBucket{UpperBound: bound, Count: s.Value})
+			}
+		case f.Name + "_sum":
+			h.Sum = s.Value
+		case f.Name + "_count":
+			h.Count = s.Value
+		}
+	}
+
+	for i := range histograms {
+		sort.Slice(histograms[i].Buckets, func(a, b int) bool {
+			return histograms[i].Buckets[a].UpperBound < histograms[i].Buckets[b].UpperBound
+		})
+	}
+	return histograms
+}
+
+// MergeHistograms adds histograms with the same bucket bounds, e.g. all label sets of a family
+func MergeHistograms(histograms []Histogram) Histogram {
+	merged := Histogram{Labels: map[string]string{}}
+	counts := make(map[float64]float64)
+	for _, h := range histograms {
+		merged.Sum += h.Sum
+		merged.Count += h.Count
+		for _, b := range h.Buckets {
+			counts[b.UpperBound] += b.Count
+		}
+	}
+	for bound, count := range counts {
+		merged.Buckets = append(merged.Buckets, HistogramBucket{UpperBound: bound, Count: count})
+	}
+	sort.Slice(merged.Buckets, func(i, j int) bool {
+		return merged.Buckets[i].UpperBound < merged.Buckets[j].UpperBound
+	})
+	return merged
+}
+
+// Quantile estimates the q-quantile (0..1) by linear interpolation within the bucket
+// holding it, as PromQL's histogram_quantile does. Returns NaN with no observations;
+// a quantile in the +Inf bucket returns the highest finite bound.
+func (h Histogram) Quantile(q float64) float64 {
+	n := len(h.Buckets)
+	if n < 2 || !math.IsInf(h.Buckets[n-1].UpperBound, 1) {
+		return math.NaN()
+	}
+	total := h.Buckets[n-1].Count
+	if total == 0 {
+		return math.NaN()
+	}
+
+	rank := q * total
+	i := sort.Search(n-1, func(i int) bool { return h.Buckets[i].Count >= rank })
+	if i == n-1 {
+		return h.Buckets[n-2].UpperBound
+	}
+
+	start, below := 0.0, 0.0
+	if i > 0 {
+		start = h.Buckets[i-1].UpperBound
+		below = h.Buckets[i-1].Count
+	} else if h.Buckets[0].UpperBound <= 0 {
+		return h.Buckets[0].UpperBound
+	}
+	end := h.Buckets[i].UpperBound
+	inBucket := h.Buckets[i].Count - below
+	if inBucket == 0 {
+		return end
+	}
+	return start + (end-start)*(rank-below)/inBucket
+}
+
+// formatSeconds formats a latency in seconds, e.g. "850µs", "4.2ms", "1.25s"
+func formatSeconds(s float64) string {
+	switch {
+	case math.IsNaN(s):
+		return "-"
+	case s < 0.001:
+		return fmt.Sprintf("%.0fµs", s*1e6)
+	case s < 1:
+		return fmt.Sprintf("%.1fms", s*1e3)
+	default:
+		return fmt.Sprintf("%.2fs", s)
+	}
+}
+
+// EtcdMetricChecks checks an etcd scrape for slow disks (WAL fsync and backend commit p99),
+// leader loss and churn, failed proposals and slow applies. Checks whose metrics are
+// missing from the scrape are skipped.
+func EtcdMetricChecks(families []MetricFamily) []MetricCheck {
+	var checks []MetricCheck
+
+	latency := func(name, metric string, warn, critical float64, detail string) {
+		f := FindMetricFamily(families, metric)
+		if f == nil {
+			return
+		}
+		p99 := MergeHistograms(f.Histograms()).Quantile(0.99)
+		if math.IsNaN(p99) {
+			return
+		}
+		check := MetricCheck{Name: name, Metric: metric, Status: MetricCheckOK, Value: formatSeconds(p99)}
+		switch {
+		case p99 > critical:
+			check.Status = MetricCheckCritical
+		case p99 > warn:
+			check.Status = MetricCheckWarning
+		}
+		if check.Status == MetricCheckOK {
+			check.Detail = fmt.Sprintf("p99 under %s", formatSeconds(warn))
+		} else {
+			check.Detail = fmt.Sprintf("p99 %s exceeds %s: %s", check.Value, formatSeconds(warn), detail)
+		}
+		checks = append(checks, check)
+	}
+	latency("WAL fsync p99", "etcd_disk_wal_fsync_duration_seconds", etcdWALFsyncP99Warn, etcdWALFsyncP99Critical,
+		"disk too slow for etcd, expect heartbeat timeouts and leader elections")
+	latency("Backend commit p99", "etcd_disk_backend_commit_duration_seconds", etcdBackendCommitP99Warn, etcdBackendCommitP99Critical,
+		"slow disk or large DB, writes are delayed")
+
+	if hasLeader, ok := metricValue(families, "etcd_server_has_leader"); ok {
+		check := MetricCheck{Name: "Has leader", Metric: "etcd_server_has_leader", Status: MetricCheckOK, Value: "yes", Detail: "member sees a leader"}
+		if hasLeader == 0 {
+			check.Status = MetricCheckCritical
+			check.Value = "no"
+			check.Detail = "member has no leader: lost quorum or partitioned"
+		}
+		checks = append(checks, check)
+	}
+
+	if changes, ok := metricValue(families, "etcd_server_leader_changes_seen_total"); ok {
+		check := MetricCheck{Name: "Leader changes", Metric: "etcd_server_leader_changes_seen_total", Status: MetricCheckOK,
+			Value: fmt.Sprintf("%.0f", changes), Detail: "stable leadership since etcd started"}
+		if changes > etcdLeaderChangesWarn {
+			check.Status = MetricCheckWarning
+			check.Detail = fmt.Sprintf("%.0f leader changes since etcd started: slow disk, network or overloaded members", changes)
+		}
+		checks = append(checks, check)
+	}
+
+	if failed, ok := metricValue(families, "etcd_server_proposals_failed_total"); ok {
+		check := MetricCheck{Name: "Proposal failures", Metric: "etcd_server_proposals_failed_total", Status: MetricCheckOK,
+			Value: fmt.Sprintf("%.0f", failed), Detail: "no failed proposals"}
+		if failed > 0 {
+			check.Status = MetricCheckWarning
+			check.Detail = fmt.Sprintf("%.0f proposals failed, usually from leader elections or lost quorum", failed)
+		}
+		checks = append(checks, check)
+	}
+
+	if slow, ok := metricValue(families, "etcd_server_slow_apply_total"); ok {
+		applied, _ := metricValue(families, "etcd_server_proposals_applied_total")
+		check := MetricCheck{Name: "Slow applies", Metric: "etcd_server_slow_apply_total", Status: MetricCheckOK,
+			Value: fmt.Sprintf("%.0f", slow), Detail: "applies complete within 100ms"}
+		if applied > 0 {
+			ratio := slow / applied
+			check.Value = fmt.Sprintf("%.0f (%.2f%%)", slow, ratio*100)
+			check.Detail = fmt.Sprintf("%.2f%% of applied proposals took over 100ms", ratio*100)
+			if ratio > etcdSlowApplyRatioWarn {
+				check.Status = MetricCheckWarning
+				check.Detail += ": slow disk, expensive requests or CPU starvation"
+			}
+		}
+		checks = append(checks, check)
+	}
+
+	return checks
+}
@@ -0,0 +1,147 @@
+package bundle
+
+import (
+	"math"
+	"testing"
+)
+
+// TestParsePrometheusText tests families, labels with escapes, and histogram grouping
+func TestParsePrometheusText(t *testing.T) {
+	families := ParsePrometheusText([]byte(`# HELP etcd_server_has_leader Whether or not a leader exists. 1 is existence, 0 is not.
+# TYPE etcd_server_has_leader gauge
+etcd_server_has_leader 1
+# TYPE grpc_server_handled_total counter
+grpc_server_handled_total{grpc_code="OK",grpc_method="Range"} 120
+grpc_server_handled_total{grpc_code="Unavailable",grpc_method="Range"} 3 1733303757000
+# TYPE request_seconds histogram
+request_seconds_bucket{op="get",le="0.1"} 50
+request_seconds_bucket{op="get",le="0.2"} 90
+request_seconds_bucket{op="get",le="+Inf"} 100
+request_seconds_sum{op="get"} 12.5
+request_seconds_count{op="get"} 100
+request_seconds_bucket{op="put",le="0.1"} 0
+request_seconds_bucket{op="put",le="0.2"} 10
+request_seconds_bucket{op="put",le="+Inf"} 10
+request_seconds_count{op="put"} 10
+weird_labels{path="C:\\tmp",msg="say \"hi\""} 2
+truncated_line{a="b
+`))
+
+	if f := FindMetricFamily(families, "etcd_server_has_leader"); f == nil || f.Type != MetricTypeGauge || f.Help == "" || len(f.Samples) != 1 {
+		t.Errorf("has_leader family: %+v", f)
+	}
+	if v, ok := metricValue(families, "grpc_server_handled_total"); !ok || v != 123 {
+		t.Errorf("grpc_server_handled_total = %v, %v; want 123 across label sets", v, ok)
+	}
+
+	weird := FindMetricFamily(families, "weird_labels")
+	if weird == nil || weird.Type != MetricTypeUntyped || weird.Samples[0].Labels["path"] != `C:\tmp` || weird.Samples[0].Labels["msg"] != `say "hi"` {
+		t.Errorf("escaped labels: %+v", weird)
+	}
+	if FindMetricFamily(families, "truncated_line") != nil {
+		t.Errorf("malformed line should be skipped")
+	}
+
+	f := FindMetricFamily(families, "request_seconds")
+	if f == nil || len(f.Samples) != 9 {
+		t.Fatalf("histogram samples not grouped under base name: %+v", f)
+	}
+	histograms := f.Histograms()
+	if len(histograms) != 2 || histograms[0].Labels["op"] != "get" || histograms[0].Count != 100 || histograms[0].Sum != 12.5 {
+		t.Fatalf("histograms: %+v", histograms)
+	}
+	if got := MetricLabelsKey(histograms[0].Labels); got != `op="get"` {
+		t.Errorf("labels key = %s, le should be dropped", got)
+	}
+}
+
+// TestHistogramQuantile tests interpolation, the +Inf bucket and merging label sets
+func TestHistogramQuantile(t *testing.T) {
+	h := Histogram{Buckets: []HistogramBucket{
+		{UpperBound: 0.1, Count: 50},
+		{UpperBound: 0.2, Count: 90},
+		{UpperBound: math.Inf(1), Count: 100},
+	}}
+
+	tests := []struct {
+		q    float64
+		want float64
+	}{
+		{0.25, 0.05}, // Halfway into the first bucket
+		{0.7, 0.15},  // 20 of 40 observations into the second bucket
+		{0.99, 0.2},  // +Inf bucket: highest finite bound
+	}
+	for _, tt := range tests {
+		if got := h.Quantile(tt.q); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Quantile(%v) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+
+	if got := (Histogram{}).Quantile(0.99); !math.IsNaN(got) {
+		t.Errorf("empty histogram quantile = %v, want NaN", got)
+	}
+
+	merged := MergeHistograms([]Histogram{h, {Buckets: []HistogramBucket{
+		{UpperBound: 0.1, Count: 0},
+		{UpperBound: 0.2, Count: 0},
+		{UpperBound: math.Inf(1), Count: 100},
+	}}})
+	if len(merged.Buckets) != 3 || merged.Buckets[2].Count != 200 {
+		t.Fatalf("merged buckets: %+v", merged.Buckets)
+	}
+	if got := merged.Quantile(0.5); got != 0.2 {
+		t.Errorf("merged median = %v, want 0.2 (+Inf bucket)", got)
+	}
+}
+
+// TestEtcdMetricChecks tests disk latency, leader and proposal checks
+func TestEtcdMetricChecks(t *testing.T) {
+	families := ParsePrometheusText([]byte(`# TYPE etcd_disk_wal_fsync_duration_seconds histogram
+etcd_disk_wal_fsync_duration_seconds_bucket{le="0.004"} 100
+etcd_disk_wal_fsync_duration_seconds_bucket{le="0.008"} 900
+etcd_disk_wal_fsync_duration_seconds_bucket{le="0.016"} 960
+etcd_disk_wal_fsync_duration_seconds_bucket{le="0.032"} 1000
+etcd_disk_wal_fsync_duration_seconds_bucket{le="+Inf"} 1000
+# TYPE etcd_disk_backend_commit_duration_seconds histogram
+etcd_disk_backend_commit_duration_seconds_bucket{le="0.008"} 1000
+etcd_disk_backend_commit_duration_seconds_bucket{le="+Inf"} 1000
+# TYPE etcd_server_has_leader gauge
+etcd_server_has_leader 0
+# TYPE etcd_server_leader_changes_seen_total counter
+etcd_server_leader_changes_seen_total 7
+# TYPE etcd_server_proposals_failed_total counter
+etcd_server_proposals_failed_total 0
+# TYPE etcd_server_proposals_applied_total gauge
+etcd_server_proposals_applied_total 1000
+# TYPE etcd_server_slow_apply_total counter
+etcd_server_slow_apply_total 50
+`))
+
+	checks := make(map[string]MetricCheck)
+	for _, c := range EtcdMetricChecks(families) {
+		checks[c.Name] = c
+	}
+
+	want := map[string]string{
+		"WAL fsync p99":      MetricCheckWarning, // ~30ms
+		"Backend commit p99": MetricCheckOK,
+		"Has leader":         MetricCheckCritical,
+		"Leader changes":     MetricCheckWarning,
+		"Proposal failures":  MetricCheckOK,
+		"Slow applies":       MetricCheckWarning, // 5%
+	}
+	for name, status := range want {
+		c, ok := checks[name]
+		if !ok {
+			t.Errorf("missing check %q", name)
+			continue
+		}
+		if c.Status != status {
+			t.Errorf("%s: status = %s (%s, %s), want %s", name, c.Status, c.Value, c.Detail, status)
+		}
+	}
+
+	if got := EtcdMetricChecks(nil); len(got) != 0 {
+		t.Errorf("checks without metrics should be skipped, got %+v", got)
+	}
+}
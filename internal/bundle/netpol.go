@@ -0,0 +1,296 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// NetworkPolicyInfo contains a NetworkPolicy and the bundle pods it selects
+type NetworkPolicyInfo struct {
+	rancher.NetworkPolicy
+	SelectedPods []string // Pod names in the policy's namespace
+	Exact        bool     // SelectedPods is complete (empty selector); otherwise a lower bound
+	Resolution   string   // How SelectedPods was determined, shown to the user
+	DenyIngress  bool     // Selected pods accept no ingress traffic
+	DenyEgress   bool     // Selected pods can send no egress traffic
+	Inferred     bool     // Deny/allow-all inferred from the name (table output has no rules)
+}
+
+// DefaultDeny reports whether the policy isolates every pod in its namespace with no allowed traffic
+func (n *NetworkPolicyInfo) DefaultDeny() bool {
+	return n.Exact && (n.DenyIngress || n.DenyEgress)
+}
+
+// ParseNetworkPolicies parses kubectl get networkpolicies output from bundle
+// Format: NAMESPACE NAME POD-SELECTOR AGE (table output), or a full -o yaml / -o json list
+func ParseNetworkPolicies(extractPath string) ([]rancher.NetworkPolicy, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	path := filepath.Join(bundleRoot, "rke2/kubectl/networkpolicies")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var policies []rancher.NetworkPolicy
+
+	if isStructuredOutput(content) {
+		// Only the rule counts and whether a rule is empty matter, so peers are left untyped
+		var list struct {
+			Items []struct {
+				Metadata struct {
+					Name      string `yaml:"name"`
+					Namespace string `yaml:"namespace"`
+				} `yaml:"metadata"`
+				Spec struct {
+					PodSelector struct {
+						MatchLabels      map[string]string `yaml:"matchLabels"`
+						MatchExpressions []interface{}     `yaml:"matchExpressions"`
+					} `yaml:"podSelector"`
+					PolicyTypes []string                 `yaml:"policyTypes"`
+					Ingress     []map[string]interface{} `yaml:"ingress"`
+					Egress      []map[string]interface{} `yaml:"egress"`
+				} `yaml:"spec"`
+			} `yaml:"items"`
+		}
+		if err := yaml.Unmarshal(content, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse networkpolicies: %w", err)
+		}
+
+		for _, item := range list.Items {
+			policy := rancher.NetworkPolicy{
+				Namespace:    item.Metadata.Namespace,
+				Name:         item.Metadata.Name,
+				PodSelector:  item.Spec.PodSelector.MatchLabels,
+				SelectorText: formatLabelSelector(item.Spec.PodSelector.MatchLabels),
+				PolicyTypes:  item.Spec.PolicyTypes,
+				IngressRules: len(item.Spec.Ingress),
+				EgressRules:  len(item.Spec.Egress),
+				HasSpec:      true,
+			}
+			if len(item.Spec.PodSelector.MatchExpressions) > 0 {
+				policy.SelectorText = strings.TrimPrefix(policy.SelectorText+",<expressions>", "<none>,")
+			}
+
+			// Kubernetes defaults policyTypes to Ingress, plus Egress if egress rules exist
+			if len(policy.PolicyTypes) == 0 {
+				policy.PolicyTypes = []string{"Ingress"}
+				if policy.EgressRules > 0 {
+					policy.PolicyTypes = append(policy.PolicyTypes, "Egress")
+				}
+			}
+
+			for _, rule := range append(item.Spec.Ingress, item.Spec.Egress...) {
+				if len(rule) == 0 {
+					policy.AllowAll = true
+				}
+			}
+
+			policies = append(policies, policy)
+		}
+		return policies, nil
+	}
+
+	table := ParseKubectlTable(content)
+	for _, row := range table.Rows {
+		name := table.Value(row, "NAME")
+		if name == "" {
+			continue
+		}
+
+		selectorText := table.Value(row, "POD-SELECTOR")
+		policies = append(policies, rancher.NetworkPolicy{
+			Namespace:    table.Value(row, "NAMESPACE"),
+			Name:         name,
+			PodSelector:  parseLabelSelector(selectorText),
+			SelectorText: selectorText,
+			Age:          table.Value(row, "AGE"),
+		})
+	}
+
+	return policies, nil
+}
+
+// AnalyzeNetworkPolicies resolves the pods selected by each NetworkPolicy.
+//
+// The bundle does not collect pod labels, so a non-empty selector is matched against the
+// selectors of workloads and Services: if a workload's selector contains every label the
+// policy selects on, all of the workload's pods carry those labels and are selected.
+// Pods selected through labels their controller does not select on are missed.
+func AnalyzeNetworkPolicies(extractPath string) ([]NetworkPolicyInfo, error) {
+	policies, err := ParseNetworkPolicies(extractPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Missing workloads/services files just mean less can be resolved
+	workloads, _ := ParseWorkloads(extractPath)
+	services, _ := ParseServices(extractPath)
+	idx := loadServiceIndex(extractPath)
+
+	infos := make([]NetworkPolicyInfo, len(policies))
+	for i, policy := range policies {
+		info := &infos[i]
+		info.NetworkPolicy = policy
+
+		switch {
+		case policy.SelectorText == "<none>" || policy.SelectorText == "":
+			info.Exact = true
+			info.Resolution = "empty selector: all pods in namespace"
+			for _, pod := range idx.pods {
+				if pod.NamespaceID == policy.Namespace {
+					info.SelectedPods = append(info.SelectedPods, pod.Name)
+				}
+			}
+
+		case len(policy.PodSelector) == 0:
+			info.Resolution = "set-based selector: cannot be resolved without pod labels"
+
+		default:
+			var via []string
+			seen := make(map[string]bool)
+			add := func(names []string) {
+				for _, name := range names {
+					if !seen[name] {
+						seen[name] = true
+						info.SelectedPods = append(info.SelectedPods, name)
+					}
+				}
+			}
+
+			for _, w := range workloads {
+				if w.Namespace == policy.Namespace && selectorContains(w.Selector, policy.PodSelector) {
+					add(w.PodsOf(idx.pods))
+					via = append(via, strings.ToLower(w.Kind)+"/"+w.Name)
+				}
+			}
+			for _, svc := range services {
+				if svc.NamespaceID == policy.Namespace && selectorContains(svc.Selector, policy.PodSelector) {
+					add(idx.resolve(svc.NamespaceID, svc.Name).BackendPods)
+					via = append(via, "service/"+svc.Name)
+				}
+			}
+
+			if len(via) == 0 {
+				info.Resolution = "no workload or service selects these labels (pod labels not collected)"
+			} else {
+				info.Resolution = "matched via " + strings.Join(via, ", ")
+			}
+		}
+
+		classifyNetworkPolicy(info)
+	}
+
+	return infos, nil
+}
+
+// classifyNetworkPolicy sets the deny flags from the policy rules, or from the policy
+// name when only the table was collected (e.g. "default-deny-ingress", "allow-all")
+func classifyNetworkPolicy(info *NetworkPolicyInfo) {
+	if info.HasSpec {
+		for _, policyType := range info.PolicyTypes {
+			switch policyType {
+			case "Ingress":
+				info.DenyIngress = info.IngressRules == 0
+			case "Egress":
+				info.DenyEgress = info.EgressRules == 0
+			}
+		}
+		return
+	}
+
+	name := strings.ToLower(info.Name)
+	switch {
+	case strings.Contains(name, "allow-all"):
+		info.AllowAll = true
+		info.Inferred = true
+	case strings.Contains(name, "deny"):
+		info.Inferred = true
+		egress := strings.Contains(name, "egress")
+		ingress := strings.Contains(name, "ingress")
+		info.DenyIngress = ingress || !egress
+		info.DenyEgress = egress || strings.Contains(name, "all")
+	}
+}
+
+// ConnectionErrorPattern matches log lines reporting refused or timed-out connections,
+// which a NetworkPolicy isolating the pod (or its peer) can cause. The prefilter takes the
+// lowercase and Title Case forms of each message; other capitalizations are not matched.
+var ConnectionErrorPattern = NewLogPattern(`(?i)connection refused|i/o timeout|connection timed out|dial tcp [^ ]+: .*timeout|no route to host|context deadline exceeded`,
+	"refused", "Refused", "timeout", "Timeout", "timed out", "Timed Out", "route to host", "Route To Host",
+	"deadline exceeded", "Deadline Exceeded")
+
+// NetworkPolicyLogFiles returns a LogSearch file filter for the logs of the pods the given
+// policies select, the only pods whose connection errors they can explain
+func NetworkPolicyLogFiles(infos []NetworkPolicyInfo) func(*LogFileInfo) bool {
+	selected := make(map[string]bool)
+	for _, info := range infos {
+		for _, pod := range info.SelectedPods {
+			selected[info.Namespace+"/"+pod] = true
+		}
+	}
+	return func(f *LogFileInfo) bool {
+		return f.Type == LogTypePod && selected[f.Namespace+"/"+f.PodName]
+	}
+}
+
+// PoliciesForPod returns the policies that select a pod, i.e. isolate it
+func PoliciesForPod(infos []NetworkPolicyInfo, namespace, podName string) []*NetworkPolicyInfo {
+	var selecting []*NetworkPolicyInfo
+	for i := range infos {
+		if infos[i].Namespace != namespace {
+			continue
+		}
+		for _, name := range infos[i].SelectedPods {
+			if name == podName {
+				selecting = append(selecting, &infos[i])
+				break
+			}
+		}
+	}
+	return selecting
+}
+
+// selectorContains reports whether selector includes every label in labels
+func selectorContains(selector, labels map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	for key, value := range labels {
+		if selector[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// formatLabelSelector formats matchLabels the way kubectl prints them
+func formatLabelSelector(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "<none>"
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	// Simple bubble sort for small maps
+	for i := 0; i < len(keys); i++ {
+		for j := i + 1; j < len(keys); j++ {
+			if keys[j] < keys[i] {
+				keys[i], keys[j] = keys[j], keys[i]
+			}
+		}
+	}
+
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key + "=" + labels[key]
+	}
+	return strings.Join(parts, ",")
+}
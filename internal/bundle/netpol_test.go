@@ -0,0 +1,132 @@
+package bundle
+
+import "testing"
+
+// TestAnalyzeNetworkPolicies_Table tests selected-pod resolution via workload selectors
+// and default-deny inference from policy names
+func TestAnalyzeNetworkPolicies_Table(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "networkpolicies", `NAMESPACE     NAME                   POD-SELECTOR       AGE
+apps          default-deny-ingress   <none>             3d
+kube-system   coredns-allow-all      k8s-app=kube-dns   8d
+kube-system   metrics-only           app in (metrics)   8d
+`)
+	writeKubectlFile(t, root, "deployments", `NAMESPACE     NAME                        READY   UP-TO-DATE   AVAILABLE   AGE   CONTAINERS   IMAGES          SELECTOR
+kube-system   rke2-coredns-rke2-coredns   2/2     2            2           14d   coredns      rancher/coredns app.kubernetes.io/name=rke2-coredns,k8s-app=kube-dns
+`)
+	writeKubectlFile(t, root, "pods", `NAMESPACE     NAME                                             READY   STATUS    RESTARTS   AGE   IP           NODE     NOMINATED NODE   READINESS GATES
+apps          web-6d4cf56db6-x2x9k                             1/1     Running   0          3d    10.42.0.20   node-1   <none>           <none>
+apps          web-6d4cf56db6-kq8zp                             1/1     Running   0          3d    10.42.0.21   node-1   <none>           <none>
+kube-system   rke2-coredns-rke2-coredns-7b4f9d8c6-abcde        1/1     Running   0          14d   10.42.0.5    node-1   <none>           <none>
+kube-system   rke2-coredns-rke2-coredns-autoscaler-6d4b8-q9x   1/1     Running   0          14d   10.42.0.6    node-1   <none>           <none>
+`)
+
+	infos, err := AnalyzeNetworkPolicies(root)
+	if err != nil {
+		t.Fatalf("AnalyzeNetworkPolicies() error = %v", err)
+	}
+	if len(infos) != 3 {
+		t.Fatalf("expected 3 policies, got %d", len(infos))
+	}
+
+	deny := infos[0]
+	if !deny.Exact || len(deny.SelectedPods) != 2 {
+		t.Errorf("default-deny: expected both apps pods selected exactly, got %v (exact=%v)", deny.SelectedPods, deny.Exact)
+	}
+	if !deny.DefaultDeny() || !deny.DenyIngress || deny.DenyEgress || !deny.Inferred {
+		t.Errorf("default-deny: expected inferred default-deny ingress, got %+v", deny)
+	}
+
+	coredns := infos[1]
+	if coredns.Exact || len(coredns.SelectedPods) != 1 || coredns.SelectedPods[0] != "rke2-coredns-rke2-coredns-7b4f9d8c6-abcde" {
+		t.Errorf("coredns: expected only the coredns deployment pod, got %v", coredns.SelectedPods)
+	}
+	if !coredns.AllowAll || coredns.DefaultDeny() {
+		t.Errorf("coredns: expected allow-all, got %+v", coredns)
+	}
+
+	if setBased := infos[2]; len(setBased.SelectedPods) != 0 {
+		t.Errorf("set-based selector: expected no pods, got %v", setBased.SelectedPods)
+	}
+
+	isolating := PoliciesForPod(infos, "apps", "web-6d4cf56db6-kq8zp")
+	if len(isolating) != 1 || isolating[0].Name != "default-deny-ingress" {
+		t.Errorf("PoliciesForPod() = %v, want [default-deny-ingress]", isolating)
+	}
+}
+
+// TestParseNetworkPolicies_YAML tests rule classification from a full -o yaml list
+func TestParseNetworkPolicies_YAML(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "networkpolicies", `apiVersion: v1
+kind: List
+items:
+- metadata:
+    name: deny-all
+    namespace: secure
+  spec:
+    podSelector: {}
+    policyTypes: [Ingress, Egress]
+- metadata:
+    name: allow-dns
+    namespace: secure
+  spec:
+    podSelector:
+      matchLabels:
+        app: web
+    egress:
+    - ports:
+      - port: 53
+        protocol: UDP
+`)
+
+	infos, err := AnalyzeNetworkPolicies(root)
+	if err != nil {
+		t.Fatalf("AnalyzeNetworkPolicies() error = %v", err)
+	}
+	if len(infos) != 2 {
+		t.Fatalf("expected 2 policies, got %d", len(infos))
+	}
+
+	if p := infos[0]; !p.DefaultDeny() || !p.DenyIngress || !p.DenyEgress || p.Inferred {
+		t.Errorf("deny-all: expected default-deny ingress+egress from spec, got %+v", p)
+	}
+
+	p := infos[1]
+	if p.SelectorText != "app=web" || p.EgressRules != 1 {
+		t.Errorf("allow-dns: unexpected parse %+v", p.NetworkPolicy)
+	}
+	// No policyTypes: Ingress is implied (and has no rules), Egress because egress rules exist
+	if !p.DenyIngress || p.DenyEgress || p.DefaultDeny() {
+		t.Errorf("allow-dns: expected ingress isolation only, got %+v", p)
+	}
+}
+
+// TestConnectionErrorsOfSelectedPods tests that only pods a policy selects are searched for
+// connection errors, in either capitalization
+func TestConnectionErrorsOfSelectedPods(t *testing.T) {
+	root := t.TempDir()
+	writeBundleFile(t, root, "rke2/podlogs/apps-web-1", "dial tcp 10.43.0.10:5432: connect: Connection Refused\nok\n")
+	writeBundleFile(t, root, "rke2/podlogs/apps-worker-1", "Get \"http://api:8080\": context deadline exceeded\n")
+	writeBundleFile(t, root, "rke2/podlogs/other-web-1", "dial tcp 10.43.0.10:5432: connect: connection refused\n")
+
+	logFiles, err := InventoryLogFiles(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &Bundle{ExtractPath: root, LogFiles: logFiles}
+
+	infos := []NetworkPolicyInfo{{SelectedPods: []string{"web-1", "worker-1"}}}
+	infos[0].Namespace = "apps"
+	search := &LogSearch{Pattern: ConnectionErrorPattern, Files: NetworkPolicyLogFiles(infos)}
+	b.ScanLogs(search)
+
+	if len(search.Matches) != 2 {
+		t.Fatalf("expected connection errors of apps/web-1 and apps/worker-1, got %+v", search.Matches)
+	}
+	for _, m := range search.Matches {
+		if m.Namespace != "apps" || m.LineNumber != 1 {
+			t.Errorf("unexpected match %+v", m)
+		}
+	}
+}
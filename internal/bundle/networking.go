@@ -0,0 +1,915 @@
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Network check results (see NetworkCheck.Status)
+const (
+	NetworkCheckOK       = "ok"
+	NetworkCheckWarning  = "warning"
+	NetworkCheckCritical = "critical"
+)
+
+// Interface kinds (see NetworkInterface.Kind)
+const (
+	InterfaceLoopback = "loopback"
+	InterfaceHost     = "host"    // Physical or cloud NIC
+	InterfacePod      = "pod"     // Host side of a pod veth
+	InterfaceOverlay  = "overlay" // CNI tunnel device
+	InterfaceVirtual  = "virtual" // Bridges and other CNI/kube-proxy devices
+)
+
+// iptables backends (see NetworkStatus.IptablesBackend)
+const (
+	IptablesNFTables = "nf_tables"
+	IptablesLegacy   = "legacy"
+)
+
+// RKE2 defaults for cluster-cidr and service-cidr when 50-rancher.yaml does not set them
+const (
+	rke2DefaultClusterCIDR = "10.42.0.0/16"
+	rke2DefaultServiceCIDR = "10.43.0.0/16"
+)
+
+// overlayOverhead is the encapsulation overhead of CNI tunnel devices: the tunnel's MTU must
+// be at most the uplink MTU minus this, or encapsulated packets are too big for the wire.
+// Cilium sets MTUs on routes rather than its tunnel devices, so they are not listed.
+var overlayOverhead = map[string]int{
+	"vxlan.calico":    50,
+	"vxlan-v6.calico": 70,
+	"wireguard.cali":  60,
+	"wg-v6.cali":      80,
+	"tunl0":           20, // Calico IP-in-IP
+	"flannel.1":       50,
+	"flannel-v6.1":    70,
+	"flannel-wg":      60,
+	"flannel-wg-v6":   80,
+}
+
+// cniPluginTypes are the CNI config plugin types each RKE2 cni setting installs
+var cniPluginTypes = map[string][]string{
+	"canal":   {"calico", "flannel"},
+	"calico":  {"calico"},
+	"cilium":  {"cilium-cni"},
+	"flannel": {"flannel"},
+	"multus":  {"multus"},
+}
+
+// virtualInterfaces are CNI and kube-proxy devices that are neither pods, tunnels nor NICs
+var virtualInterfaces = []string{"cni0", "cilium_host", "cilium_net", "cilium_vxlan", "cilium_geneve", "cilium_wg0", "kube-ipvs0", "docker0", "nodelocaldns"}
+
+// rke2RequiredPorts are the TCP ports RKE2 components must listen on, by node role
+var rke2RequiredPorts = []struct {
+	port      int
+	component string
+	server    bool // Only on control-plane nodes
+	etcd      bool // Only on etcd nodes
+}{
+	{6443, "kube-apiserver", true, false},
+	{9345, "rke2 supervisor", true, false},
+	{2379, "etcd client", false, true},
+	{2380, "etcd peer", false, true},
+	{10250, "kubelet", false, false},
+}
+
+// NetworkInterface is a link from `ip link show` with its addresses from `ip addr show`
+type NetworkInterface struct {
+	Index     int
+	Name      string
+	Peer      string // "if2" for a veth whose peer is in another namespace
+	Flags     []string
+	MTU       int
+	State     string   // UP, DOWN, UNKNOWN
+	Addresses []string // CIDR notation, e.g. "10.42.207.1/32"
+	Kind      string   // Interface* constant
+}
+
+// NetworkRoute is a route from `ip route show table all`
+type NetworkRoute struct {
+	Type        string // unicast, local, broadcast, blackhole, throw, unreachable, prohibit
+	Destination string // "default" or a prefix/address
+	Gateway     string
+	Device      string
+	Table       string // "main" when not shown
+	Protocol    string
+	Scope       string
+	Source      string
+}
+
+// ListeningSocket is a listening TCP or bound UDP socket from `ss -tunlp`
+type ListeningSocket struct {
+	Protocol string // tcp or udp
+	Address  string // "0.0.0.0", "*", "::", or an IP (zone removed)
+	Port     int
+	Process  string
+	PID      int
+}
+
+// CNIConfig is a config file from /etc/cni/net.d
+type CNIConfig struct {
+	File    string
+	Name    string
+	Plugins []string // Plugin types in chain order
+	MTU     int      // 0 if not set (auto-detected by the CNI)
+}
+
+// NetworkStatus is the node's network configuration from the networking directory
+type NetworkStatus struct {
+	Node       string
+	Interfaces []NetworkInterface
+	Routes     []NetworkRoute
+	Rules      []string // `ip rule` lines
+	Sockets    []ListeningSocket
+	CNI        []CNIConfig
+
+	ClusterCIDR   string
+	ServiceCIDR   string
+	ConfiguredCNI []string // cni in 50-rancher.yaml, else RKE2's default canal
+	ClusterSize   int      // Nodes in kubectl get nodes, 0 if not collected
+	IsServer      bool     // Runs kube-apiserver and the rke2 supervisor
+	IsEtcd        bool
+	RolesKnown    bool // IsServer/IsEtcd came from pod manifests or kubectl nodes
+
+	IptablesHeader      string   // First line of iptables-save, e.g. "# Generated by iptables-save v1.8.7 ..."
+	IptablesRules       int      // Rules (-A lines) in iptables-save
+	IptablesLegacyWarn  bool     // iptables-nft warned that legacy tables are also present
+	NftTables           []string // e.g. "ip filter"
+	NftKubernetesChains int      // KUBE-*/cali-*/CILIUM_* chains in the nftables ruleset
+}
+
+// NetworkCheck is the result of a built-in network check
+type NetworkCheck struct {
+	Name   string // e.g. "Port 6443 (kube-apiserver)"
+	Status string // NetworkCheck* constant
+	Detail string
+}
+
+// ParseNetworking parses the networking directory of a bundle: `ip link/addr/route/rule`
+// output, ss socket dumps, the CNI config, and iptables/nftables rulesets
+func ParseNetworking(extractPath string) (*NetworkStatus, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	netDir := filepath.Join(bundleRoot, "networking")
+	if _, err := os.Stat(netDir); err != nil {
+		return nil, err
+	}
+
+	config := loadRKE2Config(extractPath)
+	status := &NetworkStatus{
+		Node:          extractNodeName(extractPath),
+		ClusterCIDR:   config.ClusterCIDR,
+		ServiceCIDR:   config.ServiceCIDR,
+		ConfiguredCNI: config.CNI,
+	}
+
+	if content, err := os.ReadFile(filepath.Join(netDir, "iplinkshow")); err == nil {
+		status.Interfaces = parseIPLinks(content)
+	}
+	for _, name := range []string{"ipaddrshow", "ipv6addrshow"} {
+		if content, err := os.ReadFile(filepath.Join(netDir, name)); err == nil {
+			status.Interfaces = mergeInterfaceAddresses(status.Interfaces, parseIPLinks(content))
+		}
+	}
+	for i := range status.Interfaces {
+		status.Interfaces[i].Kind = interfaceKind(status.Interfaces[i])
+	}
+
+	for _, name := range []string{"iproute", "ipv6route"} {
+		if content, err := os.ReadFile(filepath.Join(netDir, name)); err == nil {
+			status.Routes = append(status.Routes, parseIPRoutes(content)...)
+		}
+	}
+	if content, err := os.ReadFile(filepath.Join(netDir, "iprule")); err == nil {
+		for _, line := range strings.Split(string(content), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				status.Rules = append(status.Rules, strings.Join(strings.Fields(line), " "))
+			}
+		}
+	}
+
+	// Listening sockets: ss -tunlp per address family, else the all-sockets dump
+	socketFiles := []string{"sstunlp4", "sstunlp6"}
+	if _, err := os.Stat(filepath.Join(netDir, "sstunlp4")); err != nil {
+		socketFiles = []string{"ssanp"}
+	}
+	for _, name := range socketFiles {
+		if content, err := os.ReadFile(filepath.Join(netDir, name)); err == nil {
+			status.Sockets = append(status.Sockets, parseListeningSockets(content)...)
+		}
+	}
+	sort.SliceStable(status.Sockets, func(i, j int) bool {
+		return status.Sockets[i].Port < status.Sockets[j].Port
+	})
+
+	cniFiles, _ := filepath.Glob(filepath.Join(netDir, "cni", "*"))
+	sort.Strings(cniFiles)
+	for _, path := range cniFiles {
+		if content, err := os.ReadFile(path); err == nil {
+			if config, ok := parseCNIConfig(content); ok {
+				config.File = filepath.Base(path)
+				status.CNI = append(status.CNI, config)
+			}
+		}
+	}
+
+	if content, err := os.ReadFile(filepath.Join(netDir, "iptablessave")); err == nil {
+		for _, line := range strings.Split(string(content), "\n") {
+			switch {
+			case strings.HasPrefix(line, "# Generated by") && status.IptablesHeader == "":
+				status.IptablesHeader = strings.TrimSpace(line)
+			case strings.HasPrefix(line, "# Warning: iptables-legacy tables present"):
+				status.IptablesLegacyWarn = true
+			case strings.HasPrefix(line, "-A "):
+				status.IptablesRules++
+			}
+		}
+	}
+	if content, err := os.ReadFile(filepath.Join(netDir, "nft_ruleset")); err == nil {
+		status.NftTables, status.NftKubernetesChains = parseNftRuleset(content)
+	}
+
+	status.detectRoles(bundleRoot)
+
+	return status, nil
+}
+
+// detectRoles sets IsServer and IsEtcd from the node's static pod manifests, falling back
+// to the node's roles in kubectl get nodes, and counts the cluster's nodes
+func (s *NetworkStatus) detectRoles(bundleRoot string) {
+	manifests := filepath.Join(bundleRoot, "rke2/pod-manifests")
+	if entries, err := os.ReadDir(manifests); err == nil && len(entries) > 0 {
+		s.RolesKnown = true
+		for _, e := range entries {
+			switch strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())) {
+			case "kube-apiserver":
+				s.IsServer = true
+			case "etcd":
+				s.IsEtcd = true
+			}
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(bundleRoot, "rke2/kubectl/nodes"))
+	if err != nil {
+		return
+	}
+	table := ParseKubectlTable(content)
+	s.ClusterSize = len(table.Rows)
+	if s.RolesKnown {
+		return
+	}
+	for _, row := range table.Rows {
+		if table.Value(row, "NAME") != s.Node {
+			continue
+		}
+		roles := strings.Split(table.Value(row, "ROLES"), ",")
+		s.RolesKnown = true
+		s.IsServer = contains(roles, "control-plane") || contains(roles, "master")
+		s.IsEtcd = contains(roles, "etcd")
+	}
+}
+
+// ipLinkHeaderRe matches an interface header line of `ip link show` / `ip addr show`
+// Format: 4: cali622a93eb23b@if2: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc noqueue state UP ...
+var ipLinkHeaderRe = regexp.MustCompile(`^(\d+):\s+([^:@\s]+)(?:@([^:\s]+))?:\s+<([^>]*)>(.*)$`)
+
+// parseIPLinks parses `ip link show` or `ip addr show` output. Addresses are collected
+// from the inet/inet6 lines of `ip addr show`.
+func parseIPLinks(content []byte) []NetworkInterface {
+	var links []NetworkInterface
+	for _, line := range strings.Split(string(content), "\n") {
+		if m := ipLinkHeaderRe.FindStringSubmatch(line); m != nil {
+			index, _ := strconv.Atoi(m[1])
+			link := NetworkInterface{Index: index, Name: m[2], Peer: m[3]}
+			if m[4] != "" {
+				link.Flags = strings.Split(m[4], ",")
+			}
+			fields := strings.Fields(m[5])
+			for i := 0; i+1 < len(fields); i++ {
+				switch fields[i] {
+				case "mtu":
+					link.MTU, _ = strconv.Atoi(fields[i+1])
+				case "state":
+					link.State = fields[i+1]
+				}
+			}
+			links = append(links, link)
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(links) > 0 && len(fields) >= 2 && (fields[0] == "inet" || fields[0] == "inet6") {
+			last := &links[len(links)-1]
+			last.Addresses = append(last.Addresses, fields[1])
+		}
+	}
+	return links
+}
+
+// mergeInterfaceAddresses adds the addresses of parsed `ip addr show` interfaces to links,
+// appending interfaces that `ip link show` did not list
+func mergeInterfaceAddresses(links, withAddresses []NetworkInterface) []NetworkInterface {
+	byName := make(map[string]int, len(links))
+	for i, link := range links {
+		byName[link.Name] = i
+	}
+	for _, iface := range withAddresses {
+		if i, ok := byName[iface.Name]; ok {
+			for _, addr := range iface.Addresses {
+				if !contains(links[i].Addresses, addr) {
+					links[i].Addresses = append(links[i].Addresses, addr)
+				}
+			}
+			continue
+		}
+		byName[iface.Name] = len(links)
+		links = append(links, iface)
+	}
+	return links
+}
+
+// interfaceKind classifies an interface by its name
+func interfaceKind(iface NetworkInterface) string {
+	switch {
+	case iface.Name == "lo":
+		return InterfaceLoopback
+	case overlayOverhead[iface.Name] > 0:
+		return InterfaceOverlay
+	case contains(virtualInterfaces, iface.Name):
+		return InterfaceVirtual
+	case strings.HasPrefix(iface.Name, "cali"), strings.HasPrefix(iface.Name, "veth"),
+		strings.HasPrefix(iface.Name, "lxc"):
+		return InterfacePod
+	default:
+		return InterfaceHost
+	}
+}
+
+// routeTypes are the `ip route` types that precede the destination
+var routeTypes = []string{"unicast", "local", "broadcast", "multicast", "anycast", "blackhole", "unreachable", "prohibit", "throw", "nat"}
+
+// parseIPRoutes parses `ip route show table all` output
+// Format: [TYPE] DEST [via GW] [dev DEV] [table T] [proto P] [scope S] [src ADDR] [flags...]
+func parseIPRoutes(content []byte) []NetworkRoute {
+	var routes []NetworkRoute
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "nexthop") {
+			continue
+		}
+		route := NetworkRoute{Type: "unicast", Table: "main"}
+		if contains(routeTypes, fields[0]) {
+			route.Type = fields[0]
+			fields = fields[1:]
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		route.Destination = fields[0]
+		for i := 1; i+1 < len(fields); i++ {
+			switch fields[i] {
+			case "via":
+				route.Gateway = fields[i+1]
+			case "dev":
+				route.Device = fields[i+1]
+			case "table":
+				route.Table = fields[i+1]
+			case "proto":
+				route.Protocol = fields[i+1]
+			case "scope":
+				route.Scope = fields[i+1]
+			case "src":
+				route.Source = fields[i+1]
+			default:
+				continue
+			}
+			i++
+		}
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// Prefix returns the route's destination as a network, or nil for a malformed destination.
+// "default" is 0.0.0.0/0 (or ::/0 for an IPv6 gateway), a bare address a host route.
+func (r NetworkRoute) Prefix() *net.IPNet {
+	dest := r.Destination
+	if dest == "default" {
+		dest = "0.0.0.0/0"
+		if strings.Contains(r.Gateway, ":") {
+			dest = "::/0"
+		}
+	}
+	if !strings.Contains(dest, "/") {
+		if ip := net.ParseIP(dest); ip != nil {
+			if ip.To4() != nil {
+				dest += "/32"
+			} else {
+				dest += "/128"
+			}
+		}
+	}
+	_, prefix, err := net.ParseCIDR(dest)
+	if err != nil {
+		return nil
+	}
+	return prefix
+}
+
+// Forwards reports whether the route delivers traffic (not blackhole, throw, unreachable or prohibit)
+func (r NetworkRoute) Forwards() bool {
+	switch r.Type {
+	case "blackhole", "throw", "unreachable", "prohibit":
+		return false
+	}
+	return true
+}
+
+// ssProcessRe extracts the first process from ss's users:(("name",pid=N,fd=M)) column
+var ssProcessRe = regexp.MustCompile(`\(\("([^"]+)",pid=(\d+)`)
+
+// parseListeningSockets parses ss output, keeping listening TCP and bound UDP sockets
+// Format: [Netid] State Recv-Q Send-Q Local-Address:Port Peer-Address:Port [Process]
+func parseListeningSockets(content []byte) []ListeningSocket {
+	var sockets []ListeningSocket
+	lines := strings.Split(string(content), "\n")
+	if len(lines) == 0 {
+		return nil
+	}
+	hasNetid := strings.HasPrefix(strings.TrimSpace(lines[0]), "Netid")
+
+	for _, line := range lines[1:] {
+		fields := strings.Fields(line)
+		netid := ""
+		if hasNetid {
+			if len(fields) == 0 {
+				continue
+			}
+			netid, fields = fields[0], fields[1:]
+		}
+		if len(fields) < 5 {
+			continue
+		}
+		state := fields[0]
+		switch {
+		case state == "LISTEN" && (netid == "" || netid == "tcp"):
+			netid = "tcp"
+		case state == "UNCONN" && (netid == "" || netid == "udp"):
+			netid = "udp"
+		default:
+			continue
+		}
+
+		local := fields[3]
+		sep := strings.LastIndex(local, ":")
+		if sep < 0 {
+			continue
+		}
+		port, err := strconv.Atoi(local[sep+1:])
+		if err != nil {
+			continue
+		}
+		address := strings.Trim(local[:sep], "[]")
+		if zone := strings.Index(address, "%"); zone >= 0 {
+			address = address[:zone]
+		}
+
+		socket := ListeningSocket{Protocol: netid, Address: address, Port: port}
+		if m := ssProcessRe.FindStringSubmatch(line); m != nil {
+			socket.Process = m[1]
+			socket.PID, _ = strconv.Atoi(m[2])
+		}
+		sockets = append(sockets, socket)
+	}
+	return sockets
+}
+
+// Loopback reports whether the socket is bound to a loopback address only
+func (s ListeningSocket) Loopback() bool {
+	ip := net.ParseIP(s.Address)
+	return ip != nil && ip.IsLoopback()
+}
+
+// parseCNIConfig parses a CNI .conf or .conflist file
+func parseCNIConfig(content []byte) (CNIConfig, bool) {
+	type plugin struct {
+		Type string `json:"type"`
+		MTU  int    `json:"mtu"`
+	}
+	var raw struct {
+		Name    string   `json:"name"`
+		Plugins []plugin `json:"plugins"`
+		plugin
+	}
+	if err := json.Unmarshal(content, &raw); err != nil {
+		return CNIConfig{}, false
+	}
+
+	config := CNIConfig{Name: raw.Name}
+	plugins := raw.Plugins
+	if len(plugins) == 0 && raw.Type != "" {
+		plugins = []plugin{raw.plugin}
+	}
+	for _, p := range plugins {
+		config.Plugins = append(config.Plugins, p.Type)
+		if config.MTU == 0 && p.MTU > 0 {
+			config.MTU = p.MTU
+		}
+	}
+	return config, true
+}
+
+// parseNftRuleset returns the tables of `nft list ruleset` and how many chains were
+// created by kube-proxy or a CNI
+func parseNftRuleset(content []byte) ([]string, int) {
+	var tables []string
+	chains := 0
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		switch fields[0] {
+		case "table":
+			tables = append(tables, fields[1]+" "+fields[2])
+		case "chain":
+			name := fields[1]
+			if strings.HasPrefix(name, "KUBE-") || strings.HasPrefix(name, "cali-") || strings.HasPrefix(name, "CILIUM_") ||
+				strings.HasPrefix(name, "FLANNEL-") {
+				chains++
+			}
+		}
+	}
+	return tables, chains
+}
+
+// IptablesBackend returns the backend iptables-save used: from its version header when it
+// says, otherwise nf_tables if the nftables ruleset holds Kubernetes chains
+func (s *NetworkStatus) IptablesBackend() string {
+	switch {
+	case strings.Contains(s.IptablesHeader, "(nf_tables)"):
+		return IptablesNFTables
+	case strings.Contains(s.IptablesHeader, "(legacy)"):
+		return IptablesLegacy
+	case s.NftKubernetesChains > 0:
+		return IptablesNFTables
+	case s.IptablesRules > 0:
+		return IptablesLegacy
+	}
+	return ""
+}
+
+// Uplink returns the interface of the main table's IPv4 default route, or nil
+func (s *NetworkStatus) Uplink() *NetworkInterface {
+	for _, r := range s.Routes {
+		if r.Destination == "default" && r.Table == "main" && !strings.Contains(r.Gateway, ":") {
+			return s.Interface(r.Device)
+		}
+	}
+	return nil
+}
+
+// Interface returns the named interface, or nil
+func (s *NetworkStatus) Interface(name string) *NetworkInterface {
+	for i := range s.Interfaces {
+		if s.Interfaces[i].Name == name {
+			return &s.Interfaces[i]
+		}
+	}
+	return nil
+}
+
+// PodMTU returns the MTU pod interfaces should have: the CNI config's, else the smallest
+// tunnel MTU, else the most common pod interface MTU (0 if none of these is known)
+func (s *NetworkStatus) PodMTU() (int, string) {
+	for _, c := range s.CNI {
+		if c.MTU > 0 {
+			return c.MTU, "CNI config " + c.File
+		}
+	}
+
+	overlay := ""
+	mtu := 0
+	for _, iface := range s.Interfaces {
+		if iface.Kind == InterfaceOverlay && (mtu == 0 || iface.MTU < mtu) {
+			mtu, overlay = iface.MTU, iface.Name
+		}
+	}
+	if mtu > 0 {
+		return mtu, "tunnel " + overlay
+	}
+
+	counts := make(map[int]int)
+	for _, iface := range s.Interfaces {
+		if iface.Kind == InterfacePod {
+			counts[iface.MTU]++
+			if counts[iface.MTU] > counts[mtu] || (counts[iface.MTU] == counts[mtu] && iface.MTU < mtu) {
+				mtu = iface.MTU
+			}
+		}
+	}
+	return mtu, "most pod interfaces"
+}
+
+// splitCIDRs parses a comma-separated cidr list (dual-stack clusters set two)
+func splitCIDRs(value string) []*net.IPNet {
+	var prefixes []*net.IPNet
+	for _, cidr := range splitList(value) {
+		if _, prefix, err := net.ParseCIDR(cidr); err == nil {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// Checks runs the built-in checks: required RKE2 ports, pod and service CIDR routes,
+// MTU consistency between the CNI, tunnels and host interfaces, the iptables backend, and
+// whether the installed CNI config is the one 50-rancher.yaml asks for
+func (s *NetworkStatus) Checks() []NetworkCheck {
+	var checks []NetworkCheck
+	checks = append(checks, s.portChecks()...)
+	checks = append(checks, s.routeChecks()...)
+	checks = append(checks, s.mtuChecks()...)
+	if check, ok := s.iptablesCheck(); ok {
+		checks = append(checks, check)
+	}
+	if check, ok := s.cniCheck(); ok {
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// portChecks reports whether each port required for the node's roles is listening, and
+// reachable from other nodes (not bound to loopback only)
+func (s *NetworkStatus) portChecks() []NetworkCheck {
+	if len(s.Sockets) == 0 {
+		return nil
+	}
+
+	var checks []NetworkCheck
+	for _, required := range rke2RequiredPorts {
+		if (required.server && !s.IsServer) || (required.etcd && !s.IsEtcd) {
+			continue
+		}
+		check := NetworkCheck{Name: fmt.Sprintf("Port %d (%s)", required.port, required.component)}
+
+		var addresses []string
+		process := ""
+		external := false
+		for _, socket := range s.Sockets {
+			if socket.Protocol != "tcp" || socket.Port != required.port {
+				continue
+			}
+			addresses = append(addresses, socket.Address)
+			if process == "" {
+				process = socket.Process
+			}
+			if !socket.Loopback() {
+				external = true
+			}
+		}
+
+		switch {
+		case len(addresses) == 0:
+			check.Status = NetworkCheckCritical
+			check.Detail = fmt.Sprintf("nothing is listening on TCP %d; %s is down or failed to bind", required.port, required.component)
+		case !external && required.port != 2379:
+			// etcd clients on the node use 127.0.0.1:2379; every other port is used by other nodes
+			check.Status = NetworkCheckWarning
+			check.Detail = fmt.Sprintf("%s listens on %s only; other nodes cannot reach it", processName(process), strings.Join(addresses, ", "))
+		default:
+			check.Status = NetworkCheckOK
+			check.Detail = fmt.Sprintf("%s listening on %s", processName(process), strings.Join(addresses, ", "))
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// processName returns a socket's process name for display
+func processName(process string) string {
+	if process == "" {
+		return "process (unknown)"
+	}
+	return process
+}
+
+// routeChecks checks that the CNI installed routes into the cluster CIDR (including to other
+// nodes' pod CIDRs in a multi-node cluster) and that the service CIDR is routable
+func (s *NetworkStatus) routeChecks() []NetworkCheck {
+	if len(s.Routes) == 0 {
+		return nil
+	}
+	var checks []NetworkCheck
+
+	for _, cidr := range splitCIDRs(s.ClusterCIDR) {
+		check := NetworkCheck{Name: "Pod CIDR routes " + cidr.String()}
+		routes, remote := 0, make(map[string]bool)
+		for _, r := range s.Routes {
+			prefix := r.Prefix()
+			if prefix == nil || r.Table == "local" || r.Type == "throw" || !cidr.Contains(prefix.IP) {
+				continue
+			}
+			routes++
+			// A block routed via a gateway or tunnel; the node's own block is a blackhole
+			// (Calico) or a bridge route (flannel)
+			ones, bits := prefix.Mask.Size()
+			if ones < bits && r.Forwards() && (r.Gateway != "" || overlayOverhead[r.Device] > 0) {
+				remote[prefix.String()] = true
+			}
+		}
+
+		switch {
+		case routes == 0:
+			check.Status = NetworkCheckCritical
+			check.Detail = fmt.Sprintf("no routes into cluster-cidr %s; the CNI has not set up pod networking on this node", cidr)
+		case s.ClusterSize > 1 && len(remote) == 0:
+			check.Status = NetworkCheckWarning
+			check.Detail = fmt.Sprintf("%d local pod routes but none to other nodes' pod CIDRs (%d nodes); cross-node pod traffic will fail", routes, s.ClusterSize)
+		default:
+			check.Status = NetworkCheckOK
+			check.Detail = fmt.Sprintf("%d routes, %d remote pod CIDR blocks", routes, len(remote))
+		}
+		checks = append(checks, check)
+	}
+
+	for _, cidr := range splitCIDRs(s.ServiceCIDR) {
+		check := NetworkCheck{Name: "Service CIDR route " + cidr.String()}
+		// Locally originated traffic is routed before kube-proxy's DNAT, so the ClusterIP
+		// range must be covered by a route (usually the default route)
+		var best *NetworkRoute
+		bestLen := -1
+		for i, r := range s.Routes {
+			prefix := r.Prefix()
+			if prefix == nil || !r.Forwards() || (r.Table != "main" && r.Table != "local") || !prefix.Contains(cidr.IP) {
+				continue
+			}
+			if ones, _ := prefix.Mask.Size(); ones > bestLen {
+				best, bestLen = &s.Routes[i], ones
+			}
+		}
+
+		if best == nil {
+			check.Status = NetworkCheckCritical
+			check.Detail = fmt.Sprintf("no route covers service-cidr %s; connections to ClusterIPs from the host fail with 'network unreachable'", cidr)
+		} else {
+			check.Status = NetworkCheckOK
+			check.Detail = fmt.Sprintf("routed by %s", best)
+		}
+		checks = append(checks, check)
+	}
+
+	return checks
+}
+
+// String formats the route like `ip route`, e.g. "default via 10.0.0.1 dev eth0"
+func (r NetworkRoute) String() string {
+	parts := []string{}
+	if r.Type != "unicast" {
+		parts = append(parts, r.Type)
+	}
+	parts = append(parts, r.Destination)
+	if r.Gateway != "" {
+		parts = append(parts, "via", r.Gateway)
+	}
+	if r.Device != "" {
+		parts = append(parts, "dev", r.Device)
+	}
+	if r.Table != "main" {
+		parts = append(parts, "table", r.Table)
+	}
+	return strings.Join(parts, " ")
+}
+
+// mtuChecks compares pod interface MTUs with the expected pod MTU, and tunnel MTUs with the
+// uplink MTU less encapsulation overhead
+func (s *NetworkStatus) mtuChecks() []NetworkCheck {
+	var checks []NetworkCheck
+	uplink := s.Uplink()
+
+	podMTU, source := s.PodMTU()
+	if podMTU > 0 {
+		check := NetworkCheck{Name: "Pod interface MTU"}
+		var mismatched []string
+		pods := 0
+		for _, iface := range s.Interfaces {
+			if iface.Kind != InterfacePod {
+				continue
+			}
+			pods++
+			if iface.MTU != podMTU {
+				mismatched = append(mismatched, fmt.Sprintf("%s (%d)", iface.Name, iface.MTU))
+			}
+		}
+
+		smallestTunnel := 0
+		for _, iface := range s.Interfaces {
+			if iface.Kind == InterfaceOverlay && (smallestTunnel == 0 || iface.MTU < smallestTunnel) {
+				smallestTunnel = iface.MTU
+			}
+		}
+
+		switch {
+		case uplink != nil && podMTU > uplink.MTU:
+			check.Status = NetworkCheckCritical
+			check.Detail = fmt.Sprintf("pod MTU %d (%s) exceeds %s MTU %d; large packets are dropped", podMTU, source, uplink.Name, uplink.MTU)
+		case smallestTunnel > 0 && podMTU > smallestTunnel:
+			check.Status = NetworkCheckCritical
+			check.Detail = fmt.Sprintf("pod MTU %d (%s) exceeds tunnel MTU %d; cross-node packets over %d bytes are dropped", podMTU, source, smallestTunnel, smallestTunnel)
+		case len(mismatched) > 0:
+			check.Status = NetworkCheckWarning
+			check.Detail = fmt.Sprintf("%d of %d pod interfaces differ from MTU %d (%s): %s; recreate those pods", len(mismatched), pods, podMTU, source, strings.Join(mismatched, ", "))
+		default:
+			check.Status = NetworkCheckOK
+			check.Detail = fmt.Sprintf("%d pod interfaces at MTU %d (%s)", pods, podMTU, source)
+		}
+		if pods > 0 || check.Status != NetworkCheckOK {
+			checks = append(checks, check)
+		}
+	}
+
+	if uplink == nil {
+		return checks
+	}
+	for _, iface := range s.Interfaces {
+		overhead := overlayOverhead[iface.Name]
+		if iface.Kind != InterfaceOverlay || overhead == 0 {
+			continue
+		}
+		check := NetworkCheck{Name: "Tunnel MTU " + iface.Name}
+		if limit := uplink.MTU - overhead; iface.MTU > limit {
+			check.Status = NetworkCheckCritical
+			check.Detail = fmt.Sprintf("MTU %d, but %s MTU %d leaves %d after %d bytes of encapsulation; encapsulated packets over the limit are dropped",
+				iface.MTU, uplink.Name, uplink.MTU, limit, overhead)
+		} else {
+			check.Status = NetworkCheckOK
+			check.Detail = fmt.Sprintf("MTU %d fits %s MTU %d with %d bytes of encapsulation", iface.MTU, uplink.Name, uplink.MTU, overhead)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// iptablesCheck reports rules split across the legacy and nf_tables backends, left behind
+// when the host's iptables alternative differs from the one kube-proxy or the CNI uses
+func (s *NetworkStatus) iptablesCheck() (NetworkCheck, bool) {
+	if s.IptablesHeader == "" && len(s.NftTables) == 0 {
+		return NetworkCheck{}, false
+	}
+	check := NetworkCheck{Name: "iptables backend"}
+	backend := s.IptablesBackend()
+
+	switch {
+	case backend == IptablesLegacy && s.NftKubernetesChains > 0 && s.IptablesRules > 0:
+		check.Status = NetworkCheckCritical
+		check.Detail = fmt.Sprintf("iptables-save uses the legacy backend (%d rules) but nftables also holds %d Kubernetes/CNI chains; "+
+			"both rule sets are evaluated and stale rules can drop or misroute traffic", s.IptablesRules, s.NftKubernetesChains)
+	case s.IptablesLegacyWarn:
+		check.Status = NetworkCheckWarning
+		check.Detail = "iptables-nft reports iptables-legacy tables are also present; remove the leftover legacy rules (iptables-legacy -F)"
+	case backend == "":
+		check.Status = NetworkCheckOK
+		check.Detail = "no iptables rules"
+	default:
+		check.Status = NetworkCheckOK
+		check.Detail = fmt.Sprintf("%s only (%d iptables rules, %d Kubernetes/CNI nftables chains)", backend, s.IptablesRules, s.NftKubernetesChains)
+	}
+	return check, true
+}
+
+// cniCheck compares the CNI configs in /etc/cni/net.d with the cni setting. The runtime uses
+// the first config in file name order, so a leftover config from a previous CNI takes over.
+func (s *NetworkStatus) cniCheck() (NetworkCheck, bool) {
+	if len(s.CNI) == 0 || len(s.ConfiguredCNI) == 0 {
+		return NetworkCheck{}, false
+	}
+	cni := s.ConfiguredCNI[0]
+	types, ok := cniPluginTypes[cni]
+	if !ok {
+		return NetworkCheck{}, false
+	}
+
+	first := s.CNI[0]
+	check := NetworkCheck{
+		Name:   "CNI config",
+		Status: NetworkCheckOK,
+		Detail: fmt.Sprintf("cni %s, using %s (%s)", strings.Join(s.ConfiguredCNI, ","), first.File, strings.Join(first.Plugins, ",")),
+	}
+	for _, plugin := range first.Plugins {
+		if contains(types, plugin) {
+			return check, true
+		}
+	}
+	check.Status = NetworkCheckWarning
+	check.Detail = fmt.Sprintf("cni is %s in 50-rancher.yaml but the runtime uses %s (%s), the first config in /etc/cni/net.d; "+
+		"remove configs left by a previous CNI", cni, first.File, strings.Join(first.Plugins, ","))
+	return check, true
+}
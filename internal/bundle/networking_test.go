@@ -0,0 +1,105 @@
+package bundle
+
+import (
+	"testing"
+)
+
+// TestParseNetworking tests the networking parsers and checks on a misconfigured server node
+func TestParseNetworking(t *testing.T) {
+	root := t.TempDir()
+
+	writeBundleFile(t, root, "rke2/50-rancher.yaml", "cluster-cidr: 10.244.0.0/16\nservice-cidr: 10.96.0.0/12\n")
+	writeBundleFile(t, root, "rke2/pod-manifests/kube-apiserver.yaml", "kind: Pod\n")
+	writeKubectlFile(t, root, "nodes", `NAME     STATUS   ROLES                  AGE   VERSION
+server   Ready    control-plane,master   14d   v1.32.7+rke2r1
+worker   Ready    worker                 14d   v1.32.7+rke2r1
+`)
+	writeBundleFile(t, root, "networking/iplinkshow", `1: lo: <LOOPBACK,UP,LOWER_UP> mtu 65536 qdisc noqueue state UNKNOWN mode DEFAULT group default qlen 1000
+    link/loopback 00:00:00:00:00:00 brd 00:00:00:00:00:00
+2: eth0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc fq_codel state UP mode DEFAULT group default qlen 1000
+    link/ether 5e:6b:aa:1d:0b:7c brd ff:ff:ff:ff:ff:ff
+5: flannel.1: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1480 qdisc noqueue state UNKNOWN mode DEFAULT group default
+6: cni0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1480 qdisc noqueue state UP mode DEFAULT group default qlen 1000
+7: veth1a2b3c4d@if2: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1480 qdisc noqueue master cni0 state UP mode DEFAULT group default
+`)
+	writeBundleFile(t, root, "networking/ipaddrshow", `2: eth0: <BROADCAST,MULTICAST,UP,LOWER_UP> mtu 1500 qdisc fq_codel state UP group default qlen 1000
+    inet 192.168.1.10/24 brd 192.168.1.255 scope global eth0
+       valid_lft forever preferred_lft forever
+`)
+	writeBundleFile(t, root, "networking/iproute", `192.168.1.0/24 dev eth0 proto kernel scope link src 192.168.1.10
+10.244.0.0/24 dev cni0 proto kernel scope link src 10.244.0.1
+local 192.168.1.10 dev eth0 table local proto kernel scope host src 192.168.1.10
+`)
+	writeBundleFile(t, root, "networking/sstunlp4", `Netid State  Recv-Q Send-Q   Local Address:Port  Peer Address:PortProcess
+udp   UNCONN 0      0              0.0.0.0:8472       0.0.0.0:*
+tcp   LISTEN 0      4096         127.0.0.1:6443       0.0.0.0:*    users:(("kube-apiserver",pid=9110,fd=3))
+tcp   LISTEN 0      4096     127.0.0.53%lo:53         0.0.0.0:*    users:(("systemd-resolve",pid=232732,fd=14))
+`)
+	writeBundleFile(t, root, "networking/sstunlp6", `Netid State  Recv-Q Send-Q Local Address:Port  Peer Address:PortProcess
+tcp   LISTEN 0      4096               *:10250            *:*    users:(("kubelet",pid=8869,fd=14))
+`)
+	writeBundleFile(t, root, "networking/cni/10-canal.conflist", `{
+  "name": "k8s-pod-network",
+  "cniVersion": "0.3.1",
+  "plugins": [{"type": "calico", "mtu": 1450}, {"type": "portmap", "snat": true}]
+}`)
+	writeBundleFile(t, root, "networking/iptablessave", `# Generated by iptables-save v1.8.7 (legacy) on Thu Dec  4 09:17:05 2025
+*filter
+:INPUT ACCEPT [0:0]
+-A INPUT -j KUBE-FIREWALL
+COMMIT
+`)
+	writeBundleFile(t, root, "networking/nft_ruleset", `table ip filter {
+	chain KUBE-FIREWALL {
+	}
+}
+`)
+
+	status, err := ParseNetworking(root)
+	if err != nil {
+		t.Fatalf("ParseNetworking() error = %v", err)
+	}
+
+	if !status.IsServer || status.IsEtcd || status.ClusterSize != 2 {
+		t.Errorf("roles: server=%v etcd=%v nodes=%d", status.IsServer, status.IsEtcd, status.ClusterSize)
+	}
+	if eth0 := status.Interface("eth0"); eth0 == nil || eth0.MTU != 1500 || eth0.Kind != InterfaceHost ||
+		len(eth0.Addresses) != 1 || eth0.Addresses[0] != "192.168.1.10/24" {
+		t.Errorf("eth0 parsed as %+v", eth0)
+	}
+	if veth := status.Interface("veth1a2b3c4d"); veth == nil || veth.Peer != "if2" || veth.Kind != InterfacePod {
+		t.Errorf("veth parsed as %+v", veth)
+	}
+	if len(status.CNI) != 1 || status.CNI[0].MTU != 1450 || len(status.CNI[0].Plugins) != 2 {
+		t.Errorf("CNI parsed as %+v", status.CNI)
+	}
+	if len(status.Sockets) != 4 || status.Sockets[0].Port != 53 || status.Sockets[0].Address != "127.0.0.53" {
+		t.Errorf("sockets parsed as %+v", status.Sockets)
+	}
+	if status.IptablesBackend() != IptablesLegacy {
+		t.Errorf("backend = %q, want legacy", status.IptablesBackend())
+	}
+
+	want := map[string]string{
+		"Port 6443 (kube-apiserver)":      NetworkCheckWarning,  // loopback only
+		"Port 9345 (rke2 supervisor)":     NetworkCheckCritical, // not listening
+		"Port 10250 (kubelet)":            NetworkCheckOK,
+		"Pod CIDR routes 10.244.0.0/16":   NetworkCheckWarning,  // no routes to the worker's pods
+		"Service CIDR route 10.96.0.0/12": NetworkCheckCritical, // no default route
+		"Pod interface MTU":               NetworkCheckWarning,  // veth 1480, CNI config 1450
+		"iptables backend":                NetworkCheckCritical,
+		"CNI config":                      NetworkCheckOK, // cni defaults to canal, which installs the calico plugin
+	}
+	got := make(map[string]NetworkCheck)
+	for _, c := range status.Checks() {
+		got[c.Name] = c
+	}
+	if _, ok := got["Port 2379 (etcd client)"]; ok {
+		t.Errorf("etcd ports checked on a node without the etcd role")
+	}
+	for name, wantStatus := range want {
+		if got[name].Status != wantStatus {
+			t.Errorf("%s = %s (%s), want %s", name, got[name].Status, got[name].Detail, wantStatus)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// rbacObjectList is the subset of Role/Binding list fields we read from -o yaml/json output
+type rbacObjectList struct {
+	Items []struct {
+		Metadata struct {
+			Name              string `yaml:"name"`
+			Namespace         string `yaml:"namespace"`
+			CreationTimestamp string `yaml:"creationTimestamp"`
+		} `yaml:"metadata"`
+		Rules []struct {
+			APIGroups       []string `yaml:"apiGroups"`
+			Resources       []string `yaml:"resources"`
+			ResourceNames   []string `yaml:"resourceNames"`
+			Verbs           []string `yaml:"verbs"`
+			NonResourceURLs []string `yaml:"nonResourceURLs"`
+		} `yaml:"rules"`
+		RoleRef struct {
+			Kind string `yaml:"kind"`
+			Name string `yaml:"name"`
+		} `yaml:"roleRef"`
+		Subjects []struct {
+			Kind      string `yaml:"kind"`
+			Name      string `yaml:"name"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"subjects"`
+	} `yaml:"items"`
+}
+
+// ParseRoles parses kubectl get clusterroles and roles output from bundle
+// Format: [NAMESPACE] NAME CREATED AT (table output), or a full -o yaml / -o json list.
+// Rules are only available from structured output.
+func ParseRoles(extractPath string) ([]rancher.Role, error) {
+	bundleRoot := getBundleRoot(extractPath)
+
+	var roles []rancher.Role
+	found := false
+
+	for _, kind := range []string{"ClusterRole", "Role"} {
+		path := filepath.Join(bundleRoot, "rke2/kubectl", strings.ToLower(kind)+"s")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		found = true
+
+		if isStructuredOutput(content) {
+			var list rbacObjectList
+			if err := yaml.Unmarshal(content, &list); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+			}
+			for _, item := range list.Items {
+				role := rancher.Role{
+					Kind:      kind,
+					Namespace: item.Metadata.Namespace,
+					Name:      item.Metadata.Name,
+					CreatedAt: item.Metadata.CreationTimestamp,
+				}
+				for _, r := range item.Rules {
+					role.Rules = append(role.Rules, rancher.PolicyRule{
+						APIGroups:       r.APIGroups,
+						Resources:       r.Resources,
+						ResourceNames:   r.ResourceNames,
+						Verbs:           r.Verbs,
+						NonResourceURLs: r.NonResourceURLs,
+					})
+				}
+				roles = append(roles, role)
+			}
+			continue
+		}
+
+		table := ParseKubectlTable(content)
+		for _, row := range table.Rows {
+			name := table.Value(row, "NAME")
+			if name == "" {
+				continue
+			}
+			roles = append(roles, rancher.Role{
+				Kind:      kind,
+				Namespace: table.Value(row, "NAMESPACE"),
+				Name:      name,
+				CreatedAt: table.Value(row, "CREATED AT"),
+			})
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no roles found in bundle")
+	}
+
+	return roles, nil
+}
+
+// ParseRoleBindings parses kubectl get clusterrolebindings and rolebindings output from bundle
+// Format: [NAMESPACE] NAME ROLE AGE USERS GROUPS SERVICEACCOUNTS (-o wide table output),
+// or a full -o yaml / -o json list
+func ParseRoleBindings(extractPath string) ([]rancher.RoleBinding, error) {
+	bundleRoot := getBundleRoot(extractPath)
+
+	var bindings []rancher.RoleBinding
+	found := false
+
+	for _, kind := range []string{"ClusterRoleBinding", "RoleBinding"} {
+		path := filepath.Join(bundleRoot, "rke2/kubectl", strings.ToLower(kind)+"s")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		found = true
+
+		if isStructuredOutput(content) {
+			var list rbacObjectList
+			if err := yaml.Unmarshal(content, &list); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+			}
+			for _, item := range list.Items {
+				binding := rancher.RoleBinding{
+					Kind:      kind,
+					Namespace: item.Metadata.Namespace,
+					Name:      item.Metadata.Name,
+					RoleRef:   rancher.RoleRef{Kind: item.RoleRef.Kind, Name: item.RoleRef.Name},
+				}
+				for _, s := range item.Subjects {
+					binding.Subjects = append(binding.Subjects, rancher.Subject{
+						Kind:      s.Kind,
+						Name:      s.Name,
+						Namespace: s.Namespace,
+					})
+				}
+				bindings = append(bindings, binding)
+			}
+			continue
+		}
+
+		table := ParseKubectlTable(content)
+		for _, row := range table.Rows {
+			name := table.Value(row, "NAME")
+			if name == "" {
+				continue
+			}
+
+			binding := rancher.RoleBinding{
+				Kind:      kind,
+				Namespace: table.Value(row, "NAMESPACE"),
+				Name:      name,
+				Age:       table.Value(row, "AGE"),
+			}
+
+			// ROLE format: "ClusterRole/cluster-admin" or "Role/leader-election"
+			roleParts := strings.SplitN(table.Value(row, "ROLE"), "/", 2)
+			if len(roleParts) == 2 {
+				binding.RoleRef = rancher.RoleRef{Kind: roleParts[0], Name: roleParts[1]}
+			}
+
+			for _, user := range splitList(table.Value(row, "USERS")) {
+				binding.Subjects = append(binding.Subjects, rancher.Subject{Kind: "User", Name: user})
+			}
+			for _, group := range splitList(table.Value(row, "GROUPS")) {
+				binding.Subjects = append(binding.Subjects, rancher.Subject{Kind: "Group", Name: group})
+			}
+			// SERVICEACCOUNTS format: "namespace/name"
+			for _, sa := range splitList(table.Value(row, "SERVICEACCOUNTS")) {
+				subject := rancher.Subject{Kind: "ServiceAccount", Name: sa}
+				if parts := strings.SplitN(sa, "/", 2); len(parts) == 2 {
+					subject.Namespace = parts[0]
+					subject.Name = parts[1]
+				}
+				binding.Subjects = append(binding.Subjects, subject)
+			}
+
+			bindings = append(bindings, binding)
+		}
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no role bindings found in bundle")
+	}
+
+	return bindings, nil
+}
+
+// splitList splits a comma-separated kubectl cell, ignoring empty and <none> values
+func splitList(cell string) []string {
+	var values []string
+	for _, v := range strings.Split(cell, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" && v != "<none>" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
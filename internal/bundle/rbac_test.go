@@ -0,0 +1,51 @@
+package bundle
+
+import "testing"
+
+// TestParseRoleBindings_Table tests -o wide table output with empty subject columns
+func TestParseRoleBindings_Table(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "clusterrolebindings", `NAME                           ROLE                        AGE   USERS        GROUPS           SERVICEACCOUNTS
+cluster-admin                  ClusterRole/cluster-admin   14d                system:masters
+globaladmin-user-2rkjh         ClusterRole/cluster-admin   14d   user-2rkjh
+helm-kube-system-rke2-canal    ClusterRole/cluster-admin   14d                                 kube-system/helm-rke2-canal
+`)
+	writeKubectlFile(t, root, "rolebindings", `NAMESPACE       NAME              ROLE                   AGE   USERS   GROUPS   SERVICEACCOUNTS
+cattle-system   rancher-webhook   Role/rancher-webhook   14d                    cattle-system/rancher-webhook
+`)
+
+	bindings, err := ParseRoleBindings(root)
+	if err != nil {
+		t.Fatalf("ParseRoleBindings() error = %v", err)
+	}
+	if len(bindings) != 4 {
+		t.Fatalf("expected 4 bindings, got %d", len(bindings))
+	}
+
+	tests := []struct {
+		idx       int
+		kind      string
+		subject   string
+		namespace string
+	}{
+		{0, "Group", "system:masters", ""},
+		{1, "User", "user-2rkjh", ""},
+		{2, "ServiceAccount", "helm-rke2-canal", "kube-system"},
+		{3, "ServiceAccount", "rancher-webhook", "cattle-system"},
+	}
+	for _, tt := range tests {
+		b := bindings[tt.idx]
+		if len(b.Subjects) != 1 {
+			t.Errorf("%s: expected 1 subject, got %+v", b.Name, b.Subjects)
+			continue
+		}
+		s := b.Subjects[0]
+		if s.Kind != tt.kind || s.Name != tt.subject || s.Namespace != tt.namespace {
+			t.Errorf("%s: subject = %+v, want %s %s/%s", b.Name, s, tt.kind, tt.namespace, tt.subject)
+		}
+	}
+
+	if bindings[3].Kind != "RoleBinding" || bindings[3].Namespace != "cattle-system" || bindings[3].RoleRef.Kind != "Role" {
+		t.Errorf("unexpected rolebinding: %+v", bindings[3])
+	}
+}
@@ -0,0 +1,329 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// ResourceFile is a kubectl output file under rke2/kubectl with its api-resources entry
+type ResourceFile struct {
+	File       string               // File name, e.g. "hpa"
+	Resource   *rancher.APIResource // nil if the file matches no api-resources entry
+	Table      *KubectlTable        // nil if the file is neither table nor list output
+	Structured bool                 // Table was built from -o yaml / -o json items
+	Message    string               // Non-table output, e.g. "No resources found" or a kubectl error
+}
+
+// ParseAPIResources parses kubectl api-resources output from bundle
+// Format: NAME SHORTNAMES APIVERSION NAMESPACED KIND
+func ParseAPIResources(extractPath string) ([]rancher.APIResource, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	path := filepath.Join(bundleRoot, "rke2/kubectl/api-resources")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	table := ParseKubectlTable(content)
+	var resources []rancher.APIResource
+
+	for _, row := range table.Rows {
+		name := table.Value(row, "NAME")
+		if name == "" {
+			continue
+		}
+		resources = append(resources, rancher.APIResource{
+			Name:       name,
+			ShortNames: splitList(table.Value(row, "SHORTNAMES")),
+			APIVersion: table.Value(row, "APIVERSION"),
+			Namespaced: table.Value(row, "NAMESPACED") == "true",
+			Kind:       table.Value(row, "KIND"),
+		})
+	}
+
+	return resources, nil
+}
+
+// FindAPIResource returns the api-resources entry named by a plural name, kind, or short name
+// (case-insensitive), as kubectl resolves "kubectl get <name>". Like kubectl, built-in groups
+// win over CRDs with the same name, e.g. "networkpolicies" is networking.k8s.io rather than
+// crd.projectcalico.org.
+func FindAPIResource(resources []rancher.APIResource, name string) *rancher.APIResource {
+	name = strings.ToLower(name)
+
+	var fallback *rancher.APIResource
+	for i := range resources {
+		r := &resources[i]
+		if r.Name != name && strings.ToLower(r.Kind) != name && !contains(r.ShortNames, name) {
+			continue
+		}
+		if isBuiltinAPIVersion(r.APIVersion) {
+			return r
+		}
+		if fallback == nil {
+			fallback = r
+		}
+	}
+	return fallback
+}
+
+// isBuiltinAPIVersion reports whether an API version belongs to Kubernetes itself:
+// the core group ("v1"), unqualified groups ("apps/v1") or *.k8s.io groups
+func isBuiltinAPIVersion(apiVersion string) bool {
+	group, _, found := strings.Cut(apiVersion, "/")
+	if !found {
+		return true
+	}
+	return !strings.Contains(group, ".") || strings.HasSuffix(group, ".k8s.io")
+}
+
+// LoadResourceFiles reads every file under rke2/kubectl, sorted by file name, matching each
+// to api-resources (file names are resource names or short names: "pods", "hpa", "pvc")
+func LoadResourceFiles(extractPath string) ([]ResourceFile, error) {
+	dir := filepath.Join(getBundleRoot(extractPath), "rke2/kubectl")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	apiResources, _ := ParseAPIResources(extractPath)
+
+	var files []ResourceFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		file, err := LoadResourceFile(extractPath, entry.Name())
+		if err != nil {
+			continue
+		}
+		file.Resource = FindAPIResource(apiResources, entry.Name())
+		files = append(files, *file)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].File < files[j].File })
+	return files, nil
+}
+
+// LoadResourceFile reads one file under rke2/kubectl as a table. Table output is used as is;
+// -o yaml / -o json lists become NAMESPACE/NAME/AGE rows. Anything else (version output,
+// "No resources found", kubectl errors) is returned as Message.
+func LoadResourceFile(extractPath, name string) (*ResourceFile, error) {
+	path := filepath.Join(getBundleRoot(extractPath), "rke2/kubectl", filepath.Base(name))
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &ResourceFile{File: filepath.Base(name)}
+
+	if isStructuredOutput(content) {
+		objects, err := decodeObjects(content)
+		if err == nil {
+			file.Table = objectsTable(objects)
+			file.Structured = true
+			return file, nil
+		}
+	}
+
+	table := ParseKubectlTable(content)
+	if isResourceTable(table) {
+		file.Table = table
+		return file, nil
+	}
+
+	file.Message = strings.TrimSpace(string(content))
+	return file, nil
+}
+
+// isResourceTable reports whether parsed output has an all-caps header with a NAME column
+func isResourceTable(table *KubectlTable) bool {
+	if table.Column("NAME") < 0 {
+		return false
+	}
+	for _, h := range table.Headers {
+		if strings.ToUpper(h) != h {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeObjects decodes -o yaml / -o json list output (or a single object) into generic maps
+func decodeObjects(content []byte) ([]map[string]interface{}, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+
+	items, ok := doc["items"].([]interface{})
+	if !ok {
+		if _, isObject := doc["metadata"]; isObject {
+			return []map[string]interface{}{doc}, nil
+		}
+		return nil, fmt.Errorf("no items in list output")
+	}
+
+	var objects []map[string]interface{}
+	for _, item := range items {
+		if obj, ok := item.(map[string]interface{}); ok {
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
+
+// objectsTable builds the NAMESPACE NAME AGE table kubectl would print for list items
+func objectsTable(objects []map[string]interface{}) *KubectlTable {
+	namespaced := false
+	for _, obj := range objects {
+		if ns, _ := objectMetadata(obj)["namespace"].(string); ns != "" {
+			namespaced = true
+			break
+		}
+	}
+
+	table := &KubectlTable{Headers: []string{"NAME", "AGE"}}
+	if namespaced {
+		table.Headers = []string{"NAMESPACE", "NAME", "AGE"}
+	}
+
+	for _, obj := range objects {
+		meta := objectMetadata(obj)
+		name, _ := meta["name"].(string)
+		namespace, _ := meta["namespace"].(string)
+
+		age := ""
+		if created, ok := meta["creationTimestamp"].(time.Time); ok {
+			age = formatKubectlAge(time.Since(created))
+		} else if created, ok := meta["creationTimestamp"].(string); ok {
+			if t, err := time.Parse(time.RFC3339, created); err == nil {
+				age = formatKubectlAge(time.Since(t))
+			}
+		}
+
+		row := []string{name, age}
+		if namespaced {
+			row = []string{namespace, name, age}
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table
+}
+
+// objectMetadata returns an object's metadata map (empty if missing)
+func objectMetadata(obj map[string]interface{}) map[string]interface{} {
+	meta, _ := obj["metadata"].(map[string]interface{})
+	if meta == nil {
+		return map[string]interface{}{}
+	}
+	return meta
+}
+
+// formatKubectlAge formats a duration the way kubectl's AGE column does (largest unit only)
+func formatKubectlAge(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
+
+// ResourceObjects returns the objects in a rke2/kubectl file as generic maps. Full objects
+// are returned for -o yaml / -o json output; table rows become objects with metadata
+// (name, namespace, creationTimestamp derived from AGE) and the row's cells under "columns".
+func ResourceObjects(extractPath, name string) ([]map[string]interface{}, error) {
+	path := filepath.Join(getBundleRoot(extractPath), "rke2/kubectl", filepath.Base(name))
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if isStructuredOutput(content) {
+		return decodeObjects(content)
+	}
+
+	table := ParseKubectlTable(content)
+	if !isResourceTable(table) {
+		return []map[string]interface{}{}, nil
+	}
+
+	objects := []map[string]interface{}{}
+	for _, row := range table.Rows {
+		meta := map[string]interface{}{"name": table.Value(row, "NAME")}
+		if ns := table.Value(row, "NAMESPACE"); ns != "" {
+			meta["namespace"] = ns
+		}
+		if created := parseKubectlAge(table.Value(row, "AGE")); !created.IsZero() {
+			meta["creationTimestamp"] = created.Format(time.RFC3339)
+		}
+
+		columns := make(map[string]interface{}, len(table.Headers))
+		for i, h := range table.Headers {
+			columns[h] = row[i]
+		}
+
+		objects = append(objects, map[string]interface{}{"metadata": meta, "columns": columns})
+	}
+	return objects, nil
+}
+
+// CompareKubectlValues orders two table cells: numbers ("3", "2/3", "5 (2m ago)", "45%")
+// and ages ("3d4h") compare numerically, everything else as case-insensitive text
+func CompareKubectlValues(a, b string) int {
+	if da, okA := kubectlAgeDuration(a); okA {
+		if db, okB := kubectlAgeDuration(b); okB {
+			return compareFloats(float64(da), float64(db))
+		}
+	}
+	if na, okA := leadingNumber(a); okA {
+		if nb, okB := leadingNumber(b); okB {
+			return compareFloats(na, nb)
+		}
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// leadingNumber parses the number at the start of a cell like "2/3", "45%" or "5 (2m ago)"
+func leadingNumber(s string) (float64, bool) {
+	end := 0
+	for end < len(s) && (s[end] >= '0' && s[end] <= '9' || s[end] == '.' || (end == 0 && s[end] == '-')) {
+		end++
+	}
+	if end == 0 {
+		return 0, false
+	}
+	// Reject identifiers that merely start with digits, e.g. "10.42.0.15" or "3scale"
+	if end < len(s) && !strings.ContainsRune("/% (", rune(s[end])) {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(s[:end], 64)
+	return n, err == nil
+}
+
+// compareFloats returns -1, 0 or 1
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,85 @@
+package bundle
+
+import "testing"
+
+// TestLoadResourceFiles tests api-resources matching, list output and non-table files
+func TestLoadResourceFiles(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "api-resources", `NAME                        SHORTNAMES   APIVERSION                   NAMESPACED   KIND
+pods                        po           v1                           true         Pod
+networkpolicies                          crd.projectcalico.org/v1     true         NetworkPolicy
+horizontalpodautoscalers    hpa          autoscaling/v2               true         HorizontalPodAutoscaler
+networkpolicies             netpol       networking.k8s.io/v1         true         NetworkPolicy
+helmcharts                               helm.cattle.io/v1            true         HelmChart
+`)
+	writeKubectlFile(t, root, "hpa", "No resources found\n")
+	writeKubectlFile(t, root, "networkpolicies", `NAMESPACE     NAME              POD-SELECTOR   AGE
+kube-system   default-deny-all   <none>         14d
+`)
+	writeKubectlFile(t, root, "version", "Client Version: v1.32.7+rke2r1\nServer Version: v1.32.7+rke2r1\n")
+	writeKubectlFile(t, root, "helmcharts", `apiVersion: v1
+kind: List
+items:
+- apiVersion: helm.cattle.io/v1
+  kind: HelmChart
+  metadata:
+    name: rke2-coredns
+    namespace: kube-system
+    creationTimestamp: "2025-11-20T09:15:57Z"
+`)
+
+	files, err := LoadResourceFiles(root)
+	if err != nil {
+		t.Fatalf("LoadResourceFiles() error = %v", err)
+	}
+
+	byName := make(map[string]ResourceFile)
+	for _, f := range files {
+		byName[f.File] = f
+	}
+
+	if hpa := byName["hpa"]; hpa.Resource == nil || hpa.Resource.Kind != "HorizontalPodAutoscaler" || hpa.Table != nil || hpa.Message != "No resources found" {
+		t.Errorf("hpa: unexpected %+v", hpa)
+	}
+	if netpol := byName["networkpolicies"]; netpol.Resource == nil || netpol.Resource.APIVersion != "networking.k8s.io/v1" || len(netpol.Table.Rows) != 1 {
+		t.Errorf("networkpolicies: expected built-in group to win over the CRD, got %+v", netpol.Resource)
+	}
+	if version := byName["version"]; version.Resource != nil || version.Table != nil {
+		t.Errorf("version: expected non-table file, got %+v", version)
+	}
+	if charts := byName["helmcharts"]; !charts.Structured || len(charts.Table.Rows) != 1 || charts.Table.Rows[0][1] != "rke2-coredns" {
+		t.Errorf("helmcharts: unexpected table %+v", charts.Table)
+	}
+
+	objects, err := ResourceObjects(root, "networkpolicies")
+	if err != nil || len(objects) != 1 {
+		t.Fatalf("ResourceObjects() = %v, %v", objects, err)
+	}
+	if meta := objectMetadata(objects[0]); meta["name"] != "default-deny-all" || meta["creationTimestamp"] == nil {
+		t.Errorf("unexpected object metadata %v", meta)
+	}
+}
+
+// TestCompareKubectlValues tests numeric, ready-count and age ordering of table cells
+func TestCompareKubectlValues(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"9", "10", -1},
+		{"2/3", "10/10", -1},
+		{"5 (2m ago)", "12", -1},
+		{"45%", "8%", 1},
+		{"3h", "2d", -1},
+		{"90m", "1h", 1},
+		{"10.42.0.9", "10.42.0.15", 1}, // IPs compare as text
+		{"coredns", "Calico", 1},
+		{"14d", "14d", 0},
+	}
+
+	for _, tt := range tests {
+		if got := CompareKubectlValues(tt.a, tt.b); got != tt.want {
+			t.Errorf("CompareKubectlValues(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
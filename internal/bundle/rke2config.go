@@ -0,0 +1,442 @@
+package bundle
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RKE2 config issue types (see RKE2ConfigIssue.Type)
+const (
+	RKE2ConfigIssueUnknownKey   = "unknown-key"
+	RKE2ConfigIssueServerOnly   = "server-only"
+	RKE2ConfigIssueInvalidValue = "invalid-value"
+)
+
+// RKE2 defaults for settings 50-rancher.yaml does not set (cluster-cidr and service-cidr are in networking.go)
+const (
+	rke2DefaultCNI               = "canal"
+	rke2DefaultClusterDNS        = "10.43.0.10"
+	rke2DefaultSnapshotSchedule  = "0 */12 * * *"
+	rke2DefaultSnapshotRetention = 5
+)
+
+// rke2ConfigMask replaces secret values in the config view
+const rke2ConfigMask = "********"
+
+// rke2AgentConfigKeys are the config keys (CLI flags without "--") accepted by both rke2 server and rke2 agent
+var rke2AgentConfigKeys = []string{
+	"config", "debug", "data-dir", "token", "token-file", "server", "node-name", "with-node-id",
+	"node-label", "node-taint", "node-ip", "node-external-ip", "node-internal-dns", "node-external-dns",
+	"image-credential-provider-bin-dir", "image-credential-provider-config", "container-runtime-endpoint",
+	"image-service-endpoint", "default-runtime", "snapshotter", "private-registry", "system-default-registry",
+	"disable-default-registry-endpoint", "airgap-extra-registry", "resolv-conf", "kubelet-arg", "kubelet-path",
+	"kube-proxy-arg", "kube-proxy-extra-mount", "kube-proxy-extra-env", "protect-kernel-defaults", "selinux",
+	"lb-server-port", "cni", "enable-pprof", "profile", "cloud-provider-name", "cloud-provider-config",
+	"pause-image", "runtime-image", "vpn-auth", "vpn-auth-file", "log", "alsologtostderr",
+}
+
+// rke2ServerConfigKeys are the config keys only rke2 server accepts; rke2 agent skips them
+var rke2ServerConfigKeys = []string{
+	"bind-address", "advertise-address", "advertise-port", "tls-san", "tls-san-security",
+	"cluster-cidr", "service-cidr", "service-node-port-range", "cluster-dns", "cluster-domain",
+	"egress-selector-mode", "servicelb-namespace", "enable-servicelb", "ingress-controller", "embedded-registry",
+	"supervisor-metrics", "write-kubeconfig", "write-kubeconfig-mode", "write-kubeconfig-group", "write-kubeconfig-user",
+	"agent-token", "agent-token-file", "disable", "disable-scheduler", "disable-cloud-controller", "disable-kube-proxy",
+	"disable-apiserver", "disable-controller-manager", "disable-etcd", "cluster-reset", "cluster-reset-restore-path",
+	"secrets-encryption", "secrets-encryption-provider", "audit-policy-file", "pod-security-admission-config-file",
+	"control-plane-resource-requests", "control-plane-resource-limits", "control-plane-probe-configuration",
+	"kube-apiserver-arg", "kube-scheduler-arg", "kube-controller-manager-arg", "kube-cloud-controller-manager-arg", "etcd-arg",
+	"kube-apiserver-extra-mount", "kube-scheduler-extra-mount", "kube-controller-manager-extra-mount",
+	"kube-cloud-controller-manager-extra-mount", "etcd-extra-mount",
+	"kube-apiserver-extra-env", "kube-scheduler-extra-env", "kube-controller-manager-extra-env",
+	"kube-cloud-controller-manager-extra-env", "etcd-extra-env",
+	"kube-apiserver-image", "kube-controller-manager-image", "cloud-controller-manager-image", "kube-proxy-image",
+	"kube-scheduler-image", "etcd-image", "helm-job-image",
+	"datastore-endpoint", "datastore-cafile", "datastore-certfile", "datastore-keyfile",
+	"etcd-expose-metrics", "etcd-disable-snapshots", "etcd-snapshot-name", "etcd-snapshot-schedule-cron",
+	"etcd-snapshot-retention", "etcd-snapshot-dir", "etcd-snapshot-compress",
+	"etcd-s3", "etcd-s3-endpoint", "etcd-s3-endpoint-ca", "etcd-s3-skip-ssl-verify", "etcd-s3-access-key",
+	"etcd-s3-secret-key", "etcd-s3-session-token", "etcd-s3-bucket", "etcd-s3-region", "etcd-s3-folder",
+	"etcd-s3-proxy", "etcd-s3-config-secret", "etcd-s3-insecure", "etcd-s3-timeout",
+}
+
+// rke2SecretConfigKeys hold credentials; keys containing "token", "secret" or "password" are masked too
+var rke2SecretConfigKeys = []string{"etcd-s3-access-key", "vpn-auth"}
+
+// rke2CNIs are the values accepted by the cni setting
+var rke2CNIs = []string{"canal", "calico", "cilium", "flannel", "multus", "none"}
+
+// RKE2ConfigSetting is a top-level key of 50-rancher.yaml
+type RKE2ConfigSetting struct {
+	Key    string
+	Value  string // Lists joined with ", "; secrets masked
+	Masked bool
+	Known  bool
+}
+
+// RKE2ServiceUnit is an rke2-server/rke2-agent systemd unit file from the bundle
+type RKE2ServiceUnit struct {
+	Name             string // e.g. "rke2-server.service"
+	ExecStart        string // Secrets masked
+	EnvironmentFiles []string
+	Directives       []ControlPlaneFlag // [Service] directives in file order; secrets masked
+}
+
+// RKE2Config is the node's RKE2 configuration: 50-rancher.yaml and the service unit files,
+// with the effective values of settings other analyses depend on
+type RKE2Config struct {
+	NodeName string
+	Role     string // "server" or "agent" from the node's static pods; empty if unknown
+	Found    bool   // 50-rancher.yaml is in the bundle
+	Settings []RKE2ConfigSetting
+	Units    []RKE2ServiceUnit
+
+	// Effective values: the config's, else RKE2's default
+	CNI               []string
+	ClusterCIDR       string
+	ServiceCIDR       string
+	ClusterDNS        string
+	TLSSANs           []string
+	SnapshotSchedule  string
+	SnapshotRetention int
+	SnapshotsDisabled bool
+
+	values map[string]*yaml.Node
+}
+
+// RKE2ConfigIssue is an unknown, misplaced or invalid config key
+type RKE2ConfigIssue struct {
+	Type   string
+	Key    string
+	Detail string
+}
+
+// ParseRKE2Config parses rke2/50-rancher.yaml and the rke2-server/rke2-agent unit files.
+// Returns an error only if the bundle has none of them.
+func ParseRKE2Config(extractPath string) (*RKE2Config, error) {
+	config := loadRKE2Config(extractPath)
+	if !config.Found && len(config.Units) == 0 {
+		return nil, fmt.Errorf("no rke2/50-rancher.yaml or rke2 unit files in bundle")
+	}
+	return config, nil
+}
+
+// loadRKE2Config reads the node's RKE2 config, falling back to RKE2 defaults for every
+// effective setting when 50-rancher.yaml is missing or unreadable
+func loadRKE2Config(extractPath string) *RKE2Config {
+	bundleRoot := getBundleRoot(extractPath)
+	config := &RKE2Config{
+		NodeName: extractNodeName(extractPath),
+		values:   make(map[string]*yaml.Node),
+	}
+
+	if content, err := os.ReadFile(filepath.Join(bundleRoot, "rke2/50-rancher.yaml")); err == nil {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(content, &doc); err == nil && len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+			config.Found = true
+			mapping := doc.Content[0]
+			for i := 0; i+1 < len(mapping.Content); i += 2 {
+				key, value := mapping.Content[i].Value, mapping.Content[i+1]
+				config.values[key] = value
+				setting := RKE2ConfigSetting{
+					Key:   key,
+					Value: rke2ConfigValue(value),
+					Known: rke2KnownConfigKey(key),
+				}
+				if rke2SecretKey(key) {
+					setting.Value, setting.Masked = rke2ConfigMask, true
+				} else if key == "datastore-endpoint" {
+					setting.Value, setting.Masked = maskURLPassword(setting.Value)
+				}
+				config.Settings = append(config.Settings, setting)
+			}
+		}
+	}
+
+	for _, name := range []string{"rke2-server.service", "rke2-agent.service"} {
+		if content, err := os.ReadFile(filepath.Join(bundleRoot, "rke2", name)); err == nil {
+			config.Units = append(config.Units, parseRKE2ServiceUnit(name, string(content)))
+		}
+	}
+
+	if entries, err := os.ReadDir(filepath.Join(bundleRoot, "rke2/pod-manifests")); err == nil {
+		config.Role = "agent"
+		for _, e := range entries {
+			switch strings.TrimSuffix(e.Name(), filepath.Ext(e.Name())) {
+			case "kube-apiserver", "etcd":
+				config.Role = "server"
+			}
+		}
+	}
+
+	config.CNI = splitList(config.Value("cni"))
+	if len(config.CNI) == 0 {
+		config.CNI = []string{rke2DefaultCNI}
+	}
+	config.ClusterCIDR = stringOr(config.Value("cluster-cidr"), rke2DefaultClusterCIDR)
+	config.ServiceCIDR = stringOr(config.Value("service-cidr"), rke2DefaultServiceCIDR)
+	config.ClusterDNS = stringOr(config.Value("cluster-dns"), rke2DefaultClusterDNS)
+	config.TLSSANs = config.List("tls-san")
+	config.SnapshotSchedule = stringOr(config.Value("etcd-snapshot-schedule-cron"), rke2DefaultSnapshotSchedule)
+	config.SnapshotRetention = rke2DefaultSnapshotRetention
+	if n, err := strconv.Atoi(config.Value("etcd-snapshot-retention")); err == nil {
+		config.SnapshotRetention = n
+	}
+	config.SnapshotsDisabled = config.Bool("etcd-disable-snapshots")
+
+	return config
+}
+
+// Value returns a scalar setting (empty if unset or not a scalar)
+func (c *RKE2Config) Value(key string) string {
+	if node, ok := c.values[key]; ok && node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	return ""
+}
+
+// Bool returns a boolean setting (false if unset)
+func (c *RKE2Config) Bool(key string) bool {
+	value, _ := strconv.ParseBool(c.Value(key))
+	return value
+}
+
+// List returns a list setting; RKE2 also accepts a single comma-separated string
+func (c *RKE2Config) List(key string) []string {
+	node, ok := c.values[key]
+	if !ok {
+		return nil
+	}
+	if node.Kind == yaml.ScalarNode {
+		return splitList(node.Value)
+	}
+	var values []string
+	for _, item := range node.Content {
+		values = append(values, item.Value)
+	}
+	return values
+}
+
+// Setting returns a setting from 50-rancher.yaml, or nil if unset
+func (c *RKE2Config) Setting(key string) *RKE2ConfigSetting {
+	for i := range c.Settings {
+		if c.Settings[i].Key == key {
+			return &c.Settings[i]
+		}
+	}
+	return nil
+}
+
+// Issues returns unknown keys (with the closest known key when it looks like a typo),
+// server-only keys on an agent node, and invalid cni and CIDR values
+func (c *RKE2Config) Issues() []RKE2ConfigIssue {
+	var issues []RKE2ConfigIssue
+	for _, s := range c.Settings {
+		switch {
+		case !s.Known:
+			detail := fmt.Sprintf("%s is not an RKE2 config key; RKE2 skips it (\"Unknown flag\" in the rke2 log) so it has no effect", s.Key)
+			if suggestion := closestRKE2ConfigKey(s.Key); suggestion != "" {
+				detail = fmt.Sprintf("%s is not an RKE2 config key, did you mean %s? RKE2 skips unknown keys so it has no effect", s.Key, suggestion)
+			}
+			issues = append(issues, RKE2ConfigIssue{Type: RKE2ConfigIssueUnknownKey, Key: s.Key, Detail: detail})
+		case c.Role == "agent" && contains(rke2ServerConfigKeys, strings.TrimSuffix(s.Key, "+")):
+			issues = append(issues, RKE2ConfigIssue{
+				Type:   RKE2ConfigIssueServerOnly,
+				Key:    s.Key,
+				Detail: fmt.Sprintf("%s is a server setting but this node runs rke2 agent (no control plane static pods), which ignores it", s.Key),
+			})
+		}
+	}
+
+	for _, cni := range c.CNI {
+		if !contains(rke2CNIs, cni) {
+			issues = append(issues, RKE2ConfigIssue{
+				Type:   RKE2ConfigIssueInvalidValue,
+				Key:    "cni",
+				Detail: fmt.Sprintf("cni %q is not one of %s", cni, strings.Join(rke2CNIs, ", ")),
+			})
+		}
+	}
+	for _, key := range []string{"cluster-cidr", "service-cidr"} {
+		for _, cidr := range splitList(c.Value(key)) {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				issues = append(issues, RKE2ConfigIssue{
+					Type:   RKE2ConfigIssueInvalidValue,
+					Key:    key,
+					Detail: fmt.Sprintf("%s %q is not a valid CIDR", key, cidr),
+				})
+			}
+		}
+	}
+	return issues
+}
+
+// parseRKE2ServiceUnit parses a systemd unit file's [Service] section, masking secrets in the
+// values like the config settings
+// Format: INI sections with Key=Value lines; keys such as EnvironmentFile may repeat
+func parseRKE2ServiceUnit(name, content string) RKE2ServiceUnit {
+	unit := RKE2ServiceUnit{Name: name}
+	section := ""
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section = line
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if section != "[Service]" || !ok || strings.HasPrefix(line, "#") {
+			continue
+		}
+		value = maskUnitSecrets(value)
+		unit.Directives = append(unit.Directives, ControlPlaneFlag{Name: key, Value: value})
+		switch key {
+		case "ExecStart":
+			unit.ExecStart = value
+		case "EnvironmentFile":
+			unit.EnvironmentFiles = append(unit.EnvironmentFiles, strings.TrimPrefix(value, "-"))
+		}
+	}
+	return unit
+}
+
+// rke2ConfigValue formats a config value for display: scalars as-is, lists joined with ", "
+func rke2ConfigValue(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Value
+	case yaml.SequenceNode:
+		var values []string
+		for _, item := range node.Content {
+			values = append(values, rke2ConfigValue(item))
+		}
+		return strings.Join(values, ", ")
+	default:
+		out, _ := yaml.Marshal(node)
+		return strings.TrimSpace(string(out))
+	}
+}
+
+// rke2KnownConfigKey reports whether key is an RKE2 config key; "key+" appends to a value
+// set in an earlier config file
+func rke2KnownConfigKey(key string) bool {
+	key = strings.TrimSuffix(key, "+")
+	return contains(rke2AgentConfigKeys, key) || contains(rke2ServerConfigKeys, key)
+}
+
+// rke2SecretKey reports whether a config key holds a credential
+func rke2SecretKey(key string) bool {
+	return contains(rke2SecretConfigKeys, key) || strings.Contains(key, "token") ||
+		strings.Contains(key, "secret") || strings.Contains(key, "password")
+}
+
+// maskURLPassword masks the password in a URL such as a datastore endpoint
+func maskURLPassword(value string) (string, bool) {
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil {
+		return value, false
+	}
+	password, ok := u.User.Password()
+	if !ok || password == "" {
+		return value, false
+	}
+	return strings.Replace(value, ":"+password+"@", ":"+rke2ConfigMask+"@", 1), true
+}
+
+// maskUnitSecrets masks credentials in a unit directive value: environment assignments
+// (RKE2_TOKEN=...) and --flag=value or --flag value arguments whose name is a secret config
+// key, and passwords in URLs such as a --datastore-endpoint
+func maskUnitSecrets(value string) string {
+	fields := strings.Fields(value)
+	masked := false
+	for i := 0; i < len(fields); i++ {
+		field := strings.Trim(fields[i], `"'`)
+		name, v, assigned := strings.Cut(field, "=")
+		key := strings.ToLower(strings.ReplaceAll(strings.TrimLeft(name, "-"), "_", "-"))
+		switch {
+		case assigned && v != "" && rke2SecretKey(key):
+			fields[i] = strings.Replace(fields[i], "="+v, "="+rke2ConfigMask, 1)
+			masked = true
+		case !assigned && strings.HasPrefix(name, "--") && rke2SecretKey(key) && i+1 < len(fields):
+			i++
+			fields[i] = rke2ConfigMask
+			masked = true
+		default:
+			if !assigned {
+				v = field
+			}
+			if m, ok := maskURLPassword(v); ok {
+				fields[i] = strings.Replace(fields[i], v, m, 1)
+				masked = true
+			}
+		}
+	}
+	if !masked {
+		return value
+	}
+	return strings.Join(fields, " ")
+}
+
+// closestRKE2ConfigKey returns the known key a misspelled key most likely meant: the same key
+// with underscores, or one within two edits. Empty if nothing is close.
+func closestRKE2ConfigKey(key string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(key, "_", "-"))
+	best, bestDistance := "", 3
+	for _, keys := range [][]string{rke2AgentConfigKeys, rke2ServerConfigKeys} {
+		for _, known := range keys {
+			if known == normalized {
+				return known
+			}
+			if d := editDistance(normalized, known); d < bestDistance {
+				best, bestDistance = known, d
+			}
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// stringOr returns value, or fallback if value is empty; used to apply RKE2's default for a
+// setting 50-rancher.yaml leaves unset
+func stringOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// rke2ConfigString returns a string setting from rke2/50-rancher.yaml (empty if unset)
+func rke2ConfigString(extractPath, key string) string {
+	return loadRKE2Config(extractPath).Value(key)
+}
+
+// rke2ConfigBool returns a boolean setting from rke2/50-rancher.yaml (false if unset)
+func rke2ConfigBool(extractPath, key string) bool {
+	return loadRKE2Config(extractPath).Bool(key)
+}
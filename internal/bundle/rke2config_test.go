@@ -0,0 +1,152 @@
+package bundle
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestParseRKE2Config tests config parsing, secret masking, effective defaults, unit files and lint
+func TestParseRKE2Config(t *testing.T) {
+	root := t.TempDir()
+
+	writeBundleFile(t, root, "rke2/50-rancher.yaml", `{
+  "cni": "calico",
+  "cluster_cidr": "10.100.0.0/16",
+  "service-cidr": "10.200.0.0/33",
+  "tls-san": ["rancher.example.com", "10.0.0.5"],
+  "token": "K10abc::server:s3cr3t",
+  "etcd-s3-secret-key": "hunter2",
+  "datastore-endpoint": "postgres://rke2:pa55@db:5432/rke2",
+  "etcd-snapshot-schedule-cron": "0 */5 * * *",
+  "etcd-snapshot-retention": 10,
+  "node-labels": ["zone=a"],
+  "kubelet-arg+": ["max-pods=200"],
+}
+`)
+	writeBundleFile(t, root, "rke2/rke2-agent.service", `[Unit]
+Description=Rancher Kubernetes Engine v2 (agent)
+
+[Service]
+Type=notify
+EnvironmentFile=-/etc/default/%N
+EnvironmentFile=-/etc/sysconfig/%N
+Restart=always
+ExecStart=/usr/local/bin/rke2 agent
+`)
+	writeBundleFile(t, root, "rke2/pod-manifests/kube-proxy.yaml", "apiVersion: v1\nkind: Pod\n")
+
+	config, err := ParseRKE2Config(root)
+	if err != nil {
+		t.Fatalf("ParseRKE2Config() error = %v", err)
+	}
+
+	if config.Role != "agent" || len(config.Settings) != 11 {
+		t.Fatalf("role=%q settings=%+v", config.Role, config.Settings)
+	}
+	masked := map[string]string{
+		"token":              rke2ConfigMask,
+		"etcd-s3-secret-key": rke2ConfigMask,
+		"datastore-endpoint": "postgres://rke2:" + rke2ConfigMask + "@db:5432/rke2",
+	}
+	for key, want := range masked {
+		if s := config.Setting(key); s == nil || !s.Masked || s.Value != want {
+			t.Errorf("%s = %+v, want masked %q", key, s, want)
+		}
+	}
+	if s := config.Setting("tls-san"); s.Value != "rancher.example.com, 10.0.0.5" {
+		t.Errorf("tls-san value = %q", s.Value)
+	}
+
+	// cluster_cidr is a typo, so the default applies
+	if config.ClusterCIDR != rke2DefaultClusterCIDR || config.ServiceCIDR != "10.200.0.0/33" || config.ClusterDNS != rke2DefaultClusterDNS ||
+		len(config.CNI) != 1 || config.CNI[0] != "calico" || len(config.TLSSANs) != 2 ||
+		config.SnapshotSchedule != "0 */5 * * *" || config.SnapshotRetention != 10 {
+		t.Errorf("effective settings = %+v", config)
+	}
+	if len(config.Units) != 1 || config.Units[0].ExecStart != "/usr/local/bin/rke2 agent" || len(config.Units[0].EnvironmentFiles) != 2 {
+		t.Errorf("units = %+v", config.Units)
+	}
+
+	type want struct {
+		issueType string
+		detail    string
+	}
+	wantIssues := map[string]want{
+		"cluster_cidr":                {RKE2ConfigIssueUnknownKey, "did you mean cluster-cidr?"},
+		"node-labels":                 {RKE2ConfigIssueUnknownKey, "did you mean node-label?"},
+		"service-cidr":                {RKE2ConfigIssueServerOnly, ""},
+		"tls-san":                     {RKE2ConfigIssueServerOnly, ""},
+		"etcd-s3-secret-key":          {RKE2ConfigIssueServerOnly, ""},
+		"datastore-endpoint":          {RKE2ConfigIssueServerOnly, ""},
+		"etcd-snapshot-schedule-cron": {RKE2ConfigIssueServerOnly, ""},
+		"etcd-snapshot-retention":     {RKE2ConfigIssueServerOnly, ""},
+	}
+	var invalid int
+	for _, issue := range config.Issues() {
+		if issue.Type == RKE2ConfigIssueInvalidValue {
+			invalid++
+			if issue.Key != "service-cidr" {
+				t.Errorf("unexpected invalid value: %s", issue.Detail)
+			}
+			continue
+		}
+		w, ok := wantIssues[issue.Key]
+		if !ok || issue.Type != w.issueType || !strings.Contains(issue.Detail, w.detail) {
+			t.Errorf("unexpected issue %s %s: %s", issue.Type, issue.Key, issue.Detail)
+		}
+		delete(wantIssues, issue.Key)
+	}
+	if invalid != 1 || len(wantIssues) != 0 {
+		t.Errorf("invalid values = %d, missing issues %+v", invalid, wantIssues)
+	}
+}
+
+// TestRKE2ServiceUnitMasksSecrets tests that unit directives hide tokens and passwords
+func TestRKE2ServiceUnitMasksSecrets(t *testing.T) {
+	unit := parseRKE2ServiceUnit("rke2-server.service", `[Service]
+Environment=RKE2_TOKEN=K10abc::server:s3cr3t
+Environment="HOME=/root" "CATTLE_AGENT_PASSWORD=hunter2"
+ExecStart=/usr/local/bin/rke2 server --token s3cr3t --agent-token=s3cr3t --datastore-endpoint=postgres://rke2:pa55@db:5432/rke2
+KillMode=process
+`)
+
+	want := []string{
+		"RKE2_TOKEN=" + rke2ConfigMask,
+		`"HOME=/root" "CATTLE_AGENT_PASSWORD=` + rke2ConfigMask + `"`,
+		"/usr/local/bin/rke2 server --token " + rke2ConfigMask + " --agent-token=" + rke2ConfigMask +
+			" --datastore-endpoint=postgres://rke2:" + rke2ConfigMask + "@db:5432/rke2",
+		"process",
+	}
+	if len(unit.Directives) != len(want) {
+		t.Fatalf("directives = %+v", unit.Directives)
+	}
+	for i, w := range want {
+		if unit.Directives[i].Value != w {
+			t.Errorf("%s = %q, want %q", unit.Directives[i].Name, unit.Directives[i].Value, w)
+		}
+	}
+	if unit.ExecStart != want[2] {
+		t.Errorf("ExecStart = %q", unit.ExecStart)
+	}
+}
+
+// TestCronInterval tests the snapshot schedule intervals used for the stale snapshot check
+func TestCronInterval(t *testing.T) {
+	tests := map[string]time.Duration{
+		"0 */12 * * *":  12 * time.Hour,
+		"0 */5 * * *":   5 * time.Hour,
+		"*/30 * * * *":  30 * time.Minute,
+		"15 * * * *":    time.Hour,
+		"0 0,12 * * *":  12 * time.Hour,
+		"30 2 * * *":    24 * time.Hour,
+		"0 2 * * 0":     0,
+		"not a cron":    0,
+		"0 */0 * * * *": 0,
+	}
+	for schedule, want := range tests {
+		if got := cronInterval(schedule); got != want {
+			t.Errorf("cronInterval(%q) = %s, want %s", schedule, got, want)
+		}
+	}
+}
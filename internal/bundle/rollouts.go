@@ -0,0 +1,229 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// RolloutInfo links a Deployment to its ReplicaSets, one per rollout revision
+type RolloutInfo struct {
+	Deployment  WorkloadInfo
+	ReplicaSets []rancher.ReplicaSet // Newest revision first
+}
+
+// NewReplicaSet returns the ReplicaSet of the latest revision, or nil if none were found
+func (r *RolloutInfo) NewReplicaSet() *rancher.ReplicaSet {
+	if len(r.ReplicaSets) == 0 {
+		return nil
+	}
+	return &r.ReplicaSets[0]
+}
+
+// OldServing returns older ReplicaSets that still have ready pods
+func (r *RolloutInfo) OldServing() []rancher.ReplicaSet {
+	var serving []rancher.ReplicaSet
+	for i := 1; i < len(r.ReplicaSets); i++ {
+		if r.ReplicaSets[i].Ready > 0 {
+			serving = append(serving, r.ReplicaSets[i])
+		}
+	}
+	return serving
+}
+
+// InProgress reports whether more than one revision still has pods, i.e. the rollout has not finished
+func (r *RolloutInfo) InProgress() bool {
+	active := 0
+	for _, rs := range r.ReplicaSets {
+		if rs.Desired > 0 || rs.Current > 0 {
+			active++
+		}
+	}
+	return active > 1
+}
+
+// Stalled reports whether the new ReplicaSet wants pods but has none ready while an
+// old ReplicaSet is still serving traffic
+func (r *RolloutInfo) Stalled() bool {
+	newRS := r.NewReplicaSet()
+	return newRS != nil && newRS.Desired > 0 && newRS.Ready == 0 && len(r.OldServing()) > 0
+}
+
+// ParseReplicaSets parses kubectl get replicasets output from bundle
+// Format: NAMESPACE NAME DESIRED CURRENT READY AGE [CONTAINERS IMAGES SELECTOR] (table output),
+// or a full -o yaml / -o json list with owner references and revision annotations
+func ParseReplicaSets(extractPath string) ([]rancher.ReplicaSet, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	path := filepath.Join(bundleRoot, "rke2/kubectl/replicasets")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var replicaSets []rancher.ReplicaSet
+
+	if isStructuredOutput(content) {
+		var list struct {
+			Items []struct {
+				Metadata struct {
+					Name            string            `yaml:"name"`
+					Namespace       string            `yaml:"namespace"`
+					Annotations     map[string]string `yaml:"annotations"`
+					OwnerReferences []struct {
+						Kind string `yaml:"kind"`
+						Name string `yaml:"name"`
+					} `yaml:"ownerReferences"`
+				} `yaml:"metadata"`
+				Spec struct {
+					Replicas int `yaml:"replicas"`
+					Template struct {
+						Spec struct {
+							Containers []struct {
+								Name  string `yaml:"name"`
+								Image string `yaml:"image"`
+							} `yaml:"containers"`
+						} `yaml:"spec"`
+					} `yaml:"template"`
+				} `yaml:"spec"`
+				Status struct {
+					Replicas      int `yaml:"replicas"`
+					ReadyReplicas int `yaml:"readyReplicas"`
+				} `yaml:"status"`
+			} `yaml:"items"`
+		}
+		if err := yaml.Unmarshal(content, &list); err != nil {
+			return nil, fmt.Errorf("failed to parse replicasets: %w", err)
+		}
+
+		for _, item := range list.Items {
+			rs := rancher.ReplicaSet{
+				Namespace: item.Metadata.Namespace,
+				Name:      item.Metadata.Name,
+				Desired:   item.Spec.Replicas,
+				Current:   item.Status.Replicas,
+				Ready:     item.Status.ReadyReplicas,
+			}
+			rs.Revision, _ = strconv.Atoi(item.Metadata.Annotations["deployment.kubernetes.io/revision"])
+			for _, owner := range item.Metadata.OwnerReferences {
+				if owner.Kind == "Deployment" {
+					rs.Owner = owner.Name
+				}
+			}
+			for _, c := range item.Spec.Template.Spec.Containers {
+				rs.Containers = append(rs.Containers, c.Name)
+				rs.Images = append(rs.Images, c.Image)
+			}
+			replicaSets = append(replicaSets, rs)
+		}
+		return replicaSets, nil
+	}
+
+	table := ParseKubectlTable(content)
+	for _, row := range table.Rows {
+		name := table.Value(row, "NAME")
+		if name == "" {
+			continue
+		}
+
+		rs := rancher.ReplicaSet{
+			Namespace:  table.Value(row, "NAMESPACE"),
+			Name:       name,
+			Age:        table.Value(row, "AGE"),
+			Containers: splitList(table.Value(row, "CONTAINERS")),
+			Images:     splitList(table.Value(row, "IMAGES")),
+		}
+		fmt.Sscanf(table.Value(row, "DESIRED"), "%d", &rs.Desired)
+		fmt.Sscanf(table.Value(row, "CURRENT"), "%d", &rs.Current)
+		fmt.Sscanf(table.Value(row, "READY"), "%d", &rs.Ready)
+
+		replicaSets = append(replicaSets, rs)
+	}
+
+	return replicaSets, nil
+}
+
+// AnalyzeRollouts links every Deployment to its ReplicaSets.
+// ReplicaSets are matched by owner reference when collected, otherwise by the
+// "<deployment>-<pod-template-hash>" naming convention.
+func AnalyzeRollouts(extractPath string) ([]RolloutInfo, error) {
+	replicaSets, err := ParseReplicaSets(extractPath)
+	if err != nil {
+		return nil, err
+	}
+
+	workloads, err := ParseWorkloads(extractPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var rollouts []RolloutInfo
+	for _, w := range workloads {
+		if w.Kind != "Deployment" {
+			continue
+		}
+
+		rollout := RolloutInfo{Deployment: w}
+		for _, rs := range replicaSets {
+			if rs.Namespace != w.Namespace {
+				continue
+			}
+			if rs.Owner == w.Name || (rs.Owner == "" && isGeneratedName(rs.Name, w.Name, 0)) {
+				rollout.ReplicaSets = append(rollout.ReplicaSets, rs)
+			}
+		}
+		sortRevisions(rollout.ReplicaSets, w.Images)
+
+		rollouts = append(rollouts, rollout)
+	}
+
+	return rollouts, nil
+}
+
+// sortRevisions orders ReplicaSets newest first: by revision annotation when known, otherwise
+// by age. Table ages are coarse ("14d"), so ties go to the ReplicaSet running the Deployment's
+// current images, then to the one with desired replicas.
+func sortRevisions(replicaSets []rancher.ReplicaSet, deploymentImages []string) {
+	newer := func(a, b rancher.ReplicaSet) bool {
+		if a.Revision > 0 && b.Revision > 0 {
+			return a.Revision > b.Revision
+		}
+
+		ageA, okA := kubectlAgeDuration(a.Age)
+		ageB, okB := kubectlAgeDuration(b.Age)
+		if okA && okB && ageA != ageB {
+			return ageA < ageB
+		}
+
+		matchA := len(deploymentImages) > 0 && sameStrings(a.Images, deploymentImages)
+		matchB := len(deploymentImages) > 0 && sameStrings(b.Images, deploymentImages)
+		if matchA != matchB {
+			return matchA
+		}
+		return a.Desired > 0 && b.Desired == 0
+	}
+
+	// Simple insertion sort (few revisions per deployment)
+	for i := 1; i < len(replicaSets); i++ {
+		for j := i; j > 0 && newer(replicaSets[j], replicaSets[j-1]); j-- {
+			replicaSets[j], replicaSets[j-1] = replicaSets[j-1], replicaSets[j]
+		}
+	}
+}
+
+// sameStrings reports whether two slices hold the same values in the same order
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,73 @@
+package bundle
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAnalyzeRollouts_Stalled tests revision ordering from table ages and stalled detection
+func TestAnalyzeRollouts_Stalled(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "deployments", `NAMESPACE   NAME      READY   UP-TO-DATE   AVAILABLE   AGE   CONTAINERS   IMAGES        SELECTOR
+apps        web       2/3     1            2           30d   web          web:v2        app=web
+apps        web-api   1/1     1            1           30d   api          api:v1        app=web-api
+`)
+	writeKubectlFile(t, root, "replicasets", `NAMESPACE   NAME                 DESIRED   CURRENT   READY   AGE   CONTAINERS   IMAGES
+apps        web-7d9f8b6c5d       0         0         0       30d   web          web:v0
+apps        web-5c6d7e8f9a       1         1         0       25m   web          web:v2
+apps        web-6b7c8d9e0f       2         2         2       12d   web          web:v1
+apps        web-api-84c9d7f6b5   1         1         1       30d   api          api:v1
+`)
+
+	rollouts, err := AnalyzeRollouts(root)
+	if err != nil {
+		t.Fatalf("AnalyzeRollouts() error = %v", err)
+	}
+	if len(rollouts) != 2 {
+		t.Fatalf("expected 2 rollouts, got %d", len(rollouts))
+	}
+
+	web := rollouts[0]
+	wantOrder := []string{"web-5c6d7e8f9a", "web-6b7c8d9e0f", "web-7d9f8b6c5d"}
+	if len(web.ReplicaSets) != len(wantOrder) {
+		t.Fatalf("web: expected %d replicasets (web-api's must not match), got %d", len(wantOrder), len(web.ReplicaSets))
+	}
+	for i, name := range wantOrder {
+		if web.ReplicaSets[i].Name != name {
+			t.Errorf("web revision %d = %s, want %s", i, web.ReplicaSets[i].Name, name)
+		}
+	}
+	if !web.InProgress() || !web.Stalled() {
+		t.Errorf("web: expected in-progress stalled rollout")
+	}
+	if serving := web.OldServing(); len(serving) != 1 || serving[0].Name != "web-6b7c8d9e0f" {
+		t.Errorf("web: old serving = %v, want [web-6b7c8d9e0f]", serving)
+	}
+
+	if api := rollouts[1]; api.InProgress() || api.Stalled() || len(api.ReplicaSets) != 1 {
+		t.Errorf("web-api: expected one settled revision, got %+v", api.ReplicaSets)
+	}
+}
+
+// TestKubectlAgeDuration tests compound kubectl age formats
+func TestKubectlAgeDuration(t *testing.T) {
+	tests := []struct {
+		age  string
+		want time.Duration
+		ok   bool
+	}{
+		{"14d", 14 * 24 * time.Hour, true},
+		{"3d4h", 76 * time.Hour, true},
+		{"5m30s", 330 * time.Second, true},
+		{"2y10d", 740 * 24 * time.Hour, true},
+		{"<invalid>", 0, false},
+		{"12", 0, false},
+		{"", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := kubectlAgeDuration(tt.age)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("kubectlAgeDuration(%q) = %v, %v; want %v, %v", tt.age, got, ok, tt.want, tt.ok)
+		}
+	}
+}
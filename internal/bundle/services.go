@@ -0,0 +1,111 @@
+package bundle
+
+import (
+	"strings"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// ServiceBackends is the resolved endpoint and pod state behind a Service
+type ServiceBackends struct {
+	ServiceFound      bool     // Service exists in the bundle's services list
+	EndpointAddresses []string // Ready "ip:port" addresses from the endpoints list
+	BackendPods       []string // Pods backing the endpoints (or candidate pods if there are none)
+	ReadyBackends     int      // Number of ready backend addresses
+}
+
+// serviceIndex resolves Services to their Endpoints and Pods
+type serviceIndex struct {
+	services  map[string]rancher.Service   // key: namespace/name
+	endpoints map[string]rancher.Endpoints // key: namespace/name
+	podsByIP  map[string]rancher.Pod
+	pods      []rancher.Pod
+}
+
+// loadServiceIndex parses services, endpoints and pods from the bundle.
+// Missing files just mean less can be resolved.
+func loadServiceIndex(extractPath string) *serviceIndex {
+	services, _ := ParseServices(extractPath)
+	endpoints, _ := ParseEndpoints(extractPath)
+	pods, _ := ParsePods(extractPath)
+
+	idx := &serviceIndex{
+		services:  make(map[string]rancher.Service),
+		endpoints: make(map[string]rancher.Endpoints),
+		podsByIP:  make(map[string]rancher.Pod),
+		pods:      pods,
+	}
+	for _, svc := range services {
+		idx.services[svc.NamespaceID+"/"+svc.Name] = svc
+	}
+	for _, ep := range endpoints {
+		idx.endpoints[ep.Namespace+"/"+ep.Name] = ep
+	}
+	for _, pod := range pods {
+		if pod.PodIP != "" {
+			idx.podsByIP[pod.PodIP] = pod
+		}
+	}
+	return idx
+}
+
+// resolve returns the endpoint and pod state behind a Service
+func (idx *serviceIndex) resolve(namespace, name string) ServiceBackends {
+	var backends ServiceBackends
+	if name == "" {
+		return backends
+	}
+
+	key := namespace + "/" + name
+	svc, ok := idx.services[key]
+	backends.ServiceFound = ok
+
+	// Endpoints only list ready addresses; cross-check them against pod readiness
+	// since the endpoints snapshot may be older than the pods snapshot
+	for _, addr := range idx.endpoints[key].Addresses {
+		backends.EndpointAddresses = append(backends.EndpointAddresses, addr)
+
+		ip := addr
+		if i := strings.LastIndex(addr, ":"); i > 0 {
+			ip = addr[:i]
+		}
+
+		pod, found := idx.podsByIP[ip]
+		if !found {
+			backends.ReadyBackends++ // Not a pod we know about (e.g. hostNetwork) - trust the endpoint
+			continue
+		}
+
+		backends.BackendPods = append(backends.BackendPods, pod.Name)
+		if pod.KubectlStatus == "Running" && isReadyCount(pod.KubectlReady) {
+			backends.ReadyBackends++
+		}
+	}
+
+	// No endpoints: point at pods that look like they should back the service
+	if len(backends.BackendPods) == 0 {
+		prefixes := []string{name + "-"}
+		for _, value := range svc.Selector {
+			prefixes = append(prefixes, value+"-")
+		}
+		for _, pod := range idx.pods {
+			if pod.NamespaceID != namespace {
+				continue
+			}
+			for _, prefix := range prefixes {
+				if strings.HasPrefix(pod.Name, prefix) {
+					backends.BackendPods = append(backends.BackendPods, pod.Name)
+					break
+				}
+			}
+		}
+	}
+
+	return backends
+}
+
+// isReadyCount checks a kubectl READY column value like "2/2"
+func isReadyCount(ready string) bool {
+	parts := strings.Split(ready, "/")
+	return len(parts) == 2 && parts[0] == parts[1] && parts[0] != "0"
+}
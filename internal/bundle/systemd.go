@@ -0,0 +1,253 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// systemdJournalTail is how many trailing journald lines are kept as evidence for a unit
+const systemdJournalTail = 5
+
+// systemdRestartLoop is the systemd restart counter at which a unit counts as restart-looping
+const systemdRestartLoop = 3
+
+// watchedUnits are the host services RKE2 nodes depend on. A unit in a group with several
+// names (time sync daemons) only needs one of them running.
+var watchedUnits = []struct {
+	units    []string
+	critical bool
+	role     string
+}{
+	{[]string{"rke2-server.service"}, true, "RKE2 server: kubelet, control plane and etcd on this node"},
+	{[]string{"rke2-agent.service"}, true, "RKE2 agent: kubelet and containerd on this node"},
+	{[]string{"containerd.service"}, true, "container runtime"},
+	{[]string{"rancher-system-agent.service"}, false, "Rancher's provisioning agent; upgrades and config plans stop applying to the node"},
+	{[]string{"chronyd.service", "chrony.service", "systemd-timesyncd.service", "ntpd.service", "ntp.service"}, false,
+		"time sync; clock drift breaks etcd leader leases, certificate validity and token expiry"},
+	{[]string{"multipathd.service"}, false, "device-mapper multipath; when misconfigured it claims Longhorn and CSI volumes"},
+}
+
+// Format: "rke2-server.service: Scheduled restart job, restart counter is at 12."
+var restartCounterRe = regexp.MustCompile(`restart counter is at (\d+)`)
+
+// SystemdUnit is a unit from systemctl list-units, with its unit file state and journald log
+type SystemdUnit struct {
+	Name          string
+	Load          string
+	Active        string // active, inactive, failed, activating
+	Sub           string // running, exited, dead, failed, auto-restart
+	Description   string
+	UnitFileState string // enabled, disabled, masked, static; empty if not in systemd-unit-files
+	Watched       bool   // In the watched units list
+	Role          string // Why RKE2 needs a watched unit
+	Critical      bool   // A watched unit whose failure stops the node
+	Journal       string // journald/<name> relative to the bundle root, empty if not collected
+	JournalTail   []string
+	Restarts      int // Highest systemd restart counter in the journal
+}
+
+// parseSystemdUnits parses systemctl list-units output
+// Format: [●] UNIT LOAD ACTIVE SUB DESCRIPTION, ending at the blank line before the legend
+func parseSystemdUnits(content string) []SystemdUnit {
+	var units []SystemdUnit
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimLeft(line, " ●*")
+		if strings.HasPrefix(line, "UNIT ") {
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			if len(units) > 0 {
+				break
+			}
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		units = append(units, SystemdUnit{
+			Name:        fields[0],
+			Load:        fields[1],
+			Active:      fields[2],
+			Sub:         fields[3],
+			Description: strings.Join(fields[4:], " "),
+		})
+	}
+	return units
+}
+
+// parseSystemdUnitFiles parses systemctl list-unit-files output into unit → state
+// Format: UNIT FILE STATE VENDOR PRESET
+func parseSystemdUnitFiles(content string) map[string]string {
+	states := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] == "UNIT" {
+			continue
+		}
+		states[fields[0]] = fields[1]
+	}
+	return states
+}
+
+// parseServiceStatusAll parses service --status-all output into SysV-style units, used when
+// systemctl output was not collected
+// Format: " [ + ]  apparmor" (running), " [ - ]  cryptdisks" (stopped), " [ ? ]  x" (unknown)
+func parseServiceStatusAll(content string) []SystemdUnit {
+	var units []SystemdUnit
+	for _, line := range strings.Split(content, "\n") {
+		state, name, ok := strings.Cut(strings.TrimSpace(line), "]")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			continue
+		}
+		unit := SystemdUnit{Name: name + ".service", Load: "loaded", Active: "inactive", Sub: "dead"}
+		switch strings.TrimSpace(strings.TrimPrefix(state, "[")) {
+		case "+":
+			unit.Active, unit.Sub = "active", "running"
+		case "?":
+			continue
+		}
+		units = append(units, unit)
+	}
+	return units
+}
+
+// parseSystemdStatus builds the node's unit list from systemd-units (or service-statusall),
+// marks watched units, and attaches each unit's journald log from journald/<unit>
+func parseSystemdStatus(bundleRoot string, units, unitFiles, statusAll string) []SystemdUnit {
+	parsed := parseSystemdUnits(units)
+	if len(parsed) == 0 {
+		parsed = parseServiceStatusAll(statusAll)
+	}
+	states := parseSystemdUnitFiles(unitFiles)
+
+	listed := make(map[string]bool)
+	for _, u := range parsed {
+		listed[u.Name] = true
+	}
+	// list-units omits inactive units: add watched units that are installed but not loaded
+	for _, w := range watchedUnits {
+		for _, name := range w.units {
+			if _, ok := states[name]; ok && !listed[name] {
+				parsed = append(parsed, SystemdUnit{Name: name, Load: "not-loaded", Active: "inactive", Sub: "dead"})
+			}
+		}
+	}
+
+	for i := range parsed {
+		u := &parsed[i]
+		u.UnitFileState = states[u.Name]
+		for _, w := range watchedUnits {
+			if contains(w.units, u.Name) {
+				u.Watched, u.Critical, u.Role = true, w.critical, w.role
+			}
+		}
+		journal := filepath.Join("journald", strings.TrimSuffix(u.Name, ".service"))
+		content, err := os.ReadFile(filepath.Join(bundleRoot, journal))
+		if err != nil {
+			continue
+		}
+		u.Journal = journal
+		var lines []string
+		for _, line := range strings.Split(string(content), "\n") {
+			if strings.TrimSpace(line) == "" {
+				continue
+			}
+			lines = append(lines, line)
+			if m := restartCounterRe.FindStringSubmatch(line); m != nil {
+				if n, _ := strconv.Atoi(m[1]); n > u.Restarts {
+					u.Restarts = n
+				}
+			}
+		}
+		if len(lines) > systemdJournalTail {
+			lines = lines[len(lines)-systemdJournalTail:]
+		}
+		u.JournalTail = lines
+	}
+	return parsed
+}
+
+// unitIssues reports failed units, units in a restart loop, enabled watched units that are
+// not running, and nodes with no time sync daemon running
+func unitIssues(units []SystemdUnit) []SystemIssue {
+	var issues []SystemIssue
+	evidence := func(u SystemdUnit) []string {
+		if u.Journal == "" {
+			return nil
+		}
+		return append([]string{u.Journal + ":"}, u.JournalTail...)
+	}
+	role := func(u SystemdUnit) string {
+		if u.Role == "" {
+			return ""
+		}
+		return " (" + u.Role + ")"
+	}
+
+	timeSyncRunning, timeSyncReported := false, false
+	for _, u := range units {
+		timeSync := u.Watched && strings.HasPrefix(u.Role, "time sync")
+		if timeSync && u.Active == "active" {
+			timeSyncRunning = true
+		}
+		before := len(issues)
+
+		switch {
+		case u.Active == "failed":
+			issues = append(issues, SystemIssue{
+				Type:     SystemIssueUnitFailed,
+				Critical: u.Critical,
+				Subject:  u.Name,
+				Detail:   fmt.Sprintf("%s has failed (%s)%s", u.Name, u.Sub, role(u)),
+				Evidence: evidence(u),
+			})
+		case u.Sub == "auto-restart":
+			detail := fmt.Sprintf("%s is waiting to restart after exiting%s", u.Name, role(u))
+			if u.Restarts > 0 {
+				detail = fmt.Sprintf("%s is in a restart loop, restart counter at %d%s", u.Name, u.Restarts, role(u))
+			}
+			issues = append(issues, SystemIssue{
+				Type:     SystemIssueUnitRestarting,
+				Critical: u.Critical,
+				Subject:  u.Name,
+				Detail:   detail,
+				Evidence: evidence(u),
+			})
+		case u.Restarts >= systemdRestartLoop:
+			// Recovered since, but a crash-looping unit may have just come up between restarts
+			issues = append(issues, SystemIssue{
+				Type:     SystemIssueUnitRestarting,
+				Subject:  u.Name,
+				Detail:   fmt.Sprintf("%s was restarted %d times by systemd per its journal, now %s (%s)%s", u.Name, u.Restarts, u.Active, u.Sub, role(u)),
+				Evidence: evidence(u),
+			})
+		case u.Watched && u.UnitFileState == "enabled" && u.Active != "active":
+			issues = append(issues, SystemIssue{
+				Type:     SystemIssueUnitInactive,
+				Subject:  u.Name,
+				Detail:   fmt.Sprintf("%s is enabled but %s (%s)%s", u.Name, u.Active, u.Sub, role(u)),
+				Evidence: evidence(u),
+			})
+		}
+		if timeSync && len(issues) > before {
+			timeSyncReported = true
+		}
+	}
+
+	// A failed or stopped time sync unit is already reported above
+	if len(units) > 0 && !timeSyncRunning && !timeSyncReported {
+		issues = append(issues, SystemIssue{
+			Type:    SystemIssueNoTimeSync,
+			Subject: "time sync",
+			Detail:  "No time sync daemon (chronyd, systemd-timesyncd, ntpd) is running; clock drift breaks etcd leases and certificates",
+		})
+	}
+
+	return issues
+}
@@ -0,0 +1,85 @@
+package bundle
+
+import (
+	"testing"
+)
+
+// TestSystemdUnitIssues tests unit parsing, journald linking and the failed/restart/time sync issues
+func TestSystemdUnitIssues(t *testing.T) {
+	root := t.TempDir()
+
+	writeBundleFile(t, root, "systeminfo/systemd-units", `  UNIT                          LOAD   ACTIVE     SUB          DESCRIPTION
+  rancher-system-agent.service  loaded active     running      Rancher System Agent
+● rke2-agent.service            loaded activating auto-restart Rancher Kubernetes Engine v2 (agent)
+● chronyd.service               loaded failed     failed       NTP client/server
+● snapd.seeded.service          loaded failed     failed       Wait until snapd is fully seeded
+
+LOAD   = Reflects whether the unit definition was properly loaded.
+3 loaded units listed.
+`)
+	writeBundleFile(t, root, "systeminfo/systemd-unit-files", `UNIT FILE                     STATE           VENDOR PRESET
+rke2-agent.service            enabled         disabled
+rke2-server.service           disabled        disabled
+multipathd.service            enabled         enabled
+chronyd.service               enabled         enabled
+`)
+	writeBundleFile(t, root, "journald/rke2-agent", `Dec 04 09:10:01 node1 systemd[1]: rke2-agent.service: Scheduled restart job, restart counter is at 41.
+Dec 04 09:10:02 node1 rke2[1201]: time="2025-12-04T09:10:02Z" level=info msg="Starting rke2 agent"
+Dec 04 09:10:07 node1 rke2[1201]: time="2025-12-04T09:10:07Z" level=fatal msg="failed to get CA certs: connection refused"
+Dec 04 09:10:07 node1 systemd[1]: rke2-agent.service: Main process exited, code=exited, status=1/FAILURE
+Dec 04 09:10:12 node1 systemd[1]: rke2-agent.service: Scheduled restart job, restart counter is at 42.
+Dec 04 09:10:13 node1 rke2[1250]: time="2025-12-04T09:10:13Z" level=info msg="Starting rke2 agent"
+Dec 04 09:10:18 node1 systemd[1]: rke2-agent.service: Main process exited, code=exited, status=1/FAILURE
+`)
+
+	res, err := ParseSystemResources(root)
+	if err != nil {
+		t.Fatalf("ParseSystemResources() error = %v", err)
+	}
+
+	units := make(map[string]SystemdUnit)
+	for _, u := range res.Units {
+		units[u.Name] = u
+	}
+	if len(res.Units) != 6 {
+		t.Errorf("expected 4 listed units plus inactive multipathd and rke2-server, got %+v", res.Units)
+	}
+	agent := units["rke2-agent.service"]
+	if !agent.Watched || agent.Restarts != 42 || agent.Journal != "journald/rke2-agent" || len(agent.JournalTail) != systemdJournalTail ||
+		agent.UnitFileState != "enabled" {
+		t.Errorf("rke2-agent parsed as %+v", agent)
+	}
+	if mp := units["multipathd.service"]; mp.Active != "inactive" || mp.Load != "not-loaded" {
+		t.Errorf("multipathd parsed as %+v", mp)
+	}
+
+	type want struct {
+		issueType string
+		critical  bool
+	}
+	wantIssues := map[string]want{
+		"rke2-agent.service":   {SystemIssueUnitRestarting, true},
+		"chronyd.service":      {SystemIssueUnitFailed, false},
+		"snapd.seeded.service": {SystemIssueUnitFailed, false},
+		"multipathd.service":   {SystemIssueUnitInactive, false},
+	}
+	issues := unitIssues(res.Units)
+	if len(issues) != len(wantIssues) {
+		t.Errorf("got %d issues, want %d (no separate time sync issue for a failed chronyd): %+v", len(issues), len(wantIssues), issues)
+	}
+	for _, issue := range issues {
+		w, ok := wantIssues[issue.Subject]
+		if !ok || issue.Type != w.issueType || issue.Critical != w.critical {
+			t.Errorf("unexpected issue %s %s critical=%v: %s", issue.Type, issue.Subject, issue.Critical, issue.Detail)
+		}
+		if issue.Subject == "rke2-agent.service" && (len(issue.Evidence) != systemdJournalTail+1 || issue.Evidence[0] != "journald/rke2-agent:") {
+			t.Errorf("rke2-agent evidence = %q", issue.Evidence)
+		}
+	}
+
+	// No time sync unit at all
+	noSync := unitIssues([]SystemdUnit{{Name: "cron.service", Active: "active", Sub: "running"}})
+	if len(noSync) != 1 || noSync[0].Type != SystemIssueNoTimeSync {
+		t.Errorf("expected a no-time-sync issue, got %+v", noSync)
+	}
+}
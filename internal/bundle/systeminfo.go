@@ -1,8 +1,11 @@
 package bundle
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 )
@@ -64,3 +67,628 @@ func ParseSystemHealth(extractPath string) (*SystemHealthInfo, error) {
 
 	return health, nil
 }
+
+// System issue types (see SystemIssue.Type)
+const (
+	SystemIssueDiskFull        = "disk-full"
+	SystemIssueInodes          = "inode-exhaustion"
+	SystemIssueSwap            = "swap-in-use"
+	SystemIssueLoad            = "high-load"
+	SystemIssueFileHandles     = "fd-saturation"
+	SystemIssueReadOnlyMount   = "read-only-mount"
+	SystemIssueZombies         = "zombie-processes"
+	SystemIssueUninterruptible = "blocked-processes"
+	SystemIssueUnitFailed      = "unit-failed"
+	SystemIssueUnitRestarting  = "unit-restart-loop"
+	SystemIssueUnitInactive    = "unit-inactive"
+	SystemIssueNoTimeSync      = "no-time-sync"
+)
+
+const (
+	// Filesystem block and inode usage thresholds (percent)
+	systemDiskWarnPercent     = 85
+	systemDiskCriticalPercent = 95
+
+	// Load average relative to CPU cores
+	systemLoadWarnRatio     = 2.0
+	systemLoadCriticalRatio = 4.0
+
+	// Allocated file handles relative to fs.file-max
+	systemFileHandleWarnRatio     = 0.8
+	systemFileHandleCriticalRatio = 0.95
+
+	// rke2DataDir holds containerd images and snapshots, etcd, and the agent's state
+	rke2DataDir = "/var/lib/rancher"
+)
+
+// pseudoFilesystems are per-container mounts repeated once per pod in df and mount output
+var pseudoFilesystems = []string{"overlay", "shm", "nsfs"}
+
+// Filesystem is a mounted filesystem with its block usage (df -h), inode usage (df -i)
+// and mount options
+type Filesystem struct {
+	Device      string
+	MountPoint  string
+	Type        string // From mount, empty if not collected
+	Size        string // Human-readable, as df prints it
+	Used        string
+	Avail       string
+	UsePercent  int // -1 if unknown
+	Inodes      int64
+	IUsed       int64
+	IUsePercent int // -1 if unknown or the filesystem has no inode limit (vfat, btrfs)
+	Options     []string
+	ReadOnly    bool
+}
+
+// Mount is a line of mount output
+// Format: /dev/vda1 on / type ext4 (rw,relatime,discard,errors=remount-ro)
+type Mount struct {
+	Device     string
+	MountPoint string
+	Type       string
+	Options    []string
+}
+
+// BlockDevice is a disk, partition or loop device from lsblk
+type BlockDevice struct {
+	Name        string
+	Size        string
+	ReadOnly    bool
+	Type        string // disk, part, loop, lvm, rom
+	MountPoints []string
+}
+
+// Process is a process from ps aux
+type Process struct {
+	User    string
+	PID     int
+	CPU     float64 // %CPU
+	Memory  float64 // %MEM
+	RSS     int64   // KiB
+	Stat    string
+	Command string
+}
+
+// Ulimit is a resource limit from ulimit -a output
+type Ulimit struct {
+	Kind  string // "hard" or "soft", from the file name
+	Name  string // e.g. "open files"
+	Flag  string // e.g. "-n"
+	Value string // Number or "unlimited"
+}
+
+// VmstatSample is one line of vmstat output
+type VmstatSample struct {
+	Runnable int // r
+	Blocked  int // b: uninterruptible sleep, usually waiting on I/O
+	SwapIn   int // si, KiB/s
+	SwapOut  int // so
+	IOWait   int // wa, percent
+	Steal    int // st, percent
+}
+
+// SystemResources is the node's CPU, memory, disks, processes and limits from systeminfo
+type SystemResources struct {
+	Hostname string
+	Kernel   string
+	CPUModel string
+	Cores    int
+	Uptime   string // e.g. "14 days, 8:33"
+	Load1    float64
+	Load5    float64
+	Load15   float64
+
+	// MiB, from free -m
+	MemTotal     int64
+	MemUsed      int64
+	MemAvailable int64
+	SwapTotal    int64
+	SwapUsed     int64
+
+	Vmstat       []VmstatSample
+	Filesystems  []Filesystem // Host filesystems; per-container overlay and shm mounts are omitted
+	Mounts       []Mount
+	BlockDevices []BlockDevice
+	Processes    []Process // Sorted by RSS, largest first
+	Ulimits      []Ulimit
+	Units        []SystemdUnit // Loaded units, plus watched units that are installed but inactive
+
+	// From /proc/sys/fs/file-nr and file-max (0 if not collected)
+	FileHandlesAllocated int64
+	FileHandlesMax       int64
+}
+
+// SystemIssue is a problem found in the node's resources
+type SystemIssue struct {
+	Type     string
+	Critical bool
+	Subject  string // Mount point, process, unit or resource name
+	Detail   string
+	Evidence []string // Journald lines for unit issues
+}
+
+// ParseSystemResources parses the systeminfo directory: cpuinfo, uptime, freem, vmstat, ps,
+// dfh/dfi, mount, lsblk, file-nr/file-max and ulimit-*
+func ParseSystemResources(extractPath string) (*SystemResources, error) {
+	bundleRoot := getBundleRoot(extractPath)
+	dir := filepath.Join(bundleRoot, "systeminfo")
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+	read := func(name string) string {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+		return string(content)
+	}
+
+	res := &SystemResources{
+		Hostname: strings.TrimSpace(read("hostname")),
+		Kernel:   strings.TrimSpace(read("uname")),
+	}
+
+	// Format: "processor : 0" per core, "model name : Intel(R) Xeon(R) ..."
+	for _, line := range strings.Split(read("cpuinfo"), "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "processor":
+			res.Cores++
+		case "model name":
+			if res.CPUModel == "" {
+				res.CPUModel = strings.TrimSpace(value)
+			}
+		}
+	}
+
+	// Format: " 09:15:58 up 14 days,  8:33,  1 user,  load average: 0.95, 0.91, 0.94"
+	// (top's first line has the same layout)
+	uptime := strings.TrimSpace(read("uptime"))
+	if uptime == "" {
+		uptime, _, _ = strings.Cut(read("top"), "\n")
+	}
+	res.Uptime, res.Load1, res.Load5, res.Load15 = parseUptime(uptime)
+
+	// Format: "Mem: total used free shared buff/cache available" and "Swap: total used free" (MiB)
+	for _, line := range strings.Split(read("freem"), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		switch fields[0] {
+		case "Mem:":
+			res.MemTotal, _ = strconv.ParseInt(fields[1], 10, 64)
+			res.MemUsed, _ = strconv.ParseInt(fields[2], 10, 64)
+			if len(fields) >= 7 {
+				res.MemAvailable, _ = strconv.ParseInt(fields[6], 10, 64)
+			}
+		case "Swap:":
+			res.SwapTotal, _ = strconv.ParseInt(fields[1], 10, 64)
+			res.SwapUsed, _ = strconv.ParseInt(fields[2], 10, 64)
+		}
+	}
+
+	res.Vmstat = parseVmstat(read("vmstat"))
+	res.Mounts = parseMounts(read("mount"))
+	res.Filesystems = parseFilesystems(read("dfh"), read("dfi"), res.Mounts)
+	res.BlockDevices = parseLsblk(read("lsblk"))
+	res.Processes = parsePs(read("ps"))
+
+	// Format: "allocated<TAB>unused<TAB>max"
+	if fields := strings.Fields(read("file-nr")); len(fields) == 3 {
+		res.FileHandlesAllocated, _ = strconv.ParseInt(fields[0], 10, 64)
+		res.FileHandlesMax, _ = strconv.ParseInt(fields[2], 10, 64)
+	}
+	if res.FileHandlesMax == 0 {
+		res.FileHandlesMax, _ = strconv.ParseInt(strings.TrimSpace(read("file-max")), 10, 64)
+	}
+
+	ulimitFiles, _ := filepath.Glob(filepath.Join(dir, "ulimit-*"))
+	sort.Strings(ulimitFiles)
+	for _, path := range ulimitFiles {
+		kind := strings.TrimPrefix(filepath.Base(path), "ulimit-")
+		if content, err := os.ReadFile(path); err == nil {
+			res.Ulimits = append(res.Ulimits, parseUlimits(string(content), kind)...)
+		}
+	}
+
+	res.Units = parseSystemdStatus(bundleRoot, read("systemd-units"), read("systemd-unit-files"), read("service-statusall"))
+
+	return res, nil
+}
+
+// parseUptime returns the uptime text and load averages from uptime (or top) output
+func parseUptime(line string) (string, float64, float64, float64) {
+	uptime := ""
+	if _, rest, ok := strings.Cut(line, " up "); ok {
+		// "14 days,  8:33,  1 user,  load average: ..." — drop the user count and load
+		parts := strings.Split(rest, ",")
+		var kept []string
+		for _, part := range parts {
+			part = strings.TrimSpace(part)
+			if strings.Contains(part, "user") || strings.HasPrefix(part, "load average") {
+				break
+			}
+			kept = append(kept, part)
+		}
+		uptime = strings.Join(kept, ", ")
+	}
+
+	var loads [3]float64
+	if _, rest, ok := strings.Cut(line, "load average:"); ok {
+		for i, value := range strings.Split(rest, ",") {
+			if i < len(loads) {
+				loads[i], _ = strconv.ParseFloat(strings.TrimSpace(value), 64)
+			}
+		}
+	}
+	return uptime, loads[0], loads[1], loads[2]
+}
+
+// parseVmstat parses vmstat samples, locating columns by the header line
+// Format: r b swpd free buff cache si so bi bo in cs us sy id wa st [timestamp]
+func parseVmstat(content string) []VmstatSample {
+	var samples []VmstatSample
+	columns := map[string]int{}
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "r" {
+			for i, name := range fields {
+				if _, seen := columns[name]; !seen {
+					columns[name] = i
+				}
+			}
+			continue
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[0]); err != nil {
+			continue
+		}
+		value := func(name string) int {
+			i, ok := columns[name]
+			if !ok || i >= len(fields) {
+				return 0
+			}
+			v, _ := strconv.Atoi(fields[i])
+			return v
+		}
+		samples = append(samples, VmstatSample{
+			Runnable: value("r"),
+			Blocked:  value("b"),
+			SwapIn:   value("si"),
+			SwapOut:  value("so"),
+			IOWait:   value("wa"),
+			Steal:    value("st"),
+		})
+	}
+	return samples
+}
+
+// parseMounts parses mount output
+func parseMounts(content string) []Mount {
+	var mounts []Mount
+	for _, line := range strings.Split(content, "\n") {
+		device, rest, ok := strings.Cut(line, " on ")
+		if !ok {
+			continue
+		}
+		mountPoint, rest, ok := strings.Cut(rest, " type ")
+		if !ok {
+			continue
+		}
+		fsType, options, _ := strings.Cut(rest, " ")
+		mounts = append(mounts, Mount{
+			Device:     device,
+			MountPoint: mountPoint,
+			Type:       fsType,
+			Options:    strings.Split(strings.Trim(options, "()"), ","),
+		})
+	}
+	return mounts
+}
+
+// ReadOnly reports whether the mount has the ro option
+func (m Mount) ReadOnly() bool {
+	return contains(m.Options, "ro")
+}
+
+// parseFilesystems joins df -h and df -i output by mount point, skipping per-container
+// overlay/shm mounts and the pod volume mounts under /var/lib/kubelet/pods
+// Format: Filesystem Size Used Avail Use% Mounted-on / Filesystem Inodes IUsed IFree IUse% Mounted-on
+func parseFilesystems(dfh, dfi string, mounts []Mount) []Filesystem {
+	var filesystems []Filesystem
+	byMount := make(map[string]int)
+
+	skip := func(fields []string) bool {
+		return len(fields) < 6 || fields[0] == "Filesystem" || contains(pseudoFilesystems, fields[0]) ||
+			strings.HasPrefix(fields[5], "/var/lib/kubelet/pods/") || strings.HasPrefix(fields[5], "/run/k3s/")
+	}
+	percent := func(value string) int {
+		if p, err := strconv.Atoi(strings.TrimSuffix(value, "%")); err == nil {
+			return p
+		}
+		return -1
+	}
+
+	for _, line := range strings.Split(dfh, "\n") {
+		fields := strings.Fields(line)
+		if skip(fields) {
+			continue
+		}
+		mountPoint := strings.Join(fields[5:], " ")
+		if _, seen := byMount[mountPoint]; seen {
+			continue
+		}
+		byMount[mountPoint] = len(filesystems)
+		filesystems = append(filesystems, Filesystem{
+			Device:      fields[0],
+			MountPoint:  mountPoint,
+			Size:        fields[1],
+			Used:        fields[2],
+			Avail:       fields[3],
+			UsePercent:  percent(fields[4]),
+			IUsePercent: -1,
+		})
+	}
+
+	for _, line := range strings.Split(dfi, "\n") {
+		fields := strings.Fields(line)
+		if skip(fields) {
+			continue
+		}
+		mountPoint := strings.Join(fields[5:], " ")
+		i, seen := byMount[mountPoint]
+		if !seen {
+			i = len(filesystems)
+			byMount[mountPoint] = i
+			filesystems = append(filesystems, Filesystem{Device: fields[0], MountPoint: mountPoint, UsePercent: -1, IUsePercent: -1})
+		}
+		fs := &filesystems[i]
+		fs.Inodes, _ = strconv.ParseInt(fields[1], 10, 64)
+		fs.IUsed, _ = strconv.ParseInt(fields[2], 10, 64)
+		if fs.Inodes > 0 {
+			fs.IUsePercent = percent(fields[4])
+		}
+	}
+
+	for _, m := range mounts {
+		if i, ok := byMount[m.MountPoint]; ok {
+			filesystems[i].Type = m.Type
+			filesystems[i].Options = m.Options
+			filesystems[i].ReadOnly = m.ReadOnly()
+		}
+	}
+	return filesystems
+}
+
+// FilesystemFor returns the filesystem holding path: the one with the longest matching mount point
+func (r *SystemResources) FilesystemFor(path string) *Filesystem {
+	var best *Filesystem
+	for i, fs := range r.Filesystems {
+		mp := fs.MountPoint
+		if path != mp && !strings.HasPrefix(path, strings.TrimSuffix(mp, "/")+"/") {
+			continue
+		}
+		if best == nil || len(mp) > len(best.MountPoint) {
+			best = &r.Filesystems[i]
+		}
+	}
+	return best
+}
+
+// parseLsblk parses lsblk output, including tree prefixes and mount points continued on
+// following lines
+// Format: NAME MAJ:MIN RM SIZE RO TYPE MOUNTPOINTS
+func parseLsblk(content string) []BlockDevice {
+	var devices []BlockDevice
+	lines := strings.Split(content, "\n")
+	if len(lines) == 0 || !strings.HasPrefix(lines[0], "NAME") {
+		return nil
+	}
+	for _, line := range lines[1:] {
+		fields := strings.Fields(strings.TrimLeft(line, "|`- "))
+		if len(fields) == 0 {
+			continue
+		}
+		// A continuation line holds only another mount point of the previous device
+		if len(fields) == 1 && strings.HasPrefix(fields[0], "/") && len(devices) > 0 {
+			last := &devices[len(devices)-1]
+			last.MountPoints = append(last.MountPoints, fields[0])
+			continue
+		}
+		if len(fields) < 6 {
+			continue
+		}
+		device := BlockDevice{
+			Name:     fields[0],
+			Size:     fields[3],
+			ReadOnly: fields[4] == "1",
+			Type:     fields[5],
+		}
+		device.MountPoints = append(device.MountPoints, fields[6:]...)
+		devices = append(devices, device)
+	}
+	return devices
+}
+
+// parsePs parses ps aux output (with or without the f tree), sorted by RSS
+// Format: USER PID %CPU %MEM VSZ RSS TTY STAT START TIME COMMAND
+func parsePs(content string) []Process {
+	var processes []Process
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 11 || fields[0] == "USER" {
+			continue
+		}
+		pid, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		cpu, _ := strconv.ParseFloat(fields[2], 64)
+		mem, _ := strconv.ParseFloat(fields[3], 64)
+		rss, _ := strconv.ParseInt(fields[5], 10, 64)
+		command := strings.Join(fields[10:], " ")
+		command = strings.TrimSpace(strings.TrimLeft(command, `\_| `))
+		processes = append(processes, Process{
+			User:    fields[0],
+			PID:     pid,
+			CPU:     cpu,
+			Memory:  mem,
+			RSS:     rss,
+			Stat:    fields[7],
+			Command: command,
+		})
+	}
+	sort.SliceStable(processes, func(i, j int) bool {
+		return processes[i].RSS > processes[j].RSS
+	})
+	return processes
+}
+
+// ulimitLineRe matches a line of ulimit -a output
+// Format: open files                          (-n) 1048576
+var ulimitLineRe = regexp.MustCompile(`^(.*?)\s+\((?:[^)]*, )?(-\w)\)\s+(\S+)$`)
+
+// parseUlimits parses ulimit -a output
+func parseUlimits(content, kind string) []Ulimit {
+	var limits []Ulimit
+	for _, line := range strings.Split(content, "\n") {
+		if m := ulimitLineRe.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			limits = append(limits, Ulimit{Kind: kind, Name: m[1], Flag: m[2], Value: m[3]})
+		}
+	}
+	return limits
+}
+
+// Issues returns full disks and inode exhaustion on any host filesystem, swap in use, load
+// far above the core count, file handle saturation, read-only mounts of disk filesystems,
+// and zombie or uninterruptible processes
+func (r *SystemResources) Issues() []SystemIssue {
+	var issues []SystemIssue
+
+	rancherFS := r.FilesystemFor(rke2DataDir)
+	for i, fs := range r.Filesystems {
+		holds := ""
+		if rancherFS == &r.Filesystems[i] {
+			holds = fmt.Sprintf(" (holds %s: containerd images, etcd)", rke2DataDir)
+		}
+		if fs.UsePercent >= systemDiskWarnPercent {
+			issues = append(issues, SystemIssue{
+				Type:     SystemIssueDiskFull,
+				Critical: fs.UsePercent >= systemDiskCriticalPercent,
+				Subject:  fs.MountPoint,
+				Detail:   fmt.Sprintf("%s is %d%% full, %s of %s free%s", fs.MountPoint, fs.UsePercent, fs.Avail, fs.Size, holds),
+			})
+		}
+		if fs.IUsePercent >= systemDiskWarnPercent {
+			issues = append(issues, SystemIssue{
+				Type:     SystemIssueInodes,
+				Critical: fs.IUsePercent >= systemDiskCriticalPercent,
+				Subject:  fs.MountPoint,
+				Detail: fmt.Sprintf("%s has used %d%% of its inodes (%d of %d)%s; new files fail with 'no space left on device' despite free space",
+					fs.MountPoint, fs.IUsePercent, fs.IUsed, fs.Inodes, holds),
+			})
+		}
+	}
+
+	swapping := 0
+	for _, s := range r.Vmstat {
+		if s.SwapIn > 0 || s.SwapOut > 0 {
+			swapping++
+		}
+	}
+	if r.SwapUsed > 0 || swapping > 0 {
+		detail := fmt.Sprintf("%d MiB of %d MiB swap in use", r.SwapUsed, r.SwapTotal)
+		if swapping > 0 {
+			detail += fmt.Sprintf(", actively swapping in %d of %d vmstat samples", swapping, len(r.Vmstat))
+		}
+		issues = append(issues, SystemIssue{
+			Type:    SystemIssueSwap,
+			Subject: "swap",
+			Detail:  detail + "; the kubelet's memory accounting and evictions assume no swap",
+		})
+	}
+
+	if r.Cores > 0 {
+		if ratio := r.Load5 / float64(r.Cores); ratio >= systemLoadWarnRatio {
+			issues = append(issues, SystemIssue{
+				Type:     SystemIssueLoad,
+				Critical: ratio >= systemLoadCriticalRatio,
+				Subject:  "load",
+				Detail: fmt.Sprintf("5-minute load average %.2f is %.1fx the %d CPU cores (1m %.2f, 15m %.2f)",
+					r.Load5, ratio, r.Cores, r.Load1, r.Load15),
+			})
+		}
+	}
+
+	if r.FileHandlesMax > 0 {
+		if ratio := float64(r.FileHandlesAllocated) / float64(r.FileHandlesMax); ratio >= systemFileHandleWarnRatio {
+			issues = append(issues, SystemIssue{
+				Type:     SystemIssueFileHandles,
+				Critical: ratio >= systemFileHandleCriticalRatio,
+				Subject:  "fs.file-max",
+				Detail: fmt.Sprintf("%d of %d file handles allocated (%.0f%%); opens fail with 'too many open files' at the limit",
+					r.FileHandlesAllocated, r.FileHandlesMax, ratio*100),
+			})
+		}
+	}
+
+	for _, m := range r.Mounts {
+		if !m.ReadOnly() || !strings.HasPrefix(m.Device, "/dev/") {
+			continue
+		}
+		critical := m.MountPoint == "/" || m.MountPoint == "/var" || strings.HasPrefix(m.MountPoint, "/var/lib")
+		issues = append(issues, SystemIssue{
+			Type:     SystemIssueReadOnlyMount,
+			Critical: critical,
+			Subject:  m.MountPoint,
+			Detail:   fmt.Sprintf("%s (%s, %s) is mounted read-only; often remounted after filesystem errors (see dmesg)", m.MountPoint, m.Device, m.Type),
+		})
+	}
+
+	var zombies, blocked []string
+	for _, p := range r.Processes {
+		switch {
+		case strings.HasPrefix(p.Stat, "Z"):
+			zombies = append(zombies, fmt.Sprintf("%d", p.PID))
+		case strings.HasPrefix(p.Stat, "D"):
+			blocked = append(blocked, fmt.Sprintf("%s (%d)", processCommandName(p.Command), p.PID))
+		}
+	}
+	if len(zombies) > 0 {
+		issues = append(issues, SystemIssue{
+			Type:    SystemIssueZombies,
+			Subject: "processes",
+			Detail:  fmt.Sprintf("%d zombie processes (PIDs %s); their parent is not reaping children", len(zombies), strings.Join(zombies, ", ")),
+		})
+	}
+	if len(blocked) > 0 {
+		issues = append(issues, SystemIssue{
+			Type:    SystemIssueUninterruptible,
+			Subject: "processes",
+			Detail:  fmt.Sprintf("%d processes in uninterruptible sleep (D), usually stuck on disk or NFS I/O: %s", len(blocked), strings.Join(blocked, ", ")),
+		})
+	}
+
+	issues = append(issues, unitIssues(r.Units)...)
+
+	return issues
+}
+
+// processCommandName returns the executable name of a ps command line
+func processCommandName(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+	return filepath.Base(strings.Trim(fields[0], "[]"))
+}
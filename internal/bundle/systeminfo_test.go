@@ -0,0 +1,104 @@
+package bundle
+
+import (
+	"testing"
+)
+
+// TestParseSystemResources tests systeminfo parsing and the resource issue checks
+func TestParseSystemResources(t *testing.T) {
+	root := t.TempDir()
+
+	writeBundleFile(t, root, "systeminfo/cpuinfo", "processor\t: 0\nmodel name\t: Intel Xeon\n\nprocessor\t: 1\nmodel name\t: Intel Xeon\n")
+	writeBundleFile(t, root, "systeminfo/uptime", " 09:15:58 up 3 days,  2:01,  1 user,  load average: 9.10, 8.50, 4.00\n")
+	writeBundleFile(t, root, "systeminfo/freem", `               total        used        free      shared  buff/cache   available
+Mem:            3911        3700         50           7         161         100
+Swap:           2047         512       1535
+`)
+	writeBundleFile(t, root, "systeminfo/vmstat", `--procs-- -----------------------memory---------------------- ---swap-- -----io---- -system-- --------cpu-------- -----timestamp-----
+   r    b         swpd         free         buff        cache   si   so    bi    bo   in   cs  us  sy  id  wa  st                 UTC
+   9    2       524288        51200         9480       144928  120  300    31   115    4    2  16   9  44  30   1 2025-12-04 09:15:58
+`)
+	writeBundleFile(t, root, "systeminfo/dfh", `Filesystem      Size  Used Avail Use% Mounted on
+/dev/vda1        78G   40G   38G  52% /
+/dev/vdb1       200G  194G  6.0G  97% /var/lib/rancher
+overlay          78G   40G   38G  52% /run/k3s/containerd/io.containerd.runtime.v2.task/k8s.io/abc/rootfs
+`)
+	writeBundleFile(t, root, "systeminfo/dfi", `Filesystem       Inodes  IUsed    IFree IUse% Mounted on
+/dev/vda1      10321920 262070 10059850    3% /
+/dev/vdb1       1000000 960000    40000   96% /var/lib/rancher
+/dev/vda15            0      0        0     - /boot/efi
+`)
+	writeBundleFile(t, root, "systeminfo/mount", `/dev/vda1 on / type ext4 (rw,relatime,errors=remount-ro)
+/dev/vdb1 on /var/lib/rancher type xfs (ro,relatime)
+/var/lib/snapd/snaps/core20_2318.snap on /snap/core20/2318 type squashfs (ro,nodev,relatime)
+`)
+	writeBundleFile(t, root, "systeminfo/lsblk", "NAME    MAJ:MIN RM  SIZE RO TYPE MOUNTPOINTS\nvda     252:0    0   80G  0 disk \n|-vda1  252:1    0 79.9G  0 part /var/lib/kubelet/pods/x\n|                                /\nvdb     252:16   0  200G  0 disk /var/lib/rancher\n")
+	writeBundleFile(t, root, "systeminfo/ps", `USER         PID %CPU %MEM    VSZ   RSS TTY      STAT START   TIME COMMAND
+root        9014 25.0  3.2 11.3g 129904 ?       Ssl  Nov20 867:35 etcd --config-file=/var/lib/rancher/rke2/server/db/etcd/config
+root         312  0.0  0.0      0     0 ?        D    Nov20   0:00  \_ [jbd2/vdb1-8]
+root        4444  0.0  0.0      0     0 ?        Z    Nov20   0:00 [containerd-shim] <defunct>
+`)
+	writeBundleFile(t, root, "systeminfo/file-nr", "900000\t0\t1000000\n")
+	writeBundleFile(t, root, "systeminfo/ulimit-hard", "open files                          (-n) 1048576\nfile size                   (blocks, -f) unlimited\n")
+
+	res, err := ParseSystemResources(root)
+	if err != nil {
+		t.Fatalf("ParseSystemResources() error = %v", err)
+	}
+
+	if res.Cores != 2 || res.CPUModel != "Intel Xeon" || res.Uptime != "3 days, 2:01" || res.Load5 != 8.5 {
+		t.Errorf("cpu/uptime: cores=%d model=%q uptime=%q load5=%v", res.Cores, res.CPUModel, res.Uptime, res.Load5)
+	}
+	if res.MemAvailable != 100 || res.SwapUsed != 512 || len(res.Vmstat) != 1 || res.Vmstat[0].SwapOut != 300 {
+		t.Errorf("memory: available=%d swap=%d vmstat=%+v", res.MemAvailable, res.SwapUsed, res.Vmstat)
+	}
+	if len(res.Filesystems) != 3 {
+		t.Fatalf("expected 3 host filesystems (overlay skipped), got %+v", res.Filesystems)
+	}
+	if fs := res.FilesystemFor("/var/lib/rancher/rke2/agent"); fs == nil || fs.Device != "/dev/vdb1" || fs.IUsePercent != 96 || !fs.ReadOnly || fs.Type != "xfs" {
+		t.Errorf("/var/lib/rancher filesystem = %+v", fs)
+	}
+	if boot := res.FilesystemFor("/boot/efi"); boot == nil || boot.IUsePercent != -1 {
+		t.Errorf("vfat without inodes = %+v", boot)
+	}
+	if len(res.BlockDevices) != 3 || len(res.BlockDevices[1].MountPoints) != 2 || res.BlockDevices[1].Name != "vda1" {
+		t.Errorf("lsblk parsed as %+v", res.BlockDevices)
+	}
+	if len(res.Processes) != 3 || res.Processes[0].PID != 9014 || res.Processes[1].Command != "[jbd2/vdb1-8]" {
+		t.Errorf("ps parsed as %+v", res.Processes)
+	}
+	if len(res.Ulimits) != 2 || res.Ulimits[0].Value != "1048576" || res.Ulimits[1].Flag != "-f" || res.Ulimits[0].Kind != "hard" {
+		t.Errorf("ulimits parsed as %+v", res.Ulimits)
+	}
+
+	want := map[string]bool{ // issue type → critical
+		SystemIssueDiskFull:        true,
+		SystemIssueInodes:          true,
+		SystemIssueSwap:            false,
+		SystemIssueLoad:            true,
+		SystemIssueFileHandles:     false,
+		SystemIssueReadOnlyMount:   true,
+		SystemIssueZombies:         false,
+		SystemIssueUninterruptible: false,
+	}
+	got := make(map[string]SystemIssue)
+	for _, issue := range res.Issues() {
+		if _, dup := got[issue.Type]; dup {
+			t.Errorf("unexpected second %s issue: %s", issue.Type, issue.Detail)
+		}
+		got[issue.Type] = issue
+	}
+	for issueType, critical := range want {
+		issue, ok := got[issueType]
+		if !ok {
+			t.Errorf("missing %s issue", issueType)
+			continue
+		}
+		if issue.Critical != critical {
+			t.Errorf("%s critical = %v, want %v (%s)", issueType, issue.Critical, critical, issue.Detail)
+		}
+	}
+	if got[SystemIssueInodes].Subject != "/var/lib/rancher" {
+		t.Errorf("inode issue on %s, want /var/lib/rancher", got[SystemIssueInodes].Subject)
+	}
+}
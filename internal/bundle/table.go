@@ -0,0 +1,92 @@
+package bundle
+
+import (
+	"strings"
+	"unicode"
+)
+
+// KubectlTable is kubectl table output split into cells using the header's column offsets.
+// Unlike strings.Fields this keeps empty cells (e.g. a binding with no USERS) in place
+// and keeps values containing single spaces (e.g. "8 (4m53s ago)") intact.
+type KubectlTable struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// ParseKubectlTable parses kubectl table output. Columns are located from the header line:
+// headers are separated by two or more spaces ("CREATED AT" stays one column).
+func ParseKubectlTable(content []byte) *KubectlTable {
+	table := &KubectlTable{}
+
+	lines := strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) == "" {
+		return table
+	}
+
+	// Find column start offsets (in runes - kubectl's tabwriter pads by rune count)
+	header := []rune(lines[0])
+	var starts []int
+	for i := 0; i < len(header); i++ {
+		if unicode.IsSpace(header[i]) {
+			continue
+		}
+		if i == 0 || (i >= 2 && unicode.IsSpace(header[i-1]) && unicode.IsSpace(header[i-2])) {
+			starts = append(starts, i)
+		}
+	}
+
+	for idx, start := range starts {
+		end := len(header)
+		if idx+1 < len(starts) {
+			end = starts[idx+1]
+		}
+		table.Headers = append(table.Headers, strings.TrimSpace(string(header[start:end])))
+	}
+
+	for _, line := range lines[1:] {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		runes := []rune(line)
+		row := make([]string, len(starts))
+		for idx, start := range starts {
+			if start >= len(runes) {
+				break
+			}
+			end := len(runes)
+			if idx+1 < len(starts) && starts[idx+1] < len(runes) {
+				end = starts[idx+1]
+			}
+			row[idx] = strings.TrimSpace(string(runes[start:end]))
+		}
+		table.Rows = append(table.Rows, row)
+	}
+
+	return table
+}
+
+// isStructuredOutput reports whether kubectl output is -o yaml / -o json rather than a table
+func isStructuredOutput(content []byte) bool {
+	text := strings.TrimSpace(string(content))
+	return strings.HasPrefix(text, "{") || strings.HasPrefix(text, "apiVersion:") || strings.HasPrefix(text, "items:")
+}
+
+// Column returns the index of the named column, or -1 if absent
+func (t *KubectlTable) Column(name string) int {
+	for i, h := range t.Headers {
+		if h == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// Value returns the named cell of a row, or "" if the column does not exist
+func (t *KubectlTable) Value(row []string, name string) string {
+	idx := t.Column(name)
+	if idx < 0 || idx >= len(row) {
+		return ""
+	}
+	return row[idx]
+}
@@ -0,0 +1,291 @@
+package bundle
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Timeline entry kinds (see TimelineEntry.Kind)
+const (
+	TimelineLog     = "log"
+	TimelineEvent   = "event"
+	TimelineKernel  = "kernel"
+	TimelineRestart = "restart"
+)
+
+// Timeline entry levels (see TimelineEntry.Level)
+const (
+	TimelineLevelError   = "error"
+	TimelineLevelWarning = "warning"
+	TimelineLevelInfo    = "info"
+)
+
+// TimelineEntry is one timestamped record from the bundle's logs, events, dmesg or pod restarts
+type TimelineEntry struct {
+	Time        time.Time
+	Approximate bool   // Derived from a kubectl age ("4m53s", "14d"), only as precise as its last unit
+	Kind        string // TimelineLog, TimelineEvent, TimelineKernel or TimelineRestart
+	Level       string // Set for events, kernel events and restarts; log lines are left to the caller
+	Node        string
+	Namespace   string
+	Pod         string
+	Component   string // Log file or unit ("rke2-server", "syslog"), event source, "kernel" or "kubelet"
+	Message     string
+
+	// Log lines only: the file and 1-based line number, and whether it is a -previous pod log
+	Source     string
+	Path       string
+	LineNumber int
+	Previous   bool
+}
+
+// Timeline is the bundle's timestamped records from every source, oldest first
+type Timeline struct {
+	NodeName    string
+	CollectedAt time.Time
+	Entries     []TimelineEntry
+	Untimed     int // Kept log lines without a recognizable timestamp
+	Dropped     int // Older kept log lines dropped by the per-file cap
+}
+
+var (
+	// RFC3339 or "2006-01-02 15:04:05.000" anywhere near the start of the line
+	// (etcd JSON "ts", logrus time=, calico, prometheus)
+	isoTimeRe = regexp.MustCompile(`(\d{4}-\d{2}-\d{2})[T ](\d{2}:\d{2}:\d{2})(\.\d+)?(Z|[+-]\d{2}:?\d{2})?`)
+
+	// klog header: "E1130 09:20:11.481966"
+	klogTimeRe = regexp.MustCompile(`^[IWEF](\d{4} \d{2}:\d{2}:\d{2}(?:\.\d+)?)`)
+
+	// syslog and journald: "Nov 27 00:00:00 host proc[pid]: ..."
+	syslogTimeRe = regexp.MustCompile(`^([A-Z][a-z]{2} [ \d]\d \d{2}:\d{2}:\d{2})`)
+)
+
+// isoTimeSearchLength is how far into a line a timestamp is looked for
+const isoTimeSearchLength = 120
+
+// ParseLogTime extracts the timestamp of a log line. Formats without a year (syslog, klog)
+// take the year of ref, the bundle collection time, moving back a year for dates after it.
+// Times without a zone are taken as UTC.
+func ParseLogTime(line string, ref time.Time) (time.Time, bool) {
+	if m := syslogTimeRe.FindStringSubmatch(line); m != nil {
+		if t, err := time.Parse("Jan _2 15:04:05", m[1]); err == nil {
+			return withYear(t, ref), true
+		}
+	}
+	if m := klogTimeRe.FindStringSubmatch(line); m != nil {
+		if t, err := time.Parse("0102 15:04:05.999999", m[1]); err == nil {
+			return withYear(t, ref), true
+		}
+	}
+
+	prefix := line
+	if len(prefix) > isoTimeSearchLength {
+		prefix = prefix[:isoTimeSearchLength]
+	}
+	if m := isoTimeRe.FindStringSubmatch(prefix); m != nil {
+		zone := m[4]
+		if zone == "" {
+			zone = "Z"
+		} else if len(zone) == 5 {
+			zone = zone[:3] + ":" + zone[3:] // +0000 -> +00:00
+		}
+		if t, err := time.Parse(time.RFC3339Nano, m[1]+"T"+m[2]+m[3]+zone); err == nil {
+			return t.UTC(), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// withYear sets the year of a timestamp parsed without one from ref
+func withYear(t, ref time.Time) time.Time {
+	if ref.IsZero() {
+		ref = time.Now()
+	}
+	t = t.AddDate(ref.Year(), 0, 0)
+	if t.After(ref.Add(24 * time.Hour)) {
+		t = t.AddDate(-1, 0, 0) // December logs in a January bundle
+	}
+	return t
+}
+
+// Timeline gathers the bundle's timestamped records into one time-ordered stream: log lines
+// selected by keep from every inventoried log and the kubelet logs (the newest maxPerFile per
+// file, 0 = all),
+// events at their last-seen time, dmesg events and the last restart of each pod.
+// Sources that were not collected are skipped.
+func (b *Bundle) Timeline(keep LineMatcher, maxPerFile int) *Timeline {
+	tl := &Timeline{
+		NodeName:    extractNodeName(b.ExtractPath),
+		CollectedAt: bundleCollectedAt(b.ExtractPath),
+	}
+	ref := tl.CollectedAt
+
+	for i := range b.LogFiles {
+		tl.addLogFile(&b.LogFiles[i], keep, maxPerFile, ref)
+	}
+	for i := range b.KubeletLogFiles {
+		tl.addLogFile(&b.KubeletLogFiles[i], keep, maxPerFile, ref)
+	}
+
+	if !ref.IsZero() {
+		tl.Entries = append(tl.Entries, timelineEvents(b.ExtractPath, ref)...)
+	}
+
+	if kernel, err := ParseKernelEvents(b.ExtractPath); err == nil {
+		for _, e := range kernel.Events {
+			if e.Time.IsZero() {
+				continue
+			}
+			tl.Entries = append(tl.Entries, TimelineEntry{
+				Time:      e.Time,
+				Kind:      TimelineKernel,
+				Level:     TimelineLevelError,
+				Node:      kernel.NodeName,
+				Namespace: e.Namespace,
+				Pod:       e.Pod,
+				Component: "kernel",
+				Message:   e.Type + ": " + e.Message,
+			})
+		}
+	}
+
+	if pods, err := ParsePods(b.ExtractPath); err == nil && !ref.IsZero() {
+		for _, pod := range pods {
+			age, ok := kubectlAgeDuration(pod.KubectlLastRestart)
+			if !ok {
+				continue
+			}
+			tl.Entries = append(tl.Entries, TimelineEntry{
+				Time:        ref.Add(-age),
+				Approximate: true,
+				Kind:        TimelineRestart,
+				Level:       TimelineLevelWarning,
+				Node:        pod.NodeName,
+				Namespace:   pod.NamespaceID,
+				Pod:         pod.Name,
+				Component:   "kubelet",
+				Message:     fmt.Sprintf("Container restarted (%d restarts, %s)", pod.KubectlRestarts, pod.KubectlStatus),
+			})
+		}
+	}
+
+	sort.SliceStable(tl.Entries, func(i, j int) bool {
+		return tl.Entries[i].Time.Before(tl.Entries[j].Time)
+	})
+	return tl
+}
+
+// timelineEvents returns the bundle's events at their last-seen time. The events file is
+// read by column offsets, as SUBOBJECT is usually empty and SOURCE ("kubelet, node-1")
+// contains a space.
+func timelineEvents(extractPath string, ref time.Time) []TimelineEntry {
+	content, err := os.ReadFile(filepath.Join(getBundleRoot(extractPath), "rke2/kubectl/events"))
+	if err != nil || isStructuredOutput(content) {
+		return nil
+	}
+
+	var entries []TimelineEntry
+	table := ParseKubectlTable(content)
+	for _, row := range table.Rows {
+		age, ok := kubectlAgeDuration(table.Value(row, "LAST SEEN"))
+		if !ok {
+			continue
+		}
+		eventType := table.Value(row, "TYPE")
+		object := table.Value(row, "OBJECT")
+		component, node, _ := strings.Cut(table.Value(row, "SOURCE"), ",")
+		level := TimelineLevelInfo
+		if eventType == "Warning" {
+			level = TimelineLevelWarning
+		}
+		message := fmt.Sprintf("%s %s %s: %s", eventType, table.Value(row, "REASON"), object, table.Value(row, "MESSAGE"))
+		if count := table.Value(row, "COUNT"); count != "" && count != "1" {
+			message += fmt.Sprintf(" (x%s over %s)", count, table.Value(row, "FIRST SEEN"))
+		}
+		pod := ""
+		if kind, name, ok := strings.Cut(object, "/"); ok && kind == "pod" {
+			pod = name
+		}
+		entries = append(entries, TimelineEntry{
+			Time:        ref.Add(-age),
+			Approximate: true,
+			Kind:        TimelineEvent,
+			Level:       level,
+			Node:        strings.TrimSpace(node),
+			Namespace:   table.Value(row, "NAMESPACE"),
+			Pod:         pod,
+			Component:   component,
+			Message:     message,
+		})
+	}
+	return entries
+}
+
+// addLogFile adds the kept, timestamped lines of a log file, the newest maxPerFile of them.
+// Continuation lines (stack traces, multi-line messages) carry no timestamp and are counted
+// as untimed.
+func (tl *Timeline) addLogFile(logFile *LogFileInfo, keep LineMatcher, maxPerFile int, ref time.Time) {
+	f, err := openLogFile(logFile.Path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	component := logFile.Source()
+	node := tl.NodeName
+	switch logFile.Type {
+	case LogTypePod:
+		component = logFile.ContainerName
+		node = "" // Pod logs were collected cluster-wide
+	case LogTypeKubelet:
+		component = "kubelet"
+	}
+
+	var entries []TimelineEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := scanner.Text()
+		if !keep.MatchString(line) {
+			continue
+		}
+		t, ok := ParseLogTime(line, ref)
+		if !ok {
+			tl.Untimed++
+			continue
+		}
+		if len(line) > maxSearchLineLength {
+			line = line[:maxSearchLineLength-3] + "..."
+		}
+		entries = append(entries, TimelineEntry{
+			Time:       t,
+			Kind:       TimelineLog,
+			Node:       node,
+			Namespace:  logFile.Namespace,
+			Pod:        logFile.PodName,
+			Component:  component,
+			Message:    line,
+			Source:     logFile.Source(),
+			Path:       logFile.Path,
+			LineNumber: lineNum,
+			Previous:   logFile.IsPrevious,
+		})
+		if maxPerFile > 0 && len(entries) >= 2*maxPerFile {
+			tl.Dropped += len(entries) - maxPerFile
+			entries = append(entries[:0], entries[len(entries)-maxPerFile:]...)
+		}
+	}
+	if maxPerFile > 0 && len(entries) > maxPerFile {
+		tl.Dropped += len(entries) - maxPerFile
+		entries = entries[len(entries)-maxPerFile:]
+	}
+	tl.Entries = append(tl.Entries, entries...)
+}
@@ -0,0 +1,124 @@
+package bundle
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+)
+
+// TestParseLogTime tests timestamp normalization across the log formats found in bundles
+func TestParseLogTime(t *testing.T) {
+	ref := time.Date(2025, 12, 4, 9, 15, 58, 0, time.UTC)
+	tests := []struct {
+		line string
+		want time.Time
+	}{
+		{`Nov 27 00:00:00 node-1 rke2[8828]: time="2025-11-27T00:00:00Z" level=info msg="wake"`, time.Date(2025, 11, 27, 0, 0, 0, 0, time.UTC)},
+		{`Dec  3 23:59:01 node-1 systemd[1]: Started Session 4.`, time.Date(2025, 12, 3, 23, 59, 1, 0, time.UTC)},
+		{`Dec 31 23:00:00 node-1 kernel: from last year`, time.Date(2024, 12, 31, 23, 0, 0, 0, time.UTC)},
+		{`E1130 09:20:11.481966    8869 dns.go:153] "Nameserver limits exceeded"`, time.Date(2025, 11, 30, 9, 20, 11, 481966000, time.UTC)},
+		{`{"level":"warn","ts":"2025-11-27T09:20:23.452477Z","caller":"etcdserver/util.go:170","msg":"apply request took too long"}`, time.Date(2025, 11, 27, 9, 20, 23, 452477000, time.UTC)},
+		{`2025-11-27 09:18:20.320 [INFO][60] felix/summary.go 100: Summarising`, time.Date(2025, 11, 27, 9, 18, 20, 320000000, time.UTC)},
+		{`time=2025-12-03T04:19:28.805Z level=INFO source=coordinator.go:115 msg=DoScrape`, time.Date(2025, 12, 3, 4, 19, 28, 805000000, time.UTC)},
+		{`2025-12-01T10:00:00+02:00 ERROR something`, time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, ok := ParseLogTime(tt.line, ref)
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("ParseLogTime(%q) = %v, %v; want %v", tt.line, got, ok, tt.want)
+		}
+	}
+
+	for _, line := range []string{"    at com.example.Main.run(Main.java:42)", "goroutine 1 [running]:", ""} {
+		if got, ok := ParseLogTime(line, ref); ok {
+			t.Errorf("ParseLogTime(%q) = %v, want no timestamp", line, got)
+		}
+	}
+}
+
+// TestTimeline tests merging logs (including gzipped kubelet logs), events, dmesg and restarts
+func TestTimeline(t *testing.T) {
+	root := t.TempDir()
+	writeBundleFile(t, root, "systeminfo/date", "Thu Dec  4 09:15:58 UTC 2025\n")
+	writeBundleFile(t, root, "journald/rke2-server", `Dec  4 09:00:00 node-1 rke2[1]: time="2025-12-04T09:00:00Z" level=info msg="started"
+Dec  4 09:10:00 node-1 rke2[1]: time="2025-12-04T09:10:00Z" level=error msg="etcd not ready"
+`)
+	writeBundleFile(t, root, "rke2/podlogs/app-web-1", `2025-12-04 09:05:00.000 ERROR first
+panic: boom
+2025-12-04 09:06:00.000 ERROR second
+2025-12-04 09:07:00.000 ERROR third
+`)
+	writeBundleFile(t, root, "rke2/kubectl/events", `NAMESPACE   LAST SEEN   TYPE      REASON    OBJECT      SUBOBJECT   SOURCE            MESSAGE                                FIRST SEEN   COUNT   NAME
+app         5m          Warning   BackOff   pod/web-1               kubelet, node-2   Back-off restarting failed container   1h           12      web-1.1
+`)
+	writeBundleFile(t, root, "rke2/kubectl/pods", `NAMESPACE   NAME    READY   STATUS             RESTARTS        AGE   IP          NODE     NOMINATED NODE   READINESS GATES
+app         web-1   0/1     CrashLoopBackOff   8 (4m53s ago)   21m   10.42.0.5   node-2   <none>           <none>
+`)
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte("I1204 09:01:00.000000    1 kubelet.go:1] started\nE1204 09:12:00.000000    1 kubelet.go:2] PLEG is not healthy\n"))
+	zw.Close()
+	if err := os.MkdirAll(filepath.Join(root, "rke2/agent-logs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "rke2/agent-logs/kubelet.log.gz"), gz.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	logFiles, err := InventoryLogFiles(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range logFiles {
+		if f.Type == LogTypeKubelet {
+			t.Errorf("kubelet log %s is in the log search inventory", f.Path)
+		}
+	}
+	kubeletLogFiles, err := InventoryKubeletLogFiles(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &Bundle{ExtractPath: root, LogFiles: logFiles, KubeletLogFiles: kubeletLogFiles}
+	tl := b.Timeline(regexp.MustCompile(`(?i)error|panic|E1204`), 2)
+
+	collected := time.Date(2025, 12, 4, 9, 15, 58, 0, time.UTC)
+	want := []struct {
+		kind    string
+		time    time.Time
+		message string
+	}{
+		{TimelineLog, time.Date(2025, 12, 4, 9, 6, 0, 0, time.UTC), "2025-12-04 09:06:00.000 ERROR second"},
+		{TimelineLog, time.Date(2025, 12, 4, 9, 7, 0, 0, time.UTC), "2025-12-04 09:07:00.000 ERROR third"},
+		{TimelineLog, time.Date(2025, 12, 4, 9, 10, 0, 0, time.UTC), `Dec  4 09:10:00 node-1 rke2[1]: time="2025-12-04T09:10:00Z" level=error msg="etcd not ready"`},
+		{TimelineEvent, collected.Add(-5 * time.Minute), "Warning BackOff pod/web-1: Back-off restarting failed container (x12 over 1h)"},
+		{TimelineRestart, collected.Add(-(4*time.Minute + 53*time.Second)), "Container restarted (8 restarts, CrashLoopBackOff)"},
+		{TimelineLog, time.Date(2025, 12, 4, 9, 12, 0, 0, time.UTC), "E1204 09:12:00.000000    1 kubelet.go:2] PLEG is not healthy"},
+	}
+	if len(tl.Entries) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(tl.Entries), len(want), tl.Entries)
+	}
+	for i, w := range want {
+		e := tl.Entries[i]
+		if e.Kind != w.kind || !e.Time.Equal(w.time) || e.Message != w.message {
+			t.Errorf("entry %d = %s %v %q, want %s %v %q", i, e.Kind, e.Time, e.Message, w.kind, w.time, w.message)
+		}
+	}
+	if tl.Untimed != 1 || tl.Dropped != 1 {
+		t.Errorf("untimed = %d, dropped = %d; want 1 (panic line) and 1 (per-file cap)", tl.Untimed, tl.Dropped)
+	}
+
+	if e := tl.Entries[1]; e.Namespace != "app" || e.Pod != "web-1" || e.LineNumber != 4 || e.Source != "app/web-1" {
+		t.Errorf("pod log entry tags = %+v", e)
+	}
+	if e := tl.Entries[3]; e.Node != "node-2" || e.Component != "kubelet" || e.Level != TimelineLevelWarning || !e.Approximate {
+		t.Errorf("event entry tags = %+v", e)
+	}
+	if e := tl.Entries[5]; e.Component != "kubelet" || e.LineNumber != 2 {
+		t.Errorf("kubelet log entry tags = %+v", e)
+	}
+}
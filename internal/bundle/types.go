@@ -23,6 +23,9 @@ type Bundle struct {
 	// LogFiles contains inventory of all log files in the bundle
 	LogFiles []LogFileInfo
 
+	// KubeletLogFiles contains the kubelet logs, read by the timeline only
+	KubeletLogFiles []LogFileInfo
+
 	// kubectl resources parsed from bundle
 	CRDs        []interface{} // Will be []rancher.CRD when imported
 	Deployments []interface{} // Will be []rancher.Deployment
@@ -88,7 +91,7 @@ type LogFileInfo struct {
 	// Path is the relative path within the bundle
 	Path string
 
-	// Type indicates the log type (pod, system, journald)
+	// Type indicates the log type (pod, system, journald, kubelet)
 	Type LogType
 
 	// Namespace for pod logs
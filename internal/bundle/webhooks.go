@@ -0,0 +1,335 @@
+package bundle
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// knownWebhook describes the backing service of a webhook configuration commonly found
+// on RKE2/Rancher clusters. Support bundles only collect the table output of
+// "kubectl get *webhookconfigurations", so this is how we resolve their services.
+type knownWebhook struct {
+	ServiceNamespace string
+	ServiceName      string
+	FailurePolicy    string
+	NameHints        []string // Substrings of individual webhook names served by this config
+}
+
+// knownWebhooks maps webhook configuration names to their usual backing service
+var knownWebhooks = map[string]knownWebhook{
+	"rancher.cattle.io": {
+		ServiceNamespace: "cattle-system", ServiceName: "rancher-webhook", FailurePolicy: "Fail",
+		NameHints: []string{"rancher.cattle.io"},
+	},
+	"rke2-ingress-nginx-admission": {
+		ServiceNamespace: "kube-system", ServiceName: "rke2-ingress-nginx-controller-admission", FailurePolicy: "Fail",
+		NameHints: []string{"validate.nginx.ingress.kubernetes.io"},
+	},
+	"longhorn-webhook-mutator": {
+		ServiceNamespace: "longhorn-system", ServiceName: "longhorn-admission-webhook", FailurePolicy: "Fail",
+		NameHints: []string{"mutator.longhorn.io"},
+	},
+	"longhorn-webhook-validator": {
+		ServiceNamespace: "longhorn-system", ServiceName: "longhorn-admission-webhook", FailurePolicy: "Fail",
+		NameHints: []string{"validator.longhorn.io"},
+	},
+	"rancher-monitoring-admission": {
+		ServiceNamespace: "cattle-monitoring-system", ServiceName: "rancher-monitoring-operator", FailurePolicy: "Fail",
+		NameHints: []string{"monitoring.coreos.com"},
+	},
+	"cert-manager-webhook": {
+		ServiceNamespace: "cert-manager", ServiceName: "cert-manager-webhook", FailurePolicy: "Fail",
+		NameHints: []string{"webhook.cert-manager.io"},
+	},
+	"gatekeeper-validating-webhook-configuration": {
+		ServiceNamespace: "gatekeeper-system", ServiceName: "gatekeeper-webhook-service", FailurePolicy: "Ignore",
+		NameHints: []string{"gatekeeper.sh"},
+	},
+	"gatekeeper-mutating-webhook-configuration": {
+		ServiceNamespace: "gatekeeper-system", ServiceName: "gatekeeper-webhook-service", FailurePolicy: "Ignore",
+		NameHints: []string{"gatekeeper.sh"},
+	},
+}
+
+// WebhookHealthInfo contains the resolved backend state of a single admission webhook
+type WebhookHealthInfo struct {
+	ConfigName  string // Webhook configuration name
+	Kind        string // "Mutating" or "Validating"
+	WebhookName string // Individual webhook name (equals ConfigName when only table output exists)
+
+	FailurePolicy  string
+	PolicyInferred bool // FailurePolicy/service come from known defaults, not the bundle
+
+	ServiceNamespace string
+	ServiceName      string
+	URL              string
+
+	ServiceBackends
+
+	nameHints []string
+}
+
+// HasReadyBackend reports whether at least one ready address serves this webhook.
+// URL-based webhooks point outside the cluster and are assumed reachable.
+func (w *WebhookHealthInfo) HasReadyBackend() bool {
+	return w.URL != "" || w.ReadyBackends > 0
+}
+
+// Resolved reports whether we know which service backs this webhook
+func (w *WebhookHealthInfo) Resolved() bool {
+	return w.URL != "" || w.ServiceName != ""
+}
+
+// WebhookCallFailure is a parsed "failed calling webhook" message
+type WebhookCallFailure struct {
+	WebhookName      string // e.g. "validate.nginx.ingress.kubernetes.io"
+	ServiceNamespace string // From the https://<svc>.<ns>.svc URL, if present
+	ServiceName      string
+}
+
+var (
+	webhookCallFailureRe = regexp.MustCompile(`failed calling webhook \\?"([^"\\]+)\\?"`)
+	webhookServiceURLRe  = regexp.MustCompile(`https://([a-z0-9-]+)\.([a-z0-9-]+)\.svc`)
+)
+
+// WebhookFailurePattern matches log and event lines reporting a failed webhook call
+var WebhookFailurePattern = regexp.MustCompile(`failed calling webhook`)
+
+// ParseWebhookCallFailure extracts the webhook name and target service from a
+// "failed calling webhook" message. Returns false if the line is not such a message.
+func ParseWebhookCallFailure(line string) (WebhookCallFailure, bool) {
+	m := webhookCallFailureRe.FindStringSubmatch(line)
+	if m == nil {
+		return WebhookCallFailure{}, false
+	}
+
+	failure := WebhookCallFailure{WebhookName: m[1]}
+	if u := webhookServiceURLRe.FindStringSubmatch(line); u != nil {
+		failure.ServiceName = u[1]
+		failure.ServiceNamespace = u[2]
+	}
+	return failure, true
+}
+
+// MatchesFailure reports whether a parsed webhook call failure refers to this webhook
+func (w *WebhookHealthInfo) MatchesFailure(f WebhookCallFailure) bool {
+	if f.ServiceName != "" && f.ServiceName == w.ServiceName && f.ServiceNamespace == w.ServiceNamespace {
+		return true
+	}
+	if f.WebhookName == w.WebhookName {
+		return true
+	}
+	// Table-only entries stand in for every webhook in the configuration
+	if w.WebhookName == w.ConfigName && strings.HasPrefix(f.WebhookName, w.ConfigName) {
+		return true
+	}
+	for _, hint := range w.nameHints {
+		if strings.Contains(f.WebhookName, hint) {
+			return true
+		}
+	}
+	return false
+}
+
+// webhookConfigList is the subset of a *WebhookConfigurationList we read from -o yaml/json output
+type webhookConfigList struct {
+	Items []struct {
+		Metadata struct {
+			Name string `yaml:"name"`
+		} `yaml:"metadata"`
+		Webhooks []struct {
+			Name          string `yaml:"name"`
+			FailurePolicy string `yaml:"failurePolicy"`
+			ClientConfig  struct {
+				URL     string `yaml:"url"`
+				Service *struct {
+					Namespace string `yaml:"namespace"`
+					Name      string `yaml:"name"`
+					Port      int    `yaml:"port"`
+				} `yaml:"service"`
+			} `yaml:"clientConfig"`
+		} `yaml:"webhooks"`
+	} `yaml:"items"`
+}
+
+// ParseWebhookConfigurations parses mutating and validating webhook configurations from bundle
+// Format: NAME WEBHOOKS AGE (table output), or a full -o yaml / -o json list
+func ParseWebhookConfigurations(extractPath string) ([]rancher.WebhookConfiguration, error) {
+	bundleRoot := getBundleRoot(extractPath)
+
+	var configs []rancher.WebhookConfiguration
+	found := false
+
+	for _, kind := range []string{"Mutating", "Validating"} {
+		path := filepath.Join(bundleRoot, "rke2/kubectl", strings.ToLower(kind)+"webhookconfigurations")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		found = true
+
+		parsed, err := parseWebhookConfigFile(content, kind)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+		}
+		configs = append(configs, parsed...)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("no webhook configurations found in bundle")
+	}
+
+	return configs, nil
+}
+
+// parseWebhookConfigFile handles both table and structured output for one configuration kind
+func parseWebhookConfigFile(content []byte, kind string) ([]rancher.WebhookConfiguration, error) {
+	text := strings.TrimSpace(string(content))
+	var configs []rancher.WebhookConfiguration
+
+	// Structured output (yaml or json - yaml.v3 reads both)
+	if isStructuredOutput(content) {
+		var list webhookConfigList
+		if err := yaml.Unmarshal(content, &list); err != nil {
+			return nil, err
+		}
+
+		for _, item := range list.Items {
+			cfg := rancher.WebhookConfiguration{
+				Name:         item.Metadata.Name,
+				Kind:         kind,
+				WebhookCount: len(item.Webhooks),
+			}
+			for _, wh := range item.Webhooks {
+				webhook := rancher.Webhook{
+					Name:          wh.Name,
+					FailurePolicy: wh.FailurePolicy,
+					URL:           wh.ClientConfig.URL,
+				}
+				if webhook.FailurePolicy == "" {
+					webhook.FailurePolicy = "Fail" // admissionregistration/v1 default
+				}
+				if svc := wh.ClientConfig.Service; svc != nil {
+					webhook.ServiceNamespace = svc.Namespace
+					webhook.ServiceName = svc.Name
+					webhook.ServicePort = svc.Port
+				}
+				cfg.Webhooks = append(cfg.Webhooks, webhook)
+			}
+			configs = append(configs, cfg)
+		}
+		return configs, nil
+	}
+
+	// Table output
+	for i, line := range strings.Split(text, "\n") {
+		if i == 0 || strings.TrimSpace(line) == "" {
+			continue // Skip header and empty lines
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		var count int
+		fmt.Sscanf(fields[1], "%d", &count)
+
+		cfg := rancher.WebhookConfiguration{
+			Name:         fields[0],
+			Kind:         kind,
+			WebhookCount: count,
+		}
+		if len(fields) > 2 {
+			cfg.Age = fields[2]
+		}
+		configs = append(configs, cfg)
+	}
+
+	return configs, nil
+}
+
+// AnalyzeWebhooks resolves every admission webhook against the bundle's Services,
+// Endpoints and Pods and reports how many ready backends each one has.
+func AnalyzeWebhooks(extractPath string) ([]WebhookHealthInfo, error) {
+	configs, err := ParseWebhookConfigurations(extractPath)
+	if err != nil {
+		return nil, err
+	}
+
+	services := loadServiceIndex(extractPath)
+
+	var results []WebhookHealthInfo
+	for _, cfg := range configs {
+		for _, info := range expandWebhookConfig(cfg, services.services) {
+			info.ServiceBackends = services.resolve(info.ServiceNamespace, info.ServiceName)
+			results = append(results, info)
+		}
+	}
+
+	return results, nil
+}
+
+// expandWebhookConfig produces one WebhookHealthInfo per webhook. Table-only
+// configurations produce a single entry resolved from knownWebhooks or name heuristics.
+func expandWebhookConfig(cfg rancher.WebhookConfiguration, serviceMap map[string]rancher.Service) []WebhookHealthInfo {
+	var infos []WebhookHealthInfo
+
+	if len(cfg.Webhooks) > 0 {
+		for _, wh := range cfg.Webhooks {
+			infos = append(infos, WebhookHealthInfo{
+				ConfigName:       cfg.Name,
+				Kind:             cfg.Kind,
+				WebhookName:      wh.Name,
+				FailurePolicy:    wh.FailurePolicy,
+				ServiceNamespace: wh.ServiceNamespace,
+				ServiceName:      wh.ServiceName,
+				URL:              wh.URL,
+			})
+		}
+		return infos
+	}
+
+	info := WebhookHealthInfo{
+		ConfigName:     cfg.Name,
+		Kind:           cfg.Kind,
+		WebhookName:    cfg.Name,
+		FailurePolicy:  "Fail", // admissionregistration/v1 default
+		PolicyInferred: true,
+	}
+
+	if known, ok := knownWebhooks[cfg.Name]; ok {
+		info.ServiceNamespace = known.ServiceNamespace
+		info.ServiceName = known.ServiceName
+		info.FailurePolicy = known.FailurePolicy
+		info.nameHints = known.NameHints
+	} else if svc, ok := guessWebhookService(cfg.Name, serviceMap); ok {
+		info.ServiceNamespace = svc.NamespaceID
+		info.ServiceName = svc.Name
+	}
+
+	return append(infos, info)
+}
+
+// guessWebhookService looks for a service named after the webhook configuration
+func guessWebhookService(configName string, serviceMap map[string]rancher.Service) (rancher.Service, bool) {
+	base := strings.TrimSuffix(configName, "-configuration")
+	base = strings.TrimSuffix(base, "-webhook")
+	candidates := []string{configName, base, base + "-webhook", base + "-webhook-service", base + "-admission"}
+
+	for _, name := range candidates {
+		for _, svc := range serviceMap {
+			if svc.Name == name {
+				return svc, true
+			}
+		}
+	}
+	return rancher.Service{}, false
+}
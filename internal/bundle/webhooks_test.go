@@ -0,0 +1,173 @@
+package bundle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeKubectlFile writes a fixture file into <root>/rke2/kubectl/<name>
+func writeKubectlFile(t *testing.T, root, name, content string) {
+	t.Helper()
+	dir := filepath.Join(root, "rke2", "kubectl")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatalf("failed to create kubectl dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
+
+// TestParseWebhookCallFailure tests extraction of webhook name and service from API errors
+func TestParseWebhookCallFailure(t *testing.T) {
+	tests := []struct {
+		name      string
+		line      string
+		wantOK    bool
+		wantHook  string
+		wantSvc   string
+		wantSvcNS string
+	}{
+		{
+			name:      "event message",
+			line:      `Internal error occurred: failed calling webhook "validate.nginx.ingress.kubernetes.io": failed to call webhook: Post "https://rke2-ingress-nginx-controller-admission.kube-system.svc:443/networking/v1/ingresses?timeout=10s": dial tcp 10.43.67.110:443: connect: connection refused`,
+			wantOK:    true,
+			wantHook:  "validate.nginx.ingress.kubernetes.io",
+			wantSvc:   "rke2-ingress-nginx-controller-admission",
+			wantSvcNS: "kube-system",
+		},
+		{
+			name:      "escaped quotes in log line",
+			line:      `level=error msg="failed calling webhook \"rancher.cattle.io.secrets\": failed to call webhook: Post \"https://rancher-webhook.cattle-system.svc:443/v1/webhook/mutation/secrets\""`,
+			wantOK:    true,
+			wantHook:  "rancher.cattle.io.secrets",
+			wantSvc:   "rancher-webhook",
+			wantSvcNS: "cattle-system",
+		},
+		{
+			name:   "unrelated line",
+			line:   "I1204 09:15:57.123456 webhook server started",
+			wantOK: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, ok := ParseWebhookCallFailure(tt.line)
+			if ok != tt.wantOK {
+				t.Fatalf("ParseWebhookCallFailure() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if f.WebhookName != tt.wantHook {
+				t.Errorf("WebhookName = %q, want %q", f.WebhookName, tt.wantHook)
+			}
+			if f.ServiceName != tt.wantSvc || f.ServiceNamespace != tt.wantSvcNS {
+				t.Errorf("service = %s/%s, want %s/%s", f.ServiceNamespace, f.ServiceName, tt.wantSvcNS, tt.wantSvc)
+			}
+		})
+	}
+}
+
+// TestParseWebhookConfigurations_YAML tests parsing full -o yaml output
+func TestParseWebhookConfigurations_YAML(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "validatingwebhookconfigurations", `apiVersion: v1
+kind: List
+items:
+- apiVersion: admissionregistration.k8s.io/v1
+  kind: ValidatingWebhookConfiguration
+  metadata:
+    name: my-policy
+  webhooks:
+  - name: check.example.com
+    failurePolicy: Ignore
+    clientConfig:
+      service:
+        namespace: policy-system
+        name: policy-webhook
+        port: 443
+  - name: strict.example.com
+    clientConfig:
+      url: https://external.example.com/validate
+`)
+
+	configs, err := ParseWebhookConfigurations(root)
+	if err != nil {
+		t.Fatalf("ParseWebhookConfigurations() error = %v", err)
+	}
+	if len(configs) != 1 || len(configs[0].Webhooks) != 2 {
+		t.Fatalf("expected 1 config with 2 webhooks, got %+v", configs)
+	}
+
+	first := configs[0].Webhooks[0]
+	if first.FailurePolicy != "Ignore" || first.ServiceNamespace != "policy-system" || first.ServiceName != "policy-webhook" {
+		t.Errorf("unexpected first webhook: %+v", first)
+	}
+
+	second := configs[0].Webhooks[1]
+	if second.FailurePolicy != "Fail" {
+		t.Errorf("expected default failurePolicy Fail, got %q", second.FailurePolicy)
+	}
+	if second.URL == "" {
+		t.Errorf("expected URL to be parsed for external webhook")
+	}
+}
+
+// TestAnalyzeWebhooks_NoReadyBackend tests resolution from table output through
+// services, endpoints and pods
+func TestAnalyzeWebhooks_NoReadyBackend(t *testing.T) {
+	root := t.TempDir()
+	writeKubectlFile(t, root, "mutatingwebhookconfigurations", `NAME                WEBHOOKS   AGE
+rancher.cattle.io   4          14d
+`)
+	writeKubectlFile(t, root, "validatingwebhookconfigurations", `NAME                           WEBHOOKS   AGE
+rke2-ingress-nginx-admission   1          14d
+`)
+	writeKubectlFile(t, root, "services", `NAMESPACE       NAME                                      TYPE        CLUSTER-IP     EXTERNAL-IP   PORT(S)   AGE   SELECTOR
+cattle-system   rancher-webhook                           ClusterIP   10.43.83.142   <none>        443/TCP   14d   app=rancher-webhook
+kube-system     rke2-ingress-nginx-controller-admission   ClusterIP   10.43.67.110   <none>        443/TCP   14d   app.kubernetes.io/name=rke2-ingress-nginx
+`)
+	writeKubectlFile(t, root, "endpoints", `NAMESPACE       NAME                                      ENDPOINTS          AGE
+cattle-system   rancher-webhook                           <none>             14d
+kube-system     rke2-ingress-nginx-controller-admission   10.42.20.72:8443   14d
+`)
+	writeKubectlFile(t, root, "pods", `NAMESPACE       NAME                                  READY   STATUS             RESTARTS       AGE   IP            NODE     NOMINATED NODE   READINESS GATES
+cattle-system   rancher-webhook-65bdfb464d-hsfns      0/1     CrashLoopBackOff   12 (1m ago)    14d   10.42.207.13  node-1   <none>           <none>
+kube-system     rke2-ingress-nginx-controller-cj5km   1/1     Running            0              14d   10.42.20.72   node-2   <none>           <none>
+`)
+
+	results, err := AnalyzeWebhooks(root)
+	if err != nil {
+		t.Fatalf("AnalyzeWebhooks() error = %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 webhooks, got %d", len(results))
+	}
+
+	for _, wh := range results {
+		switch wh.ConfigName {
+		case "rancher.cattle.io":
+			if wh.HasReadyBackend() {
+				t.Errorf("rancher.cattle.io should have no ready backend")
+			}
+			if wh.FailurePolicy != "Fail" || !wh.PolicyInferred {
+				t.Errorf("expected inferred Fail policy, got %q (inferred=%v)", wh.FailurePolicy, wh.PolicyInferred)
+			}
+			if len(wh.BackendPods) != 1 || wh.BackendPods[0] != "rancher-webhook-65bdfb464d-hsfns" {
+				t.Errorf("expected candidate pod from selector, got %v", wh.BackendPods)
+			}
+			failure, _ := ParseWebhookCallFailure(`failed calling webhook "rancher.cattle.io.secrets": no endpoints available`)
+			if !wh.MatchesFailure(failure) {
+				t.Errorf("expected rancher.cattle.io.secrets failure to match config")
+			}
+		case "rke2-ingress-nginx-admission":
+			if !wh.HasReadyBackend() {
+				t.Errorf("ingress webhook should have a ready backend")
+			}
+		default:
+			t.Errorf("unexpected webhook %q", wh.ConfigName)
+		}
+	}
+}
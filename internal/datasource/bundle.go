@@ -3,7 +3,10 @@ package datasource
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/Rancheroo/r8s/internal/bundle"
 	"github.com/Rancheroo/r8s/internal/rancher"
@@ -12,6 +15,9 @@ import (
 // BundleDataSource uses bundle files for offline data
 type BundleDataSource struct {
 	bundle *bundle.Bundle
+
+	logSignalsOnce sync.Once
+	logSignals     *logSignals
 }
 
 // NewBundleDataSource creates a new bundle data source
@@ -207,6 +213,24 @@ func (ds *BundleDataSource) GetServices(projectID, namespace string) ([]rancher.
 	return services, nil
 }
 
+// GetConfigMaps returns configmaps from the bundle
+func (ds *BundleDataSource) GetConfigMaps(projectID, namespace string) ([]rancher.ConfigMap, error) {
+	all, err := bundle.ParseConfigMaps(ds.bundle.ExtractPath)
+	if err != nil {
+		// configmaps file might not exist
+		return []rancher.ConfigMap{}, nil
+	}
+
+	var configMaps []rancher.ConfigMap
+	for _, cm := range all {
+		// Filter by namespace if specified
+		if namespace == "" || cm.Namespace == namespace {
+			configMaps = append(configMaps, cm)
+		}
+	}
+	return configMaps, nil
+}
+
 // GetCRDs returns CRDs from the bundle
 func (ds *BundleDataSource) GetCRDs(clusterID string) ([]rancher.CRD, error) {
 	var crds []rancher.CRD
@@ -218,10 +242,27 @@ func (ds *BundleDataSource) GetCRDs(clusterID string) ([]rancher.CRD, error) {
 	return crds, nil
 }
 
-// GetCRDInstances returns CRD instances from the bundle
+// GetCRDInstances returns CRD instances collected under rke2/kubectl. Full objects are
+// returned for -o yaml / -o json files; table rows carry metadata and their "columns".
 func (ds *BundleDataSource) GetCRDInstances(clusterID, group, version, plural string) ([]map[string]interface{}, error) {
-	// Bundle mode doesn't have CRD instances in the current implementation
-	// Return empty list rather than error
+	files, err := bundle.LoadResourceFiles(ds.bundle.ExtractPath)
+	if err != nil {
+		return []map[string]interface{}{}, nil
+	}
+
+	for _, file := range files {
+		r := file.Resource
+		if r == nil || r.Name != plural || (group != "" && !strings.HasPrefix(r.APIVersion, group+"/")) {
+			continue
+		}
+		instances, err := bundle.ResourceObjects(ds.bundle.ExtractPath, file.File)
+		if err != nil {
+			return []map[string]interface{}{}, nil
+		}
+		return instances, nil
+	}
+
+	// CRD instances were not collected
 	return []map[string]interface{}{}, nil
 }
 
@@ -502,6 +543,11 @@ func (ds *BundleDataSource) DescribeService(clusterID, namespace, name string) (
 	}, nil
 }
 
+// BundleRoot returns the root directory of the extracted bundle
+func (ds *BundleDataSource) BundleRoot() string {
+	return ds.bundle.Root()
+}
+
 // Mode returns the display string for bundle mode
 func (ds *BundleDataSource) Mode() string {
 	return "BUNDLE"
@@ -582,6 +628,70 @@ func (ds *BundleDataSource) GetEtcdHealth() (*EtcdHealth, error) {
 	}, nil
 }
 
+// GetEtcdStatus returns etcd members, quota, snapshots and issues (bundle only)
+func (ds *BundleDataSource) GetEtcdStatus() (*EtcdStatus, error) {
+	info, err := bundle.ParseEtcdStatus(ds.bundle.ExtractPath)
+	if err != nil {
+		// etcd dir might not exist
+		return nil, nil
+	}
+
+	status := &EtcdStatus{
+		EtcdNodes:          info.EtcdNodes,
+		QuotaBytes:         info.QuotaBytes,
+		QuotaFromMetrics:   info.QuotaFromMetrics,
+		SnapshotsCollected: info.SnapshotsCollected,
+		SnapshotSchedule:   info.SnapshotSchedule,
+		SnapshotRetention:  info.SnapshotRetention,
+		SnapshotsDisabled:  info.SnapshotsDisabled,
+	}
+	for _, m := range info.Members {
+		status.Members = append(status.Members, EtcdMemberStatus{
+			ID:               m.ID,
+			Name:             m.Name,
+			Status:           m.Status,
+			PeerURLs:         m.PeerURLs,
+			ClientURLs:       m.ClientURLs,
+			IsLearner:        m.IsLearner,
+			HasStatus:        m.HasStatus,
+			Version:          m.Version,
+			DBSize:           m.DBSize,
+			DBSizeInUse:      m.DBSizeInUse,
+			Fragmentation:    m.Fragmentation(),
+			QuotaRatio:       info.QuotaRatio(m),
+			IsLeader:         m.IsLeader,
+			RaftTerm:         m.RaftTerm,
+			RaftIndex:        m.RaftIndex,
+			RaftAppliedIndex: m.RaftAppliedIndex,
+			Errors:           m.Errors,
+			HasHealth:        m.HasHealth,
+			Healthy:          m.Healthy,
+			Latency:          m.Latency,
+			HealthError:      m.HealthError,
+		})
+	}
+	for _, snap := range info.Snapshots {
+		status.Snapshots = append(status.Snapshots, EtcdSnapshot{Name: snap.Name, Size: snap.Size, Time: snap.Time})
+	}
+	if latest := info.LatestSnapshot(); latest != nil {
+		collected := info.CollectedAt
+		if collected.IsZero() {
+			collected = time.Now()
+		}
+		status.LatestSnapshotAge = collected.Sub(latest.Time)
+	}
+	for _, issue := range info.Issues() {
+		status.Issues = append(status.Issues, EtcdIssue{
+			Type:     issue.Type,
+			Critical: issue.Critical,
+			Member:   issue.Member,
+			Detail:   issue.Detail,
+		})
+	}
+
+	return status, nil
+}
+
 // GetSystemHealth returns system health info (bundle only)
 func (ds *BundleDataSource) GetSystemHealth() (*SystemHealth, error) {
 	healthInfo, err := bundle.ParseSystemHealth(ds.bundle.ExtractPath)
@@ -596,6 +706,1175 @@ func (ds *BundleDataSource) GetSystemHealth() (*SystemHealth, error) {
 	}, nil
 }
 
+// GetSystemResources returns the systeminfo directory parsed into resources with issues (bundle only)
+func (ds *BundleDataSource) GetSystemResources() (*SystemResources, error) {
+	res, err := bundle.ParseSystemResources(ds.bundle.ExtractPath)
+	if err != nil {
+		// systeminfo dir might not exist
+		return nil, nil
+	}
+
+	out := &SystemResources{
+		Hostname:             res.Hostname,
+		Kernel:               res.Kernel,
+		CPUModel:             res.CPUModel,
+		Cores:                res.Cores,
+		Uptime:               res.Uptime,
+		Load1:                res.Load1,
+		Load5:                res.Load5,
+		Load15:               res.Load15,
+		MemTotal:             res.MemTotal,
+		MemUsed:              res.MemUsed,
+		MemAvailable:         res.MemAvailable,
+		SwapTotal:            res.SwapTotal,
+		SwapUsed:             res.SwapUsed,
+		VmstatSamples:        len(res.Vmstat),
+		FileHandlesAllocated: res.FileHandlesAllocated,
+		FileHandlesMax:       res.FileHandlesMax,
+	}
+
+	for _, s := range res.Vmstat {
+		out.AvgRunnable += float64(s.Runnable)
+		out.AvgBlocked += float64(s.Blocked)
+		out.AvgIOWait += float64(s.IOWait)
+		out.AvgSteal += float64(s.Steal)
+		if s.SwapIn > out.MaxSwapIn {
+			out.MaxSwapIn = s.SwapIn
+		}
+		if s.SwapOut > out.MaxSwapOut {
+			out.MaxSwapOut = s.SwapOut
+		}
+	}
+	if n := float64(len(res.Vmstat)); n > 0 {
+		out.AvgRunnable /= n
+		out.AvgBlocked /= n
+		out.AvgIOWait /= n
+		out.AvgSteal /= n
+	}
+
+	rancherFS := res.FilesystemFor("/var/lib/rancher")
+	for i, fs := range res.Filesystems {
+		out.Filesystems = append(out.Filesystems, FilesystemUsage{
+			Device:      fs.Device,
+			MountPoint:  fs.MountPoint,
+			Type:        fs.Type,
+			Size:        fs.Size,
+			Used:        fs.Used,
+			Avail:       fs.Avail,
+			UsePercent:  fs.UsePercent,
+			Inodes:      fs.Inodes,
+			IUsed:       fs.IUsed,
+			IUsePercent: fs.IUsePercent,
+			Options:     fs.Options,
+			ReadOnly:    fs.ReadOnly,
+			RKE2Data:    rancherFS == &res.Filesystems[i],
+		})
+	}
+	for _, m := range res.Mounts {
+		if m.ReadOnly() {
+			out.ReadOnlyMounts = append(out.ReadOnlyMounts, fmt.Sprintf("%s (%s, %s)", m.MountPoint, m.Device, m.Type))
+		}
+	}
+	for _, b := range res.BlockDevices {
+		out.BlockDevices = append(out.BlockDevices, BlockDevice{
+			Name:        b.Name,
+			Size:        b.Size,
+			ReadOnly:    b.ReadOnly,
+			Type:        b.Type,
+			MountPoints: b.MountPoints,
+		})
+	}
+	for _, p := range res.Processes {
+		out.Processes = append(out.Processes, ProcessInfo{
+			User:    p.User,
+			PID:     p.PID,
+			CPU:     p.CPU,
+			Memory:  p.Memory,
+			RSS:     p.RSS,
+			Stat:    p.Stat,
+			Command: p.Command,
+		})
+	}
+	for _, u := range res.Ulimits {
+		out.Ulimits = append(out.Ulimits, Ulimit{Kind: u.Kind, Name: u.Name, Flag: u.Flag, Value: u.Value})
+	}
+	for _, u := range res.Units {
+		if !u.Watched && u.Active != "failed" && u.Sub != "auto-restart" {
+			continue
+		}
+		out.Units = append(out.Units, ServiceUnit{
+			Name:          u.Name,
+			Active:        u.Active,
+			Sub:           u.Sub,
+			Description:   u.Description,
+			UnitFileState: u.UnitFileState,
+			Watched:       u.Watched,
+			Role:          u.Role,
+			Journal:       u.Journal,
+			JournalTail:   u.JournalTail,
+			Restarts:      u.Restarts,
+		})
+	}
+	for _, issue := range res.Issues() {
+		out.Issues = append(out.Issues, SystemIssue{
+			Type:     issue.Type,
+			Critical: issue.Critical,
+			Subject:  issue.Subject,
+			Detail:   issue.Detail,
+			Evidence: issue.Evidence,
+		})
+	}
+
+	return out, nil
+}
+
+// GetKernelEvents returns systeminfo/dmesg parsed into kernel events (bundle only)
+func (ds *BundleDataSource) GetKernelEvents() (*KernelLog, error) {
+	log, err := bundle.ParseKernelEvents(ds.bundle.ExtractPath)
+	if err != nil {
+		// dmesg might not have been collected
+		return nil, nil
+	}
+
+	out := &KernelLog{Node: log.NodeName, Boot: log.Boot, Lines: log.Lines}
+	for _, e := range log.Events {
+		out.Events = append(out.Events, KernelEvent{
+			Type:        e.Type,
+			Time:        e.Time,
+			Uptime:      e.Uptime,
+			Process:     e.Process,
+			PID:         e.PID,
+			Device:      e.Device,
+			Message:     e.Message,
+			Lines:       e.Lines,
+			Constraint:  e.Constraint,
+			Trigger:     e.Trigger,
+			MemcgPath:   e.MemcgPath,
+			AnonRSS:     e.AnonRSS,
+			ContainerID: e.ContainerID,
+			PodUID:      e.PodUID,
+			Namespace:   e.Namespace,
+			Pod:         e.Pod,
+			Container:   e.Container,
+		})
+	}
+
+	return out, nil
+}
+
+// GetHostConfig returns systeminfo parsed into host config lint results (bundle only)
+func (ds *BundleDataSource) GetHostConfig() (*HostConfig, error) {
+	host, err := bundle.ParseHostConfig(ds.bundle.ExtractPath)
+	if err != nil {
+		// systeminfo dir might not exist
+		return nil, nil
+	}
+
+	out := &HostConfig{
+		Node:          host.NodeName,
+		OS:            host.OS,
+		Kernel:        host.Kernel,
+		ProtectKernel: host.ProtectKernel,
+		Sysctls:       len(host.Sysctls),
+		Modules:       len(host.Modules),
+		Packages:      len(host.Packages),
+	}
+	for _, c := range host.Lint() {
+		out.Checks = append(out.Checks, HostCheck{
+			Category: c.Category,
+			Name:     c.Name,
+			Status:   c.Status,
+			Expected: c.Expected,
+			Actual:   c.Actual,
+			Detail:   c.Detail,
+		})
+	}
+
+	return out, nil
+}
+
+// GetControlPlane returns rke2/pod-manifests parsed into control plane components and
+// findings (bundle only)
+func (ds *BundleDataSource) GetControlPlane() (*ControlPlane, error) {
+	cp, err := bundle.ParseControlPlane(ds.bundle.ExtractPath)
+	if err != nil {
+		// pod-manifests dir only exists on server nodes
+		return nil, nil
+	}
+
+	out := &ControlPlane{Node: cp.NodeName}
+	for _, p := range cp.Pods {
+		pod := StaticPod{
+			Component:      p.Component,
+			File:           p.File,
+			Namespace:      p.Namespace,
+			PodName:        p.PodName,
+			Container:      p.Container,
+			Image:          p.Image,
+			ContainerState: p.ContainerState,
+			Attempt:        p.Attempt,
+			Logs:           p.Logs,
+			PreviousLogs:   p.PreviousLogs,
+		}
+		for _, f := range p.Flags {
+			pod.Flags = append(pod.Flags, ControlPlaneFlag{Name: f.Name, Value: f.Value})
+		}
+		out.Components = append(out.Components, pod)
+	}
+	for _, f := range cp.Findings() {
+		out.Findings = append(out.Findings, ControlPlaneFinding{
+			Component: f.Component,
+			Flag:      f.Flag,
+			Severity:  f.Severity,
+			Detail:    f.Detail,
+		})
+	}
+
+	return out, nil
+}
+
+// GetRKE2Config returns rke2/50-rancher.yaml and the rke2 unit files with lint issues (bundle only)
+func (ds *BundleDataSource) GetRKE2Config() (*RKE2Config, error) {
+	config, err := bundle.ParseRKE2Config(ds.bundle.ExtractPath)
+	if err != nil {
+		// rke2 config might not be collected
+		return nil, nil
+	}
+
+	out := &RKE2Config{
+		Node:              config.NodeName,
+		Role:              config.Role,
+		Found:             config.Found,
+		CNI:               config.CNI,
+		ClusterCIDR:       config.ClusterCIDR,
+		ServiceCIDR:       config.ServiceCIDR,
+		ClusterDNS:        config.ClusterDNS,
+		TLSSANs:           config.TLSSANs,
+		SnapshotSchedule:  config.SnapshotSchedule,
+		SnapshotRetention: config.SnapshotRetention,
+		SnapshotsDisabled: config.SnapshotsDisabled,
+	}
+	for _, s := range config.Settings {
+		out.Settings = append(out.Settings, RKE2ConfigSetting{Key: s.Key, Value: s.Value, Masked: s.Masked, Known: s.Known})
+	}
+	for _, u := range config.Units {
+		unit := RKE2ServiceUnit{Name: u.Name, ExecStart: u.ExecStart, EnvironmentFiles: u.EnvironmentFiles}
+		for _, d := range u.Directives {
+			unit.Directives = append(unit.Directives, ControlPlaneFlag{Name: d.Name, Value: d.Value})
+		}
+		out.Units = append(out.Units, unit)
+	}
+	for _, issue := range config.Issues() {
+		out.Issues = append(out.Issues, RKE2ConfigIssue{Type: issue.Type, Key: issue.Key, Detail: issue.Detail})
+	}
+
+	return out, nil
+}
+
+// GetWebhookHealth returns admission webhooks resolved against services, endpoints and pods,
+// with "failed calling webhook" events and log lines attached to the webhook they refer to
+func (ds *BundleDataSource) GetWebhookHealth() ([]WebhookHealth, error) {
+	infos, err := bundle.AnalyzeWebhooks(ds.bundle.ExtractPath)
+	if err != nil {
+		// Webhook configuration files might not exist
+		return []WebhookHealth{}, nil
+	}
+
+	// Collect failure messages once, then attach them to matching webhooks
+	type failureLine struct {
+		failure bundle.WebhookCallFailure
+		text    string
+	}
+	var failures []failureLine
+
+	for _, item := range ds.bundle.Events {
+		if event, ok := item.(rancher.Event); ok {
+			if f, ok := bundle.ParseWebhookCallFailure(event.Message); ok {
+				failures = append(failures, failureLine{f, fmt.Sprintf("[event] %s/%s: %s (count: %d)",
+					event.Namespace, event.Object, event.Message, event.Count)})
+			}
+		}
+	}
+
+	for _, match := range ds.signals().webhooks {
+		if f, ok := bundle.ParseWebhookCallFailure(match.Line); ok {
+			failures = append(failures, failureLine{f, fmt.Sprintf("[log] %s:%d: %s",
+				match.Source, match.LineNumber, match.Line)})
+		}
+	}
+
+	var webhooks []WebhookHealth
+	for i := range infos {
+		info := &infos[i]
+		wh := WebhookHealth{
+			ConfigName:       info.ConfigName,
+			Kind:             info.Kind,
+			WebhookName:      info.WebhookName,
+			FailurePolicy:    info.FailurePolicy,
+			PolicyInferred:   info.PolicyInferred,
+			ServiceNamespace: info.ServiceNamespace,
+			ServiceName:      info.ServiceName,
+			ServiceFound:     info.ServiceFound,
+			Resolved:         info.Resolved(),
+			HasReadyBackend:  info.HasReadyBackend(),
+			Endpoints:        info.EndpointAddresses,
+			BackendPods:      info.BackendPods,
+		}
+
+		for _, fl := range failures {
+			if info.MatchesFailure(fl.failure) {
+				wh.Failures = append(wh.Failures, fl.text)
+			}
+		}
+
+		webhooks = append(webhooks, wh)
+	}
+
+	return webhooks, nil
+}
+
+// maxAPIServiceFailures caps how many correlated lines are attached to one APIService
+const maxAPIServiceFailures = 50
+
+// GetAPIServiceHealth returns aggregated (non-Local) APIServices resolved against services,
+// endpoints and pods, with discovery/availability errors attached to the APIService they name
+func (ds *BundleDataSource) GetAPIServiceHealth() ([]APIServiceHealth, error) {
+	infos, err := bundle.AnalyzeAPIServices(ds.bundle.ExtractPath)
+	if err != nil {
+		// apiservices file might not exist
+		return []APIServiceHealth{}, nil
+	}
+
+	// Collect failure lines once, keyed by the APIService names they mention
+	failures := make(map[string][]string)
+	addFailure := func(line, text string) {
+		for _, name := range bundle.APIServicesInLine(line) {
+			if len(failures[name]) < maxAPIServiceFailures {
+				failures[name] = append(failures[name], text)
+			}
+		}
+	}
+
+	for _, item := range ds.bundle.Events {
+		if event, ok := item.(rancher.Event); ok && bundle.APIServiceFailurePattern.MatchString(event.Message) {
+			addFailure(event.Message, fmt.Sprintf("[event] %s/%s: %s (count: %d)",
+				event.Namespace, event.Object, event.Message, event.Count))
+		}
+	}
+	for _, match := range ds.signals().apiServices {
+		addFailure(match.Line, fmt.Sprintf("[log] %s:%d: %s", match.Source, match.LineNumber, match.Line))
+	}
+
+	var apiServices []APIServiceHealth
+	for _, info := range infos {
+		if info.Local() {
+			continue // Served by kube-apiserver itself
+		}
+		apiServices = append(apiServices, APIServiceHealth{
+			Name:             info.Name,
+			ServiceNamespace: info.ServiceNamespace,
+			ServiceName:      info.ServiceName,
+			Available:        info.Available,
+			Reason:           info.Reason,
+			ServiceFound:     info.ServiceFound,
+			Endpoints:        info.EndpointAddresses,
+			BackendPods:      info.BackendPods,
+			Failures:         failures[info.Name],
+		})
+	}
+
+	return apiServices, nil
+}
+
+// GetRoles returns ClusterRoles and Roles from the bundle
+func (ds *BundleDataSource) GetRoles() ([]rancher.Role, error) {
+	roles, err := bundle.ParseRoles(ds.bundle.ExtractPath)
+	if err != nil {
+		// RBAC files might not exist
+		return []rancher.Role{}, nil
+	}
+	return roles, nil
+}
+
+// GetRoleBindings returns ClusterRoleBindings and RoleBindings from the bundle
+func (ds *BundleDataSource) GetRoleBindings() ([]rancher.RoleBinding, error) {
+	bindings, err := bundle.ParseRoleBindings(ds.bundle.ExtractPath)
+	if err != nil {
+		// RBAC files might not exist
+		return []rancher.RoleBinding{}, nil
+	}
+	return bindings, nil
+}
+
+// GetHelmCharts returns HelmCharts correlated with helm-install jobs, pods and workloads
+func (ds *BundleDataSource) GetHelmCharts() ([]HelmChartStatus, error) {
+	infos, err := bundle.AnalyzeHelmCharts(ds.bundle.ExtractPath)
+	if err != nil {
+		// helmcharts file might not exist
+		return []HelmChartStatus{}, nil
+	}
+
+	var charts []HelmChartStatus
+	for i := range infos {
+		info := &infos[i]
+		chart := HelmChartStatus{
+			Namespace:       info.Chart.Namespace,
+			Name:            info.Chart.Name,
+			Chart:           info.Chart.Chart,
+			Version:         info.Chart.Version,
+			TargetNamespace: info.Chart.TargetNamespace,
+			Bootstrap:       info.Chart.Bootstrap,
+			Failed:          info.Chart.Failed,
+			JobName:         info.Chart.JobName,
+			JobFailed:       info.JobFailed(),
+			WorkloadPods:    info.WorkloadPods,
+			Problem:         info.Problem(),
+		}
+		if info.Job != nil {
+			chart.JobStatus = info.Job.Status
+			chart.JobCompletions = info.Job.Completions
+		}
+		for _, pod := range info.InstallPods {
+			chart.InstallPods = append(chart.InstallPods, HelmInstallPod{
+				Name:     pod.Name,
+				Status:   pod.KubectlStatus,
+				Restarts: pod.KubectlRestarts,
+				Age:      pod.KubectlAge,
+			})
+		}
+		if len(info.InstallPods) > 0 && chart.Problem != "" {
+			newest := info.InstallPods[len(info.InstallPods)-1]
+			chart.InstallErrors = ds.podLogErrors(newest.NamespaceID, newest.Name, maxHelmErrorLines)
+		}
+		for _, w := range info.Workloads {
+			chart.Workloads = append(chart.Workloads, HelmWorkload{
+				Kind:      w.Kind,
+				Namespace: w.Namespace,
+				Name:      w.Name,
+				Ready:     w.Ready,
+				Desired:   w.Desired,
+			})
+		}
+		charts = append(charts, chart)
+	}
+
+	return charts, nil
+}
+
+// maxHelmErrorLines caps how many install log error lines are attached to a HelmChart
+const maxHelmErrorLines = 5
+
+// podLogErrors returns the last error lines from a pod's log (current, then previous).
+// Unlike GetLogs it never substitutes demo logs, so the lines are safe to show as evidence.
+func (ds *BundleDataSource) podLogErrors(namespace, pod string, max int) []string {
+	for _, previous := range []bool{false, true} {
+		for i := range ds.bundle.LogFiles {
+			logFile := &ds.bundle.LogFiles[i]
+			if logFile.Namespace != namespace || logFile.PodName != pod || logFile.IsPrevious != previous {
+				continue
+			}
+
+			content, err := ds.bundle.ReadLogFile(logFile)
+			if err != nil {
+				continue
+			}
+
+			var errors []string
+			for _, line := range strings.Split(string(content), "\n") {
+				lower := strings.ToLower(line)
+				if strings.Contains(lower, "error") || strings.Contains(lower, "failed") {
+					errors = append(errors, strings.TrimSpace(line))
+				}
+			}
+			if len(errors) > max {
+				errors = errors[len(errors)-max:]
+			}
+			if len(errors) > 0 {
+				return errors
+			}
+		}
+	}
+	return nil
+}
+
+// GetRollouts returns Deployments with their ReplicaSet revisions
+func (ds *BundleDataSource) GetRollouts(namespace string) ([]DeploymentRollout, error) {
+	infos, err := bundle.AnalyzeRollouts(ds.bundle.ExtractPath)
+	if err != nil {
+		// replicasets/deployments files might not exist
+		return []DeploymentRollout{}, nil
+	}
+
+	pods, _ := bundle.ParsePods(ds.bundle.ExtractPath)
+
+	var rollouts []DeploymentRollout
+	for i := range infos {
+		info := &infos[i]
+		if namespace != "" && info.Deployment.Namespace != namespace {
+			continue
+		}
+
+		rollout := DeploymentRollout{
+			Namespace:   info.Deployment.Namespace,
+			Name:        info.Deployment.Name,
+			Ready:       info.Deployment.Ready,
+			Desired:     info.Deployment.Desired,
+			Images:      info.Deployment.Images,
+			ReplicaSets: info.ReplicaSets,
+			InProgress:  info.InProgress(),
+			Stalled:     info.Stalled(),
+		}
+
+		// ReplicaSet pods are named <replicaset>-<suffix>
+		if newRS := info.NewReplicaSet(); newRS != nil {
+			prefix := newRS.Name + "-"
+			for _, pod := range pods {
+				if pod.NamespaceID == newRS.Namespace && strings.HasPrefix(pod.Name, prefix) &&
+					!strings.Contains(pod.Name[len(prefix):], "-") {
+					rollout.NewPods = append(rollout.NewPods, pod)
+				}
+			}
+		}
+
+		rollouts = append(rollouts, rollout)
+	}
+
+	return rollouts, nil
+}
+
+// GetHPAs returns HPAs linked to their scale targets, with unknown metrics correlated
+// to the availability of the metrics APIService serving them
+func (ds *BundleDataSource) GetHPAs(projectID, namespace string) ([]HPAStatus, error) {
+	infos, err := bundle.AnalyzeHPAs(ds.bundle.ExtractPath)
+	if err != nil {
+		// hpa file might not exist
+		return []HPAStatus{}, nil
+	}
+
+	// Availability of aggregated metrics APIs (metrics-server, custom/external adapters)
+	apiServices := make(map[string]bundle.APIServiceInfo)
+	if all, err := bundle.AnalyzeAPIServices(ds.bundle.ExtractPath); err == nil {
+		for _, svc := range all {
+			apiServices[svc.Name] = svc
+		}
+	}
+
+	var hpas []HPAStatus
+	for i := range infos {
+		info := &infos[i]
+		if namespace != "" && info.Namespace != namespace {
+			continue
+		}
+
+		hpa := HPAStatus{
+			HorizontalPodAutoscaler: info.HorizontalPodAutoscaler,
+			TargetFound:             info.Target != nil,
+			TargetMissing:           info.TargetMissing(),
+			PinnedAtMax:             info.PinnedAtMax(),
+			MetricsAPIs:             info.MetricsAPIServices(),
+			Problems:                info.Problems(),
+		}
+		if info.Target != nil {
+			hpa.TargetReady = info.Target.Ready
+			hpa.TargetDesired = info.Target.Desired
+		}
+		for _, m := range info.UnknownMetrics() {
+			name := m.Name
+			if name == "" {
+				name = "target " + m.Target // Older kubectl table output has no metric names
+			}
+			hpa.UnknownMetrics = append(hpa.UnknownMetrics, name)
+		}
+		for _, name := range hpa.MetricsAPIs {
+			svc, ok := apiServices[name]
+			switch {
+			case !ok:
+				if len(apiServices) > 0 {
+					hpa.UnavailableAPIs = append(hpa.UnavailableAPIs, name+" (not registered)")
+				}
+			case !svc.Available:
+				hpa.UnavailableAPIs = append(hpa.UnavailableAPIs, fmt.Sprintf("%s (%s)", name, svc.Reason))
+			}
+		}
+
+		hpas = append(hpas, hpa)
+	}
+
+	return hpas, nil
+}
+
+// GetRuntime returns crictl containers, sandboxes and images for the bundle's node,
+// cross-checked against kubectl pods
+func (ds *BundleDataSource) GetRuntime() (*RuntimeStatus, error) {
+	info, err := bundle.ParseRuntime(ds.bundle.ExtractPath)
+	if err != nil {
+		// crictl dir might not exist
+		return nil, nil
+	}
+
+	pods, _ := bundle.ParsePods(ds.bundle.ExtractPath)
+
+	status := &RuntimeStatus{
+		NodeName:   info.NodeName,
+		Version:    info.RuntimeVersion,
+		Containers: info.Containers,
+		Pods:       info.Pods,
+		Images:     info.Images,
+	}
+	for _, c := range info.Conditions {
+		if !c.Status {
+			status.NotReadyConditions = append(status.NotReadyConditions, fmt.Sprintf("%s: %s (%s)", c.Type, c.Reason, c.Message))
+		}
+	}
+	if info.ImageFS != nil {
+		status.ImageFSMountpoint = info.ImageFS.Mountpoint
+		status.ImageFSUsedBytes = info.ImageFS.UsedBytes
+		status.ImageFSInodesUsed = info.ImageFS.InodesUsed
+	}
+	for _, issue := range bundle.CrossCheckRuntime(info, pods) {
+		status.Issues = append(status.Issues, RuntimeIssue{
+			Type:      issue.Type,
+			Namespace: issue.Namespace,
+			Pod:       issue.Pod,
+			Container: issue.Container,
+			Attempt:   issue.Attempt,
+			Detail:    issue.Detail,
+		})
+	}
+
+	return status, nil
+}
+
+// GetImages returns the image inventory from workloads, crictl images and pull failure events
+func (ds *BundleDataSource) GetImages() (*ImageReport, error) {
+	inv, err := bundle.BuildImageInventory(ds.bundle.ExtractPath)
+	if err != nil {
+		// No workload, crictl or event data in bundle
+		return nil, nil
+	}
+
+	report := &ImageReport{ExpectedRegistries: inv.ExpectedRegistries}
+	for _, f := range inv.PullFailures {
+		report.PullFailures = append(report.PullFailures, newImagePullFailure(f))
+	}
+	for _, img := range inv.Images {
+		status := ImageStatus{
+			Image:              img.Ref.String(),
+			Registry:           img.Ref.Registry,
+			Repository:         img.Ref.Repository,
+			Tag:                img.Ref.Tag,
+			Digest:             img.Ref.Digest,
+			ID:                 img.ID,
+			Size:               img.Size,
+			OnNode:             img.OnNode,
+			RunningOnNode:      img.RunningOnNode,
+			UsedBy:             img.UsedBy,
+			Latest:             img.Latest,
+			Unused:             img.Unused(),
+			UnexpectedRegistry: img.UnexpectedRegistry,
+		}
+		for _, f := range img.PullFailures {
+			status.PullFailures = append(status.PullFailures, newImagePullFailure(f))
+		}
+		report.Images = append(report.Images, status)
+	}
+
+	return report, nil
+}
+
+// newImagePullFailure converts a bundle pull failure
+func newImagePullFailure(f bundle.PullFailure) ImagePullFailure {
+	return ImagePullFailure{
+		Namespace: f.Namespace,
+		Pod:       f.Pod,
+		Image:     f.Image,
+		Class:     f.Class,
+		Message:   f.Message,
+		Count:     f.Count,
+	}
+}
+
+// GetMetrics returns the bundle's metrics scrapes flattened to one series per metric and
+// label set, with histogram percentiles estimated from their buckets
+func (ds *BundleDataSource) GetMetrics() ([]MetricsSource, error) {
+	files, err := bundle.ParseMetricsFiles(ds.bundle.ExtractPath)
+	if err != nil {
+		return []MetricsSource{}, nil
+	}
+
+	sources := make([]MetricsSource, 0, len(files))
+	for _, file := range files {
+		source := MetricsSource{Component: file.Component, File: file.Path}
+		for _, f := range file.Families {
+			if f.Type == bundle.MetricTypeHistogram {
+				for _, h := range f.Histograms() {
+					hist := &MetricHistogram{
+						Count: h.Count,
+						Sum:   h.Sum,
+						P50:   h.Quantile(0.5),
+						P90:   h.Quantile(0.9),
+						P99:   h.Quantile(0.99),
+					}
+					for _, b := range h.Buckets {
+						hist.Buckets = append(hist.Buckets, MetricBucket{UpperBound: b.UpperBound, Count: b.Count})
+					}
+					source.Series = append(source.Series, MetricSeries{
+						Name:      f.Name,
+						Type:      f.Type,
+						Help:      f.Help,
+						Labels:    h.Labels,
+						Histogram: hist,
+					})
+				}
+				continue
+			}
+			for _, sample := range f.Samples {
+				source.Series = append(source.Series, MetricSeries{
+					Name:   sample.Name,
+					Type:   f.Type,
+					Help:   f.Help,
+					Labels: sample.Labels,
+					Value:  sample.Value,
+				})
+			}
+		}
+		for _, c := range file.Checks {
+			source.Checks = append(source.Checks, MetricCheck{
+				Name:   c.Name,
+				Metric: c.Metric,
+				Status: c.Status,
+				Value:  c.Value,
+				Detail: c.Detail,
+			})
+		}
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+// maxCertificateErrors caps how many correlated x509 lines are attached to one certificate
+const maxCertificateErrors = 50
+
+// GetCertificates returns rke2/certs certificates evaluated at bundle collection time, with
+// x509 errors from events and logs attached to the certificates that likely cause them
+func (ds *BundleDataSource) GetCertificates() (*CertificateReport, error) {
+	certs, err := bundle.ParseCertificates(ds.bundle.ExtractPath)
+	if err != nil {
+		// rke2/certs might not exist
+		return nil, nil
+	}
+
+	collected := time.Now()
+	if ds.bundle.Manifest != nil && !ds.bundle.Manifest.CollectedAt.IsZero() {
+		collected = ds.bundle.Manifest.CollectedAt
+	}
+	report := &CertificateReport{CollectedAt: collected}
+
+	// Collect x509 errors once, then attach them to the certificates they match
+	type certErrorLine struct {
+		err  bundle.CertificateError
+		text string
+	}
+	var errorLines []certErrorLine
+	for _, item := range ds.bundle.Events {
+		if event, ok := item.(rancher.Event); ok {
+			if e, ok := bundle.ParseCertificateError(event.Message); ok {
+				errorLines = append(errorLines, certErrorLine{e, fmt.Sprintf("[event] %s/%s: %s (count: %d)",
+					event.Namespace, event.Object, event.Message, event.Count)})
+			}
+		}
+	}
+	for _, match := range ds.signals().certificates {
+		if e, ok := bundle.ParseCertificateError(match.Line); ok {
+			errorLines = append(errorLines, certErrorLine{e, fmt.Sprintf("[log] %s:%d: %s",
+				match.Source, match.LineNumber, match.Line)})
+		}
+	}
+
+	linked := make([]bool, len(errorLines))
+	for _, c := range certs {
+		status := CertificateStatus{
+			File:           c.File,
+			Name:           c.CertificateName(),
+			Format:         c.Format,
+			Subject:        c.Subject,
+			CommonName:     c.CommonName,
+			Issuer:         c.Issuer,
+			IssuerCN:       c.IssuerCN,
+			DNSNames:       c.DNSNames,
+			IPAddresses:    c.IPAddresses,
+			SerialNumber:   c.SerialNumber,
+			NotBefore:      c.NotBefore,
+			NotAfter:       c.NotAfter,
+			IsCA:           c.IsCA,
+			ExtKeyUsage:    c.ExtKeyUsage,
+			SubjectKeyID:   c.SubjectKeyID,
+			AuthorityKeyID: c.AuthorityKeyID,
+			Expiry:         c.ExpiryStatus(collected),
+			ExpiresIn:      c.NotAfter.Sub(collected),
+			Critical:       c.IsCritical(collected),
+			Chain:          c.Chain,
+			ChainCA:        c.ChainCA,
+			ChainDetail:    c.ChainDetail,
+		}
+		for i, line := range errorLines {
+			if c.MatchesError(line.err, collected) {
+				linked[i] = true
+				if len(status.LogErrors) < maxCertificateErrors {
+					status.LogErrors = append(status.LogErrors, line.text)
+				}
+			}
+		}
+		report.Certificates = append(report.Certificates, status)
+	}
+	for i, line := range errorLines {
+		if !linked[i] {
+			report.UnlinkedErrors = append(report.UnlinkedErrors, line.text)
+		}
+	}
+
+	return report, nil
+}
+
+// GetNetworking returns the node's networking directory parsed into interfaces, routes,
+// sockets and CNI config, with port, route, MTU and iptables backend checks (bundle only)
+func (ds *BundleDataSource) GetNetworking() (*NetworkReport, error) {
+	status, err := bundle.ParseNetworking(ds.bundle.ExtractPath)
+	if err != nil {
+		// networking dir might not exist
+		return nil, nil
+	}
+
+	report := &NetworkReport{
+		Node:            status.Node,
+		ClusterCIDR:     status.ClusterCIDR,
+		ServiceCIDR:     status.ServiceCIDR,
+		IsServer:        status.IsServer,
+		IsEtcd:          status.IsEtcd,
+		IptablesBackend: status.IptablesBackend(),
+		IptablesHeader:  status.IptablesHeader,
+		IptablesRules:   status.IptablesRules,
+		NftTables:       status.NftTables,
+		Rules:           status.Rules,
+	}
+	if uplink := status.Uplink(); uplink != nil {
+		report.Uplink = uplink.Name
+	}
+	report.PodMTU, report.PodMTUSource = status.PodMTU()
+
+	for _, iface := range status.Interfaces {
+		report.Interfaces = append(report.Interfaces, NetworkInterface{
+			Name:      iface.Name,
+			Peer:      iface.Peer,
+			Flags:     iface.Flags,
+			MTU:       iface.MTU,
+			State:     iface.State,
+			Addresses: iface.Addresses,
+			Kind:      iface.Kind,
+		})
+	}
+	for _, r := range status.Routes {
+		report.Routes = append(report.Routes, NetworkRoute{
+			Type:        r.Type,
+			Destination: r.Destination,
+			Gateway:     r.Gateway,
+			Device:      r.Device,
+			Table:       r.Table,
+			Protocol:    r.Protocol,
+			Scope:       r.Scope,
+			Source:      r.Source,
+			Text:        r.String(),
+		})
+	}
+	for _, s := range status.Sockets {
+		report.Sockets = append(report.Sockets, ListeningSocket{
+			Protocol: s.Protocol,
+			Address:  s.Address,
+			Port:     s.Port,
+			Process:  s.Process,
+			PID:      s.PID,
+		})
+	}
+	for _, c := range status.CNI {
+		report.CNI = append(report.CNI, CNIConfig{File: c.File, Name: c.Name, Plugins: c.Plugins, MTU: c.MTU})
+	}
+	for _, c := range status.Checks() {
+		report.Checks = append(report.Checks, NetworkCheck{Name: c.Name, Status: c.Status, Detail: c.Detail})
+	}
+
+	return report, nil
+}
+
+// GetResourceTypes returns every kubectl output file in the bundle with its api-resources entry
+func (ds *BundleDataSource) GetResourceTypes() ([]ResourceType, error) {
+	files, err := bundle.LoadResourceFiles(ds.bundle.ExtractPath)
+	if err != nil {
+		// rke2/kubectl might not exist
+		return []ResourceType{}, nil
+	}
+
+	types := make([]ResourceType, len(files))
+	for i := range files {
+		types[i] = newResourceType(&files[i])
+	}
+	return types, nil
+}
+
+// GetResourceTable returns a kubectl output file as a generic table, resolving name as
+// a file name first, then through api-resources
+func (ds *BundleDataSource) GetResourceTable(name string) (*ResourceTable, error) {
+	files, err := bundle.LoadResourceFiles(ds.bundle.ExtractPath)
+	if err != nil {
+		return nil, fmt.Errorf("no kubectl output in bundle: %w", err)
+	}
+
+	var match *bundle.ResourceFile
+	for i := range files {
+		if files[i].File == name {
+			match = &files[i]
+			break
+		}
+	}
+	if match == nil {
+		apiResources, _ := bundle.ParseAPIResources(ds.bundle.ExtractPath)
+		if r := bundle.FindAPIResource(apiResources, name); r != nil {
+			for i := range files {
+				if files[i].Resource != nil && files[i].Resource.Name == r.Name {
+					match = &files[i]
+					break
+				}
+			}
+			if match == nil {
+				return nil, fmt.Errorf("%s (%s) was not collected in this bundle", r.Kind, r.Name)
+			}
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("unknown resource type %q", name)
+	}
+
+	table := &ResourceTable{ResourceType: newResourceType(match), Message: match.Message}
+	if match.Table != nil {
+		table.Headers = match.Table.Headers
+		table.Rows = match.Table.Rows
+	}
+	return table, nil
+}
+
+// newResourceType describes a bundle resource file
+func newResourceType(file *bundle.ResourceFile) ResourceType {
+	t := ResourceType{File: file.File, IsTable: file.Table != nil}
+	if file.Table != nil {
+		t.Count = len(file.Table.Rows)
+	}
+	if r := file.Resource; r != nil {
+		t.Resource = r.Name
+		t.Kind = r.Kind
+		t.APIVersion = r.APIVersion
+		t.Namespaced = r.Namespaced
+		t.ShortNames = r.ShortNames
+	}
+	return t
+}
+
+// SortRows orders the table by a column; numbers, ready counts and ages compare numerically
+func (t *ResourceTable) SortRows(column int, descending bool) {
+	if column < 0 || column >= len(t.Headers) {
+		return
+	}
+	sort.SliceStable(t.Rows, func(i, j int) bool {
+		c := bundle.CompareKubectlValues(t.Rows[i][column], t.Rows[j][column])
+		if descending {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// maxConnectionErrors caps how many connection error lines are attached to a NetworkPolicy
+const maxConnectionErrors = 20
+
+// GetNetworkPolicies returns NetworkPolicies with the pods they select
+func (ds *BundleDataSource) GetNetworkPolicies() ([]NetworkPolicyStatus, error) {
+	infos, err := bundle.AnalyzeNetworkPolicies(ds.bundle.ExtractPath)
+	if err != nil {
+		// networkpolicies file might not exist
+		return []NetworkPolicyStatus{}, nil
+	}
+
+	// Attach connection errors to every policy isolating the pod that logged them
+	connErrors := make(map[*bundle.NetworkPolicyInfo][]LogMatch)
+	if len(infos) > 0 {
+		for _, match := range ds.signals().connectionErrors {
+			for _, info := range bundle.PoliciesForPod(infos, match.Namespace, match.PodName) {
+				if len(connErrors[info]) < maxConnectionErrors {
+					connErrors[info] = append(connErrors[info], LogMatch{
+						Source:     match.Source,
+						Namespace:  match.Namespace,
+						PodName:    match.PodName,
+						LineNumber: match.LineNumber,
+						Line:       match.Line,
+					})
+				}
+			}
+		}
+	}
+
+	var policies []NetworkPolicyStatus
+	for i := range infos {
+		info := &infos[i]
+		policies = append(policies, NetworkPolicyStatus{
+			Namespace:        info.Namespace,
+			Name:             info.Name,
+			SelectorText:     info.SelectorText,
+			PolicyTypes:      info.PolicyTypes,
+			IngressRules:     info.IngressRules,
+			EgressRules:      info.EgressRules,
+			HasSpec:          info.HasSpec,
+			SelectedPods:     info.SelectedPods,
+			Exact:            info.Exact,
+			Resolution:       info.Resolution,
+			DenyIngress:      info.DenyIngress,
+			DenyEgress:       info.DenyEgress,
+			AllowAll:         info.AllowAll,
+			Inferred:         info.Inferred,
+			DefaultDeny:      info.DefaultDeny(),
+			ConnectionErrors: connErrors[info],
+		})
+	}
+
+	return policies, nil
+}
+
+// logSignals are the log lines behind the dashboard checks, from one shared pass
+type logSignals struct {
+	webhooks         []bundle.LogMatchInfo
+	apiServices      []bundle.LogMatchInfo
+	certificates     []bundle.LogMatchInfo
+	connectionErrors []bundle.LogMatchInfo // Logged by pods a NetworkPolicy selects
+}
+
+// signals searches the logs for every check in one pass on first use. The bundle does not
+// change, so the dashboard, its refreshes and the resource views all share that pass.
+func (ds *BundleDataSource) signals() *logSignals {
+	ds.logSignalsOnce.Do(func() {
+		ds.logSignals = ds.scanSignals()
+	})
+	return ds.logSignals
+}
+
+// scanSignals searches the logs for every check, and for the extra searches, in one pass
+func (ds *BundleDataSource) scanSignals(extra ...*bundle.LogSearch) *logSignals {
+	apiServices, _ := bundle.AnalyzeAPIServices(ds.bundle.ExtractPath)
+	policies, _ := bundle.AnalyzeNetworkPolicies(ds.bundle.ExtractPath)
+
+	webhookSearch := &bundle.LogSearch{Pattern: bundle.WebhookFailurePattern, MaxMatches: 200}
+	apiServiceSearch := &bundle.LogSearch{Pattern: bundle.APIServiceFailurePattern, MaxMatches: 500,
+		Files: bundle.APIServiceLogFiles(apiServices)}
+	certificateSearch := &bundle.LogSearch{Pattern: bundle.CertificateErrorPattern, MaxMatches: 500}
+	connectionSearch := &bundle.LogSearch{Pattern: bundle.ConnectionErrorPattern, MaxMatches: 500,
+		Files: bundle.NetworkPolicyLogFiles(policies)}
+	searches := append([]*bundle.LogSearch{webhookSearch, apiServiceSearch, certificateSearch, connectionSearch}, extra...)
+	ds.bundle.ScanLogs(searches...)
+
+	return &logSignals{
+		webhooks:         webhookSearch.Matches,
+		apiServices:      apiServiceSearch.Matches,
+		certificates:     certificateSearch.Matches,
+		connectionErrors: connectionSearch.Matches,
+	}
+}
+
+// SearchLogs returns bundle log lines matching pattern
+func (ds *BundleDataSource) SearchLogs(pattern LineMatcher, maxMatches int) ([]LogMatch, error) {
+	search := &LogSearch{Pattern: pattern, MaxMatches: maxMatches}
+	if err := ds.ScanLogs(search); err != nil {
+		return nil, err
+	}
+	return search.Matches, nil
+}
+
+// ScanLogs runs the searches in one pass over the bundle logs. The first pass also runs the
+// searches of the dashboard checks, which are kept for the rest of the session.
+func (ds *BundleDataSource) ScanLogs(searches ...*LogSearch) error {
+	scans := make([]*bundle.LogSearch, len(searches))
+	for i, s := range searches {
+		scans[i] = &bundle.LogSearch{Pattern: s.Pattern, MaxMatches: s.MaxMatches}
+	}
+
+	scanned := false
+	ds.logSignalsOnce.Do(func() {
+		ds.logSignals = ds.scanSignals(scans...)
+		scanned = true
+	})
+	if !scanned {
+		ds.bundle.ScanLogs(scans...)
+	}
+
+	for i, scan := range scans {
+		searches[i].Matches = nil
+		for _, m := range scan.Matches {
+			searches[i].Matches = append(searches[i].Matches, LogMatch{
+				Source:     m.Source,
+				Namespace:  m.Namespace,
+				PodName:    m.PodName,
+				LineNumber: m.LineNumber,
+				Line:       m.Line,
+			})
+		}
+	}
+	return nil
+}
+
+// GetTimeline returns the bundle's logs, events, dmesg and restarts merged in time order
+func (ds *BundleDataSource) GetTimeline(keep LineMatcher, maxPerFile int) (*Timeline, error) {
+	tl := ds.bundle.Timeline(keep, maxPerFile)
+
+	out := &Timeline{
+		Node:        tl.NodeName,
+		CollectedAt: tl.CollectedAt,
+		Untimed:     tl.Untimed,
+		Dropped:     tl.Dropped,
+	}
+	for _, e := range tl.Entries {
+		out.Entries = append(out.Entries, TimelineEntry{
+			Time:        e.Time,
+			Approximate: e.Approximate,
+			Kind:        e.Kind,
+			Level:       e.Level,
+			Node:        e.Node,
+			Namespace:   e.Namespace,
+			Pod:         e.Pod,
+			Component:   e.Component,
+			Message:     e.Message,
+			Source:      e.Source,
+			Path:        e.Path,
+			LineNumber:  e.LineNumber,
+			Previous:    e.Previous,
+		})
+	}
+	return out, nil
+}
+
+// ReadLogFile returns the lines of an inventoried bundle log file
+func (ds *BundleDataSource) ReadLogFile(path string) ([]string, error) {
+	logFile := ds.bundle.GetLogFile(path)
+	if logFile == nil {
+		return nil, fmt.Errorf("log file not found in bundle: %s", path)
+	}
+	content, err := ds.bundle.ReadLogFile(logFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log file: %w", err)
+	}
+
+	lines := strings.Split(string(content), "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, nil
+}
+
 // Close cleans up bundle resources
 func (ds *BundleDataSource) Close() error {
 	if ds.bundle != nil {
@@ -4,6 +4,9 @@
 package datasource
 
 import (
+	"time"
+
+	"github.com/Rancheroo/r8s/internal/bundle"
 	"github.com/Rancheroo/r8s/internal/rancher"
 )
 
@@ -31,6 +34,10 @@ type DataSource interface {
 	// GetServices returns services for the given project and namespace
 	GetServices(projectID, namespace string) ([]rancher.Service, error)
 
+	// GetConfigMaps returns configmaps for the given project and namespace
+	// (data only when collected as yaml/json)
+	GetConfigMaps(projectID, namespace string) ([]rancher.ConfigMap, error)
+
 	// GetCRDs returns CRDs for the given cluster
 	GetCRDs(clusterID string) ([]rancher.CRD, error)
 
@@ -64,9 +71,109 @@ type DataSource interface {
 	// GetEtcdHealth returns etcd cluster health (bundle mode only, returns nil for live)
 	GetEtcdHealth() (*EtcdHealth, error)
 
+	// GetEtcdStatus returns etcd members with endpoint status and health, quota and snapshots
+	// (nil if the bundle has no etcd directory)
+	GetEtcdStatus() (*EtcdStatus, error)
+
 	// GetSystemHealth returns system health metrics (bundle mode only, returns nil for live)
 	GetSystemHealth() (*SystemHealth, error)
 
+	// GetSystemResources returns the node's CPU, memory, filesystems, mounts, block devices,
+	// processes and limits with detected issues (nil if systeminfo was not collected)
+	GetSystemResources() (*SystemResources, error)
+
+	// GetKernelEvents returns OOM kills, hung tasks, I/O errors, conntrack drops and segfaults
+	// from dmesg, with OOM victims attributed to pods (nil if dmesg was not collected)
+	GetKernelEvents() (*KernelLog, error)
+
+	// GetHostConfig returns the node's OS and kernel with sysctl, kernel module, conflicting
+	// service and AppArmor checks against RKE2 requirements (nil if systeminfo was not collected)
+	GetHostConfig() (*HostConfig, error)
+
+	// GetControlPlane returns the static pod manifests with their flags, running containers and
+	// risky or notable settings (nil if rke2/pod-manifests was not collected, e.g. on agents)
+	GetControlPlane() (*ControlPlane, error)
+
+	// GetRKE2Config returns 50-rancher.yaml with secrets masked, the rke2 service units, the
+	// effective CNI, CIDRs, tls-san and snapshot settings, and unknown or invalid keys
+	// (nil if neither was collected)
+	GetRKE2Config() (*RKE2Config, error)
+
+	// GetWebhookHealth returns admission webhooks with their resolved backends
+	// and any correlated "failed calling webhook" events/logs
+	GetWebhookHealth() ([]WebhookHealth, error)
+
+	// GetAPIServiceHealth returns aggregated APIServices with their resolved backends
+	// and any correlated discovery/availability errors from events and logs
+	GetAPIServiceHealth() ([]APIServiceHealth, error)
+
+	// GetRoles returns ClusterRoles and Roles (rules only when collected as yaml/json)
+	GetRoles() ([]rancher.Role, error)
+
+	// GetRoleBindings returns ClusterRoleBindings and RoleBindings with their subjects
+	GetRoleBindings() ([]rancher.RoleBinding, error)
+
+	// GetHelmCharts returns HelmCharts correlated with their install jobs and workloads
+	GetHelmCharts() ([]HelmChartStatus, error)
+
+	// GetRollouts returns Deployments with their ReplicaSet revisions for the given
+	// namespace ("" for all namespaces)
+	GetRollouts(namespace string) ([]DeploymentRollout, error)
+
+	// GetHPAs returns HorizontalPodAutoscalers for the given project and namespace ("" for all)
+	// with their scale target and the state of the metrics APIs they depend on
+	GetHPAs(projectID, namespace string) ([]HPAStatus, error)
+
+	// GetNetworkPolicies returns NetworkPolicies with the bundle pods they select
+	// and connection errors logged by those pods
+	GetNetworkPolicies() ([]NetworkPolicyStatus, error)
+
+	// GetRuntime returns the container runtime state (crictl) of the bundle's node,
+	// cross-checked against the API (nil if crictl output was not collected)
+	GetRuntime() (*RuntimeStatus, error)
+
+	// GetImages returns every image used by workloads or present on the node, with
+	// classified pull failures (nil if the bundle has no image data)
+	GetImages() (*ImageReport, error)
+
+	// GetMetrics returns the bundle's Prometheus metrics scrapes (e.g. etcd) as series,
+	// with histogram percentile estimates and the results of built-in checks
+	GetMetrics() ([]MetricsSource, error)
+
+	// GetCertificates returns the RKE2 certificates with expiry at collection time, chain
+	// checks against the bundle's CAs, and the x509 log errors they likely explain
+	// (nil if rke2/certs was not collected)
+	GetCertificates() (*CertificateReport, error)
+
+	// GetNetworking returns the node's interfaces, routes, listening sockets, CNI config and
+	// iptables backend with the results of built-in checks (nil if networking/ was not collected)
+	GetNetworking() (*NetworkReport, error)
+
+	// GetResourceTypes returns every kubectl output file in the bundle, described by api-resources
+	GetResourceTypes() ([]ResourceType, error)
+
+	// GetResourceTable returns a kubectl output file as a generic table. name is a file name,
+	// resource name, kind, or short name ("hpa", "horizontalpodautoscalers", "HorizontalPodAutoscaler")
+	GetResourceTable(name string) (*ResourceTable, error)
+
+	// SearchLogs returns log lines (pod, system, journald) matching pattern, up to maxMatches.
+	// pattern is usually a *regexp.Regexp.
+	SearchLogs(pattern LineMatcher, maxMatches int) ([]LogMatch, error)
+
+	// ScanLogs runs several searches in one pass over the logs, filling in their Matches
+	ScanLogs(searches ...*LogSearch) error
+
+	// GetTimeline returns log lines selected by keep (the newest maxPerFile per log file),
+	// events, dmesg events and pod restarts with normalized timestamps, oldest first
+	GetTimeline(keep LineMatcher, maxPerFile int) (*Timeline, error)
+
+	// ReadLogFile returns the lines of a bundle log file by path (a TimelineEntry's Path),
+	// for logs that do not belong to a pod: journald units, syslog and kubelet logs
+	ReadLogFile(path string) ([]string, error)
+
+	// BundleRoot returns the root directory of the bundle, for rules that match bundle files
+	BundleRoot() string
+
 	// Mode returns a display string for the current mode (LIVE, BUNDLE, DEMO)
 	Mode() string
 
@@ -95,8 +202,828 @@ type EtcdHealth struct {
 	AlarmCount int
 }
 
+// Etcd issue types (see EtcdIssue.Type)
+const (
+	EtcdIssueNoLeader      = "no-leader"
+	EtcdIssueLearner       = "learner"
+	EtcdIssueMissingMember = "missing-member"
+	EtcdIssueFragmentation = "fragmentation"
+	EtcdIssueNoSpace       = "nospace-risk"
+	EtcdIssueNoSnapshot    = "no-snapshot"
+)
+
+// EtcdStatus is the etcd cluster as seen from the bundle's node
+type EtcdStatus struct {
+	Members            []EtcdMemberStatus
+	EtcdNodes          []string // kubectl nodes with the etcd role
+	QuotaBytes         int64
+	QuotaFromMetrics   bool           // false if QuotaBytes is etcd's 2 GiB default
+	Snapshots          []EtcdSnapshot // Newest first
+	SnapshotsCollected bool
+	SnapshotSchedule   string        // Effective etcd-snapshot-schedule-cron
+	SnapshotRetention  int           // Effective etcd-snapshot-retention
+	SnapshotsDisabled  bool          // etcd-disable-snapshots
+	LatestSnapshotAge  time.Duration // Relative to bundle collection, 0 if no snapshots
+	Issues             []EtcdIssue
+}
+
+// EtcdMemberStatus is an etcd member with its endpoint status and health
+type EtcdMemberStatus struct {
+	ID               string
+	Name             string
+	Status           string // "started" or "unstarted"
+	PeerURLs         []string
+	ClientURLs       []string
+	IsLearner        bool
+	HasStatus        bool
+	Version          string
+	DBSize           int64
+	DBSizeInUse      int64 // 0 if unknown
+	Fragmentation    float64
+	QuotaRatio       float64
+	IsLeader         bool
+	RaftTerm         uint64
+	RaftIndex        uint64
+	RaftAppliedIndex uint64
+	Errors           string
+	HasHealth        bool
+	Healthy          bool
+	Latency          time.Duration
+	HealthError      string
+}
+
+// EtcdSnapshot is an etcd snapshot file on the node
+type EtcdSnapshot struct {
+	Name string
+	Size int64
+	Time time.Time
+}
+
+// EtcdIssue is a problem found in the etcd status
+type EtcdIssue struct {
+	Type     string
+	Critical bool
+	Member   string
+	Detail   string
+}
+
 // SystemHealth represents system resource usage
 type SystemHealth struct {
 	MemoryUsedPercent float64
 	DiskUsedPercent   float64
 }
+
+// System issue types (see SystemIssue.Type)
+const (
+	SystemIssueDiskFull        = "disk-full"
+	SystemIssueInodes          = "inode-exhaustion"
+	SystemIssueSwap            = "swap-in-use"
+	SystemIssueLoad            = "high-load"
+	SystemIssueFileHandles     = "fd-saturation"
+	SystemIssueReadOnlyMount   = "read-only-mount"
+	SystemIssueZombies         = "zombie-processes"
+	SystemIssueUninterruptible = "blocked-processes"
+	SystemIssueUnitFailed      = "unit-failed"
+	SystemIssueUnitRestarting  = "unit-restart-loop"
+	SystemIssueUnitInactive    = "unit-inactive"
+	SystemIssueNoTimeSync      = "no-time-sync"
+)
+
+// SystemResources is the bundle node's CPU, memory, disks, processes and limits
+type SystemResources struct {
+	Hostname     string
+	Kernel       string
+	CPUModel     string
+	Cores        int
+	Uptime       string
+	Load1        float64
+	Load5        float64
+	Load15       float64
+	MemTotal     int64 // MiB
+	MemUsed      int64
+	MemAvailable int64
+	SwapTotal    int64
+	SwapUsed     int64
+
+	// Averages over the vmstat samples (0 if not collected)
+	VmstatSamples int
+	AvgRunnable   float64
+	AvgBlocked    float64
+	AvgIOWait     float64
+	AvgSteal      float64
+	MaxSwapIn     int // KiB/s
+	MaxSwapOut    int
+
+	Filesystems          []FilesystemUsage
+	ReadOnlyMounts       []string // "mountpoint (device, type)" for every ro mount, including snaps
+	BlockDevices         []BlockDevice
+	Processes            []ProcessInfo // Largest RSS first
+	Ulimits              []Ulimit
+	Units                []ServiceUnit // Watched units plus any failed or restarting unit
+	FileHandlesAllocated int64
+	FileHandlesMax       int64
+	Issues               []SystemIssue
+}
+
+// FilesystemUsage is a host filesystem's block and inode usage
+type FilesystemUsage struct {
+	Device      string
+	MountPoint  string
+	Type        string
+	Size        string
+	Used        string
+	Avail       string
+	UsePercent  int // -1 if unknown
+	Inodes      int64
+	IUsed       int64
+	IUsePercent int // -1 if unknown or not limited
+	Options     []string
+	ReadOnly    bool
+	RKE2Data    bool // Holds /var/lib/rancher
+}
+
+// BlockDevice is a disk, partition or loop device
+type BlockDevice struct {
+	Name        string
+	Size        string
+	ReadOnly    bool
+	Type        string
+	MountPoints []string
+}
+
+// ProcessInfo is a process from ps
+type ProcessInfo struct {
+	User    string
+	PID     int
+	CPU     float64
+	Memory  float64
+	RSS     int64 // KiB
+	Stat    string
+	Command string
+}
+
+// Ulimit is a resource limit
+type Ulimit struct {
+	Kind  string // "hard" or "soft"
+	Name  string
+	Flag  string
+	Value string
+}
+
+// ServiceUnit is a systemd unit; watched units are the host services RKE2 depends on
+type ServiceUnit struct {
+	Name          string
+	Active        string
+	Sub           string
+	Description   string
+	UnitFileState string
+	Watched       bool
+	Role          string
+	Journal       string // Bundle-relative journald log, empty if not collected
+	JournalTail   []string
+	Restarts      int
+}
+
+// SystemIssue is a problem found in the node's resources
+type SystemIssue struct {
+	Type     string
+	Critical bool
+	Subject  string
+	Detail   string
+	Evidence []string // Journald lines for unit issues
+}
+
+// Kernel event types (see KernelEvent.Type)
+const (
+	KernelEventOOMKill       = "oom-kill"
+	KernelEventHungTask      = "hung-task"
+	KernelEventIOError       = "io-error"
+	KernelEventConntrackFull = "conntrack-full"
+	KernelEventSegfault      = "segfault"
+)
+
+// OOM kill constraints (see KernelEvent.Constraint)
+const (
+	OOMConstraintMemcg = "memcg limit"
+	OOMConstraintNode  = "node out of memory"
+)
+
+// KernelLog is the bundle node's dmesg with the events found in it
+type KernelLog struct {
+	Node   string
+	Boot   time.Time // Zero if date/uptime were not collected
+	Lines  int
+	Events []KernelEvent
+}
+
+// KernelEvent is a notable kernel message; OOM kills carry the victim's container and pod
+type KernelEvent struct {
+	Type        string
+	Time        time.Time // Zero if unknown
+	Uptime      float64   // Seconds since boot; -1 for dmesg -T output
+	Process     string
+	PID         int
+	Device      string
+	Message     string
+	Lines       []string
+	Constraint  string
+	Trigger     string
+	MemcgPath   string
+	AnonRSS     int64 // KiB
+	ContainerID string
+	PodUID      string
+	Namespace   string
+	Pod         string
+	Container   string
+}
+
+// Host config check results and categories (see HostCheck)
+const (
+	HostCheckPass = "pass"
+	HostCheckWarn = "warn"
+	HostCheckFail = "fail"
+	HostCheckSkip = "skip"
+
+	HostCategorySysctl   = "sysctl"
+	HostCategoryModule   = "module"
+	HostCategoryPackage  = "package"
+	HostCategorySecurity = "security"
+)
+
+// HostConfig is the bundle node's OS configuration linted against RKE2 requirements
+type HostConfig struct {
+	Node          string
+	OS            string
+	Kernel        string
+	ProtectKernel bool // protect-kernel-defaults or CIS profile; enables the kubelet sysctl rules
+	Sysctls       int  // Counts of collected inputs
+	Modules       int
+	Packages      int
+	Checks        []HostCheck
+}
+
+// HostCheck is the result of one host config rule
+type HostCheck struct {
+	Category string
+	Name     string
+	Status   string
+	Expected string
+	Actual   string
+	Detail   string
+}
+
+// Control plane finding severities (see ControlPlaneFinding)
+const (
+	ControlPlaneDown    = "down"
+	ControlPlaneRisky   = "risky"
+	ControlPlaneNotable = "notable"
+)
+
+// ControlPlane is the bundle node's static pods from rke2/pod-manifests
+type ControlPlane struct {
+	Node       string
+	Components []StaticPod
+	Findings   []ControlPlaneFinding
+}
+
+// StaticPod is a control plane component with its command-line flags and running container
+type StaticPod struct {
+	Component      string
+	File           string
+	Namespace      string
+	PodName        string
+	Container      string
+	Image          string
+	Flags          []ControlPlaneFlag
+	ContainerState string // Empty if crictl did not list the container
+	Attempt        int
+	Logs           bool // Pod logs are in the bundle
+	PreviousLogs   bool
+}
+
+// ControlPlaneFlag is a static pod command-line flag
+type ControlPlaneFlag struct {
+	Name  string
+	Value string
+}
+
+// ControlPlaneFinding is a risky or notable control plane setting, or a component that is down
+type ControlPlaneFinding struct {
+	Component string
+	Flag      string // Empty for a component that is down
+	Severity  string
+	Detail    string
+}
+
+// RKE2 config issue types (see RKE2ConfigIssue)
+const (
+	RKE2ConfigIssueUnknownKey   = "unknown-key"
+	RKE2ConfigIssueServerOnly   = "server-only"
+	RKE2ConfigIssueInvalidValue = "invalid-value"
+)
+
+// RKE2Config is the bundle node's RKE2 configuration
+type RKE2Config struct {
+	Node     string
+	Role     string // "server" or "agent", empty if unknown
+	Found    bool   // 50-rancher.yaml was collected
+	Settings []RKE2ConfigSetting
+	Units    []RKE2ServiceUnit
+	Issues   []RKE2ConfigIssue
+
+	// Effective values (config or RKE2 default)
+	CNI               []string
+	ClusterCIDR       string
+	ServiceCIDR       string
+	ClusterDNS        string
+	TLSSANs           []string
+	SnapshotSchedule  string
+	SnapshotRetention int
+	SnapshotsDisabled bool
+}
+
+// RKE2ConfigSetting is a key of 50-rancher.yaml
+type RKE2ConfigSetting struct {
+	Key    string
+	Value  string // Secrets masked
+	Masked bool
+	Known  bool
+}
+
+// RKE2ServiceUnit is an rke2-server/rke2-agent systemd unit file
+type RKE2ServiceUnit struct {
+	Name             string
+	ExecStart        string // Secrets masked
+	EnvironmentFiles []string
+	Directives       []ControlPlaneFlag // [Service] Key=Value lines; secrets masked
+}
+
+// RKE2ConfigIssue is an unknown, misplaced or invalid config key
+type RKE2ConfigIssue struct {
+	Type   string
+	Key    string
+	Detail string
+}
+
+// WebhookHealth represents an admission webhook and the state of its backend
+type WebhookHealth struct {
+	ConfigName       string
+	Kind             string // "Mutating" or "Validating"
+	WebhookName      string
+	FailurePolicy    string // "Fail" or "Ignore"
+	PolicyInferred   bool   // Policy/service inferred from known defaults (table-only bundles)
+	ServiceNamespace string
+	ServiceName      string
+	ServiceFound     bool
+	Resolved         bool // Backing service (or URL) is known
+	HasReadyBackend  bool
+	Endpoints        []string // Ready "ip:port" addresses
+	BackendPods      []string // Pods behind the service (candidates if no endpoints)
+	Failures         []string // Correlated "failed calling webhook" event/log lines
+}
+
+// APIServiceHealth represents an aggregated APIService and the state of its backend
+type APIServiceHealth struct {
+	Name             string // "<version>.<group>"
+	ServiceNamespace string
+	ServiceName      string
+	Available        bool
+	Reason           string // e.g. "MissingEndpoints", "FailedDiscoveryCheck"
+	ServiceFound     bool
+	Endpoints        []string // Ready "ip:port" addresses
+	BackendPods      []string // Pods behind the service (candidates if no endpoints)
+	Failures         []string // Correlated "unable to retrieve the complete list of server APIs" lines
+}
+
+// HelmChartStatus represents a HelmChart with its install job and deployed workloads
+type HelmChartStatus struct {
+	Namespace       string
+	Name            string
+	Chart           string // Chart reference (empty for bundled RKE2 charts)
+	Version         string
+	TargetNamespace string
+	Bootstrap       bool
+	Failed          bool // HelmChart Failed condition
+	JobName         string
+	JobStatus       string // Complete, Running, Failed, or "" if the job is missing
+	JobCompletions  string
+	JobFailed       bool
+	InstallPods     []HelmInstallPod
+	InstallErrors   []string // Error lines from the newest install pod's log
+	Workloads       []HelmWorkload
+	WorkloadPods    []string
+	Problem         string // Short description of what is wrong, "" if healthy
+}
+
+// HelmInstallPod represents a pod created by a helm-install job
+type HelmInstallPod struct {
+	Name     string
+	Status   string
+	Restarts int
+	Age      string
+}
+
+// HelmWorkload represents a workload deployed by a HelmChart
+type HelmWorkload struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Ready     int
+	Desired   int
+}
+
+// DeploymentRollout represents a Deployment and its rollout history
+type DeploymentRollout struct {
+	Namespace   string
+	Name        string
+	Ready       int
+	Desired     int
+	Images      []string
+	ReplicaSets []rancher.ReplicaSet // Newest revision first
+	InProgress  bool                 // More than one revision still has pods
+	Stalled     bool                 // New ReplicaSet has 0 ready while an old one still serves
+	NewPods     []rancher.Pod        // Pods of the newest ReplicaSet
+}
+
+// HPAStatus represents a HorizontalPodAutoscaler, its scale target and what limits scaling
+type HPAStatus struct {
+	rancher.HorizontalPodAutoscaler
+	TargetFound     bool
+	TargetMissing   bool // Target kind was collected but the target does not exist
+	TargetReady     int
+	TargetDesired   int
+	PinnedAtMax     bool
+	UnknownMetrics  []string // Names of metrics showing <unknown>
+	MetricsAPIs     []string // APIServices serving the unknown metrics, e.g. "v1beta1.metrics.k8s.io"
+	UnavailableAPIs []string // Those APIServices that are unavailable, with reason
+	Problems        []string // Short descriptions of what is wrong, empty if healthy
+}
+
+// Runtime issue types (see RuntimeIssue.Type)
+const (
+	RuntimeIssueCrashLoop          = "crashloop"            // Latest attempt Exited after several restarts
+	RuntimeIssueMissingFromAPI     = "missing-from-api"     // Ready sandbox with no pod in the API
+	RuntimeIssueMissingFromRuntime = "missing-from-runtime" // Running pod on this node with no sandbox
+)
+
+// RuntimeStatus is the container runtime (containerd via crictl) state of the bundle's node
+type RuntimeStatus struct {
+	NodeName           string
+	Version            string   // e.g. "containerd v2.0.5-k3s2"
+	NotReadyConditions []string // e.g. "NetworkReady: NetworkPluginNotReady (cni plugin not initialized)"
+	Containers         []rancher.RuntimeContainer
+	Pods               []rancher.RuntimePod
+	Images             []rancher.RuntimeImage
+	ImageFSMountpoint  string
+	ImageFSUsedBytes   int64
+	ImageFSInodesUsed  int64
+	Issues             []RuntimeIssue
+}
+
+// RuntimeIssue is a runtime container or pod that disagrees with the API or is crash-looping
+type RuntimeIssue struct {
+	Type      string
+	Namespace string
+	Pod       string
+	Container string // Empty for pod-level issues
+	Attempt   int
+	Detail    string
+}
+
+// Image pull failure classes (see ImagePullFailure.Class)
+const (
+	PullFailureNotFound = "not-found"
+	PullFailureAuth     = "auth"
+	PullFailureTLS      = "tls"
+	PullFailureTimeout  = "timeout"
+	PullFailureUnknown  = "unknown"
+)
+
+// ImageReport is the bundle's image inventory
+type ImageReport struct {
+	ExpectedRegistries []string // system-default-registry if configured, else docker.io and the kube-system images' registries
+	Images             []ImageStatus
+	PullFailures       []ImagePullFailure
+}
+
+// ImageStatus is one image with where it is used and what is questionable about it
+type ImageStatus struct {
+	Image              string // Normalized reference, e.g. "docker.io/library/nginx:latest"
+	Registry           string
+	Repository         string
+	Tag                string // Empty if pinned by digest only
+	Digest             string
+	ID                 string // crictl IMAGE ID, empty if not on the node
+	Size               string // e.g. "29.2MB", empty if not on the node
+	OnNode             bool
+	RunningOnNode      bool
+	UsedBy             []string // e.g. "Deployment kube-system/coredns"
+	Latest             bool
+	Unused             bool // On the node but used by no workload or container
+	UnexpectedRegistry bool
+	PullFailures       []ImagePullFailure
+}
+
+// ImagePullFailure is a classified image pull error for a pod
+type ImagePullFailure struct {
+	Namespace string
+	Pod       string
+	Image     string
+	Class     string // PullFailure* constant
+	Message   string
+	Count     int
+}
+
+// Metric check results (see MetricCheck.Status)
+const (
+	MetricCheckOK       = "ok"
+	MetricCheckWarning  = "warning"
+	MetricCheckCritical = "critical"
+)
+
+// MetricsSource is one metrics scrape in the bundle
+type MetricsSource struct {
+	Component string // e.g. "etcd"
+	File      string // Relative to the bundle root
+	Series    []MetricSeries
+	Checks    []MetricCheck
+}
+
+// MetricSeries is one metric and label set. Histograms are a single series per label
+// set (without "le") carrying percentile estimates instead of a value.
+type MetricSeries struct {
+	Name      string
+	Type      string // counter, gauge, histogram, summary or untyped
+	Help      string
+	Labels    map[string]string
+	Value     float64
+	Histogram *MetricHistogram // nil unless Type is histogram
+}
+
+// MetricHistogram is a histogram with its percentiles estimated from the buckets
+type MetricHistogram struct {
+	Count   float64
+	Sum     float64
+	P50     float64 // NaN if there are no observations
+	P90     float64
+	P99     float64
+	Buckets []MetricBucket
+}
+
+// MetricBucket is a cumulative histogram bucket
+type MetricBucket struct {
+	UpperBound float64
+	Count      float64
+}
+
+// MetricCheck is a built-in check against a component's metrics
+type MetricCheck struct {
+	Name   string
+	Metric string
+	Status string // MetricCheck* constant
+	Value  string
+	Detail string
+}
+
+// Certificate expiry states (see CertificateStatus.Expiry)
+const (
+	CertValid       = "valid"
+	CertExpiring    = "expiring"
+	CertExpired     = "expired"
+	CertNotYetValid = "not-yet-valid"
+)
+
+// Certificate chain results (see CertificateStatus.Chain)
+const (
+	CertChainValid       = "valid"
+	CertChainIssuerMatch = "issuer-match"
+	CertChainInvalid     = "invalid"
+	CertChainUnknownCA   = "unknown-ca"
+	CertChainRoot        = "root"
+)
+
+// CertificateReport is the bundle's RKE2 certificates as of collection time
+type CertificateReport struct {
+	CollectedAt    time.Time
+	Certificates   []CertificateStatus
+	UnlinkedErrors []string // x509 errors in events and logs not explained by a bundle certificate
+}
+
+// CertificateStatus is one certificate with its expiry and chain state
+type CertificateStatus struct {
+	File           string // Relative to rke2/certs, e.g. "server/serving-kube-apiserver.crt"
+	Name           string // e.g. "serving-kube-apiserver"
+	Format         string // "pem" or "text" (openssl dump)
+	Subject        string
+	CommonName     string
+	Issuer         string
+	IssuerCN       string
+	DNSNames       []string
+	IPAddresses    []string
+	SerialNumber   string
+	NotBefore      time.Time
+	NotAfter       time.Time
+	IsCA           bool
+	ExtKeyUsage    []string
+	SubjectKeyID   string
+	AuthorityKeyID string
+	Expiry         string        // CertExpiry* constant at collection time
+	ExpiresIn      time.Duration // NotAfter relative to collection, negative once expired
+	Critical       bool          // Expired, not yet valid, or expiring within 7 days
+	Chain          string        // CertChain* constant
+	ChainCA        string
+	ChainDetail    string
+	LogErrors      []string // Correlated x509 errors from events and logs
+}
+
+// Network check results (see NetworkCheck.Status)
+const (
+	NetworkCheckOK       = "ok"
+	NetworkCheckWarning  = "warning"
+	NetworkCheckCritical = "critical"
+)
+
+// Interface kinds (see NetworkInterface.Kind)
+const (
+	InterfaceLoopback = "loopback"
+	InterfaceHost     = "host"
+	InterfacePod      = "pod"
+	InterfaceOverlay  = "overlay"
+	InterfaceVirtual  = "virtual"
+)
+
+// NetworkReport is the bundle node's network configuration
+type NetworkReport struct {
+	Node            string
+	ClusterCIDR     string
+	ServiceCIDR     string
+	IsServer        bool
+	IsEtcd          bool
+	Uplink          string // Interface of the default route
+	PodMTU          int    // Expected pod interface MTU, 0 if unknown
+	PodMTUSource    string // Where PodMTU came from, e.g. "tunnel wireguard.cali"
+	IptablesBackend string // "nf_tables", "legacy", or "" if no rules were collected
+	IptablesHeader  string
+	IptablesRules   int
+	NftTables       []string
+	Interfaces      []NetworkInterface
+	Routes          []NetworkRoute
+	Rules           []string // `ip rule` lines
+	Sockets         []ListeningSocket
+	CNI             []CNIConfig
+	Checks          []NetworkCheck
+}
+
+// NetworkInterface is a network link with its addresses
+type NetworkInterface struct {
+	Name      string
+	Peer      string // e.g. "if2" for a pod veth
+	Flags     []string
+	MTU       int
+	State     string
+	Addresses []string
+	Kind      string // Interface* constant
+}
+
+// NetworkRoute is a route from any routing table
+type NetworkRoute struct {
+	Type        string
+	Destination string
+	Gateway     string
+	Device      string
+	Table       string
+	Protocol    string
+	Scope       string
+	Source      string
+	Text        string // Formatted like `ip route`
+}
+
+// ListeningSocket is a listening TCP or bound UDP socket
+type ListeningSocket struct {
+	Protocol string
+	Address  string
+	Port     int
+	Process  string
+	PID      int
+}
+
+// CNIConfig is a CNI config file
+type CNIConfig struct {
+	File    string
+	Name    string
+	Plugins []string
+	MTU     int // 0 if auto-detected
+}
+
+// NetworkCheck is the result of a built-in network check
+type NetworkCheck struct {
+	Name   string
+	Status string // NetworkCheck* constant
+	Detail string
+}
+
+// ResourceType describes a kubectl output file in the bundle
+type ResourceType struct {
+	File       string // File name under rke2/kubectl, e.g. "hpa"
+	Resource   string // Plural resource name from api-resources ("" if unknown)
+	Kind       string
+	APIVersion string
+	Namespaced bool
+	ShortNames []string
+	Count      int  // Table rows
+	IsTable    bool // false for version output, "No resources found" and kubectl errors
+}
+
+// ResourceTable is a kubectl output file as header columns and rows
+type ResourceTable struct {
+	ResourceType
+	Headers []string
+	Rows    [][]string
+	Message string // File content when it is not a table
+}
+
+// NetworkPolicyStatus represents a NetworkPolicy and the pods it isolates
+type NetworkPolicyStatus struct {
+	Namespace        string
+	Name             string
+	SelectorText     string   // e.g. "k8s-app=kube-dns" or "<none>" (all pods)
+	PolicyTypes      []string // Empty if only the table was collected
+	IngressRules     int
+	EgressRules      int
+	HasSpec          bool
+	SelectedPods     []string
+	Exact            bool   // SelectedPods is complete; otherwise matched via workload/service selectors
+	Resolution       string // How SelectedPods was determined
+	DenyIngress      bool
+	DenyEgress       bool
+	AllowAll         bool
+	Inferred         bool       // Deny/allow-all inferred from the policy name
+	DefaultDeny      bool       // Isolates every pod in the namespace with no allowed traffic
+	ConnectionErrors []LogMatch // Connection refused/timeout lines logged by selected pods
+}
+
+// ConnectionErrorPattern matches log lines reporting refused or timed-out connections,
+// which a NetworkPolicy isolating the pod (or its peer) can cause
+var ConnectionErrorPattern LineMatcher = bundle.ConnectionErrorPattern
+
+// LineMatcher selects the log lines SearchLogs returns
+type LineMatcher interface {
+	MatchString(s string) bool
+}
+
+// LogMatch represents a single log line matched by SearchLogs
+type LogMatch struct {
+	Source     string // "namespace/pod" for pod logs, file name otherwise
+	Namespace  string // Pod logs only
+	PodName    string // Pod logs only
+	LineNumber int
+	Line       string
+}
+
+// LogSearch is one search of a ScanLogs pass
+type LogSearch struct {
+	Pattern    LineMatcher
+	MaxMatches int
+	Matches    []LogMatch // Filled in by ScanLogs
+}
+
+// Timeline entry kinds (see TimelineEntry.Kind)
+const (
+	TimelineLog     = "log"
+	TimelineEvent   = "event"
+	TimelineKernel  = "kernel"
+	TimelineRestart = "restart"
+)
+
+// Timeline entry levels (see TimelineEntry.Level)
+const (
+	TimelineLevelError   = "error"
+	TimelineLevelWarning = "warning"
+	TimelineLevelInfo    = "info"
+)
+
+// Timeline is the bundle's timestamped records from every source, oldest first
+type Timeline struct {
+	Node        string
+	CollectedAt time.Time // Zero if unknown; events and restarts then have no time
+	Entries     []TimelineEntry
+	Untimed     int // Kept log lines without a recognizable timestamp
+	Dropped     int // Older kept log lines dropped by the per-file cap
+}
+
+// TimelineEntry is one timestamped log line, event, kernel event or pod restart
+type TimelineEntry struct {
+	Time        time.Time
+	Approximate bool   // Derived from a kubectl age ("5m", "14d")
+	Kind        string // TimelineLog, TimelineEvent, TimelineKernel or TimelineRestart
+	Level       string // Empty for log lines
+	Node        string
+	Namespace   string
+	Pod         string
+	Component   string // Log file or unit, event source, "kernel" or "kubelet"
+	Message     string
+	Source      string // Log lines: "namespace/pod" for pod logs, file name otherwise
+	Path        string // Log lines: the file, for ReadLogFile
+	LineNumber  int    // Log lines: 1-based line in the file
+	Previous    bool   // Log lines: from a -previous pod log
+}
@@ -0,0 +1,346 @@
+// Package knowledge is a knowledge base of known error signatures: what the error means, its
+// likely cause, how to fix it and where to read more. The built-in entries are embedded in the
+// binary; users add or override entries with YAML files in ~/.r8s/kb.d/ and the bundle's kb.d/.
+package knowledge
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"os"
+	"regexp"
+	"regexp/syntax"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Rancheroo/r8s/internal/userfiles"
+)
+
+// Entry severities, used when a signature is found in logs or events no dashboard item covers
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// BuiltinSource is the Source of entries embedded in the binary
+const BuiltinSource = "built-in"
+
+// minLiteralLength is the shortest literal worth prefiltering lines with
+const minLiteralLength = 3
+
+//go:embed knowledge.yaml
+var builtinData []byte
+
+// Entry is a known issue and the error signatures that identify it
+type Entry struct {
+	ID          string   `yaml:"id"`
+	Title       string   `yaml:"title"`
+	Severity    string   `yaml:"severity"`    // critical, warning or info (default warning)
+	Signatures  []string `yaml:"signatures"`  // Regexes, case-insensitive, over log lines, events and item text
+	Explanation string   `yaml:"explanation"` // What the error means
+	Cause       string   `yaml:"cause"`       // Likely cause
+	Remediation []string `yaml:"remediation"` // Steps to fix it, in order
+	Links       []string `yaml:"links"`       // Documentation URLs
+
+	Source string `yaml:"-"` // BuiltinSource or the file the entry was loaded from
+
+	patterns []*regexp.Regexp
+}
+
+// Problem is a knowledge base file or entry that failed to load or validate
+type Problem = userfiles.Problem
+
+// Base holds the built-in entries, overridden and extended by user entries
+type Base struct {
+	Version  string // Version of the built-in knowledge base
+	Entries  []*Entry
+	Problems []Problem
+	Files    []string // User files read
+
+	pattern    *regexp.Regexp // Any signature of any entry
+	literals   []string       // Lowercase; a line matching a prefiltered signature contains one
+	unfiltered *regexp.Regexp // Signatures without a usable literal, nil if none
+}
+
+// File is the format of knowledge base files
+type File struct {
+	Version string   `yaml:"version"`
+	Entries []*Entry `yaml:"entries"`
+}
+
+// DefaultDirs returns the existing user knowledge base directories: ~/.r8s/kb.d and
+// <bundleRoot>/kb.d
+func DefaultDirs(bundleRoot string) []string {
+	return userfiles.DefaultDirs(bundleRoot, "kb.d")
+}
+
+// Load returns the built-in entries plus the entries of user files and directories (every
+// *.yaml and *.yml file, in name order). A user entry with the ID of a built-in entry replaces
+// it; invalid entries and IDs defined twice by user files are reported as problems.
+func Load(paths ...string) *Base {
+	kb := &Base{}
+	index := make(map[string]int) // entry ID -> position in Entries
+
+	// The package tests keep the built-in file free of problems
+	builtin, err := Parse(builtinData)
+	if err != nil {
+		kb.Problems = append(kb.Problems, Problem{File: BuiltinSource, Message: err.Error()})
+		builtin = &File{}
+	}
+	kb.Version = builtin.Version
+	for _, e := range builtin.Entries {
+		e.Source = BuiltinSource
+		if msgs := e.Validate(); len(msgs) > 0 {
+			for _, msg := range msgs {
+				kb.Problems = append(kb.Problems, Problem{File: BuiltinSource, ID: e.ID, Message: msg})
+			}
+			continue
+		}
+		index[e.ID] = len(kb.Entries)
+		kb.Entries = append(kb.Entries, e)
+	}
+
+	for _, path := range paths {
+		files, err := userfiles.YAMLFiles(path)
+		if err != nil {
+			kb.Problems = append(kb.Problems, Problem{File: path, Message: err.Error()})
+			continue
+		}
+		for _, name := range files {
+			kb.Files = append(kb.Files, name)
+			data, err := os.ReadFile(name)
+			if err != nil {
+				kb.Problems = append(kb.Problems, Problem{File: name, Message: err.Error()})
+				continue
+			}
+			f, err := Parse(data)
+			if err != nil {
+				kb.Problems = append(kb.Problems, Problem{File: name, Message: err.Error()})
+				continue
+			}
+			for _, e := range f.Entries {
+				e.Source = name
+				if msgs := e.Validate(); len(msgs) > 0 {
+					for _, msg := range msgs {
+						kb.Problems = append(kb.Problems, Problem{File: name, ID: e.ID, Message: msg})
+					}
+					continue
+				}
+				i, ok := index[e.ID]
+				switch {
+				case !ok:
+					index[e.ID] = len(kb.Entries)
+					kb.Entries = append(kb.Entries, e)
+				case kb.Entries[i].Source == BuiltinSource:
+					kb.Entries[i] = e
+				default:
+					kb.Problems = append(kb.Problems, Problem{File: name, ID: e.ID, Message: "duplicate entry id, already defined in " + kb.Entries[i].Source})
+				}
+			}
+		}
+	}
+
+	var all, unfiltered []string
+	for _, e := range kb.Entries {
+		for _, sig := range e.Signatures {
+			all = append(all, sig)
+			if lits := signatureLiterals(sig); lits != nil {
+				kb.literals = append(kb.literals, lits...)
+			} else {
+				unfiltered = append(unfiltered, sig)
+			}
+		}
+	}
+	if len(all) > 0 {
+		kb.pattern = alternation(all)
+	}
+	if len(unfiltered) > 0 {
+		kb.unfiltered = alternation(unfiltered)
+	}
+	return kb
+}
+
+// Parse decodes a knowledge base file: entries under "entries:" and an optional version.
+// Unknown keys are errors so misspelled settings are not silently ignored, and so are empty
+// list items.
+func Parse(data []byte) (*File, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var f File
+	if err := dec.Decode(&f); err != nil {
+		return nil, fmt.Errorf("invalid knowledge base file: %w", err)
+	}
+	if len(f.Entries) == 0 {
+		return nil, fmt.Errorf("no entries in file")
+	}
+	for i, e := range f.Entries {
+		if e == nil {
+			return nil, fmt.Errorf("empty entry at index %d", i)
+		}
+	}
+	return &f, nil
+}
+
+// Validate checks the entry and compiles its signatures, returning one message per problem
+func (e *Entry) Validate() []string {
+	var msgs []string
+	add := func(format string, args ...interface{}) {
+		msgs = append(msgs, fmt.Sprintf(format, args...))
+	}
+
+	if e.ID == "" {
+		add("id is required")
+	}
+	if e.Title == "" {
+		add("title is required")
+	}
+	switch e.Severity {
+	case SeverityCritical, SeverityWarning, SeverityInfo:
+	case "":
+		e.Severity = SeverityWarning
+	default:
+		add("invalid severity %q (critical, warning or info)", e.Severity)
+	}
+	if len(e.Signatures) == 0 {
+		add("at least one signature is required")
+	}
+	if e.Explanation == "" && len(e.Remediation) == 0 {
+		add("explanation or remediation is required")
+	}
+	for _, link := range e.Links {
+		if !strings.HasPrefix(link, "https://") && !strings.HasPrefix(link, "http://") {
+			add("link must be an http(s) URL: %q", link)
+		}
+	}
+
+	e.patterns = nil
+	for _, sig := range e.Signatures {
+		re, err := regexp.Compile("(?i)" + sig)
+		if err != nil {
+			add("invalid signature regex %q: %v", sig, err)
+			continue
+		}
+		e.patterns = append(e.patterns, re)
+	}
+	return msgs
+}
+
+// Matches reports whether any signature of the entry matches text
+func (e *Entry) Matches(text string) bool {
+	for _, re := range e.patterns {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchString reports whether any signature matches line, so the knowledge base can be
+// passed to a log search. Lines are prefiltered by the signatures' literal text, which is
+// much faster than running one large case-insensitive alternation over every line.
+func (kb *Base) MatchString(line string) bool {
+	if kb == nil || kb.pattern == nil {
+		return false
+	}
+	if kb.unfiltered != nil && kb.unfiltered.MatchString(line) {
+		return true
+	}
+	lower := strings.ToLower(line)
+	for _, lit := range kb.literals {
+		if strings.Contains(lower, lit) {
+			return kb.pattern.MatchString(line)
+		}
+	}
+	return false
+}
+
+// Match returns the entries with a signature matching text
+func (kb *Base) Match(text string) []*Entry {
+	if !kb.MatchString(text) {
+		return nil
+	}
+	var matched []*Entry
+	for _, e := range kb.Entries {
+		if e.Matches(text) {
+			matched = append(matched, e)
+		}
+	}
+	return matched
+}
+
+// Lookup returns the entry with the given ID, or nil
+func (kb *Base) Lookup(id string) *Entry {
+	for _, e := range kb.Entries {
+		if e.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// alternation compiles a case-insensitive regex matching any of the (valid) signatures
+func alternation(signatures []string) *regexp.Regexp {
+	return regexp.MustCompile("(?i)(?:" + strings.Join(signatures, ")|(?:") + ")")
+}
+
+// signatureLiterals returns lowercase strings one of which occurs in every line the signature
+// matches, or nil if the signature has no such literals
+func signatureLiterals(sig string) []string {
+	re, err := syntax.Parse(sig, syntax.Perl|syntax.FoldCase)
+	if err != nil {
+		return nil
+	}
+	return requiredLiterals(re)
+}
+
+// requiredLiterals returns literals one of which occurs in every match of re, preferring
+// long ones, or nil if there are none of at least minLiteralLength bytes
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		lit := strings.ToLower(string(re.Rune))
+		if len(lit) < minLiteralLength {
+			return nil
+		}
+		return []string{lit}
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return requiredLiterals(re.Sub[0])
+		}
+	case syntax.OpConcat:
+		var best []string
+		for _, sub := range re.Sub {
+			lits := requiredLiterals(sub)
+			if lits != nil && (best == nil || shortest(lits) > shortest(best)) {
+				best = lits
+			}
+		}
+		return best
+	case syntax.OpAlternate:
+		var all []string
+		for _, sub := range re.Sub {
+			lits := requiredLiterals(sub)
+			if lits == nil {
+				return nil
+			}
+			all = append(all, lits...)
+		}
+		return all
+	}
+	return nil
+}
+
+// shortest returns the length of the shortest string in list
+func shortest(list []string) int {
+	n := -1
+	for _, s := range list {
+		if n < 0 || len(s) < n {
+			n = len(s)
+		}
+	}
+	return n
+}
@@ -0,0 +1,176 @@
+package knowledge
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestBuiltin tests that the embedded knowledge base is valid and recognizes common errors
+func TestBuiltin(t *testing.T) {
+	kb := Load()
+	if len(kb.Problems) > 0 {
+		t.Fatalf("built-in knowledge base has problems: %v", kb.Problems)
+	}
+	if kb.Version == "" {
+		t.Error("built-in knowledge base has no version")
+	}
+	if kb.unfiltered != nil {
+		t.Errorf("built-in signatures without a literal prefilter: %s", kb.unfiltered)
+	}
+	for _, e := range kb.Entries {
+		if e.Explanation == "" || e.Cause == "" || len(e.Remediation) == 0 || len(e.Links) == 0 {
+			t.Errorf("entry %s is missing explanation, cause, remediation or links", e.ID)
+		}
+	}
+
+	tests := []struct {
+		line string
+		want string
+	}{
+		{`{"level":"warn","msg":"etcdserver: mvcc: database space exceeded"}`, "etcd-db-space-exceeded"},
+		{`reflector.go: x509: certificate has expired or is not yet valid: current time 2025-12-04`, "x509-certificate-expired"},
+		{`failed to create fsnotify watcher: too many open files`, "too-many-open-files"},
+		{`"Container runtime network not ready" err="network plugin returns error: cni plugin not initialized"`, "cni-not-initialized"},
+		{`DNSConfigForming: Nameserver limits were exceeded, some nameservers have been omitted`, "dns-nameserver-limit"},
+		{`dial tcp 10.0.0.1:9345: connect: connection refused`, "apiserver-unreachable"},
+		{`lookup api.example.com on 10.43.0.10:53: read udp 10.42.1.5:40000->10.43.0.10:53: i/o timeout`, "dns-resolution-failing"},
+	}
+	for _, tt := range tests {
+		matched := kb.Match(tt.line)
+		found := false
+		for _, e := range matched {
+			if e.ID == tt.want {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("Match(%q) = %v, want %s", tt.line, entryIDs(matched), tt.want)
+		}
+	}
+	if got := kb.Match("Started container nginx"); len(got) > 0 {
+		t.Errorf("Match(unrelated line) = %v, want none", entryIDs(got))
+	}
+}
+
+// TestLoadUserEntries tests adding, overriding and validating user entries
+func TestLoadUserEntries(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("10-team.yaml", `entries:
+- id: multipath-longhorn
+  title: multipathd claims Longhorn devices
+  signatures: ['multipathd.*sd[a-z]+: add path']
+  explanation: multipathd grabs Longhorn's iSCSI devices.
+  remediation: [Blacklist the devices in /etc/multipath.conf]
+  links: [https://longhorn.io/kb/troubleshooting-volume-with-multipath/]
+- id: disk-full
+  title: Disk full (team runbook)
+  severity: critical
+  signatures: ['no space left on device']
+  remediation: [Follow https://wiki.example.com/disk-full]
+- id: broken
+  title: Broken
+  severity: urgent
+  signatures: ['(unclosed']
+`)
+	write("20-dup.yml", `entries:
+- id: multipath-longhorn
+  title: Duplicate
+  signatures: [multipathd]
+  explanation: duplicate
+`)
+	write("30-typo.yaml", `entries:
+- id: typo
+  title: Misspelled key
+  signature: [oops]
+`)
+
+	kb := Load(dir)
+	if len(kb.Files) != 3 {
+		t.Errorf("files = %v, want 3", kb.Files)
+	}
+	if e := kb.Lookup("multipath-longhorn"); e == nil || e.Severity != SeverityWarning || !strings.HasSuffix(e.Source, "10-team.yaml") {
+		t.Errorf("user entry = %+v, want added with default severity", e)
+	}
+	if e := kb.Lookup("disk-full"); e == nil || e.Title != "Disk full (team runbook)" {
+		t.Errorf("disk-full = %+v, want the user entry to replace the built-in one", e)
+	}
+	if got := entryIDs(kb.Match("multipathd: sdb: add path")); len(got) != 1 || got[0] != "multipath-longhorn" {
+		t.Errorf("Match(multipath line) = %v", got)
+	}
+
+	want := []string{
+		"broken: invalid severity",
+		"broken: explanation or remediation is required",
+		"broken: invalid signature regex",
+		"multipath-longhorn: duplicate entry id",
+		"30-typo.yaml: invalid knowledge base file",
+	}
+	if len(kb.Problems) != len(want) {
+		t.Errorf("got %d problems, want %d: %v", len(kb.Problems), len(want), kb.Problems)
+	}
+	for _, w := range want {
+		found := false
+		for _, p := range kb.Problems {
+			if strings.Contains(p.String(), w) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing problem %q in %v", w, kb.Problems)
+		}
+	}
+}
+
+// TestLoadEmptyEntry tests that an empty list item is reported instead of loaded as a nil entry
+func TestLoadEmptyEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.yaml")
+	if err := os.WriteFile(path, []byte("entries:\n  -\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	kb := Load(path)
+	if len(kb.Problems) != 1 || !strings.Contains(kb.Problems[0].String(), "empty entry at index 0") {
+		t.Errorf("problems = %v", kb.Problems)
+	}
+	if kb.Lookup("disk-full") == nil {
+		t.Error("built-in entries were not loaded")
+	}
+}
+
+// TestSignatureLiterals tests the literals used to prefilter log lines
+func TestSignatureLiterals(t *testing.T) {
+	tests := []struct {
+		sig  string
+		want []string
+	}{
+		{`too many open files`, []string{"too many open files"}},
+		{`ErrImagePull|ImagePullBackOff`, []string{"errimagepull", "imagepullbackoff"}},
+		{`lookup \S+ on \S+:53: .*(i/o timeout|server misbehaving)`, []string{"i/o timeout", "server misbehaving"}},
+		{`0/\d+ nodes are available`, []string{" nodes are available"}},
+		{`(a|bc)+`, nil},
+		{`.*`, nil},
+	}
+	for _, tt := range tests {
+		got := signatureLiterals(tt.sig)
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") || (got == nil) != (tt.want == nil) {
+			t.Errorf("signatureLiterals(%q) = %q, want %q", tt.sig, got, tt.want)
+		}
+	}
+}
+
+// entryIDs returns the IDs of entries
+func entryIDs(entries []*Entry) []string {
+	var ids []string
+	for _, e := range entries {
+		ids = append(ids, e.ID)
+	}
+	return ids
+}
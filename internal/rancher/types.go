@@ -1,7 +1,7 @@
 // Package rancher defines the data structures for Rancher API responses and Kubernetes
 // resources. It includes types for clusters, projects, namespaces, pods, deployments,
-// services, and CustomResourceDefinitions (CRDs). These types are used for JSON
-// unmarshaling of Rancher v3 API responses and Kubernetes API proxy responses.
+// services, admission webhooks, APIServices, RBAC, HelmCharts, NetworkPolicies, ConfigMaps, ReplicaSets, HorizontalPodAutoscalers, CRI runtime containers/pods/images, and CustomResourceDefinitions (CRDs). These types are
+// used for JSON unmarshaling of Rancher v3 API responses and Kubernetes API proxy responses.
 package rancher
 
 import (
@@ -215,6 +215,7 @@ type Pod struct {
 	KubectlIP             string   `json:"-"` // Pod IP from kubectl output
 	KubectlReadinessGates string   `json:"-"` // e.g., "<none>", "1/1"
 	KubectlRestarts       int      `json:"-"` // Restart count from kubectl
+	KubectlLastRestart    string   `json:"-"` // Age of the last restart, e.g. "4m53s" (empty if not shown)
 	KubectlEvents         []string `json:"-"` // Recent events for this pod
 }
 
@@ -344,6 +345,7 @@ type Service struct {
 	ClusterIP   string            `json:"clusterIp"`
 	Kind        string            `json:"kind"` // Service type (ClusterIP, NodePort, etc.)
 	Ports       []ServicePort     `json:"ports,omitempty"`
+	Selector    map[string]string `json:"selector,omitempty"`
 	Created     time.Time         `json:"created"`
 	Labels      map[string]string `json:"labels,omitempty"`
 	Annotations map[string]string `json:"annotations,omitempty"`
@@ -366,3 +368,218 @@ type Event struct {
 	PodName    string `json:"podName"`    // Extracted pod name from Object field
 	ObjectKind string `json:"objectKind"` // Extracted from Object (e.g., "pod", "node")
 }
+
+// Endpoints represents the ready addresses behind a Kubernetes service
+type Endpoints struct {
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Addresses []string `json:"addresses"` // "ip:port" entries; empty when kubectl shows <none>
+}
+
+// WebhookConfiguration represents a Mutating or ValidatingWebhookConfiguration
+type WebhookConfiguration struct {
+	Name         string    `json:"name"`
+	Kind         string    `json:"kind"` // "Mutating" or "Validating"
+	WebhookCount int       `json:"webhookCount"`
+	Age          string    `json:"age"`
+	Webhooks     []Webhook `json:"webhooks,omitempty"` // Only populated when the bundle has full YAML/JSON output
+}
+
+// Webhook represents a single admission webhook entry within a configuration
+type Webhook struct {
+	Name             string `json:"name"`
+	FailurePolicy    string `json:"failurePolicy"` // "Fail" or "Ignore"
+	ServiceNamespace string `json:"serviceNamespace,omitempty"`
+	ServiceName      string `json:"serviceName,omitempty"`
+	ServicePort      int    `json:"servicePort,omitempty"`
+	URL              string `json:"url,omitempty"` // Set instead of a service for external webhooks
+}
+
+// PolicyRule represents a single RBAC rule within a Role or ClusterRole
+type PolicyRule struct {
+	APIGroups       []string `json:"apiGroups,omitempty"`
+	Resources       []string `json:"resources,omitempty"`
+	ResourceNames   []string `json:"resourceNames,omitempty"`
+	Verbs           []string `json:"verbs"`
+	NonResourceURLs []string `json:"nonResourceURLs,omitempty"`
+}
+
+// Role represents an RBAC Role (namespaced) or ClusterRole (Namespace is empty)
+type Role struct {
+	Kind      string       `json:"kind"` // "Role" or "ClusterRole"
+	Namespace string       `json:"namespace,omitempty"`
+	Name      string       `json:"name"`
+	CreatedAt string       `json:"createdAt,omitempty"`
+	Rules     []PolicyRule `json:"rules,omitempty"` // Only populated when the bundle has full YAML/JSON output
+}
+
+// RoleRef identifies the role granted by a binding
+type RoleRef struct {
+	Kind string `json:"kind"` // "Role" or "ClusterRole"
+	Name string `json:"name"`
+}
+
+// Subject represents a User, Group or ServiceAccount referenced by a binding
+type Subject struct {
+	Kind      string `json:"kind"` // "User", "Group" or "ServiceAccount"
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"` // ServiceAccounts only
+}
+
+// RoleBinding represents an RBAC RoleBinding or ClusterRoleBinding (Namespace is empty)
+type RoleBinding struct {
+	Kind      string    `json:"kind"` // "RoleBinding" or "ClusterRoleBinding"
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name"`
+	RoleRef   RoleRef   `json:"roleRef"`
+	Subjects  []Subject `json:"subjects,omitempty"`
+	Age       string    `json:"age,omitempty"`
+}
+
+// HelmChart represents a helm.cattle.io/v1 HelmChart, used by RKE2 to install its add-ons
+type HelmChart struct {
+	Namespace       string `json:"namespace"`
+	Name            string `json:"name"`
+	Repo            string `json:"repo,omitempty"`
+	Chart           string `json:"chart,omitempty"`
+	Version         string `json:"version,omitempty"`
+	TargetNamespace string `json:"targetNamespace,omitempty"` // Defaults to the HelmChart namespace
+	Bootstrap       bool   `json:"bootstrap,omitempty"`
+	Failed          bool   `json:"failed"`            // Status condition Failed=True
+	JobName         string `json:"jobName,omitempty"` // helm-install-<name> job
+}
+
+// Job represents a batch/v1 Job
+type Job struct {
+	Namespace   string   `json:"namespace"`
+	Name        string   `json:"name"`
+	Status      string   `json:"status,omitempty"` // Complete, Running, Failed (newer kubectl only)
+	Completions string   `json:"completions"`      // e.g. "1/1"
+	Duration    string   `json:"duration,omitempty"`
+	Age         string   `json:"age,omitempty"`
+	Images      []string `json:"images,omitempty"` // -o wide only
+}
+
+// APIService represents an apiregistration.k8s.io/v1 APIService
+type APIService struct {
+	Name             string `json:"name"` // "<version>.<group>", e.g. "v1beta1.metrics.k8s.io"
+	ServiceNamespace string `json:"serviceNamespace,omitempty"`
+	ServiceName      string `json:"serviceName,omitempty"` // Empty for Local (built-in) APIServices
+	Available        bool   `json:"available"`
+	Reason           string `json:"reason,omitempty"` // e.g. "MissingEndpoints", "FailedDiscoveryCheck"
+	Age              string `json:"age,omitempty"`
+}
+
+// RuntimeContainer is a container as the node's container runtime reports it (crictl ps -a),
+// with usage from crictl stats when the container is running
+type RuntimeContainer struct {
+	ID          string  `json:"id"`
+	Image       string  `json:"image"` // Image ID prefix
+	Created     string  `json:"created"`
+	State       string  `json:"state"` // Running, Exited, Created, Unknown
+	Name        string  `json:"name"`
+	Attempt     int     `json:"attempt"` // Restart attempt (kubectl RESTARTS counts these)
+	PodID       string  `json:"podId"`
+	PodName     string  `json:"podName"`
+	Namespace   string  `json:"namespace"`
+	HasStats    bool    `json:"hasStats"`
+	CPUPercent  float64 `json:"cpuPercent,omitempty"`
+	MemoryBytes int64   `json:"memoryBytes,omitempty"`
+	Memory      string  `json:"memory,omitempty"` // As printed, e.g. "29.42MB"
+}
+
+// RuntimePod is a pod sandbox as the node's container runtime reports it (crictl pods)
+type RuntimePod struct {
+	ID        string `json:"id"`
+	Created   string `json:"created"`
+	State     string `json:"state"` // Ready or NotReady
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Attempt   int    `json:"attempt"`
+	Runtime   string `json:"runtime,omitempty"`
+}
+
+// RuntimeImage is an image in the node's container runtime (crictl images)
+type RuntimeImage struct {
+	Image string `json:"image"`
+	Tag   string `json:"tag"`
+	ID    string `json:"id"`
+	Size  string `json:"size"`
+}
+
+// APIResource is one entry of kubectl api-resources (API discovery)
+type APIResource struct {
+	Name       string   `json:"name"` // Plural resource name, e.g. "horizontalpodautoscalers"
+	ShortNames []string `json:"shortNames,omitempty"`
+	APIVersion string   `json:"apiVersion"` // "v1" or "<group>/<version>"
+	Namespaced bool     `json:"namespaced"`
+	Kind       string   `json:"kind"`
+}
+
+// NetworkPolicy represents a networking.k8s.io/v1 NetworkPolicy
+type NetworkPolicy struct {
+	Namespace    string            `json:"namespace"`
+	Name         string            `json:"name"`
+	PodSelector  map[string]string `json:"podSelector,omitempty"` // matchLabels; empty selects every pod in the namespace
+	SelectorText string            `json:"selectorText"`          // As printed by kubectl, e.g. "k8s-app=kube-dns" or "<none>"
+	PolicyTypes  []string          `json:"policyTypes,omitempty"` // Ingress and/or Egress; empty if only the table was collected
+	IngressRules int               `json:"ingressRules"`
+	EgressRules  int               `json:"egressRules"`
+	AllowAll     bool              `json:"allowAll,omitempty"` // Has a rule with no peers or ports (matches all traffic)
+	HasSpec      bool              `json:"-"`                  // Full spec collected (-o yaml/json), not just the table
+	Age          string            `json:"age,omitempty"`
+}
+
+// ConfigMap represents a v1 ConfigMap
+type ConfigMap struct {
+	Namespace string            `json:"namespace"`
+	Name      string            `json:"name"`
+	DataCount int               `json:"dataCount"`      // DATA column; len(Keys) when the content was collected
+	Keys      []string          `json:"keys,omitempty"` // data and binaryData keys in file order
+	Data      map[string]string `json:"data,omitempty"` // nil if only the table was collected
+	Age       string            `json:"age,omitempty"`
+}
+
+// ReplicaSet represents an apps/v1 ReplicaSet; for Deployments each ReplicaSet is one rollout revision
+type ReplicaSet struct {
+	Namespace  string   `json:"namespace"`
+	Name       string   `json:"name"`
+	Desired    int      `json:"desired"`
+	Current    int      `json:"current"`
+	Ready      int      `json:"ready"`
+	Age        string   `json:"age,omitempty"`
+	Containers []string `json:"containers,omitempty"` // -o wide only
+	Images     []string `json:"images,omitempty"`     // -o wide only
+	Owner      string   `json:"owner,omitempty"`      // Owning Deployment name (yaml/json only)
+	Revision   int      `json:"revision,omitempty"`   // deployment.kubernetes.io/revision (yaml/json only)
+}
+
+// HorizontalPodAutoscaler represents an autoscaling HorizontalPodAutoscaler
+type HorizontalPodAutoscaler struct {
+	Namespace   string         `json:"namespace"`
+	Name        string         `json:"name"`
+	TargetKind  string         `json:"targetKind"` // scaleTargetRef kind, e.g. "Deployment"
+	TargetName  string         `json:"targetName"`
+	Metrics     []HPAMetric    `json:"metrics,omitempty"`
+	MinReplicas int            `json:"minReplicas"`
+	MaxReplicas int            `json:"maxReplicas"`
+	Replicas    int            `json:"replicas"`             // Current replicas
+	Conditions  []HPACondition `json:"conditions,omitempty"` // yaml/json only
+	Age         string         `json:"age,omitempty"`
+}
+
+// HPAMetric is one HPA metric with its current and target value as kubectl prints them
+type HPAMetric struct {
+	Type    string `json:"type,omitempty"` // Resource, Pods, Object, External ("" from table output)
+	Name    string `json:"name,omitempty"` // e.g. "cpu"; "" when table output omits it
+	Current string `json:"current"`        // e.g. "45%", "120Mi" or "<unknown>"
+	Target  string `json:"target"`         // e.g. "80%"
+}
+
+// HPACondition is a status condition of an HPA (AbleToScale, ScalingActive, ScalingLimited)
+type HPACondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
@@ -0,0 +1,64 @@
+package rbac
+
+import "github.com/Rancheroo/r8s/internal/rancher"
+
+// defaultClusterRoleRules holds approximate rules for well-known ClusterRoles.
+// Support bundles collect roles as table output without rules, so these are used
+// to evaluate bindings to common roles. Grants based on them are marked Inferred.
+var defaultClusterRoleRules = map[string][]rancher.PolicyRule{
+	"cluster-admin": {
+		{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+		{NonResourceURLs: []string{"*"}, Verbs: []string{"*"}},
+	},
+	// Rancher global/cluster owner roles grant everything
+	"cluster-owner": {
+		{APIGroups: []string{"*"}, Resources: []string{"*"}, Verbs: []string{"*"}},
+		{NonResourceURLs: []string{"*"}, Verbs: []string{"*"}},
+	},
+	"admin": {
+		{APIGroups: []string{"", "apps", "batch", "autoscaling", "extensions", "policy", "networking.k8s.io"},
+			Resources: []string{"*"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"}},
+		{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"roles", "rolebindings"},
+			Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+		{APIGroups: []string{""}, Resources: []string{"serviceaccounts"}, Verbs: []string{"impersonate"}},
+	},
+	"project-owner": {
+		{APIGroups: []string{"", "apps", "batch", "autoscaling", "extensions", "policy", "networking.k8s.io"},
+			Resources: []string{"*"}, Verbs: []string{"*"}},
+		{APIGroups: []string{"rbac.authorization.k8s.io"}, Resources: []string{"roles", "rolebindings"}, Verbs: []string{"*"}},
+	},
+	"edit": {
+		{APIGroups: []string{"", "apps", "batch", "autoscaling", "extensions", "policy", "networking.k8s.io"},
+			Resources: []string{"*"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"}},
+		{APIGroups: []string{""}, Resources: []string{"serviceaccounts"}, Verbs: []string{"impersonate"}},
+	},
+	"project-member": {
+		{APIGroups: []string{"", "apps", "batch", "autoscaling", "extensions", "policy", "networking.k8s.io"},
+			Resources: []string{"*"}, Verbs: []string{"get", "list", "watch", "create", "update", "patch", "delete"}},
+	},
+	"view": {
+		{APIGroups: []string{"", "apps", "batch", "autoscaling", "extensions", "policy", "networking.k8s.io"},
+			Resources: []string{"pods", "services", "endpoints", "configmaps", "persistentvolumeclaims", "deployments",
+				"replicasets", "statefulsets", "daemonsets", "jobs", "cronjobs", "ingresses", "events", "namespaces"},
+			Verbs: []string{"get", "list", "watch"}},
+	},
+	"read-only": {
+		{APIGroups: []string{"", "apps", "batch", "autoscaling", "extensions", "policy", "networking.k8s.io"},
+			Resources: []string{"pods", "services", "endpoints", "configmaps", "persistentvolumeclaims", "deployments",
+				"replicasets", "statefulsets", "daemonsets", "jobs", "cronjobs", "ingresses", "events", "namespaces"},
+			Verbs: []string{"get", "list", "watch"}},
+	},
+	"system:auth-delegator": {
+		{APIGroups: []string{"authentication.k8s.io"}, Resources: []string{"tokenreviews"}, Verbs: []string{"create"}},
+		{APIGroups: []string{"authorization.k8s.io"}, Resources: []string{"subjectaccessreviews"}, Verbs: []string{"create"}},
+	},
+	"system:basic-user": {
+		{APIGroups: []string{"authorization.k8s.io"}, Resources: []string{"selfsubjectaccessreviews", "selfsubjectrulesreviews"}, Verbs: []string{"create"}},
+	},
+	"system:discovery": {
+		{NonResourceURLs: []string{"/api", "/api/*", "/apis", "/apis/*", "/healthz", "/livez", "/readyz", "/version", "/version/"}, Verbs: []string{"get"}},
+	},
+	"system:public-info-viewer": {
+		{NonResourceURLs: []string{"/healthz", "/livez", "/readyz", "/version", "/version/"}, Verbs: []string{"get"}},
+	},
+}
@@ -0,0 +1,528 @@
+// Package rbac evaluates Kubernetes RBAC from bundle data. It resolves subjects to the
+// roles bound to them, answers "who can <verb> <resource>" and "what can <subject> do"
+// queries, flags risky grants, and explains "forbidden" errors found in logs.
+package rbac
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// Grant is a single binding that gives a subject a role, optionally with the matching rule
+type Grant struct {
+	Subject  rancher.Subject
+	Binding  rancher.RoleBinding
+	Role     rancher.RoleRef
+	Rule     *rancher.PolicyRule // Rule that allowed the query (WhoCan) or raised the risk (RiskyGrants); nil otherwise
+	Scope    string              // Namespace the grant applies to, or "cluster-wide"
+	Known    bool                // Role rules are available (from the bundle or built-in defaults)
+	Inferred bool                // Role rules come from built-in defaults, not the bundle
+
+	// ResourceNames restricts Rule to these objects (resourceNames); nil means any object
+	ResourceNames []string
+}
+
+// NameRestricted reports whether the grant only applies to named objects
+func (g Grant) NameRestricted() bool {
+	return len(g.ResourceNames) > 0
+}
+
+// Risk is a grant flagged as dangerous
+type Risk struct {
+	Grant  Grant
+	Reason string
+}
+
+// SubjectSummary aggregates the bindings of one subject for list views
+type SubjectSummary struct {
+	Subject  rancher.Subject
+	Bindings []rancher.RoleBinding
+	Roles    []string // "ClusterRole/name" or "Role/name", deduplicated
+	Risks    []string // Risk reasons, deduplicated
+}
+
+// Evaluator answers RBAC queries over a set of roles and bindings
+type Evaluator struct {
+	roles    map[string]rancher.Role // key: roleKey(kind, namespace, name)
+	bindings []rancher.RoleBinding
+}
+
+// NewEvaluator creates an evaluator over the given roles and bindings
+func NewEvaluator(roles []rancher.Role, bindings []rancher.RoleBinding) *Evaluator {
+	e := &Evaluator{
+		roles:    make(map[string]rancher.Role),
+		bindings: bindings,
+	}
+	for _, role := range roles {
+		e.roles[roleKey(role.Kind, role.Namespace, role.Name)] = role
+	}
+	return e
+}
+
+// roleKey builds the lookup key for a role
+func roleKey(kind, namespace, name string) string {
+	if kind == "ClusterRole" {
+		namespace = ""
+	}
+	return kind + "/" + namespace + "/" + name
+}
+
+// SubjectString formats a subject the way the API server reports it in audit and error messages
+func SubjectString(s rancher.Subject) string {
+	if s.Kind == "ServiceAccount" {
+		return fmt.Sprintf("system:serviceaccount:%s:%s", s.Namespace, s.Name)
+	}
+	return s.Name
+}
+
+// ParseSubject parses "serviceaccount:ns:name", "system:serviceaccount:ns:name", "ns/name"
+// (service account), "group:name" or a plain user name
+func ParseSubject(s string) rancher.Subject {
+	lower := strings.ToLower(s)
+	switch {
+	case strings.HasPrefix(lower, "system:serviceaccount:"):
+		parts := strings.SplitN(s[len("system:serviceaccount:"):], ":", 2)
+		if len(parts) == 2 {
+			return rancher.Subject{Kind: "ServiceAccount", Namespace: parts[0], Name: parts[1]}
+		}
+	case strings.HasPrefix(lower, "serviceaccount:") || strings.HasPrefix(lower, "sa:"):
+		rest := s[strings.Index(s, ":")+1:]
+		parts := strings.SplitN(rest, ":", 2)
+		if len(parts) == 2 {
+			return rancher.Subject{Kind: "ServiceAccount", Namespace: parts[0], Name: parts[1]}
+		}
+		if parts = strings.SplitN(rest, "/", 2); len(parts) == 2 {
+			return rancher.Subject{Kind: "ServiceAccount", Namespace: parts[0], Name: parts[1]}
+		}
+	case strings.HasPrefix(lower, "group:"):
+		return rancher.Subject{Kind: "Group", Name: s[len("group:"):]}
+	case strings.HasPrefix(lower, "user:"):
+		return rancher.Subject{Kind: "User", Name: s[len("user:"):]}
+	case strings.Contains(s, "/"):
+		parts := strings.SplitN(s, "/", 2)
+		return rancher.Subject{Kind: "ServiceAccount", Namespace: parts[0], Name: parts[1]}
+	}
+	return rancher.Subject{Kind: "User", Name: s}
+}
+
+// rulesFor returns the rules of the role referenced by a binding and whether they
+// are known, and whether they came from built-in defaults
+func (e *Evaluator) rulesFor(binding rancher.RoleBinding) (rules []rancher.PolicyRule, known, inferred bool) {
+	ref := binding.RoleRef
+	if role, ok := e.roles[roleKey(ref.Kind, binding.Namespace, ref.Name)]; ok && len(role.Rules) > 0 {
+		return role.Rules, true, false
+	}
+	if ref.Kind == "ClusterRole" {
+		if rules, ok := defaultClusterRoleRules[ref.Name]; ok {
+			return rules, true, true
+		}
+	}
+	return nil, false, false
+}
+
+// scopeOf returns where a binding's permissions apply
+func scopeOf(binding rancher.RoleBinding) string {
+	if binding.Kind == "ClusterRoleBinding" {
+		return "cluster-wide"
+	}
+	return binding.Namespace
+}
+
+// matches reports whether a list contains the value or the "*" wildcard
+func matches(list []string, value string) bool {
+	for _, v := range list {
+		if v == "*" || v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// splitResource splits "deployments.apps" into resource and API group.
+// An empty group matches any group (like an unqualified kubectl resource).
+func splitResource(resource string) (string, string, bool) {
+	if idx := strings.Index(resource, "."); idx > 0 {
+		return resource[:idx], resource[idx+1:], true
+	}
+	return resource, "", false
+}
+
+// ruleAllows reports whether a rule allows verb on resource (optionally group-qualified)
+func ruleAllows(rule rancher.PolicyRule, verb, resource string) bool {
+	if len(rule.Resources) == 0 || !matches(rule.Verbs, verb) {
+		return false
+	}
+	res, group, hasGroup := splitResource(resource)
+	if hasGroup && !matches(rule.APIGroups, group) {
+		return false
+	}
+	return matches(rule.Resources, res)
+}
+
+// WhoCan returns all grants allowing verb on resource in namespace. An empty namespace
+// means a cluster-scoped query, which only ClusterRoleBindings can satisfy.
+// Bindings to roles whose rules are unknown are returned with Known=false so callers
+// can show them as "possible" grants. A rule allowing any object is preferred; when only
+// rules limited to resourceNames match, the grant is name-restricted.
+func (e *Evaluator) WhoCan(verb, resource, namespace string) []Grant {
+	var grants []Grant
+
+	for _, binding := range e.bindings {
+		if binding.Kind == "RoleBinding" && binding.Namespace != namespace {
+			continue
+		}
+
+		rules, known, inferred := e.rulesFor(binding)
+		var matched *rancher.PolicyRule
+		if known {
+			for i := range rules {
+				if !ruleAllows(rules[i], verb, resource) {
+					continue
+				}
+				if matched == nil || len(rules[i].ResourceNames) == 0 {
+					matched = &rules[i]
+				}
+				if len(matched.ResourceNames) == 0 {
+					break
+				}
+			}
+			if matched == nil {
+				continue
+			}
+		}
+
+		for _, subject := range binding.Subjects {
+			grant := Grant{
+				Subject:  subject,
+				Binding:  binding,
+				Role:     binding.RoleRef,
+				Rule:     matched,
+				Scope:    scopeOf(binding),
+				Known:    known,
+				Inferred: inferred,
+			}
+			if matched != nil {
+				grant.ResourceNames = matched.ResourceNames
+			}
+			grants = append(grants, grant)
+		}
+	}
+
+	sortGrants(grants)
+	return grants
+}
+
+// SubjectGrants returns every binding that applies to subject, including bindings to
+// groups the subject implicitly belongs to (system:serviceaccounts, system:authenticated)
+func (e *Evaluator) SubjectGrants(subject rancher.Subject) []Grant {
+	var grants []Grant
+
+	for _, binding := range e.bindings {
+		for _, s := range binding.Subjects {
+			if !subjectMatches(s, subject) {
+				continue
+			}
+			_, known, inferred := e.rulesFor(binding)
+			grants = append(grants, Grant{
+				Subject:  s,
+				Binding:  binding,
+				Role:     binding.RoleRef,
+				Scope:    scopeOf(binding),
+				Known:    known,
+				Inferred: inferred,
+			})
+			break
+		}
+	}
+
+	sortGrants(grants)
+	return grants
+}
+
+// Rules returns the rules of the role granted by g (nil when unknown)
+func (e *Evaluator) Rules(g Grant) []rancher.PolicyRule {
+	rules, _, _ := e.rulesFor(g.Binding)
+	return rules
+}
+
+// subjectMatches reports whether binding subject s applies to the queried subject
+func subjectMatches(s, query rancher.Subject) bool {
+	if s.Kind == query.Kind && s.Name == query.Name && s.Namespace == query.Namespace {
+		return true
+	}
+	if s.Kind != "Group" {
+		return false
+	}
+	switch s.Name {
+	case "system:authenticated":
+		return true
+	case "system:serviceaccounts":
+		return query.Kind == "ServiceAccount"
+	case "system:serviceaccounts:" + query.Namespace:
+		return query.Kind == "ServiceAccount"
+	}
+	return false
+}
+
+// Subjects returns every subject referenced by a binding, riskiest first
+func (e *Evaluator) Subjects() []SubjectSummary {
+	byKey := make(map[string]*SubjectSummary)
+	var order []string
+
+	for _, binding := range e.bindings {
+		for _, s := range binding.Subjects {
+			key := s.Kind + "/" + s.Namespace + "/" + s.Name
+			summary, ok := byKey[key]
+			if !ok {
+				summary = &SubjectSummary{Subject: s}
+				byKey[key] = summary
+				order = append(order, key)
+			}
+			summary.Bindings = append(summary.Bindings, binding)
+			role := binding.RoleRef.Kind + "/" + binding.RoleRef.Name
+			if !containsString(summary.Roles, role) {
+				summary.Roles = append(summary.Roles, role)
+			}
+		}
+	}
+
+	for _, risk := range e.RiskyGrants() {
+		s := risk.Grant.Subject
+		summary := byKey[s.Kind+"/"+s.Namespace+"/"+s.Name]
+		if summary != nil && !containsString(summary.Risks, risk.Reason) {
+			summary.Risks = append(summary.Risks, risk.Reason)
+		}
+	}
+
+	var summaries []SubjectSummary
+	for _, key := range order {
+		summaries = append(summaries, *byKey[key])
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if len(summaries[i].Risks) != len(summaries[j].Risks) {
+			return len(summaries[i].Risks) > len(summaries[j].Risks)
+		}
+		return SubjectString(summaries[i].Subject) < SubjectString(summaries[j].Subject)
+	})
+
+	return summaries
+}
+
+// IsSystemSubject reports whether a subject belongs to Kubernetes or platform components
+// (system: users/groups and service accounts in *-system namespaces)
+func IsSystemSubject(s rancher.Subject) bool {
+	if strings.HasPrefix(s.Name, "system:") {
+		return true
+	}
+	if s.Kind == "ServiceAccount" {
+		return s.Namespace == "kube-system" || strings.HasSuffix(s.Namespace, "-system") ||
+			strings.HasPrefix(s.Namespace, "cattle-")
+	}
+	return false
+}
+
+// RiskyGrants flags wildcard verbs, cluster-admin-equivalent roles bound to non-system
+// subjects, and escalate/bind/impersonate rights. Each risk's grant carries the rule that
+// raised it; rules limited to resourceNames are name-restricted and say so in the reason.
+func (e *Evaluator) RiskyGrants() []Risk {
+	var risks []Risk
+
+	for _, binding := range e.bindings {
+		rules, known, inferred := e.rulesFor(binding)
+		if !known {
+			continue
+		}
+
+		var reasons []string
+		reasonRules := make(map[string]*rancher.PolicyRule)
+		addReason := func(reason string, rule *rancher.PolicyRule) {
+			if _, ok := reasonRules[reason]; !ok {
+				reasons = append(reasons, reason)
+				reasonRules[reason] = rule
+			}
+		}
+		var superuser *rancher.PolicyRule
+		for i := range rules {
+			rule := &rules[i]
+			if matches(rule.Resources, "*") && matches(rule.APIGroups, "*") && matches(rule.Verbs, "*") &&
+				len(rule.ResourceNames) == 0 {
+				if superuser == nil {
+					superuser = rule
+				}
+				continue
+			}
+			target := strings.Join(rule.Resources, ",")
+			if target == "" {
+				target = strings.Join(rule.NonResourceURLs, ",")
+			}
+			if len(rule.ResourceNames) > 0 {
+				target += fmt.Sprintf(" [names: %s]", strings.Join(rule.ResourceNames, ","))
+			}
+			if containsString(rule.Verbs, "*") {
+				addReason(fmt.Sprintf("wildcard verbs on %s", target), rule)
+			}
+			for _, verb := range []string{"escalate", "bind", "impersonate"} {
+				if containsString(rule.Verbs, verb) {
+					addReason(fmt.Sprintf("%s on %s", verb, target), rule)
+				}
+			}
+		}
+		if superuser != nil {
+			reasons = nil // Covered by the full-access risk below
+		}
+
+		for _, subject := range binding.Subjects {
+			grant := Grant{
+				Subject:  subject,
+				Binding:  binding,
+				Role:     binding.RoleRef,
+				Scope:    scopeOf(binding),
+				Known:    true,
+				Inferred: inferred,
+			}
+			if superuser != nil && !IsSystemSubject(subject) {
+				reason := fmt.Sprintf("%s (full access) bound to non-system subject", binding.RoleRef.Name)
+				if binding.Kind == "RoleBinding" {
+					reason = fmt.Sprintf("%s (full access) in namespace %s", binding.RoleRef.Name, binding.Namespace)
+				}
+				grant.Rule = superuser
+				risks = append(risks, Risk{Grant: grant, Reason: reason})
+			}
+			if IsSystemSubject(subject) {
+				continue // Platform components legitimately hold these rights
+			}
+			for _, reason := range reasons {
+				grant.Rule = reasonRules[reason]
+				grant.ResourceNames = grant.Rule.ResourceNames
+				risks = append(risks, Risk{Grant: grant, Reason: reason})
+			}
+		}
+	}
+
+	return risks
+}
+
+// Forbidden is a parsed RBAC "forbidden" error from a log line or event
+type Forbidden struct {
+	Subject   rancher.Subject
+	User      string // Raw user string from the message
+	Verb      string
+	Resource  string // Qualified with the API group when present, e.g. "deployments.apps"
+	Namespace string // Empty for cluster-scoped requests
+}
+
+// forbiddenRe matches API server authorization errors, e.g.
+// User "system:serviceaccount:ns:sa" cannot list resource "secrets" in API group "" in the namespace "ns"
+var forbiddenRe = regexp.MustCompile(`User \\?"([^"\\]+)\\?" cannot (\w+) resource \\?"([^"\\]+)\\?" in API group \\?"([^"\\]*)\\?"(?: in the namespace \\?"([^"\\]+)\\?")?`)
+
+// ForbiddenPattern matches log lines that may contain an RBAC forbidden error
+var ForbiddenPattern = regexp.MustCompile(`cannot \w+ resource`)
+
+// ParseForbidden extracts subject, verb, resource and namespace from a forbidden error
+func ParseForbidden(line string) (Forbidden, bool) {
+	m := forbiddenRe.FindStringSubmatch(line)
+	if m == nil {
+		return Forbidden{}, false
+	}
+
+	f := Forbidden{
+		Subject:   ParseSubject(m[1]),
+		User:      m[1],
+		Verb:      m[2],
+		Resource:  m[3],
+		Namespace: m[5],
+	}
+	if m[4] != "" {
+		f.Resource = m[3] + "." + m[4]
+	}
+	return f, true
+}
+
+// Explain describes why a forbidden request was denied given the collected RBAC data
+func (e *Evaluator) Explain(f Forbidden) string {
+	grants := e.WhoCan(f.Verb, f.Resource, f.Namespace)
+
+	var possible, named []string
+	for _, g := range grants {
+		if !subjectMatches(g.Subject, f.Subject) {
+			continue
+		}
+		if g.Known && g.NameRestricted() {
+			// The error does not say which object was requested
+			named = append(named, fmt.Sprintf("%s/%s [names: %s]", g.Role.Kind, g.Role.Name, strings.Join(g.ResourceNames, ",")))
+			continue
+		}
+		if g.Known {
+			return fmt.Sprintf("now allowed via %s %s (%s) - the grant was likely added after the error",
+				g.Binding.Kind, g.Binding.Name, g.Role.Name)
+		}
+		possible = append(possible, fmt.Sprintf("%s/%s", g.Role.Kind, g.Role.Name))
+	}
+
+	where := "cluster-wide"
+	if f.Namespace != "" {
+		where = "in namespace " + f.Namespace
+	}
+	if len(possible) > 0 {
+		return fmt.Sprintf("no known rule allows %s %s %s; rules not collected for %s",
+			f.Verb, f.Resource, where, strings.Join(possible, ", "))
+	}
+	if len(named) > 0 {
+		return fmt.Sprintf("%s %s %s is only allowed for named objects via %s",
+			f.Verb, f.Resource, where, strings.Join(named, ", "))
+	}
+	return fmt.Sprintf("no binding grants %s %s %s to %s", f.Verb, f.Resource, where, SubjectString(f.Subject))
+}
+
+// FormatRule renders a rule in a compact kubectl-describe-like form
+func FormatRule(rule rancher.PolicyRule) string {
+	verbs := strings.Join(rule.Verbs, ",")
+	if len(rule.NonResourceURLs) > 0 {
+		return fmt.Sprintf("%-40s %s", strings.Join(rule.NonResourceURLs, ","), verbs)
+	}
+
+	var resources []string
+	for _, group := range rule.APIGroups {
+		for _, res := range rule.Resources {
+			if group == "" {
+				resources = append(resources, res)
+			} else {
+				resources = append(resources, res+"."+group)
+			}
+		}
+	}
+	if len(rule.APIGroups) == 0 {
+		resources = rule.Resources
+	}
+	text := fmt.Sprintf("%-40s %s", strings.Join(resources, ","), verbs)
+	if len(rule.ResourceNames) > 0 {
+		text += fmt.Sprintf("  [names: %s]", strings.Join(rule.ResourceNames, ","))
+	}
+	return text
+}
+
+// sortGrants orders grants by subject, then binding name
+func sortGrants(grants []Grant) {
+	sort.SliceStable(grants, func(i, j int) bool {
+		si, sj := SubjectString(grants[i].Subject), SubjectString(grants[j].Subject)
+		if si != sj {
+			return si < sj
+		}
+		return grants[i].Binding.Name < grants[j].Binding.Name
+	})
+}
+
+// containsString checks if a string slice contains a value
+func containsString(slice []string, val string) bool {
+	for _, item := range slice {
+		if item == val {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,207 @@
+package rbac
+
+import (
+	"testing"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// testBindings returns a small set of bindings resembling an RKE2/Rancher bundle
+func testBindings() []rancher.RoleBinding {
+	return []rancher.RoleBinding{
+		{
+			Kind:     "ClusterRoleBinding",
+			Name:     "cluster-admin",
+			RoleRef:  rancher.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+			Subjects: []rancher.Subject{{Kind: "Group", Name: "system:masters"}},
+		},
+		{
+			Kind:     "ClusterRoleBinding",
+			Name:     "globaladmin-user-2rkjh",
+			RoleRef:  rancher.RoleRef{Kind: "ClusterRole", Name: "cluster-admin"},
+			Subjects: []rancher.Subject{{Kind: "User", Name: "user-2rkjh"}},
+		},
+		{
+			Kind:      "RoleBinding",
+			Namespace: "cattle-system",
+			Name:      "secret-reader",
+			RoleRef:   rancher.RoleRef{Kind: "Role", Name: "secret-reader"},
+			Subjects:  []rancher.Subject{{Kind: "ServiceAccount", Namespace: "apps", Name: "reporter"}},
+		},
+		{
+			Kind:      "RoleBinding",
+			Namespace: "apps",
+			Name:      "impersonator",
+			RoleRef:   rancher.RoleRef{Kind: "Role", Name: "impersonator"},
+			Subjects:  []rancher.Subject{{Kind: "ServiceAccount", Namespace: "apps", Name: "reporter"}},
+		},
+		{
+			Kind:     "ClusterRoleBinding",
+			Name:     "custom-controller",
+			RoleRef:  rancher.RoleRef{Kind: "ClusterRole", Name: "custom-controller"},
+			Subjects: []rancher.Subject{{Kind: "ServiceAccount", Namespace: "apps", Name: "controller"}},
+		},
+	}
+}
+
+// testRoles returns roles with rules (as collected with -o yaml)
+func testRoles() []rancher.Role {
+	return []rancher.Role{
+		{
+			Kind:      "Role",
+			Namespace: "cattle-system",
+			Name:      "secret-reader",
+			Rules:     []rancher.PolicyRule{{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list"}}},
+		},
+		{
+			Kind:      "Role",
+			Namespace: "apps",
+			Name:      "impersonator",
+			Rules:     []rancher.PolicyRule{{APIGroups: []string{""}, Resources: []string{"serviceaccounts"}, Verbs: []string{"impersonate"}}},
+		},
+	}
+}
+
+// TestWhoCan tests namespace scoping, role resolution and unknown roles
+func TestWhoCan(t *testing.T) {
+	e := NewEvaluator(testRoles(), testBindings())
+
+	grants := e.WhoCan("list", "secrets", "cattle-system")
+
+	found := map[string]Grant{}
+	for _, g := range grants {
+		found[SubjectString(g.Subject)] = g
+	}
+
+	if g, ok := found["system:serviceaccount:apps:reporter"]; !ok || !g.Known || g.Inferred {
+		t.Errorf("expected known grant for apps/reporter via secret-reader, got %+v", g)
+	}
+	if g, ok := found["user-2rkjh"]; !ok || !g.Inferred {
+		t.Errorf("expected inferred cluster-admin grant for user-2rkjh, got %+v", g)
+	}
+	if g, ok := found["system:serviceaccount:apps:controller"]; !ok || g.Known {
+		t.Errorf("expected possible grant (unknown rules) for apps/controller, got %+v", g)
+	}
+
+	// RoleBindings in cattle-system must not apply cluster-wide
+	for _, g := range e.WhoCan("list", "secrets", "") {
+		if g.Binding.Kind == "RoleBinding" {
+			t.Errorf("cluster-scoped query returned RoleBinding %s", g.Binding.Name)
+		}
+	}
+}
+
+// TestRiskyGrants tests detection of cluster-admin and impersonate grants
+func TestRiskyGrants(t *testing.T) {
+	e := NewEvaluator(testRoles(), testBindings())
+
+	reasons := map[string][]string{}
+	for _, r := range e.RiskyGrants() {
+		key := SubjectString(r.Grant.Subject)
+		reasons[key] = append(reasons[key], r.Reason)
+	}
+
+	if _, ok := reasons["system:masters"]; ok {
+		t.Errorf("system:masters should not be flagged")
+	}
+	if len(reasons["user-2rkjh"]) != 1 {
+		t.Errorf("expected one cluster-admin risk for user-2rkjh, got %v", reasons["user-2rkjh"])
+	}
+	if len(reasons["system:serviceaccount:apps:reporter"]) != 1 {
+		t.Errorf("expected impersonate risk for apps/reporter, got %v", reasons["system:serviceaccount:apps:reporter"])
+	}
+}
+
+// TestNameRestrictedGrants tests that rules limited to resourceNames are marked as such, that an
+// unrestricted rule is preferred, and that each grant carries the rule that matched
+func TestNameRestrictedGrants(t *testing.T) {
+	roles := []rancher.Role{
+		{
+			Kind:      "Role",
+			Namespace: "apps",
+			Name:      "tls-reader",
+			Rules: []rancher.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}},
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"*"}, ResourceNames: []string{"tls-web"}},
+			},
+		},
+		{
+			Kind:      "Role",
+			Namespace: "apps",
+			Name:      "secret-admin",
+			Rules: []rancher.PolicyRule{
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get"}, ResourceNames: []string{"db"}},
+				{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list"}},
+			},
+		},
+	}
+	bindings := []rancher.RoleBinding{
+		{
+			Kind:      "RoleBinding",
+			Namespace: "apps",
+			Name:      "tls-reader",
+			RoleRef:   rancher.RoleRef{Kind: "Role", Name: "tls-reader"},
+			Subjects:  []rancher.Subject{{Kind: "ServiceAccount", Namespace: "apps", Name: "web"}},
+		},
+		{
+			Kind:      "RoleBinding",
+			Namespace: "apps",
+			Name:      "secret-admin",
+			RoleRef:   rancher.RoleRef{Kind: "Role", Name: "secret-admin"},
+			Subjects:  []rancher.Subject{{Kind: "ServiceAccount", Namespace: "apps", Name: "ops"}},
+		},
+	}
+	e := NewEvaluator(roles, bindings)
+
+	found := map[string]Grant{}
+	for _, g := range e.WhoCan("get", "secrets", "apps") {
+		found[g.Subject.Name] = g
+	}
+	if g := found["web"]; !g.NameRestricted() || g.ResourceNames[0] != "tls-web" || g.Rule == nil || g.Rule.Resources[0] != "secrets" {
+		t.Errorf("web: expected grant restricted to tls-web with the secrets rule, got %+v", g)
+	}
+	if g := found["ops"]; g.NameRestricted() || g.Rule == nil || len(g.Rule.Verbs) != 2 {
+		t.Errorf("ops: expected the unrestricted get/list rule, got %+v", g)
+	}
+
+	risks := e.RiskyGrants()
+	if len(risks) != 1 || risks[0].Reason != "wildcard verbs on secrets [names: tls-web]" ||
+		!risks[0].Grant.NameRestricted() || risks[0].Grant.Rule != &roles[0].Rules[1] {
+		t.Errorf("expected one name-restricted wildcard risk, got %+v", risks)
+	}
+
+	f := Forbidden{Subject: rancher.Subject{Kind: "ServiceAccount", Namespace: "apps", Name: "web"}, Verb: "get", Resource: "secrets", Namespace: "apps"}
+	if got := e.Explain(f); got != "get secrets in namespace apps is only allowed for named objects via Role/tls-reader [names: tls-web]" {
+		t.Errorf("Explain() = %q", got)
+	}
+
+	for _, g := range e.SubjectGrants(rancher.Subject{Kind: "ServiceAccount", Namespace: "apps", Name: "web"}) {
+		if g.Rule != nil {
+			t.Errorf("SubjectGrants() attached rule %+v without a query", g.Rule)
+		}
+	}
+}
+
+// TestParseForbidden tests parsing API server authorization errors
+func TestParseForbidden(t *testing.T) {
+	line := `E1204 09:15:57.123456 reflector.go:147] failed to list *v1.Secret: secrets is forbidden: User "system:serviceaccount:apps:reporter" cannot list resource "secrets" in API group "" in the namespace "kube-system"`
+
+	f, ok := ParseForbidden(line)
+	if !ok {
+		t.Fatalf("ParseForbidden() did not match")
+	}
+	want := rancher.Subject{Kind: "ServiceAccount", Namespace: "apps", Name: "reporter"}
+	if f.Subject != want || f.Verb != "list" || f.Resource != "secrets" || f.Namespace != "kube-system" {
+		t.Errorf("unexpected result: %+v", f)
+	}
+
+	e := NewEvaluator(testRoles(), testBindings())
+	if got := e.Explain(f); got != "no binding grants list secrets in namespace kube-system to system:serviceaccount:apps:reporter" {
+		t.Errorf("Explain() = %q", got)
+	}
+
+	grouped, ok := ParseForbidden(`User \"bob\" cannot get resource \"deployments\" in API group \"apps\"`)
+	if !ok || grouped.Resource != "deployments.apps" || grouped.Namespace != "" {
+		t.Errorf("unexpected result for escaped cluster-scoped error: %+v", grouped)
+	}
+}
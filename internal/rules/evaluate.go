@@ -0,0 +1,301 @@
+package rules
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+const (
+	// maxEvidence is the number of matching lines kept per finding
+	maxEvidence = 10
+
+	// maxLogMatches caps the log search for one rule
+	maxLogMatches = 1000
+
+	// maxLineLength truncates matched file lines kept as evidence
+	maxLineLength = 300
+)
+
+// Hit is one pod, event, node, log line or file line a rule matched
+type Hit struct {
+	Resource  string // "namespace/pod", event object, node name, log source or bundle file
+	Namespace string
+	Pod       string
+	Line      string // Evidence shown with the finding
+}
+
+// Finding is a rule that fired, for the whole bundle or (PerResource) for one resource
+type Finding struct {
+	Rule        *Rule
+	Resource    string // Set for PerResource rules
+	Namespace   string // Namespace of the first hit, if any
+	Pod         string // Pod of the first hit, if any
+	Count       int
+	Description string   // Rule description with placeholders filled in
+	Evidence    []string // First matching lines
+}
+
+// Evaluate runs every rule against the data source and returns the findings of rules whose
+// matches reach their threshold
+func (s *RuleSet) Evaluate(ds datasource.DataSource) []Finding {
+	if s == nil {
+		return nil
+	}
+	var findings []Finding
+	for _, rule := range s.Rules {
+		hits, err := rule.Hits(ds)
+		if err != nil {
+			continue
+		}
+		findings = append(findings, rule.Findings(hits)...)
+	}
+	return findings
+}
+
+// LogSearches returns the log searches of the log rules, for callers that search the logs for
+// the rules in one pass together with other searches before evaluating them
+func (s *RuleSet) LogSearches() []*datasource.LogSearch {
+	if s == nil {
+		return nil
+	}
+	var searches []*datasource.LogSearch
+	for _, rule := range s.Rules {
+		if rule.Match.Logs != nil {
+			searches = append(searches, &datasource.LogSearch{Pattern: rule.pattern, MaxMatches: maxLogMatches})
+		}
+	}
+	return searches
+}
+
+// Hits returns everything the rule matches in the data source
+func (r *Rule) Hits(ds datasource.DataSource) ([]Hit, error) {
+	switch {
+	case r.Match.Logs != nil:
+		return r.logHits(ds)
+	case r.Match.File != nil:
+		return r.fileHits(ds.BundleRoot())
+	default:
+		return r.resourceHits(ds)
+	}
+}
+
+// Findings groups hits into findings: one for the rule, or one per resource for PerResource
+// rules, keeping those with at least MinMatches hits
+func (r *Rule) Findings(hits []Hit) []Finding {
+	if !r.PerResource {
+		if len(hits) < r.MinMatches() {
+			return nil
+		}
+		return []Finding{r.finding("", hits)}
+	}
+
+	var order []string
+	byResource := make(map[string][]Hit)
+	for _, h := range hits {
+		if _, ok := byResource[h.Resource]; !ok {
+			order = append(order, h.Resource)
+		}
+		byResource[h.Resource] = append(byResource[h.Resource], h)
+	}
+	var findings []Finding
+	for _, resource := range order {
+		if len(byResource[resource]) >= r.MinMatches() {
+			findings = append(findings, r.finding(resource, byResource[resource]))
+		}
+	}
+	return findings
+}
+
+// finding builds a finding from a non-empty group of hits
+func (r *Rule) finding(resource string, hits []Hit) Finding {
+	f := Finding{
+		Rule:      r,
+		Resource:  resource,
+		Namespace: hits[0].Namespace,
+		Pod:       hits[0].Pod,
+		Count:     len(hits),
+	}
+	for i, h := range hits {
+		if i == maxEvidence {
+			f.Evidence = append(f.Evidence, fmt.Sprintf("... %d more", len(hits)-maxEvidence))
+			break
+		}
+		f.Evidence = append(f.Evidence, h.Line)
+	}
+
+	description := r.Description
+	if description == "" {
+		description = fmt.Sprintf("%d match(es)", len(hits))
+	}
+	if resource == "" {
+		resource = hits[0].Resource
+	}
+	f.Description = strings.NewReplacer(
+		"{count}", strconv.Itoa(len(hits)),
+		"{resource}", resource,
+		"{namespace}", hits[0].Namespace,
+		"{pod}", hits[0].Pod,
+		"{match}", hits[0].Line,
+	).Replace(description)
+	return f
+}
+
+// resourceHits matches the fields of every pod, event or node
+func (r *Rule) resourceHits(ds datasource.DataSource) ([]Hit, error) {
+	var hits []Hit
+	switch r.Match.Resource {
+	case ResourcePod:
+		pods, err := ds.GetAllPods()
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range pods {
+			namespace := p.NamespaceID
+			if i := strings.LastIndex(namespace, ":"); i >= 0 {
+				namespace = namespace[i+1:]
+			}
+			state := p.KubectlStatus
+			if state == "" {
+				state = p.State
+			}
+			restarts := p.KubectlRestarts
+			if restarts == 0 {
+				restarts = p.RestartCount
+			}
+			fields := map[string]string{
+				"name":      p.Name,
+				"namespace": namespace,
+				"node":      p.NodeName,
+				"state":     state,
+				"ready":     p.KubectlReady,
+				"restarts":  strconv.Itoa(restarts),
+				"age":       p.KubectlAge,
+			}
+			if r.matchesFields(fields) {
+				hits = append(hits, Hit{
+					Resource:  namespace + "/" + p.Name,
+					Namespace: namespace,
+					Pod:       p.Name,
+					Line:      fmt.Sprintf("%s/%s  %s  ready %s  restarts %d  node %s", namespace, p.Name, state, p.KubectlReady, restarts, p.NodeName),
+				})
+			}
+		}
+	case ResourceEvent:
+		events, err := ds.GetAllEvents()
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range events {
+			fields := map[string]string{
+				"namespace": e.Namespace,
+				"type":      e.Type,
+				"reason":    e.Reason,
+				"object":    e.Object,
+				"kind":      e.ObjectKind,
+				"pod":       e.PodName,
+				"message":   e.Message,
+				"source":    e.Source,
+				"count":     strconv.Itoa(e.Count),
+			}
+			if r.matchesFields(fields) {
+				hits = append(hits, Hit{
+					Resource:  e.Namespace + "/" + e.Object,
+					Namespace: e.Namespace,
+					Pod:       e.PodName,
+					Line:      fmt.Sprintf("%s %s %s/%s (x%d): %s", e.Type, e.Reason, e.Namespace, e.Object, e.Count, e.Message),
+				})
+			}
+		}
+	case ResourceNode:
+		nodes, err := ds.GetNodes()
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range nodes {
+			if r.matchesFields(map[string]string{"name": n.Name, "status": n.Status}) {
+				hits = append(hits, Hit{Resource: n.Name, Line: fmt.Sprintf("%s  %s", n.Name, n.Status)})
+			}
+		}
+	}
+	return hits, nil
+}
+
+// matchesFields reports whether every field matcher of the rule matches
+func (r *Rule) matchesFields(fields map[string]string) bool {
+	for name, matcher := range r.fields {
+		if !matcher.matches(fields[name]) {
+			return false
+		}
+	}
+	return true
+}
+
+// logHits searches the bundle logs for the rule's pattern, filtered by source, namespace and pod
+func (r *Rule) logHits(ds datasource.DataSource) ([]Hit, error) {
+	matches, err := ds.SearchLogs(r.pattern, maxLogMatches)
+	if err != nil {
+		return nil, err
+	}
+
+	var hits []Hit
+	for _, m := range matches {
+		if (r.source != nil && !r.source.MatchString(m.Source)) ||
+			(r.namespace != nil && !r.namespace.MatchString(m.Namespace)) ||
+			(r.pod != nil && !r.pod.MatchString(m.PodName)) {
+			continue
+		}
+		hits = append(hits, Hit{
+			Resource:  m.Source,
+			Namespace: m.Namespace,
+			Pod:       m.PodName,
+			Line:      fmt.Sprintf("%s:%d: %s", m.Source, m.LineNumber, strings.TrimSpace(m.Line)),
+		})
+	}
+	return hits, nil
+}
+
+// fileHits matches the rule's pattern against each line of the bundle files its path glob selects
+func (r *Rule) fileHits(root string) ([]Hit, error) {
+	if root == "" {
+		return nil, nil
+	}
+	paths, err := filepath.Glob(filepath.Join(root, r.Match.File.Path))
+	if err != nil {
+		return nil, err
+	}
+
+	var hits []Hit
+	for _, path := range paths {
+		rel, _ := filepath.Rel(root, path)
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		if info, err := f.Stat(); err != nil || info.IsDir() {
+			f.Close()
+			continue
+		}
+
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+		lineNum := 0
+		for scanner.Scan() {
+			lineNum++
+			line := scanner.Text()
+			if r.pattern.MatchString(line) {
+				if len(line) > maxLineLength {
+					line = line[:maxLineLength-3] + "..."
+				}
+				hits = append(hits, Hit{Resource: rel, Line: fmt.Sprintf("%s:%d: %s", rel, lineNum, strings.TrimSpace(line))})
+			}
+		}
+		f.Close()
+	}
+	return hits, nil
+}
@@ -0,0 +1,372 @@
+// Package rules loads user-defined attention rules from YAML files. A rule matches pod, event
+// or node fields, log lines or bundle files, and fires when the number of matches reaches its
+// threshold. Rules are read from ~/.r8s/rules.d/ and the bundle's rules.d/ directory.
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Rancheroo/r8s/internal/userfiles"
+)
+
+// Rule severities
+const (
+	SeverityCritical = "critical"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// Resource kinds a rule can match fields of
+const (
+	ResourcePod   = "pod"
+	ResourceEvent = "event"
+	ResourceNode  = "node"
+)
+
+// DefaultEmoji is shown for rules that do not set one
+const DefaultEmoji = "📌"
+
+// resourceFields lists the fields each resource kind exposes to rules
+var resourceFields = map[string][]string{
+	ResourcePod:   {"name", "namespace", "node", "state", "ready", "restarts", "age"},
+	ResourceEvent: {"namespace", "type", "reason", "object", "kind", "pod", "message", "source", "count"},
+	ResourceNode:  {"name", "status"},
+}
+
+// Rule is a user-defined attention rule
+type Rule struct {
+	ID          string `yaml:"id"`
+	Title       string `yaml:"title"`
+	Severity    string `yaml:"severity"`    // critical, warning or info
+	Emoji       string `yaml:"emoji"`       // Default: DefaultEmoji
+	Description string `yaml:"description"` // May use {count}, {resource}, {namespace}, {pod}, {match}
+	Runbook     string `yaml:"runbook"`     // http(s) URL shown with the item
+	Match       Match  `yaml:"match"`
+	Threshold   int    `yaml:"threshold"`   // Minimum matches to fire (default 1)
+	PerResource bool   `yaml:"perResource"` // One item per matching pod/event/node/log source/file
+
+	File string `yaml:"-"` // Rule file the rule was loaded from
+
+	fields    map[string]valueMatcher
+	pattern   *regexp.Regexp
+	source    *regexp.Regexp
+	namespace *regexp.Regexp
+	pod       *regexp.Regexp
+}
+
+// Match selects what a rule matches. Exactly one of Resource, Logs or File is set.
+type Match struct {
+	Resource string            `yaml:"resource"` // pod, event or node
+	Fields   map[string]string `yaml:"fields"`   // Field name to regex or comparison (">5", "<=1")
+	Logs     *LogMatch         `yaml:"logs"`
+	File     *FileMatch        `yaml:"file"`
+}
+
+// LogMatch matches log lines returned by the bundle's log search
+type LogMatch struct {
+	Pattern   string `yaml:"pattern"`   // Regex over the log line
+	Source    string `yaml:"source"`    // Regex over the source: "namespace/pod" or log file name
+	Namespace string `yaml:"namespace"` // Regex over the pod namespace (pod logs only)
+	Pod       string `yaml:"pod"`       // Regex over the pod name (pod logs only)
+}
+
+// FileMatch matches lines of bundle files
+type FileMatch struct {
+	Path    string `yaml:"path"`    // Glob relative to the bundle root, e.g. "journald/*"
+	Pattern string `yaml:"pattern"` // Regex over each line
+}
+
+// Problem is a rule file or rule that failed to load or validate
+type Problem = userfiles.Problem
+
+// RuleSet holds the valid rules loaded from a set of paths and the problems found
+type RuleSet struct {
+	Rules    []*Rule
+	Problems []Problem
+	Files    []string // Rule files read
+}
+
+// DefaultDirs returns the existing rule directories: ~/.r8s/rules.d and <bundleRoot>/rules.d
+func DefaultDirs(bundleRoot string) []string {
+	return userfiles.DefaultDirs(bundleRoot, "rules.d")
+}
+
+// Load reads rules from files and directories (every *.yaml and *.yml file, in name order).
+// Invalid rules are left out of the set and reported as problems; a rule ID defined twice
+// keeps the first definition.
+func Load(paths ...string) *RuleSet {
+	set := &RuleSet{}
+	seen := make(map[string]string) // rule ID -> file
+
+	for _, path := range paths {
+		files, err := userfiles.YAMLFiles(path)
+		if err != nil {
+			set.Problems = append(set.Problems, Problem{File: path, Message: err.Error()})
+			continue
+		}
+		for _, file := range files {
+			set.Files = append(set.Files, file)
+			data, err := os.ReadFile(file)
+			if err != nil {
+				set.Problems = append(set.Problems, Problem{File: file, Message: err.Error()})
+				continue
+			}
+			rules, err := Parse(data)
+			if err != nil {
+				set.Problems = append(set.Problems, Problem{File: file, Message: err.Error()})
+				continue
+			}
+			for _, rule := range rules {
+				rule.File = file
+				if msgs := rule.Validate(); len(msgs) > 0 {
+					for _, msg := range msgs {
+						set.Problems = append(set.Problems, Problem{File: file, ID: rule.ID, Message: msg})
+					}
+					continue
+				}
+				if other, ok := seen[rule.ID]; ok {
+					set.Problems = append(set.Problems, Problem{File: file, ID: rule.ID, Message: "duplicate rule id, already defined in " + other})
+					continue
+				}
+				seen[rule.ID] = file
+				set.Rules = append(set.Rules, rule)
+			}
+		}
+	}
+
+	return set
+}
+
+// Parse decodes a rule file: either a single rule, or a list of rules under "rules:".
+// Unknown keys are errors so misspelled settings are not silently ignored, and so are empty
+// list items.
+func Parse(data []byte) ([]*Rule, error) {
+	var top map[string]yaml.Node
+	if err := yaml.Unmarshal(data, &top); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+	if len(top) == 0 {
+		return nil, fmt.Errorf("no rules in file")
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if _, ok := top["rules"]; ok {
+		var file struct {
+			Rules []*Rule `yaml:"rules"`
+		}
+		if err := dec.Decode(&file); err != nil {
+			return nil, fmt.Errorf("invalid rule file: %w", err)
+		}
+		for i, rule := range file.Rules {
+			if rule == nil {
+				return nil, fmt.Errorf("empty rule at index %d", i)
+			}
+		}
+		return file.Rules, nil
+	}
+
+	var rule Rule
+	if err := dec.Decode(&rule); err != nil {
+		return nil, fmt.Errorf("invalid rule: %w", err)
+	}
+	return []*Rule{&rule}, nil
+}
+
+// Validate checks the rule and compiles its patterns, returning one message per problem
+func (r *Rule) Validate() []string {
+	var msgs []string
+	add := func(format string, args ...interface{}) {
+		msgs = append(msgs, fmt.Sprintf(format, args...))
+	}
+
+	if r.ID == "" {
+		add("id is required")
+	}
+	if r.Title == "" {
+		add("title is required")
+	}
+	switch r.Severity {
+	case SeverityCritical, SeverityWarning, SeverityInfo:
+	case "":
+		add("severity is required (critical, warning or info)")
+	default:
+		add("invalid severity %q (critical, warning or info)", r.Severity)
+	}
+	if r.Runbook != "" && !strings.HasPrefix(r.Runbook, "https://") && !strings.HasPrefix(r.Runbook, "http://") {
+		add("runbook must be an http(s) URL: %q", r.Runbook)
+	}
+	if r.Threshold < 0 {
+		add("threshold must not be negative")
+	}
+
+	m := r.Match
+	kinds := 0
+	if m.Resource != "" || len(m.Fields) > 0 {
+		kinds++
+	}
+	if m.Logs != nil {
+		kinds++
+	}
+	if m.File != nil {
+		kinds++
+	}
+	if kinds != 1 {
+		add("match needs exactly one of resource, logs or file")
+		return msgs
+	}
+
+	compile := func(name, expr string) *regexp.Regexp {
+		if expr == "" {
+			return nil
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			add("invalid %s regex: %v", name, err)
+		}
+		return re
+	}
+
+	switch {
+	case m.Logs != nil:
+		if m.Logs.Pattern == "" {
+			add("match.logs.pattern is required")
+		}
+		r.pattern = compile("match.logs.pattern", m.Logs.Pattern)
+		r.source = compile("match.logs.source", m.Logs.Source)
+		r.namespace = compile("match.logs.namespace", m.Logs.Namespace)
+		r.pod = compile("match.logs.pod", m.Logs.Pod)
+	case m.File != nil:
+		if m.File.Path == "" {
+			add("match.file.path is required")
+		} else if filepath.IsAbs(m.File.Path) || strings.HasPrefix(filepath.Clean(m.File.Path), "..") {
+			add("match.file.path must be relative to the bundle root: %q", m.File.Path)
+		} else if _, err := filepath.Match(m.File.Path, ""); err != nil {
+			add("invalid match.file.path glob %q", m.File.Path)
+		}
+		if m.File.Pattern == "" {
+			add("match.file.pattern is required")
+		}
+		r.pattern = compile("match.file.pattern", m.File.Pattern)
+	default:
+		known, ok := resourceFields[m.Resource]
+		if !ok {
+			add("invalid match.resource %q (pod, event or node)", m.Resource)
+			break
+		}
+		if len(m.Fields) == 0 {
+			add("match.fields needs at least one field (%s fields: %s)", m.Resource, strings.Join(known, ", "))
+		}
+		r.fields = make(map[string]valueMatcher)
+		for name, expr := range m.Fields {
+			if !contains(known, name) {
+				add("unknown %s field %q (%s)", m.Resource, name, strings.Join(known, ", "))
+				continue
+			}
+			matcher, err := parseValueMatcher(expr)
+			if err != nil {
+				add("field %s: %v", name, err)
+				continue
+			}
+			r.fields[name] = matcher
+		}
+	}
+
+	return msgs
+}
+
+// MinMatches returns the number of matches needed for the rule to fire
+func (r *Rule) MinMatches() int {
+	if r.Threshold <= 0 {
+		return 1
+	}
+	return r.Threshold
+}
+
+// Icon returns the rule's emoji, or DefaultEmoji
+func (r *Rule) Icon() string {
+	if r.Emoji == "" {
+		return DefaultEmoji
+	}
+	return r.Emoji
+}
+
+// valueMatcher matches a field value against a regex or a numeric comparison
+type valueMatcher struct {
+	re    *regexp.Regexp // Anchored regex, when not a comparison
+	op    string         // ">", ">=", "<", "<=", "==" or "!="
+	limit float64
+}
+
+// comparisonRe matches a numeric comparison such as ">5" or "<= 0.5"
+var comparisonRe = regexp.MustCompile(`^(>=|<=|==|!=|>|<)\s*(-?[0-9.]+)$`)
+
+// parseValueMatcher parses a field expression: a numeric comparison, or a regex that must
+// match the whole value
+func parseValueMatcher(expr string) (valueMatcher, error) {
+	expr = strings.TrimSpace(expr)
+	if m := comparisonRe.FindStringSubmatch(expr); m != nil {
+		limit, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			return valueMatcher{}, fmt.Errorf("invalid number in %q", expr)
+		}
+		return valueMatcher{op: m[1], limit: limit}, nil
+	}
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return valueMatcher{}, fmt.Errorf("invalid regex %q: %v", expr, err)
+	}
+	return valueMatcher{re: re}, nil
+}
+
+// matches reports whether value satisfies the matcher. Comparisons use the leading number
+// of the value ("3/3" compares as 3) and never match non-numeric values.
+func (m valueMatcher) matches(value string) bool {
+	if m.re != nil {
+		return m.re.MatchString(value)
+	}
+	num := leadingNumberRe.FindString(value)
+	if num == "" {
+		return false
+	}
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return false
+	}
+	switch m.op {
+	case ">":
+		return v > m.limit
+	case ">=":
+		return v >= m.limit
+	case "<":
+		return v < m.limit
+	case "<=":
+		return v <= m.limit
+	case "==":
+		return v == m.limit
+	case "!=":
+		return v != m.limit
+	}
+	return false
+}
+
+// leadingNumberRe extracts the number a field value starts with
+var leadingNumberRe = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?`)
+
+// contains reports whether list contains s
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,217 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeFile writes content to root/rel, creating parent directories
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	path := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLoad tests rule file formats, validation problems and duplicate IDs
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "10-pods.yaml", `rules:
+- id: restarting-ingress
+  title: Ingress controller restarting
+  severity: warning
+  runbook: https://example.com/runbooks/ingress
+  match:
+    resource: pod
+    fields:
+      namespace: kube-system
+      name: rke2-ingress-nginx-.*
+      restarts: ">3"
+- id: bad-severity
+  title: Bad severity
+  severity: urgent
+  match:
+    resource: node
+    fields:
+      status: NotReady
+- id: two-kinds
+  title: Two match kinds
+  severity: info
+  match:
+    resource: event
+    fields:
+      reason: BackOff
+    logs:
+      pattern: error
+`)
+	writeFile(t, dir, "20-file.yml", `id: multipath
+title: multipathd claims Longhorn devices
+severity: critical
+emoji: 💾
+match:
+  file:
+    path: journald/*
+    pattern: 'multipathd.*(sd[a-z]+)'
+`)
+	writeFile(t, dir, "30-dup.yaml", `id: restarting-ingress
+title: Duplicate
+severity: info
+match:
+  logs:
+    pattern: '(unclosed'
+`)
+	writeFile(t, dir, "40-typo.yaml", `id: typo
+title: Misspelled key
+severity: info
+treshold: 5
+match:
+  resource: pod
+  fields:
+    state: CrashLoopBackOff
+`)
+	writeFile(t, dir, "notes.txt", "not a rule file")
+
+	set := Load(dir)
+	if len(set.Files) != 4 {
+		t.Errorf("files = %v, want the 4 YAML files", set.Files)
+	}
+	if len(set.Rules) != 2 || set.Rules[0].ID != "restarting-ingress" || set.Rules[1].ID != "multipath" {
+		t.Fatalf("rules = %+v", set.Rules)
+	}
+	if set.Rules[0].Icon() != DefaultEmoji || set.Rules[1].Icon() != "💾" || set.Rules[0].MinMatches() != 1 {
+		t.Errorf("defaults not applied: %+v", set.Rules)
+	}
+
+	want := []string{
+		"bad-severity: invalid severity",
+		"two-kinds: match needs exactly one",
+		"restarting-ingress: invalid match.logs.pattern regex",
+		"40-typo.yaml: invalid rule",
+	}
+	if len(set.Problems) != len(want) {
+		t.Errorf("got %d problems, want %d: %v", len(set.Problems), len(want), set.Problems)
+	}
+	for _, w := range want {
+		found := false
+		for _, p := range set.Problems {
+			if strings.Contains(p.String(), w) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing problem %q in %v", w, set.Problems)
+		}
+	}
+}
+
+// TestLoadEmptyRule tests that an empty list item is reported instead of loaded as a nil rule
+func TestLoadEmptyRule(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "empty.yaml", "rules:\n  -\n")
+
+	set := Load(dir)
+	if len(set.Rules) != 0 || len(set.Problems) != 1 || !strings.Contains(set.Problems[0].String(), "empty rule at index 0") {
+		t.Errorf("rules = %v, problems = %v", set.Rules, set.Problems)
+	}
+}
+
+// TestLogSearches tests that only log rules contribute a log search, with the rule's pattern
+func TestLogSearches(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "rules.yaml", `rules:
+- id: oom
+  title: OOM kills
+  severity: warning
+  match:
+    logs:
+      pattern: 'Out of memory: Killed process'
+- id: pending
+  title: Pending pods
+  severity: info
+  match:
+    resource: pod
+    fields:
+      state: Pending
+`)
+
+	set := Load(dir)
+	searches := set.LogSearches()
+	if len(searches) != 1 || searches[0].Pattern != set.Rules[0].pattern || searches[0].MaxMatches != maxLogMatches {
+		t.Errorf("searches = %+v", searches)
+	}
+	if (*RuleSet)(nil).LogSearches() != nil {
+		t.Error("nil rule set has log searches")
+	}
+}
+
+// TestValueMatcher tests regex and numeric comparison field matching
+func TestValueMatcher(t *testing.T) {
+	tests := []struct {
+		expr  string
+		value string
+		want  bool
+	}{
+		{"CrashLoopBackOff", "CrashLoopBackOff", true},
+		{"Running", "NotRunning", false}, // Regexes match the whole value
+		{"Error|OOMKilled", "OOMKilled", true},
+		{">5", "6", true},
+		{">5", "5", false},
+		{">=5", "5", true},
+		{"<1", "0/1", true}, // Leading number of "ready"
+		{"!= 0", "3", true},
+		{">5", "unknown", false},
+	}
+	for _, tt := range tests {
+		m, err := parseValueMatcher(tt.expr)
+		if err != nil {
+			t.Fatalf("parseValueMatcher(%q) error = %v", tt.expr, err)
+		}
+		if got := m.matches(tt.value); got != tt.want {
+			t.Errorf("%q matches %q = %v, want %v", tt.expr, tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestFileRuleFindings tests file matching, thresholds, per-resource grouping and placeholders
+func TestFileRuleFindings(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, root, "journald/rke2-server", "ok\nmultipathd: sdb: add path\nmultipathd: sdc: add path\n")
+	writeFile(t, root, "journald/kubelet", "multipathd: sdd: add path\n")
+
+	rule := &Rule{
+		ID:          "multipath",
+		Title:       "multipathd",
+		Severity:    SeverityCritical,
+		Description: "{count} devices claimed in {resource}, first: {match}",
+		Threshold:   2,
+		PerResource: true,
+		Match:       Match{File: &FileMatch{Path: "journald/*", Pattern: `multipathd: sd[a-z]+`}},
+	}
+	if msgs := rule.Validate(); len(msgs) > 0 {
+		t.Fatalf("Validate() = %v", msgs)
+	}
+
+	hits, err := rule.fileHits(root)
+	if err != nil || len(hits) != 3 {
+		t.Fatalf("fileHits() = %+v, %v", hits, err)
+	}
+
+	findings := rule.Findings(hits)
+	if len(findings) != 1 || findings[0].Resource != "journald/rke2-server" || findings[0].Count != 2 {
+		t.Fatalf("findings = %+v, want only rke2-server above the threshold", findings)
+	}
+	if want := "2 devices claimed in journald/rke2-server, first: journald/rke2-server:2: multipathd: sdb: add path"; findings[0].Description != want {
+		t.Errorf("description = %q", findings[0].Description)
+	}
+
+	rule.PerResource = false
+	if findings := rule.Findings(hits); len(findings) != 1 || findings[0].Count != 3 || len(findings[0].Evidence) != 3 {
+		t.Errorf("aggregate findings = %+v", findings)
+	}
+}
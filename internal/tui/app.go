@@ -7,6 +7,7 @@ package tui
 import (
 	"encoding/json"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -17,7 +18,10 @@ import (
 
 	"github.com/Rancheroo/r8s/internal/config"
 	"github.com/Rancheroo/r8s/internal/datasource"
+	"github.com/Rancheroo/r8s/internal/knowledge"
 	"github.com/Rancheroo/r8s/internal/rancher"
+	"github.com/Rancheroo/r8s/internal/rbac"
+	"github.com/Rancheroo/r8s/internal/rules"
 )
 
 // safeRowString safely extracts a string value from table row data.
@@ -81,6 +85,26 @@ const (
 	ViewCRDs
 	ViewCRDInstances
 	ViewLogs
+	ViewRBAC
+	ViewHelmCharts
+	ViewNetworkPolicies
+	ViewConfigMaps
+	ViewConfigMapData
+	ViewHPAs
+	ViewResources
+	ViewResourceTable
+	ViewRuntime
+	ViewImages
+	ViewEtcd
+	ViewMetrics
+	ViewCertificates
+	ViewNetworking
+	ViewSystem
+	ViewKernel
+	ViewHostConfig
+	ViewControlPlane
+	ViewRKE2Config
+	ViewTimeline
 )
 
 // ViewContext holds context for the current view
@@ -101,6 +125,11 @@ type ViewContext struct {
 	// Context for logs
 	podName       string
 	containerName string
+	logPath       string // Non-pod log file (journald, syslog, kubelet) opened from the timeline
+	// Context for ConfigMap content
+	configMapName string
+	// Context for the generic resource browser
+	resourceName string
 }
 
 // App represents the main TUI application
@@ -125,6 +154,74 @@ type App struct {
 	crdInstances []map[string]interface{}
 	logs         []string // Log lines for current pod
 
+	// NetworkPolicies isolating the current log pod (annotates connection errors)
+	logIsolatingPolicies []string
+
+	// RBAC view
+	rbacEvaluator *rbac.Evaluator
+	rbacSubjects  []rbac.SubjectSummary
+
+	// HelmCharts view
+	helmCharts []datasource.HelmChartStatus
+
+	// ConfigMaps view
+	configMaps       []rancher.ConfigMap
+	configMapViewing rancher.ConfigMap // ConfigMap shown in ViewConfigMapData
+
+	// HPAs view
+	hpas []datasource.HPAStatus
+
+	// Runtime view
+	runtime *datasource.RuntimeStatus
+
+	// Images view
+	images *datasource.ImageReport
+
+	// etcd view
+	etcd *datasource.EtcdStatus
+
+	// Metrics explorer (filtered with the resource browser's '/' prompt)
+	metrics []datasource.MetricsSource
+
+	// Certificates view
+	certificates *datasource.CertificateReport
+
+	// Networking view (filtered with the resource browser's '/' prompt)
+	networking *datasource.NetworkReport
+
+	// System resources view (filtered with the resource browser's '/' prompt)
+	system *datasource.SystemResources
+
+	// Kernel events (dmesg), host config lint, control plane and RKE2 config views
+	kernel       *datasource.KernelLog
+	hostConfig   *datasource.HostConfig
+	controlPlane *datasource.ControlPlane
+	rke2Config   *datasource.RKE2Config
+
+	// Timeline view: entries from every source, zoom windows (innermost last), the rows shown
+	// and the entry to highlight when the table is rebuilt
+	timeline         *datasource.Timeline
+	timelineZoom     []timelineRange
+	timelineRows     []int
+	timelineSelected int
+
+	// User-defined attention rules, loaded at startup
+	userRules *rules.RuleSet
+
+	// Known-issue knowledge base: built-in entries plus ~/.r8s/kb.d and the bundle's kb.d
+	knowledge *knowledge.Base
+
+	// Generic resource browser
+	resourceTypes    []datasource.ResourceType
+	resourceTable    *datasource.ResourceTable
+	resourceSortCol  int // -1 = file order
+	resourceSortDesc bool
+	resourceFilter   string
+
+	// NetworkPolicies view
+	networkPolicies []datasource.NetworkPolicyStatus
+	netpolByPod     bool // Show pod → policies instead of policy → pods
+
 	projectNamespaceCounts map[string]int
 
 	// UI state
@@ -138,6 +235,10 @@ type App struct {
 	describeContent    string
 	describeTitle      string
 
+	// Prompt input: '/' filters resource views, ':' jumps to a resource type (0 = closed)
+	promptMode rune
+	promptText string
+
 	// Log search state
 	searchMode    bool
 	searchQuery   string
@@ -151,6 +252,7 @@ type App struct {
 	filterLevel      string   // Log level filter: "", "ERROR", "WARN", "INFO"
 	showPrevious     bool     // Show previous logs (for crashed containers)
 	wordWrap         bool     // Enable word wrapping for long log lines
+	logJumpLine      int      // 1-based line to scroll to and highlight when logs arrive (0 = none)
 
 	// App state
 	offlineMode bool   // Flag to indicate running without live Rancher connection
@@ -165,6 +267,11 @@ type App struct {
 	expandedItems     map[int]bool    // Which collapsed event items are expanded
 	subCursor         int             // Selected pod index within expanded event (-1 = not in sub-nav)
 
+	// Incident correlation: attentionItems holds the incidents unless attentionFlat
+	attentionRaw       []AttentionItem // Items as detected, one per signal
+	attentionIncidents []AttentionItem // Items grouped into incidents by CorrelateIncidents
+	attentionFlat      bool            // Show attentionRaw instead of incidents ('x')
+
 	// Sorting state
 	sortMode        SortMode              // Current sort mode (global default)
 	sortModes       map[ViewType]SortMode // Per-view sort mode
@@ -235,12 +342,20 @@ func NewApp(cfg *config.Config, bundlePath string) *App {
 	// Always start with Attention Dashboard (the killer feature)
 	initialView := ViewContext{viewType: ViewAttention}
 
+	// User-defined attention rules from ~/.r8s/rules.d and the bundle's rules.d
+	userRules := rules.Load(rules.DefaultDirs(ds.BundleRoot())...)
+
+	// Known-issue knowledge base, extended by ~/.r8s/kb.d and the bundle's kb.d
+	kb := knowledge.Load(knowledge.DefaultDirs(ds.BundleRoot())...)
+
 	return &App{
 		config:          cfg,
 		dataSource:      ds,
 		offlineMode:     offlineMode,
 		bundleMode:      bundleMode,
 		bundlePath:      bundlePath,
+		userRules:       userRules,
+		knowledge:       kb,
 		loading:         true,
 		currentView:     initialView,
 		sortMode:        SortByCount,                 // Default to count-based sorting
@@ -318,8 +433,12 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		if a.promptMode != 0 {
+			return a.handlePromptKey(msg)
+		}
+
 		// ATTENTION DASHBOARD NAVIGATION - Handle before general navigation
-		if a.currentView.viewType == ViewAttention && len(a.attentionItems) > 0 {
+		if a.currentView.viewType == ViewAttention && len(a.attentionItems) > 0 && !a.showingDescribe {
 			// Initialize subCursor if not set
 			if a.subCursor == 0 && a.expandedItems == nil {
 				a.subCursor = -1 // -1 means not in sub-navigation
@@ -329,9 +448,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case "j", "down":
 				// Check if we're in sub-navigation mode
 				if a.subCursor >= 0 {
-					// Navigate within pod list
+					// Navigate within pod (or symptom) list
 					item := a.attentionItems[a.attentionCursor]
-					if a.subCursor < len(item.AffectedPods)-1 {
+					if a.subCursor < item.SubItemCount()-1 {
 						a.subCursor++
 					}
 					return a, nil
@@ -339,7 +458,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				// Check if current item is expanded and has pods - enter sub-nav
 				currentItem := a.attentionItems[a.attentionCursor]
-				if a.expandedItems != nil && a.expandedItems[a.attentionCursor] && len(currentItem.AffectedPods) > 0 {
+				if a.expandedItems != nil && a.expandedItems[a.attentionCursor] && currentItem.SubItemCount() > 0 {
 					// Enter pod list
 					a.subCursor = 0
 					return a, nil
@@ -377,6 +496,24 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// Check if we're in sub-navigation - navigate to selected pod's logs
 				if a.subCursor >= 0 && a.attentionCursor < len(a.attentionItems) {
 					item := a.attentionItems[a.attentionCursor]
+					if len(item.Symptoms) > 0 && a.subCursor < len(item.Symptoms) {
+						// Incident symptom - open its pod's logs, if it has one
+						symptom := item.Symptoms[a.subCursor]
+						if symptom.PodName == "" {
+							return a, nil
+						}
+						a.viewStack = append(a.viewStack, a.currentView)
+						a.currentView = ViewContext{
+							viewType:      ViewLogs,
+							clusterID:     symptom.ClusterID,
+							namespaceName: symptom.Namespace,
+							podName:       symptom.PodName,
+							containerName: symptom.ContainerName,
+						}
+						a.filterLevel = ""
+						a.loading = true
+						return a, a.fetchLogs(symptom.ClusterID, symptom.Namespace, symptom.PodName)
+					}
 					if len(item.AffectedPods) > 0 && a.subCursor < len(item.AffectedPods) {
 						podName := item.AffectedPods[a.subCursor]
 
@@ -402,10 +539,10 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					}
 				}
 
-				// Navigate to logs for the selected item (pod issues only)
+				// Navigate to logs for the selected item (pod issues, or items linked to a pod)
 				if a.attentionCursor < len(a.attentionItems) {
 					item := a.attentionItems[a.attentionCursor]
-					if item.ResourceType == "pod" && item.PodName != "" {
+					if item.PodName != "" {
 						// Push current view to stack
 						a.viewStack = append(a.viewStack, a.currentView)
 
@@ -449,6 +586,27 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					a.expandedItems[a.attentionCursor] = !a.expandedItems[a.attentionCursor]
 				}
 				return a, nil
+			case "d":
+				// Detail pane: the selected item (or symptom) with its known issues and fixes
+				if a.attentionCursor < len(a.attentionItems) {
+					item := a.attentionItems[a.attentionCursor]
+					if a.subCursor >= 0 && a.subCursor < len(item.Symptoms) {
+						item = item.Symptoms[a.subCursor]
+					}
+					return a, a.describeAttentionItem(item)
+				}
+				return a, nil
+			case "x":
+				// Toggle incident grouping (related items nested under their root cause)
+				a.attentionFlat = !a.attentionFlat
+				a.attentionItems = a.attentionIncidents
+				if a.attentionFlat {
+					a.attentionItems = a.attentionRaw
+				}
+				a.expandedItems = nil
+				a.attentionCursor = 0
+				a.subCursor = -1
+				return a, nil
 			case "m":
 				// Toggle expansion of dashboard (show all vs capped)
 				a.attentionExpanded = !a.attentionExpanded
@@ -482,18 +640,21 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, tea.Quit
 		case "r", "ctrl+r", "ctrl+l":
 			// FIX BUG #7: Handle Ctrl+L to refresh (prevent terminal clear conflicts)
+			if a.currentView.viewType == ViewTimeline {
+				a.timeline = nil // Rebuild instead of reusing the loaded timeline
+			}
 			a.loading = true
 			return a, a.refreshCurrentView()
 		case "j":
 			// FIX BUG #14: Vim-style navigation down
-			if !a.searchMode && a.currentView.viewType != ViewLogs {
+			if !a.searchMode && !a.isViewportView() {
 				newTable, cmd := a.table.Update(tea.KeyMsg{Type: tea.KeyDown})
 				a.table = newTable
 				return a, cmd
 			}
 		case "k":
 			// FIX BUG #14: Vim-style navigation up
-			if !a.searchMode && a.currentView.viewType != ViewLogs {
+			if !a.searchMode && !a.isViewportView() {
 				newTable, cmd := a.table.Update(tea.KeyMsg{Type: tea.KeyUp})
 				a.table = newTable
 				return a, cmd
@@ -537,6 +698,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				a.currentView = a.viewStack[len(a.viewStack)-1]
 				a.viewStack = a.viewStack[:len(a.viewStack)-1]
+				if a.currentView.viewType == ViewTimeline {
+					a.showPrevious = false // Set per entry by the timeline
+				}
 				a.loading = true
 				return a, a.refreshCurrentView()
 			}
@@ -553,29 +717,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return a, a.handleDescribe()
 		case "C":
 			// Special binding to jump to CRDs from Cluster view
-			if a.currentView.viewType == ViewClusters || a.currentView.viewType == ViewProjects {
-				// Need cluster ID
-				clusterID := a.currentView.clusterID
-				clusterName := a.currentView.clusterName
-
-				// If in Cluster view, get selected cluster
-				if a.currentView.viewType == ViewClusters {
-					if a.table.HighlightedRow().Data == nil {
-						return a, nil
-					}
-					name := safeRowString(a.table.HighlightedRow().Data, "name")
-					if name == "" {
-						return a, nil
-					}
-					for _, c := range a.clusters {
-						if c.Name == name {
-							clusterID = c.ID
-							clusterName = c.Name
-							break
-						}
-					}
-				}
-
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
 				// Push current view
 				a.viewStack = append(a.viewStack, a.currentView)
 
@@ -588,6 +730,50 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.loading = true
 				return a, a.fetchCRDs(clusterID)
 			}
+		case "R":
+			// Jump to RBAC subjects from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewRBAC,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.loading = true
+				return a, a.fetchRBAC()
+			}
+		case "H":
+			// Jump to HelmCharts from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewHelmCharts,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.loading = true
+				return a, a.fetchHelmCharts()
+			}
+		case "P":
+			// Jump to NetworkPolicies from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewNetworkPolicies,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.netpolByPod = false
+				a.loading = true
+				return a, a.fetchNetworkPolicies()
+			}
+		case "p":
+			// Toggle policy → pods / pod → policies in NetworkPolicies view
+			if a.currentView.viewType == ViewNetworkPolicies {
+				a.netpolByPod = !a.netpolByPod
+				a.updateTable()
+				return a, nil
+			}
 		case "1":
 			if a.isNamespaceResourceView() {
 				a.currentView.viewType = ViewPods
@@ -606,6 +792,18 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.loading = true
 				return a, a.refreshCurrentView()
 			}
+		case "4":
+			if a.isNamespaceResourceView() {
+				a.currentView.viewType = ViewConfigMaps
+				a.loading = true
+				return a, a.refreshCurrentView()
+			}
+		case "5":
+			if a.isNamespaceResourceView() {
+				a.currentView.viewType = ViewHPAs
+				a.loading = true
+				return a, a.refreshCurrentView()
+			}
 		case "c":
 			// Navigate from Attention Dashboard to Clusters
 			if a.currentView.viewType == ViewAttention {
@@ -697,6 +895,197 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				a.currentMatch = -1
 				return a, nil
 			}
+			// Filter rows in the resource browser and metrics explorer
+			if a.isResourceView() || a.currentView.viewType == ViewMetrics || a.currentView.viewType == ViewNetworking ||
+				a.currentView.viewType == ViewSystem || a.currentView.viewType == ViewKernel ||
+				a.currentView.viewType == ViewHostConfig || a.currentView.viewType == ViewControlPlane ||
+				a.currentView.viewType == ViewRKE2Config || a.currentView.viewType == ViewTimeline {
+				a.promptMode = '/'
+				a.promptText = a.resourceFilter
+				return a, nil
+			}
+		case ":":
+			// Jump to any resource type by name, kind or short name
+			if !a.isViewportView() && !a.searchMode {
+				a.promptMode = ':'
+				a.promptText = ""
+				return a, nil
+			}
+		case "A":
+			// Jump to all resource types from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				return a, a.openResourceTypes(clusterID, clusterName)
+			}
+		case "T":
+			// Jump to the container runtime (crictl) view from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewRuntime,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.loading = true
+				return a, a.fetchRuntime()
+			}
+		case "I":
+			// Jump to the image inventory from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewImages,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.loading = true
+				return a, a.fetchImages()
+			}
+		case "E":
+			// Jump to etcd members, quota and snapshots from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewEtcd,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.loading = true
+				return a, a.fetchEtcdStatus()
+			}
+		case "M":
+			// Jump to the metrics explorer from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewMetrics,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.resourceFilter = ""
+				a.loading = true
+				return a, a.fetchMetrics()
+			}
+		case "X":
+			// Jump to the certificates (x509) view from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewCertificates,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.loading = true
+				return a, a.fetchCertificates()
+			}
+		case "W":
+			// Jump to the node networking view from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewNetworking,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.resourceFilter = ""
+				a.loading = true
+				return a, a.fetchNetworking()
+			}
+		case "U":
+			// Jump to the node's system resource usage from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewSystem,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.resourceFilter = ""
+				a.loading = true
+				return a, a.fetchSystemResources()
+			}
+		case "K":
+			// Jump to the node's kernel events (dmesg) from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewKernel,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.resourceFilter = ""
+				a.loading = true
+				return a, a.fetchKernelEvents()
+			}
+		case "O":
+			// Jump to the node's host config (sysctls, modules, firewalls, AppArmor) from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewHostConfig,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.resourceFilter = ""
+				a.loading = true
+				return a, a.fetchHostConfig()
+			}
+		case "F":
+			// Jump to the node's control plane static pods and their flags from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewControlPlane,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.resourceFilter = ""
+				a.loading = true
+				return a, a.fetchControlPlane()
+			}
+		case "Y":
+			// Jump to the node's RKE2 config (50-rancher.yaml and service units) from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewRKE2Config,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.resourceFilter = ""
+				a.loading = true
+				return a, a.fetchRKE2Config()
+			}
+		case "L":
+			// Jump to the timeline of logs, events, dmesg and restarts from Cluster view
+			if clusterID, clusterName, ok := a.selectedClusterContext(); ok {
+				a.viewStack = append(a.viewStack, a.currentView)
+				a.currentView = ViewContext{
+					viewType:    ViewTimeline,
+					clusterID:   clusterID,
+					clusterName: clusterName,
+				}
+				a.resourceFilter = ""
+				a.timelineZoom = nil
+				a.timelineSelected = -1
+				a.loading = true
+				return a, a.fetchTimeline()
+			}
+		case "[", "]":
+			// Previous/next burst in the timeline histogram
+			if a.currentView.viewType == ViewTimeline {
+				if msg.String() == "[" {
+					a.moveTimelineBurst(-1)
+				} else {
+					a.moveTimelineBurst(1)
+				}
+				return a, nil
+			}
+		case "z", "Z":
+			// Zoom into the highlighted entry's histogram bar, or back out
+			if a.currentView.viewType == ViewTimeline {
+				a.zoomTimeline(msg.String() == "z")
+				return a, nil
+			}
 		case "n":
 			// Next match in search
 			if a.currentView.viewType == ViewLogs && len(a.searchMatches) > 0 {
@@ -724,13 +1113,13 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "g":
 			// Jump to first log line (vim muscle memory)
-			if a.currentView.viewType == ViewLogs && !a.searchMode {
+			if a.isViewportView() && !a.searchMode {
 				a.logViewport.GotoTop()
 				return a, nil
 			}
 		case "G":
 			// Jump to last log line (vim muscle memory)
-			if a.currentView.viewType == ViewLogs && !a.searchMode {
+			if a.isViewportView() && !a.searchMode {
 				a.logViewport.GotoBottom()
 				return a, nil
 			}
@@ -750,6 +1139,17 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else if a.currentView.viewType == ViewPods {
 				// Classic Pod view: 2-mode toggle (Count ↔ Name)
 				return a, a.togglePodSortMode()
+			} else if a.currentView.viewType == ViewResourceTable {
+				// Generic table: sort by the next column
+				a.cycleResourceSort()
+				return a, nil
+			}
+		case "S":
+			// Reverse sort order in the generic table
+			if a.currentView.viewType == ViewResourceTable && a.resourceSortCol >= 0 {
+				a.resourceSortDesc = !a.resourceSortDesc
+				a.updateTable()
+				return a, nil
 			}
 		}
 
@@ -757,7 +1157,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.width = msg.Width
 		a.height = msg.Height
 		// FIX BUG #11: Resize log viewport on window resize
-		if a.currentView.viewType == ViewLogs {
+		if a.isViewportView() {
 			a.logViewport.Width = a.width - 4
 			a.logViewport.Height = a.height - 6
 		}
@@ -818,6 +1218,139 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.error = ""
 		a.updateTable()
 
+	case helmChartsMsg:
+		a.loading = false
+		a.helmCharts = msg.charts
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case configMapsMsg:
+		a.loading = false
+		a.configMaps = msg.configMaps
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case resourceTypesMsg:
+		a.loading = false
+		a.resourceTypes = msg.types
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case resourceTableMsg:
+		a.loading = false
+		a.resourceTable = msg.table
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case hpasMsg:
+		a.loading = false
+		a.hpas = msg.hpas
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case runtimeMsg:
+		a.loading = false
+		a.runtime = msg.status
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case imagesMsg:
+		a.loading = false
+		a.images = msg.report
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case etcdStatusMsg:
+		a.loading = false
+		a.etcd = msg.status
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case metricsMsg:
+		a.loading = false
+		a.metrics = msg.sources
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case certificatesMsg:
+		a.loading = false
+		a.certificates = msg.report
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case networkingMsg:
+		a.loading = false
+		a.networking = msg.report
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case systemResourcesMsg:
+		a.loading = false
+		a.system = msg.resources
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case kernelEventsMsg:
+		a.loading = false
+		a.kernel = msg.log
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case timelineMsg:
+		a.loading = false
+		a.timeline = msg.timeline
+		a.error = ""
+		a.updateTable()
+
+	case hostConfigMsg:
+		a.loading = false
+		a.hostConfig = msg.host
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case controlPlaneMsg:
+		a.loading = false
+		a.controlPlane = msg.controlPlane
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case rke2ConfigMsg:
+		a.loading = false
+		a.rke2Config = msg.config
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case networkPoliciesMsg:
+		a.loading = false
+		a.networkPolicies = msg.policies
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
+	case rbacMsg:
+		a.loading = false
+		a.rbacEvaluator = msg.evaluator
+		a.rbacSubjects = msg.subjects
+		a.error = ""
+		a.updateTable()
+		a.restoreSelection()
+
 	case describeMsg:
 		a.loading = false
 		a.showingDescribe = true
@@ -828,11 +1361,16 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case logsMsg:
 		a.loading = false
 		a.logs = msg.logs
+		a.logIsolatingPolicies = msg.isolatingPolicies
 		a.error = ""
 
 		// Initialize viewport for logs view with colored content
 		a.logViewport = viewport.New(a.width-4, a.height-6)
 		a.logViewport.SetContent(a.renderLogsWithColors())
+		if a.logJumpLine > 0 {
+			a.jumpToLogLine(a.logJumpLine - 1)
+			a.logJumpLine = 0
+		}
 
 	case tailTickMsg:
 		// Handle tail mode tick - fetch new logs and schedule next tick
@@ -845,7 +1383,12 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case attentionMsg:
 		a.loading = false
-		a.attentionItems = msg.items
+		a.attentionRaw = msg.items
+		a.attentionIncidents = msg.incidents
+		a.attentionItems = msg.incidents
+		if a.attentionFlat {
+			a.attentionItems = msg.items
+		}
 		a.error = ""
 
 	case errMsg:
@@ -860,8 +1403,8 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, cmd)
 	}
 
-	// Update viewport if in logs view
-	if a.currentView.viewType == ViewLogs {
+	// Update viewport if in logs or ConfigMap content view
+	if a.isViewportView() {
 		newViewport, cmd := a.logViewport.Update(msg)
 		a.logViewport = newViewport
 		if cmd != nil {
@@ -916,6 +1459,11 @@ func (a *App) View() string {
 		return a.renderLogsView()
 	}
 
+	// ConfigMap content reuses the log viewport
+	if a.currentView.viewType == ViewConfigMapData {
+		return a.renderConfigMapDataView()
+	}
+
 	// Build view components
 	breadcrumb := breadcrumbStyle.Render(a.getBreadcrumb())
 	statusText := a.getStatusText()
@@ -934,6 +1482,12 @@ func (a *App) View() string {
 		components = append(components, "", warningBanner)
 	}
 
+	if a.currentView.viewType == ViewTimeline {
+		if histogram := a.renderTimelineHistogram(); histogram != "" {
+			components = append(components, "", histogram)
+		}
+	}
+
 	components = append(components, "", tableView)
 
 	// Add description caption if in CRD view and toggled on
@@ -1005,6 +1559,10 @@ func (a *App) renderLogsView() string {
 
 	contextHeader := fmt.Sprintf("Pod: %s%s (%d lines · %d errors · %d warnings)",
 		a.currentView.podName, containerInfo, len(visibleLogs), errorCount, warnCount)
+	if a.currentView.logPath != "" {
+		contextHeader = fmt.Sprintf("Log: %s (%d lines · %d errors · %d warnings)",
+			filepath.Base(a.currentView.logPath), len(visibleLogs), errorCount, warnCount)
+	}
 	contextHeaderStyled := lipgloss.NewStyle().
 		Foreground(colorCyan).
 		Bold(true).
@@ -1065,6 +1623,63 @@ func (a *App) countLogLevel(logs []string, level string) int {
 // updateTable updates the table with current view data - handles all view types
 func (a *App) updateTable() {
 	switch a.currentView.viewType {
+	case ViewRBAC:
+		a.updateRBACTable()
+
+	case ViewHelmCharts:
+		a.updateHelmChartsTable()
+
+	case ViewNetworkPolicies:
+		a.updateNetworkPoliciesTable()
+
+	case ViewConfigMaps:
+		a.updateConfigMapsTable()
+
+	case ViewHPAs:
+		a.updateHPAsTable()
+
+	case ViewResources:
+		a.updateResourceTypesTable()
+
+	case ViewResourceTable:
+		a.updateResourceTableView()
+
+	case ViewRuntime:
+		a.updateRuntimeTable()
+
+	case ViewImages:
+		a.updateImagesTable()
+
+	case ViewEtcd:
+		a.updateEtcdTable()
+
+	case ViewMetrics:
+		a.updateMetricsTable()
+
+	case ViewCertificates:
+		a.updateCertificatesTable()
+
+	case ViewNetworking:
+		a.updateNetworkingTable()
+
+	case ViewSystem:
+		a.updateSystemTable()
+
+	case ViewKernel:
+		a.updateKernelTable()
+
+	case ViewTimeline:
+		a.updateTimelineTable()
+
+	case ViewHostConfig:
+		a.updateHostConfigTable()
+
+	case ViewControlPlane:
+		a.updateControlPlaneTable()
+
+	case ViewRKE2Config:
+		a.updateRKE2ConfigTable()
+
 	case ViewCRDs:
 		if len(a.crds) > 0 {
 			columns := []table.Column{
@@ -1632,11 +2247,89 @@ func (a *App) getBreadcrumb() string {
 	case ViewServices:
 		return modeIndicator + fmt.Sprintf("Cluster: %s > Project: %s > Namespace: %s > Services",
 			a.currentView.clusterName, a.currentView.projectName, a.currentView.namespaceName)
+	case ViewConfigMaps:
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Project: %s > Namespace: %s > ConfigMaps",
+			a.currentView.clusterName, a.currentView.projectName, a.currentView.namespaceName)
+	case ViewResources:
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Resources", a.currentView.clusterName)
+	case ViewResourceTable:
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Resources > %s", a.currentView.clusterName, a.resourceTableTitle())
+	case ViewRuntime:
+		node := ""
+		if a.runtime != nil {
+			node = a.runtime.NodeName
+		}
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Runtime: %s", a.currentView.clusterName, node)
+	case ViewImages:
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Images", a.currentView.clusterName)
+	case ViewEtcd:
+		return modeIndicator + fmt.Sprintf("Cluster: %s > etcd", a.currentView.clusterName)
+	case ViewMetrics:
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Metrics", a.currentView.clusterName)
+	case ViewCertificates:
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Certificates", a.currentView.clusterName)
+	case ViewNetworking:
+		node := ""
+		if a.networking != nil {
+			node = a.networking.Node
+		}
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Networking: %s", a.currentView.clusterName, node)
+	case ViewSystem:
+		node := ""
+		if a.system != nil {
+			node = a.system.Hostname
+		}
+		return modeIndicator + fmt.Sprintf("Cluster: %s > System: %s", a.currentView.clusterName, node)
+	case ViewKernel:
+		node := ""
+		if a.kernel != nil {
+			node = a.kernel.Node
+		}
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Kernel: %s", a.currentView.clusterName, node)
+	case ViewHostConfig:
+		node := ""
+		if a.hostConfig != nil {
+			node = a.hostConfig.Node
+		}
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Host config: %s", a.currentView.clusterName, node)
+	case ViewControlPlane:
+		node := ""
+		if a.controlPlane != nil {
+			node = a.controlPlane.Node
+		}
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Control plane: %s", a.currentView.clusterName, node)
+	case ViewRKE2Config:
+		node := ""
+		if a.rke2Config != nil {
+			node = a.rke2Config.Node
+		}
+		return modeIndicator + fmt.Sprintf("Cluster: %s > RKE2 config: %s", a.currentView.clusterName, node)
+	case ViewHPAs:
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Project: %s > Namespace: %s > HPAs",
+			a.currentView.clusterName, a.currentView.projectName, a.currentView.namespaceName)
+	case ViewConfigMapData:
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Project: %s > Namespace: %s > ConfigMap: %s",
+			a.currentView.clusterName, a.currentView.projectName, a.currentView.namespaceName, a.currentView.configMapName)
 	case ViewCRDs:
 		return modeIndicator + fmt.Sprintf("Cluster: %s > CRDs", a.currentView.clusterName)
 	case ViewCRDInstances:
 		return modeIndicator + fmt.Sprintf("Cluster: %s > CRDs > %s", a.currentView.clusterName, a.currentView.crdKind)
+	case ViewRBAC:
+		return modeIndicator + fmt.Sprintf("Cluster: %s > RBAC", a.currentView.clusterName)
+	case ViewHelmCharts:
+		return modeIndicator + fmt.Sprintf("Cluster: %s > HelmCharts", a.currentView.clusterName)
+	case ViewNetworkPolicies:
+		return modeIndicator + fmt.Sprintf("Cluster: %s > NetworkPolicies", a.currentView.clusterName)
+	case ViewTimeline:
+		node := ""
+		if a.timeline != nil {
+			node = a.timeline.Node
+		}
+		return modeIndicator + fmt.Sprintf("Cluster: %s > Timeline: %s", a.currentView.clusterName, node)
 	case ViewLogs:
+		if a.currentView.logPath != "" {
+			return modeIndicator + fmt.Sprintf("Cluster: %s > Log: %s", a.currentView.clusterName, filepath.Base(a.currentView.logPath))
+		}
 		return modeIndicator + fmt.Sprintf("Cluster: %s > Project: %s > Namespace: %s > Pod: %s > Logs",
 			a.currentView.clusterName, a.currentView.projectName, a.currentView.namespaceName, a.currentView.podName)
 	default:
@@ -1649,6 +2342,10 @@ func (a *App) getStatusText() string {
 	var status string
 	offlinePrefix := ""
 
+	if a.promptMode != 0 {
+		return a.promptStatusText()
+	}
+
 	if a.offlineMode {
 		offlinePrefix = "[OFFLINE MODE - Mock Data] "
 	}
@@ -1656,11 +2353,11 @@ func (a *App) getStatusText() string {
 	switch a.currentView.viewType {
 	case ViewClusters:
 		count := len(a.clusters)
-		status = fmt.Sprintf(" %s%d clusters | Enter=projects 'C'=CRDs 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count)
+		status = fmt.Sprintf(" %s%d clusters | Enter=projects 'C'=CRDs 'R'=RBAC 'H'=Helm 'P'=NetPol 'T'=runtime 'I'=images 'E'=etcd 'M'=metrics 'X'=certs 'W'=network 'U'=system 'K'=kernel 'O'=host 'F'=control plane 'Y'=rke2 config 'A'=all resources 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count)
 
 	case ViewProjects:
 		count := len(a.projects)
-		status = fmt.Sprintf(" %s%d projects | Enter=namespaces 'C'=CRDs 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count)
+		status = fmt.Sprintf(" %s%d projects | Enter=namespaces 'C'=CRDs 'R'=RBAC 'H'=Helm 'P'=NetPol 'T'=runtime 'I'=images 'E'=etcd 'M'=metrics 'X'=certs 'W'=network 'U'=system 'K'=kernel 'O'=host 'F'=control plane 'Y'=rke2 config 'A'=all resources 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count)
 
 	case ViewNamespaces:
 		count := len(a.namespaces)
@@ -1673,20 +2370,152 @@ func (a *App) getStatusText() string {
 		if !exists {
 			sortMode = a.sortMode
 		}
-		status = fmt.Sprintf(" %s%d pods | Sort: %s | 's'=sort 'l'=logs 'd'=describe '1/2/3'=switch | '?'=help 'q'=quit ", offlinePrefix, count, sortMode.String())
+		status = fmt.Sprintf(" %s%d pods | Sort: %s | 's'=sort 'l'=logs 'd'=describe '1-5'=switch | '?'=help 'q'=quit ", offlinePrefix, count, sortMode.String())
 
 	case ViewDeployments:
 		count := len(a.deployments)
-		status = fmt.Sprintf(" %s%d deployments | 'd'=describe '1/2/3'=switch view 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count)
+		status = fmt.Sprintf(" %s%d deployments | Enter/'d'=rollout history '1-5'=switch view 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count)
 
 	case ViewServices:
 		count := len(a.services)
-		status = fmt.Sprintf(" %s%d services | 'd'=describe '1/2/3'=switch view 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count)
+		status = fmt.Sprintf(" %s%d services | 'd'=describe '1-5'=switch view 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count)
+
+	case ViewConfigMaps:
+		count := len(a.configMaps)
+		status = fmt.Sprintf(" %s%d configmaps | Enter=view content 'd'=keys '1-5'=switch view 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count)
+
+	case ViewEtcd:
+		status = fmt.Sprintf(" %s%s | Enter/'d'=details 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, a.etcdStatusText())
+
+	case ViewCertificates:
+		count := 0
+		if a.certificates != nil {
+			count = len(a.certificates.Certificates)
+		}
+		status = fmt.Sprintf(" %s%d certificates (%d flagged) | Enter/'d'=details 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count, a.certificateProblemCount())
+
+	case ViewNetworking:
+		checks := 0
+		if a.networking != nil {
+			checks = len(a.networking.Checks)
+		}
+		filter := ""
+		if a.resourceFilter != "" {
+			filter = fmt.Sprintf(" (filter: %s)", a.resourceFilter)
+		}
+		status = fmt.Sprintf(" %s%d network checks, %d failing%s | Enter/'d'=details '/'=filter 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, checks, a.networkCheckFailures(), filter)
+
+	case ViewSystem:
+		filter := ""
+		if a.resourceFilter != "" {
+			filter = fmt.Sprintf(" (filter: %s)", a.resourceFilter)
+		}
+		status = fmt.Sprintf(" %s%s%s | Enter/'d'=details '/'=filter 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, a.systemStatusText(), filter)
+
+	case ViewKernel:
+		filter := ""
+		if a.resourceFilter != "" {
+			filter = fmt.Sprintf(" (filter: %s)", a.resourceFilter)
+		}
+		status = fmt.Sprintf(" %s%s%s | Enter/'d'=details '/'=filter 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, a.kernelStatusText(), filter)
+
+	case ViewTimeline:
+		filter := ""
+		if a.resourceFilter != "" {
+			filter = fmt.Sprintf(" (filter: %s)", a.resourceFilter)
+		}
+		status = fmt.Sprintf(" %s%s%s | Enter=open log at line 'd'=details '/'=filter 'r'=rebuild | '?'=help 'q'=quit ", offlinePrefix, a.timelineStatusText(), filter)
+
+	case ViewHostConfig:
+		filter := ""
+		if a.resourceFilter != "" {
+			filter = fmt.Sprintf(" (filter: %s)", a.resourceFilter)
+		}
+		status = fmt.Sprintf(" %s%s%s | Enter/'d'=details '/'=filter 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, a.hostConfigStatusText(), filter)
+
+	case ViewControlPlane:
+		filter := ""
+		if a.resourceFilter != "" {
+			filter = fmt.Sprintf(" (filter: %s)", a.resourceFilter)
+		}
+		status = fmt.Sprintf(" %s%s%s | Enter=logs 'd'=details '/'=filter 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, a.controlPlaneStatusText(), filter)
+
+	case ViewRKE2Config:
+		filter := ""
+		if a.resourceFilter != "" {
+			filter = fmt.Sprintf(" (filter: %s)", a.resourceFilter)
+		}
+		status = fmt.Sprintf(" %s%s%s | Enter/'d'=details '/'=filter 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, a.rke2ConfigStatusText(), filter)
+
+	case ViewMetrics:
+		shown, total := a.metricsCount()
+		filter := ""
+		if a.resourceFilter != "" {
+			filter = fmt.Sprintf(" (filter: %s)", a.resourceFilter)
+		}
+		status = fmt.Sprintf(" %s%d/%d series%s, %d checks failing | Enter/'d'=details '/'=filter (name, label=value) 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, shown, total, filter, a.metricsCheckFailures())
+
+	case ViewImages:
+		count := 0
+		if a.images != nil {
+			count = len(a.images.Images)
+		}
+		status = fmt.Sprintf(" %s%d images (%d flagged) | Enter/'d'=details 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count, a.imageProblemCount())
+
+	case ViewRuntime:
+		count := 0
+		if a.runtime != nil {
+			count = len(a.runtime.Containers)
+		}
+		status = fmt.Sprintf(" %s%d containers (%d issues) | Enter=pod logs 'd'=describe 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count, a.runtimeIssueCount())
+
+	case ViewResources:
+		status = fmt.Sprintf(" %s%d resource files | Enter=open '/'=filter ':'=jump 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, len(a.resourceTypes))
+
+	case ViewResourceTable:
+		shown, total := a.resourceTableCount()
+		filter := ""
+		if a.resourceFilter != "" {
+			filter = fmt.Sprintf(" (filter: %s)", a.resourceFilter)
+		}
+		status = fmt.Sprintf(" %s%d/%d rows%s | 's'=sort column 'S'=reverse '/'=filter ':'=jump 'd'=row details | '?'=help 'q'=quit ", offlinePrefix, shown, total, filter)
+
+	case ViewHPAs:
+		count := len(a.hpas)
+		status = fmt.Sprintf(" %s%d HPAs (%d limited) | Enter/'d'=describe '1-5'=switch view 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count, hpaProblemCount(a.hpas))
 
 	case ViewCRDs:
 		count := len(a.crds)
 		status = fmt.Sprintf(" %s%d CRDs | 'i'=toggle description Enter=instances 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count)
 
+	case ViewRBAC:
+		count := len(a.rbacSubjects)
+		status = fmt.Sprintf(" %s%d subjects (%d risky) | Enter/'d'=what can it do 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count, rbacRiskyCount(a.rbacSubjects))
+
+	case ViewHelmCharts:
+		count := len(a.helmCharts)
+		failing := 0
+		for _, chart := range a.helmCharts {
+			if chart.Problem != "" {
+				failing++
+			}
+		}
+		status = fmt.Sprintf(" %s%d HelmCharts (%d failing) | Enter=install logs 'd'=describe 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count, failing)
+
+	case ViewNetworkPolicies:
+		count := len(a.networkPolicies)
+		defaultDeny := 0
+		for _, policy := range a.networkPolicies {
+			if policy.DefaultDeny {
+				defaultDeny++
+			}
+		}
+		if a.netpolByPod {
+			status = fmt.Sprintf(" %s%d isolated pods | Enter=logs 'd'=describe 'p'=by policy 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, len(isolatedPods(a.networkPolicies)))
+		} else {
+			status = fmt.Sprintf(" %s%d NetworkPolicies (%d default-deny) | Enter/'d'=describe 'p'=by pod 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count, defaultDeny)
+		}
+
 	case ViewCRDInstances:
 		count := len(a.crdInstances)
 		status = fmt.Sprintf(" %s%d %s instances | 'd'=describe(soon) 'r'=refresh | '?'=help 'q'=quit ", offlinePrefix, count, a.currentView.crdKind)
@@ -1796,8 +2625,46 @@ func (a *App) refreshCurrentView() tea.Cmd {
 		return a.fetchDeployments(a.currentView.projectID, a.currentView.namespaceName)
 	case ViewServices:
 		return a.fetchServices(a.currentView.projectID, a.currentView.namespaceName)
+	case ViewConfigMaps, ViewConfigMapData:
+		return a.fetchConfigMaps(a.currentView.projectID, a.currentView.namespaceName)
+	case ViewHPAs:
+		return a.fetchHPAs(a.currentView.projectID, a.currentView.namespaceName)
+	case ViewResources:
+		return a.fetchResourceTypes()
+	case ViewRuntime:
+		return a.fetchRuntime()
+	case ViewImages:
+		return a.fetchImages()
+	case ViewEtcd:
+		return a.fetchEtcdStatus()
+	case ViewMetrics:
+		return a.fetchMetrics()
+	case ViewCertificates:
+		return a.fetchCertificates()
+	case ViewNetworking:
+		return a.fetchNetworking()
+	case ViewSystem:
+		return a.fetchSystemResources()
+	case ViewKernel:
+		return a.fetchKernelEvents()
+	case ViewTimeline:
+		return a.fetchTimeline()
+	case ViewHostConfig:
+		return a.fetchHostConfig()
+	case ViewControlPlane:
+		return a.fetchControlPlane()
+	case ViewRKE2Config:
+		return a.fetchRKE2Config()
+	case ViewResourceTable:
+		return a.fetchResourceTable(a.currentView.resourceName)
 	case ViewCRDs:
 		return a.fetchCRDs(a.currentView.clusterID)
+	case ViewRBAC:
+		return a.fetchRBAC()
+	case ViewHelmCharts:
+		return a.fetchHelmCharts()
+	case ViewNetworkPolicies:
+		return a.fetchNetworkPolicies()
 	default:
 		return nil
 	}
@@ -2020,6 +2887,67 @@ func (a *App) handleEnter() tea.Cmd {
 		a.loading = true
 		return a.fetchCRDInstances(a.currentView.clusterID, selectedCRD.Spec.Group, storageVersion, selectedCRD.Spec.Names.Plural)
 
+	case ViewRBAC:
+		return a.describeRBACSubject(selected)
+
+	case ViewHelmCharts:
+		return a.viewHelmInstallLogs(selected)
+
+	case ViewNetworkPolicies:
+		return a.handleNetworkPolicyEnter(selected)
+
+	case ViewDeployments:
+		// Drill down into rollout history (same as describe)
+		return a.handleDescribe()
+
+	case ViewConfigMaps:
+		return a.viewConfigMapData(selected)
+
+	case ViewHPAs:
+		return a.describeHPA(selected)
+
+	case ViewResources:
+		return a.handleResourceEnter(selected)
+
+	case ViewRuntime:
+		return a.handleRuntimeEnter(selected)
+
+	case ViewImages:
+		return a.describeImage(selected)
+
+	case ViewEtcd:
+		return a.describeEtcd(selected)
+
+	case ViewMetrics:
+		return a.describeMetric(selected)
+
+	case ViewCertificates:
+		return a.describeCertificate(selected)
+
+	case ViewNetworking:
+		return a.describeNetworkRow(selected)
+
+	case ViewSystem:
+		return a.describeSystemRow(selected)
+
+	case ViewKernel:
+		return a.describeKernelEvent(selected)
+
+	case ViewTimeline:
+		return a.handleTimelineEnter(selected)
+
+	case ViewHostConfig:
+		return a.describeHostCheck(selected)
+
+	case ViewControlPlane:
+		return a.handleControlPlaneEnter(selected)
+
+	case ViewRKE2Config:
+		return a.describeRKE2ConfigRow(selected)
+
+	case ViewResourceTable:
+		return a.describeResourceRow(selected)
+
 	default:
 		return nil
 	}
@@ -2058,6 +2986,60 @@ func (a *App) handleDescribe() tea.Cmd {
 		}
 		return a.describeService(a.currentView.clusterID, namespaceName, serviceName)
 
+	case ViewRBAC:
+		return a.describeRBACSubject(selected)
+
+	case ViewHelmCharts:
+		return a.describeHelmChart(selected)
+
+	case ViewNetworkPolicies:
+		return a.describeNetworkPolicy(selected)
+
+	case ViewConfigMaps:
+		return a.describeConfigMap(selected)
+
+	case ViewHPAs:
+		return a.describeHPA(selected)
+
+	case ViewResourceTable:
+		return a.describeResourceRow(selected)
+
+	case ViewRuntime:
+		return a.describeRuntimeContainer(selected)
+
+	case ViewImages:
+		return a.describeImage(selected)
+
+	case ViewEtcd:
+		return a.describeEtcd(selected)
+
+	case ViewMetrics:
+		return a.describeMetric(selected)
+
+	case ViewCertificates:
+		return a.describeCertificate(selected)
+
+	case ViewNetworking:
+		return a.describeNetworkRow(selected)
+
+	case ViewSystem:
+		return a.describeSystemRow(selected)
+
+	case ViewKernel:
+		return a.describeKernelEvent(selected)
+
+	case ViewTimeline:
+		return a.describeTimelineEntry(selected)
+
+	case ViewHostConfig:
+		return a.describeHostCheck(selected)
+
+	case ViewControlPlane:
+		return a.describeControlPlaneRow(selected)
+
+	case ViewRKE2Config:
+		return a.describeRKE2ConfigRow(selected)
+
 	default:
 		// No description available for this resource type
 		a.error = "Describe is not yet implemented for this resource type"
@@ -2105,6 +3087,16 @@ func (a *App) describeDeployment(clusterID, namespace, name string) tea.Cmd {
 
 		content := fmt.Sprintf("Deployment Details (JSON):\n\n%s", string(jsonBytes))
 
+		// Rollout history from ReplicaSets goes first - it answers "is this mid-rollout or stuck?"
+		if rollouts, err := a.dataSource.GetRollouts(namespace); err == nil {
+			for _, r := range rollouts {
+				if r.Name == name {
+					content = formatRolloutHistory(r) + "\n" + content
+					break
+				}
+			}
+		}
+
 		return describeMsg{
 			title:   fmt.Sprintf("Deployment: %s/%s", namespace, name),
 			content: content,
@@ -2135,15 +3127,28 @@ func (a *App) describeService(clusterID, namespace, name string) tea.Cmd {
 	}
 }
 
-// fetchLogs fetches logs for a pod using the data source
+// fetchLogs fetches logs for a pod using the data source, or the log file opened from the
+// timeline
 func (a *App) fetchLogs(clusterID, namespace, podName string) tea.Cmd {
+	if path := a.currentView.logPath; path != "" {
+		return func() tea.Msg {
+			if a.dataSource == nil {
+				return errMsg{fmt.Errorf("no data source available")}
+			}
+			logs, err := a.dataSource.ReadLogFile(path)
+			if err != nil {
+				return errMsg{fmt.Errorf("failed to fetch logs: %w", err)}
+			}
+			return logsMsg{logs: logs}
+		}
+	}
 	return func() tea.Msg {
 		// Try to get logs from data source first
 		if a.dataSource != nil {
 			logs, err := a.dataSource.GetLogs(clusterID, namespace, podName, a.currentContainer, a.showPrevious)
 			if err == nil {
 				// Return even if empty - empty logs is valid
-				return logsMsg{logs: logs}
+				return logsMsg{logs: logs, isolatingPolicies: a.podIsolatingPolicies(namespace, podName)}
 			}
 			// FIX BUG #13: NO SILENT FALLBACK - return error with context
 			if a.config.Verbose {
@@ -2891,10 +3896,19 @@ func (a *App) fetchAttention() tea.Cmd {
 			scanDepth = 200
 		}
 
+		// Search the logs for the user rules and the knowledge base in one pass
+		ds := PrescanLogs(a.dataSource, a.userRules, a.knowledge)
+
 		// Detect all issues across the cluster
-		items := ComputeAttentionItems(a.dataSource, scanDepth)
+		items := ComputeAttentionItems(ds, scanDepth, a.userRules)
 
-		return attentionMsg{items: items}
+		// Explain known errors with the knowledge base
+		items = MatchKnownIssues(ds, items, a.knowledge)
+
+		// Group related items into incidents under their suspected root cause
+		incidents := CorrelateIncidents(a.dataSource, items)
+
+		return attentionMsg{items: items, incidents: incidents}
 	}
 }
 
@@ -3040,9 +4054,8 @@ func (a *App) cycleSortMode() tea.Cmd {
 	// Store per-view preference
 	a.sortModes[a.currentView.viewType] = nextMode
 
-	// Trigger refresh to re-sort
-	a.loading = true
-	return a.refreshCurrentView()
+	// getDisplayedItems sorts the loaded items by the new mode; no need to analyze the bundle again
+	return nil
 }
 
 // togglePodSortMode toggles between Count ↔ Name (Pod view only)
@@ -3069,11 +4082,49 @@ func (a *App) togglePodSortMode() tea.Cmd {
 	return a.refreshCurrentView()
 }
 
+// selectedClusterContext returns the cluster to jump into from the Cluster or Project view:
+// the highlighted cluster in Cluster view, or the current cluster in Project view
+func (a *App) selectedClusterContext() (string, string, bool) {
+	if a.currentView.viewType != ViewClusters && a.currentView.viewType != ViewProjects {
+		return "", "", false
+	}
+
+	clusterID := a.currentView.clusterID
+	clusterName := a.currentView.clusterName
+
+	// If in Cluster view, get selected cluster
+	if a.currentView.viewType == ViewClusters {
+		if a.table.HighlightedRow().Data == nil {
+			return "", "", false
+		}
+		name := safeRowString(a.table.HighlightedRow().Data, "name")
+		if name == "" {
+			return "", "", false
+		}
+		for _, c := range a.clusters {
+			if c.Name == name {
+				clusterID = c.ID
+				clusterName = c.Name
+				break
+			}
+		}
+	}
+
+	return clusterID, clusterName, true
+}
+
+// isViewportView returns true if the current view scrolls a viewport (logs, ConfigMap content)
+func (a *App) isViewportView() bool {
+	return a.currentView.viewType == ViewLogs || a.currentView.viewType == ViewConfigMapData
+}
+
 // isNamespaceResourceView returns true if the current view is a namespace-scoped resource view
 func (a *App) isNamespaceResourceView() bool {
 	return a.currentView.viewType == ViewPods ||
 		a.currentView.viewType == ViewDeployments ||
-		a.currentView.viewType == ViewServices
+		a.currentView.viewType == ViewServices ||
+		a.currentView.viewType == ViewConfigMaps ||
+		a.currentView.viewType == ViewHPAs
 }
 
 // getPodNodeName extracts the node name from a Pod with fallback support
@@ -3128,6 +4179,18 @@ func (a *App) performSearch() {
 	}
 }
 
+// jumpToLogLine scrolls the log view to a line of the unfiltered log and highlights it like
+// a search match, for logs opened from a timeline entry
+func (a *App) jumpToLogLine(line int) {
+	if line < 0 || line >= len(a.getVisibleLogs()) {
+		return
+	}
+	a.searchMatches = []int{line}
+	a.currentMatch = 0
+	a.logViewport.SetContent(a.renderLogsWithColors())
+	a.logViewport.SetYOffset(line)
+}
+
 // tickTail returns a command to refresh logs in tail mode
 func (a *App) tickTail() tea.Cmd {
 	return tea.Tick(2*time.Second, func(t time.Time) tea.Msg {
@@ -3443,12 +4506,14 @@ type describeMsg struct {
 
 // logsMsg represents a message containing log data
 type logsMsg struct {
-	logs []string
+	logs              []string
+	isolatingPolicies []string // NetworkPolicies selecting the pod, used to annotate connection errors
 }
 
 // attentionMsg represents attention dashboard analysis results
 type attentionMsg struct {
-	items []AttentionItem
+	items     []AttentionItem
+	incidents []AttentionItem
 }
 
 // colorizeLogLine applies color styling based on log level
@@ -3494,7 +4559,7 @@ func (a *App) renderLogsWithColors() string {
 		// No wrapping - colorize and return as-is
 		coloredLines := make([]string, len(visibleLogs))
 		for i, line := range visibleLogs {
-			coloredLines[i] = a.colorizeLogLine(line, i)
+			coloredLines[i] = a.colorizeLogLine(line, i) + a.isolationNote(line)
 		}
 		return strings.Join(coloredLines, "\n")
 	}
@@ -3550,6 +4615,10 @@ func (a *App) renderLogsWithColors() string {
 				remainingLine = remainingLine[segmentEnd:]
 			}
 		}
+
+		if note := a.isolationNote(line); note != "" {
+			wrappedLines[len(wrappedLines)-1] += note
+		}
 	}
 
 	return strings.Join(wrappedLines, "\n")
@@ -3604,18 +4673,44 @@ NAVIGATION
   
 ACTIONS
   l           View logs (Pod view)
-  d           Describe resource (Pods/Deployments/Services)
+  d           Describe resource (Pods/Deployments/Services/ConfigMaps/HPAs/RBAC/HelmCharts/NetworkPolicies/Runtime/Images/etcd/Metrics/Certificates/Networking/System/Kernel/Host config/Control plane/RKE2 config/Timeline)
   r           Refresh current view
   
 VIEW SWITCHING (Namespace Context)
   1           Switch to Pods
   2           Switch to Deployments
   3           Switch to Services
+  4           Switch to ConfigMaps (Enter views content)
+  5           Switch to HPAs
   
 CLUSTER VIEWS
   C           Jump to CRDs (from Cluster/Project view)
+  R           Jump to RBAC subjects (from Cluster/Project view)
+  H           Jump to HelmCharts (from Cluster/Project view)
+  P           Jump to NetworkPolicies (from Cluster/Project view)
+  T           Jump to container runtime (crictl) view (from Cluster/Project view)
+  I           Jump to image inventory and pull failures (from Cluster/Project view)
+  E           Jump to etcd members, DB size, quota and snapshots (from Cluster/Project view)
+  L           Jump to timeline: log errors/warnings, events, dmesg and restarts in time order; [ ]=bursts z/Z=zoom Enter=log at line (from Cluster/Project view)
+  M           Jump to metrics explorer with histogram percentiles and checks (from Cluster/Project view)
+  X           Jump to certificate expiry and chain checks (from Cluster/Project view)
+  W           Jump to node networking: ports, routes, MTUs, CNI and iptables (from Cluster/Project view)
+  U           Jump to node system resources: load, memory, disks, inodes, processes, limits (from Cluster/Project view)
+  K           Jump to kernel events: OOM kills by pod, hung tasks, I/O errors, conntrack full, segfaults (from Cluster/Project view)
+  O           Jump to host config lint: sysctls, kernel modules, firewalls, AppArmor (from Cluster/Project view)
+  F           Jump to control plane static pods: flags by component, risky settings, Enter=logs (from Cluster/Project view)
+  Y           Jump to RKE2 config: 50-rancher.yaml (secrets masked), unknown keys, effective CNI/CIDRs/snapshots (from Cluster/Project view)
+  A           Jump to all resource types (from Cluster/Project view)
+  :           Jump to any resource type by name, kind or short name (:pods, :hpa, :HelmChart)
+  p           Toggle policy → pods / pod → policies (in NetworkPolicies view)
   i           Toggle CRD description (in CRD view)
   
+ATTENTION DASHBOARD
+  m           Show all issues / top 20
+  →/l, ←/h    Expand/collapse an item (incidents list their related issues)
+  x           Toggle incident grouping (related issues nested under the suspected root cause)
+  d           Details: what the issue means, likely cause, how to fix it and docs
+  
 LOG VIEWING (when viewing logs)
   g           Jump to first line
   G           Jump to last line
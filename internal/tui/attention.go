@@ -123,6 +123,16 @@ func (a *App) renderAttentionDashboard() string {
 
 	headerText := fmt.Sprintf("🚨 ATTENTION DASHBOARD       %s%s", mode, clusterName)
 	summaryText := fmt.Sprintf("%d issues (%d critical, %d warning)", totalIssues, criticalCount, warningCount)
+	incidents, related := 0, 0
+	for _, item := range a.attentionItems {
+		if len(item.Symptoms) > 0 {
+			incidents++
+			related += len(item.Symptoms)
+		}
+	}
+	if incidents > 0 {
+		summaryText += fmt.Sprintf(", %d related issues grouped into %d incidents", related, incidents)
+	}
 
 	header := lipgloss.NewStyle().
 		Foreground(colorWhite).
@@ -151,7 +161,7 @@ func (a *App) renderAttentionDashboard() string {
 			lines = append(lines, line)
 
 			// Show expanded content if this item is expanded
-			if a.expandedItems != nil && a.expandedItems[itemIdx] && len(item.AffectedPods) > 0 {
+			if a.expandedItems != nil && a.expandedItems[itemIdx] && item.IsExpandable() {
 				// Pass current position to know if we should highlight pods
 				inSubNav := (itemIdx == a.attentionCursor && a.subCursor >= 0)
 				lines = append(lines, a.renderExpandedContent(item, inSubNav)...)
@@ -169,7 +179,7 @@ func (a *App) renderAttentionDashboard() string {
 			lines = append(lines, line)
 
 			// Show expanded content if this item is expanded
-			if a.expandedItems != nil && a.expandedItems[itemIdx] && len(item.AffectedPods) > 0 {
+			if a.expandedItems != nil && a.expandedItems[itemIdx] && item.IsExpandable() {
 				inSubNav := (itemIdx == a.attentionCursor && a.subCursor >= 0)
 				lines = append(lines, a.renderExpandedContent(item, inSubNav)...)
 			}
@@ -186,7 +196,7 @@ func (a *App) renderAttentionDashboard() string {
 			lines = append(lines, line)
 
 			// Show expanded content if this item is expanded
-			if a.expandedItems != nil && a.expandedItems[itemIdx] && len(item.AffectedPods) > 0 {
+			if a.expandedItems != nil && a.expandedItems[itemIdx] && item.IsExpandable() {
 				inSubNav := (itemIdx == a.attentionCursor && a.subCursor >= 0)
 				lines = append(lines, a.renderExpandedContent(item, inSubNav)...)
 			}
@@ -270,11 +280,21 @@ func (a *App) renderAttentionDashboard() string {
 
 	statusParts = append(statusParts, "[s]=sort")
 	statusParts = append(statusParts, "[m]=expand")
+	if a.attentionFlat {
+		statusParts = append(statusParts, "[x]=group incidents")
+	} else {
+		statusParts = append(statusParts, "[x]=ungroup")
+	}
 	statusParts = append(statusParts, "[g/G]=top/bottom")
 	statusParts = append(statusParts, "[Enter]=logs")
+	statusParts = append(statusParts, "[d]=details")
 	statusParts = append(statusParts, "[c]=classic")
+	statusParts = append(statusParts, "[:]=jump")
 
 	statusText := " " + strings.Join(statusParts, " · ") + " "
+	if a.promptMode != 0 {
+		statusText = a.promptStatusText()
+	}
 	status := statusStyle.Render(statusText)
 
 	return lipgloss.JoinVertical(
@@ -295,7 +315,7 @@ func (a *App) renderAttentionItem(num int, item AttentionItem, isSelected bool)
 
 	// Add ►/▼ indicator for collapsible event items
 	expandIndicator := ""
-	if item.ResourceType == "event" || item.ResourceType == "cluster" {
+	if item.ResourceType == "event" || item.ResourceType == "cluster" || item.IsExpandable() {
 		// Check if this item is expanded
 		itemIdx := num - 1 // Convert to 0-based index
 		if a.expandedItems != nil && a.expandedItems[itemIdx] {
@@ -309,10 +329,16 @@ func (a *App) renderAttentionItem(num int, item AttentionItem, isSelected bool)
 	descWidth := 25
 	nsWidth := 20
 
+	// Incidents show how many related items are nested under the root cause
+	related := ""
+	if len(item.Symptoms) > 0 {
+		related = fmt.Sprintf(" +%d", len(item.Symptoms))
+	}
 	title := item.Title
-	if len(title) > titleWidth {
-		title = title[:titleWidth-3] + "..."
+	if len(title)+len(related) > titleWidth {
+		title = title[:titleWidth-len(related)-3] + "..."
 	}
+	title += related
 
 	desc := item.Description
 	if len(desc) > descWidth {
@@ -332,6 +358,9 @@ func (a *App) renderAttentionItem(num int, item AttentionItem, isSelected bool)
 		descWidth, desc,
 		ns,
 	)
+	if len(item.KnownIssues) > 0 {
+		line += " " + knownIssueEmoji // Explained in the detail pane
+	}
 
 	// Apply selection highlight (inverts colors for visibility)
 	if isSelected {
@@ -356,10 +385,53 @@ func (a *App) renderAttentionItem(num int, item AttentionItem, isSelected bool)
 	return style.Render(line)
 }
 
-// renderExpandedContent renders the expanded pod list for event items
+// renderExpandedContent renders the expanded pod list and evidence lines for an item
 func (a *App) renderExpandedContent(item AttentionItem, inSubNav bool) []string {
 	var lines []string
 
+	// Incidents list the related items explained by this root cause first
+	if len(item.Symptoms) > 0 {
+		cause := "suspected root cause"
+		switch item.Incident {
+		case "workload", "namespace":
+			cause = "same " + item.Incident
+		case "":
+		default:
+			cause += " (" + item.Incident + ")"
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorGray).Italic(true).Render(
+			fmt.Sprintf("       ⛓ %s of %d related issues", cause, len(item.Symptoms))))
+	}
+	for i, symptom := range item.Symptoms {
+		if i >= maxIncidentSymptoms {
+			hint := lipgloss.NewStyle().Foreground(colorGray).Render(
+				fmt.Sprintf("       ... and %d more related issues (press 'x' to ungroup)", len(item.Symptoms)-maxIncidentSymptoms))
+			lines = append(lines, hint)
+			break
+		}
+		prefix := "       ├─ "
+		if i == len(item.Symptoms)-1 || i == maxIncidentSymptoms-1 {
+			prefix = "       └─ "
+		}
+		text := fmt.Sprintf("%s%s %s  %s  %s", prefix, symptom.Emoji, symptom.Title, symptom.Description, symptom.Namespace)
+		if len(text) > a.width-16 && a.width > 40 {
+			text = text[:a.width-19] + "..."
+		}
+		style := lipgloss.NewStyle().Foreground(colorGray)
+		if inSubNav && i == a.subCursor {
+			style = lipgloss.NewStyle().Background(colorCyan).Foreground(colorDarkGray).Bold(true)
+		} else if symptom.Severity == SeverityCritical {
+			style = lipgloss.NewStyle().Foreground(colorRed)
+		}
+		lines = append(lines, style.Render(text))
+	}
+
+	// Known issues point at the detail pane for the explanation and fix
+	for _, e := range item.KnownIssues {
+		text := fmt.Sprintf("       %s Known issue: %s (press 'd' for cause and fix)", knownIssueEmoji, e.Title)
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorCyan).Render(text))
+	}
+
 	// Show top pods with event counts
 	for i, podName := range item.AffectedPods {
 		if i >= 5 { // Show max 5 pods to avoid clutter
@@ -385,9 +457,12 @@ func (a *App) renderExpandedContent(item AttentionItem, inSubNav bool) []string
 		}
 
 		podText := fmt.Sprintf("%s%s (%d events)", prefix, podName, eventCount)
+		if item.AffectedPodCounts == nil {
+			podText = prefix + podName // Not an event aggregate - no counts to show
+		}
 
 		// Highlight if this pod is selected in sub-navigation
-		isSelectedPod := inSubNav && i == a.subCursor
+		isSelectedPod := inSubNav && i == a.subCursor && len(item.Symptoms) == 0
 		if isSelectedPod {
 			podLine := lipgloss.NewStyle().
 				Background(colorCyan).
@@ -401,6 +476,20 @@ func (a *App) renderExpandedContent(item AttentionItem, inSubNav bool) []string
 		}
 	}
 
+	// Show correlated event/log lines below the pods
+	for i, line := range item.Evidence {
+		if i >= 5 {
+			hint := lipgloss.NewStyle().Foreground(colorGray).Render(
+				fmt.Sprintf("       ... and %d more related lines", len(item.Evidence)-5))
+			lines = append(lines, hint)
+			break
+		}
+		if len(line) > a.width-16 && a.width > 40 {
+			line = line[:a.width-19] + "..."
+		}
+		lines = append(lines, lipgloss.NewStyle().Foreground(colorGray).Italic(true).Render("       │ "+line))
+	}
+
 	return lines
 }
 
@@ -0,0 +1,424 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// maxIncidentSymptoms is the number of symptoms shown under an expanded incident
+const maxIncidentSymptoms = 10
+
+// incidentContext holds what correlation needs beyond the items: where pods run and which
+// node the bundle was collected on
+type incidentContext struct {
+	podNodes   map[string]string // "namespace/pod" and bare pod name -> node
+	bundleNode string            // Node-local items (system, kernel, runtime...) belong to it
+}
+
+// nodeLocalTypes are item types describing the node the bundle was collected on
+var nodeLocalTypes = map[string]bool{
+	"runtime": true, "system": true, "kernel": true, "certificate": true, "hostconfig": true,
+	"network": true, "controlplane": true, "rke2config": true, "etcd": true, "metrics": true,
+}
+
+// incidentChain is a known causal chain: an item matching cause explains the items matching
+// symptom. Chains are tried in order, so earlier chains win the items they share.
+type incidentChain struct {
+	name    string
+	cause   func(item AttentionItem) bool
+	symptom func(cause, item AttentionItem, ctx incidentContext) bool
+}
+
+// incidentChains lists the known causal chains, deepest causes first
+var incidentChains = []incidentChain{
+	{
+		// Full disks, NotReady runtime, node-wide OOM, I/O errors and expired certificates
+		// take down everything scheduled on the node, including the node itself
+		name: "host failure",
+		cause: func(item AttentionItem) bool {
+			if item.Severity != SeverityCritical {
+				return false
+			}
+			switch item.ResourceType {
+			case "system", "runtime", "certificate":
+				return true
+			case "kernel":
+				return item.PodName == ""
+			}
+			return false
+		},
+		symptom: func(cause, item AttentionItem, ctx incidentContext) bool {
+			switch item.ResourceType {
+			case "node", "pod", "runtime", "controlplane", "kernel":
+				return sameNode(cause, item, ctx)
+			}
+			return false
+		},
+	},
+	{
+		// Pods on a NotReady node are evicted or stop reporting
+		name:  "node NotReady",
+		cause: func(item AttentionItem) bool { return item.ResourceType == "node" },
+		symptom: func(cause, item AttentionItem, ctx incidentContext) bool {
+			switch item.ResourceType {
+			case "pod", "runtime", "controlplane", "kernel":
+				return sameNode(cause, item, ctx)
+			}
+			return false
+		},
+	},
+	{
+		// Without a healthy etcd the API server, aggregated APIs and webhooks fail. The control
+		// plane on the etcd node goes down with it; APIServices and webhooks only count when
+		// their errors name etcd, as a missing backend fails them just the same.
+		name: "etcd",
+		cause: func(item AttentionItem) bool {
+			return item.ResourceType == "etcd" && item.Severity == SeverityCritical
+		},
+		symptom: func(cause, item AttentionItem, ctx incidentContext) bool {
+			switch item.ResourceType {
+			case "etcd":
+				return true
+			case "controlplane":
+				return sameNode(cause, item, ctx)
+			case "apiservice", "webhook":
+				return mentionsEtcd(item)
+			case "metrics":
+				return item.Namespace == "etcd"
+			case "pod":
+				return strings.HasPrefix(item.PodName, "etcd-") || strings.HasPrefix(item.PodName, "kube-apiserver-")
+			}
+			return false
+		},
+	},
+	{
+		// An unavailable metrics APIService leaves HPAs blind; its backend pods explain it
+		name: "APIService",
+		cause: func(item AttentionItem) bool {
+			return item.ResourceType == "apiservice" && item.Severity == SeverityCritical
+		},
+		symptom: func(cause, item AttentionItem, ctx incidentContext) bool {
+			switch item.ResourceType {
+			case "hpa":
+				return containsName(item.DependsOn, cause.ResourceName)
+			case "pod":
+				return item.Namespace == cause.Namespace && containsName(cause.AffectedPods, item.PodName)
+			}
+			return false
+		},
+	},
+	{
+		// The kernel OOM killer explains a pod's OOMKilled state and restarts
+		name:  "OOM kill",
+		cause: func(item AttentionItem) bool { return item.ResourceType == "kernel" && item.PodName != "" },
+		symptom: func(cause, item AttentionItem, ctx incidentContext) bool {
+			return item.ResourceType == "pod" && item.Namespace == cause.Namespace && item.PodName == cause.PodName
+		},
+	},
+	{
+		// The new pods of a stalled rollout are why it is stalled
+		name:  "stalled rollout",
+		cause: func(item AttentionItem) bool { return item.ResourceType == "rollout" },
+		symptom: func(cause, item AttentionItem, ctx incidentContext) bool {
+			return item.ResourceType == "pod" && item.Namespace == cause.Namespace &&
+				podOwner(item.PodName) == cause.ResourceName
+		},
+	},
+	{
+		// Pods isolated by a policy that log connection errors fail their probes; the item's
+		// affected pods are the isolated pods that logged them
+		name: "NetworkPolicy",
+		cause: func(item AttentionItem) bool {
+			return item.ResourceType == "networkpolicy" && item.Count > 0
+		},
+		symptom: func(cause, item AttentionItem, ctx incidentContext) bool {
+			// AffectedPods are the selected pods that logged connection errors
+			return item.ResourceType == "pod" && item.Namespace == cause.Namespace &&
+				containsName(cause.AffectedPods, item.PodName)
+		},
+	},
+}
+
+// CorrelateIncidents groups related attention items into incidents: the suspected root cause
+// with the items it explains nested under it as Symptoms. Failing pods no chain explains are
+// grouped per workload, then per namespace, with the events about them; other items are
+// kept as they are.
+func CorrelateIncidents(ds datasource.DataSource, items []AttentionItem) []AttentionItem {
+	ctx := incidentContext{podNodes: make(map[string]string)}
+	if pods, err := ds.GetAllPods(); err == nil {
+		for _, pod := range pods {
+			ctx.podNodes[extractNamespace(pod.NamespaceID)+"/"+pod.Name] = pod.NodeName
+			ctx.podNodes[pod.Name] = pod.NodeName
+		}
+	}
+	if clusters, err := ds.GetClusters(); err == nil && len(clusters) > 0 {
+		ctx.bundleNode = clusters[0].Name // Bundle clusters are named after the collecting node
+	}
+
+	incidents := correlateIncidents(items, ctx)
+	sortAttentionItems(incidents)
+	return incidents
+}
+
+// correlateIncidents applies the causal chains, then pod grouping, keeping input order
+func correlateIncidents(items []AttentionItem, ctx incidentContext) []AttentionItem {
+	claimed := make([]bool, len(items))
+	symptoms := make(map[int][]int) // root index -> symptom indexes
+	chains := make(map[int]string)  // root index -> chain name
+
+	for _, chain := range incidentChains {
+		for i, cause := range items {
+			if claimed[i] || !chain.cause(cause) {
+				continue
+			}
+			var found []int
+			for j, item := range items {
+				if j != i && !claimed[j] && chain.symptom(cause, item, ctx) {
+					found = append(found, j)
+				}
+			}
+			found = append(found, relatedItems(items, claimed, i, found, ctx)...)
+			if len(found) == 0 {
+				continue
+			}
+			claimed[i] = true
+			for _, j := range found {
+				claimed[j] = true
+			}
+			symptoms[i] = found
+			chains[i] = chain.name
+		}
+	}
+
+	// Remaining failing pods of one workload, then of one namespace, become one incident
+	grouped := make(map[int]AttentionItem) // first pod index -> incident
+	for _, kind := range []string{"workload", "namespace"} {
+		for first, incident := range groupPodItems(items, claimed, kind, ctx) {
+			grouped[first] = incident
+		}
+	}
+
+	var out []AttentionItem
+	for i, item := range items {
+		if incident, ok := grouped[i]; ok {
+			out = append(out, incident)
+			continue
+		}
+		found, isRoot := symptoms[i]
+		if !isRoot {
+			if !claimed[i] {
+				out = append(out, item)
+			}
+			continue
+		}
+		item.Incident = chains[i]
+		for _, j := range found {
+			item.Symptoms = append(item.Symptoms, items[j])
+			if items[j].Severity < item.Severity {
+				item.Severity = items[j].Severity // An incident is as severe as its worst symptom
+			}
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// groupPodItems groups unclaimed pod items by workload or namespace and returns an incident
+// for each group of two or more, keyed by the index of its first pod. Grouped pods and the
+// events they explain are claimed.
+func groupPodItems(items []AttentionItem, claimed []bool, kind string, ctx incidentContext) map[int]AttentionItem {
+	groups := make(map[string][]int)
+	var order []string
+	for i, item := range items {
+		if claimed[i] || item.ResourceType != "pod" {
+			continue
+		}
+		key := item.Namespace
+		if kind == "workload" {
+			key += "/" + podOwner(item.PodName)
+		}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	incidents := make(map[int]AttentionItem)
+	for _, key := range order {
+		pods := groups[key]
+		if len(pods) < 2 {
+			continue
+		}
+		incident := podGroupIncident(kind, key, items, pods)
+		for _, j := range pods {
+			claimed[j] = true
+		}
+		for _, j := range relatedItems(items, claimed, -1, pods, ctx) {
+			claimed[j] = true
+			incident.Symptoms = append(incident.Symptoms, items[j])
+		}
+		incidents[pods[0]] = incident
+	}
+	return incidents
+}
+
+// relatedItems returns the unclaimed event and DaemonSet items explained by an incident
+// (root index, -1 for pod groups, and its symptoms so far): events whose affected pods are
+// mostly in the incident or, for causal chains, on its node, and DaemonSets missing no more
+// pods than the incident has NotReady nodes
+func relatedItems(items []AttentionItem, claimed []bool, root int, found []int, ctx incidentContext) []int {
+	members := found
+	if root >= 0 {
+		members = append([]int{root}, found...)
+	}
+	pods := make(map[string]bool)
+	nodes := make(map[string]bool)
+	notReady := 0
+	for _, m := range members {
+		item := items[m]
+		if item.PodName != "" {
+			pods[item.PodName] = true
+		}
+		if node := itemNode(item, ctx); node != "" && root >= 0 {
+			nodes[node] = true
+		}
+		if item.ResourceType == "node" {
+			notReady++
+		}
+	}
+
+	var related []int
+	for j, item := range items {
+		if j == root || claimed[j] || containsIndex(found, j) {
+			continue
+		}
+		switch item.ResourceType {
+		case "event":
+			inside := 0
+			for _, pod := range item.AffectedPods {
+				if pods[pod] || nodes[ctx.podNodes[pod]] {
+					inside++
+				}
+			}
+			if inside > 0 && inside*2 >= len(item.AffectedPods) {
+				related = append(related, j)
+			}
+		case "daemonset":
+			if notReady > 0 && daemonSetMissing(item) <= notReady {
+				related = append(related, j)
+			}
+		}
+	}
+	return related
+}
+
+// podGroupIncident builds the incident for failing pods of one workload ("namespace/owner")
+// or namespace
+func podGroupIncident(kind, key string, items []AttentionItem, pods []int) AttentionItem {
+	first := items[pods[0]]
+	title := fmt.Sprintf("%s namespace (%d pods)", key, len(pods))
+	if kind == "workload" {
+		title = fmt.Sprintf("%s (%d pods)", podOwner(first.PodName), len(pods))
+	}
+	incident := AttentionItem{
+		Severity:     first.Severity,
+		Emoji:        first.Emoji,
+		Title:        title,
+		Description:  first.Description,
+		Namespace:    first.Namespace,
+		ResourceType: "incident",
+		Incident:     kind,
+		PodName:      first.PodName,
+		Timestamp:    first.Timestamp,
+	}
+	for _, j := range pods {
+		item := items[j]
+		incident.Symptoms = append(incident.Symptoms, item)
+		incident.Count += item.Count
+		if item.Severity < incident.Severity {
+			incident.Severity = item.Severity
+			incident.Emoji = item.Emoji
+		}
+		if item.Description != first.Description {
+			incident.Description = "Pods failing"
+		}
+	}
+	return incident
+}
+
+// itemNode returns the node an item is about, or "" if it is not node-specific
+func itemNode(item AttentionItem, ctx incidentContext) string {
+	switch {
+	case item.ResourceType == "node":
+		return item.ResourceName
+	case item.PodName != "" && (item.ResourceType == "pod" || item.ResourceType == "kernel"):
+		if node, ok := ctx.podNodes[item.Namespace+"/"+item.PodName]; ok {
+			return node
+		}
+		return ctx.podNodes[item.PodName]
+	case nodeLocalTypes[item.ResourceType]:
+		return ctx.bundleNode
+	}
+	return ""
+}
+
+// sameNode reports whether two items are about the same node
+func sameNode(a, b AttentionItem, ctx incidentContext) bool {
+	node := itemNode(a, ctx)
+	return node != "" && node == itemNode(b, ctx)
+}
+
+// etcdErrorRe matches etcd errors passed on by the API server, e.g. "etcdserver: request
+// timed out" or "etcd cluster is unavailable or misconfigured"
+var etcdErrorRe = regexp.MustCompile(`etcdserver|etcd cluster is unavailable|:2379\b`)
+
+// mentionsEtcd reports whether an item's description or evidence names an etcd error
+func mentionsEtcd(item AttentionItem) bool {
+	if etcdErrorRe.MatchString(item.Description) {
+		return true
+	}
+	for _, line := range item.Evidence {
+		if etcdErrorRe.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+// daemonSetMissing returns how many pods a DaemonSet item is missing
+func daemonSetMissing(item AttentionItem) int {
+	return item.Desired - item.Ready
+}
+
+// Pod name suffixes added by controllers: the pod's random suffix, a ReplicaSet's
+// pod-template hash, and a StatefulSet ordinal
+var (
+	podRandomSuffixRe  = regexp.MustCompile(`-[bcdfghjklmnpqrstvwxz2456789]{5}$`)
+	podTemplateHashRe  = regexp.MustCompile(`-[bcdfghjklmnpqrstvwxz2456789]{6,10}$`)
+	statefulSetOrdinal = regexp.MustCompile(`-[0-9]+$`)
+)
+
+// podOwner guesses the owning workload's name from a pod name
+func podOwner(pod string) string {
+	if statefulSetOrdinal.MatchString(pod) {
+		return statefulSetOrdinal.ReplaceAllString(pod, "")
+	}
+	if !podRandomSuffixRe.MatchString(pod) {
+		return pod
+	}
+	owner := podRandomSuffixRe.ReplaceAllString(pod, "")
+	return podTemplateHashRe.ReplaceAllString(owner, "")
+}
+
+// containsIndex reports whether list contains i
+func containsIndex(list []int, i int) bool {
+	for _, v := range list {
+		if v == i {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCorrelateIncidents tests that a NotReady node absorbs its pods, events and DaemonSet,
+// and that unexplained failing pods are grouped per workload, then per namespace
+func TestCorrelateIncidents(t *testing.T) {
+	ctx := incidentContext{
+		podNodes: map[string]string{
+			"app/web-7d9f8c6b5-x2k4q": "worker-2",
+			"app/web-7d9f8c6b5-q8wzt": "worker-2",
+			"web-7d9f8c6b5-x2k4q":     "worker-2",
+			"web-7d9f8c6b5-q8wzt":     "worker-2",
+			"api-6c8d4b9f7-mnp2v":     "worker-1",
+			"api-6c8d4b9f7-tz5rk":     "worker-1",
+		},
+		bundleNode: "cp-1",
+	}
+	items := []AttentionItem{
+		{Severity: SeverityCritical, Title: "worker-2", ResourceType: "node", ResourceName: "worker-2", Description: "NotReady"},
+		{Severity: SeverityCritical, Title: "web-7d9f8c6b5-x2k4q", ResourceType: "pod", Namespace: "app", PodName: "web-7d9f8c6b5-x2k4q"},
+		{Severity: SeverityWarning, Title: "web-7d9f8c6b5-q8wzt", ResourceType: "pod", Namespace: "app", PodName: "web-7d9f8c6b5-q8wzt"},
+		{Severity: SeverityWarning, Title: "3× NodeNotReady", ResourceType: "event",
+			AffectedPods: []string{"web-7d9f8c6b5-x2k4q", "web-7d9f8c6b5-q8wzt"}},
+		{Severity: SeverityWarning, Title: "canal (DaemonSet)", ResourceType: "daemonset", ResourceName: "canal", Description: "3/4 ready", Ready: 3, Desired: 4},
+		{Severity: SeverityWarning, Title: "api-6c8d4b9f7-mnp2v", ResourceType: "pod", Namespace: "app", PodName: "api-6c8d4b9f7-mnp2v", Count: 1},
+		{Severity: SeverityCritical, Title: "api-6c8d4b9f7-tz5rk", ResourceType: "pod", Namespace: "app", PodName: "api-6c8d4b9f7-tz5rk", Count: 1},
+		{Severity: SeverityWarning, Title: "40× BackOff", ResourceType: "event",
+			AffectedPods: []string{"api-6c8d4b9f7-mnp2v", "unrelated"}},
+		{Severity: SeverityCritical, Title: "test-crash", ResourceType: "pod", Namespace: "default", PodName: "test-crash"},
+		{Severity: SeverityCritical, Title: "test-oom", ResourceType: "pod", Namespace: "default", PodName: "test-oom"},
+		{Severity: SeverityCritical, Title: "lonely", ResourceType: "pod", Namespace: "other", PodName: "lonely"},
+		{Severity: SeverityInfo, Title: "2× Unhealthy", ResourceType: "event", AffectedPods: []string{"elsewhere"}},
+	}
+
+	got := correlateIncidents(items, ctx)
+
+	want := []struct {
+		title    string
+		incident string
+		symptoms int
+	}{
+		{"worker-2", "node NotReady", 4},
+		{"api (2 pods)", "workload", 3},
+		{"default namespace (2 pods)", "namespace", 2},
+		{"lonely", "", 0},
+		{"2× Unhealthy", "", 0},
+	}
+	if len(got) != len(want) {
+		for _, item := range got {
+			t.Logf("%s (%s): %d symptoms", item.Title, item.Incident, len(item.Symptoms))
+		}
+		t.Fatalf("got %d items, want %d", len(got), len(want))
+	}
+	for i, w := range want {
+		if got[i].Title != w.title || got[i].Incident != w.incident || len(got[i].Symptoms) != w.symptoms {
+			t.Errorf("item %d = %q (%q, %d symptoms), want %q (%q, %d symptoms)",
+				i, got[i].Title, got[i].Incident, len(got[i].Symptoms), w.title, w.incident, w.symptoms)
+		}
+	}
+	if got[1].Severity != SeverityCritical || got[1].Count != 2 || got[1].ResourceType != "incident" {
+		t.Errorf("workload incident = %+v, want critical with both pods counted", got[1])
+	}
+	if got[0].SubItemCount() != 4 || !got[0].IsExpandable() {
+		t.Errorf("node incident should be expandable with 4 selectable symptoms")
+	}
+}
+
+// TestIncidentChainEvidence tests that chains only claim symptoms they have evidence for: an
+// etcd outage does not absorb webhooks failing for their own reasons, a NetworkPolicy only its
+// pods that logged connection errors, and an APIService only the HPAs depending on it
+func TestIncidentChainEvidence(t *testing.T) {
+	ctx := incidentContext{podNodes: map[string]string{}, bundleNode: "cp-1"}
+	items := []AttentionItem{
+		{Severity: SeverityCritical, Title: "etcd NOSPACE alarm", ResourceType: "etcd", Namespace: "etcd"},
+		{Severity: SeverityCritical, Title: "rancher.cattle.io (MutatingWebhook)", ResourceType: "webhook",
+			Description: "Service rancher-webhook missing (Fail)"},
+		{Severity: SeverityWarning, Title: "validate.kyverno.svc (ValidatingWebhook)", ResourceType: "webhook",
+			Evidence: []string{`[log] kube-apiserver: failed calling webhook "validate.kyverno.svc": etcdserver: request timed out`}},
+		{Severity: SeverityCritical, Title: "kube-scheduler is down", ResourceType: "controlplane"},
+		{Severity: SeverityCritical, Title: "v1beta1.metrics.k8s.io (APIService)", ResourceType: "apiservice",
+			ResourceName: "v1beta1.metrics.k8s.io", Namespace: "kube-system"},
+		{Severity: SeverityWarning, Title: "web (HPA)", ResourceType: "hpa", Namespace: "app", DependsOn: []string{"v1beta1.metrics.k8s.io"}},
+		{Severity: SeverityWarning, Title: "queue (HPA)", ResourceType: "hpa", Namespace: "app",
+			Description: "pinned at maxReplicas (v1beta1.metrics.k8s.io was fine)"},
+		{Severity: SeverityWarning, Title: "app (NetworkPolicy)", ResourceType: "networkpolicy", Namespace: "app", Count: 3,
+			AffectedPods: []string{"web-1"}},
+		{Severity: SeverityCritical, Title: "web-1", ResourceType: "pod", Namespace: "app", PodName: "web-1"},
+		{Severity: SeverityCritical, Title: "batch-oom", ResourceType: "pod", Namespace: "app", PodName: "batch-oom"},
+	}
+
+	got := correlateIncidents(items, ctx)
+
+	symptoms := make(map[string][]string)
+	for _, item := range got {
+		for _, s := range item.Symptoms {
+			symptoms[item.Title] = append(symptoms[item.Title], s.Title)
+		}
+	}
+	want := map[string][]string{
+		"etcd NOSPACE alarm":                  {"validate.kyverno.svc (ValidatingWebhook)", "kube-scheduler is down"},
+		"v1beta1.metrics.k8s.io (APIService)": {"web (HPA)"},
+		"app (NetworkPolicy)":                 {"web-1"},
+	}
+	for cause, titles := range want {
+		if strings.Join(symptoms[cause], ", ") != strings.Join(titles, ", ") {
+			t.Errorf("%s symptoms = %v, want %v", cause, symptoms[cause], titles)
+		}
+	}
+	if len(got) != 6 {
+		t.Errorf("got %d items, want 3 incidents and the 3 unexplained items", len(got))
+	}
+}
+
+// TestPodOwner tests guessing the owning workload from pod names
+func TestPodOwner(t *testing.T) {
+	tests := map[string]string{
+		"web-7d9f8c6b5-x2k4q":      "web",
+		"rke2-canal-x7k2p":         "rke2-canal",
+		"postgres-0":               "postgres",
+		"test-crash":               "test-crash",
+		"coredns-6799fbcd5-mnp2v":  "coredns",
+		"helm-install-ingress-8bx": "helm-install-ingress-8bx",
+	}
+	for pod, want := range tests {
+		if got := podOwner(pod); got != want {
+			t.Errorf("podOwner(%q) = %q, want %q", pod, got, want)
+		}
+	}
+}
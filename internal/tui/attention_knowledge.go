@@ -0,0 +1,259 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+	"github.com/Rancheroo/r8s/internal/knowledge"
+)
+
+const (
+	// maxKnownIssueLogMatches caps the log search for knowledge base signatures
+	maxKnownIssueLogMatches = 2000
+
+	// maxKnownIssueEvidence is the number of matching lines kept on a known issue item
+	maxKnownIssueEvidence = 10
+
+	// knownIssueEmoji marks items with a knowledge base entry
+	knownIssueEmoji = "📖"
+)
+
+// knownIssueHits collects the log lines and events matching an entry no item covers
+type knownIssueHits struct {
+	entry     *knowledge.Entry
+	namespace string // Of the first hit
+	pod       string // Of the first hit
+	count     int
+	lines     []string
+}
+
+// MatchKnownIssues attaches knowledge base entries (kb may be nil) to attention items: to items
+// whose evidence matches a signature, and to the pod and event items whose pod status, events
+// or logs do (ds may be nil to match evidence only). Only text found in the bundle is matched,
+// never r8s's own titles and descriptions. Signatures found only in logs or events become
+// items of their own.
+func MatchKnownIssues(ds datasource.DataSource, items []AttentionItem, kb *knowledge.Base) []AttentionItem {
+	if kb == nil {
+		return items
+	}
+
+	for i := range items {
+		for _, e := range kb.Match(strings.Join(items[i].Evidence, "\n")) {
+			items[i].addKnownIssue(e)
+		}
+	}
+
+	hits := make(map[string]*knownIssueHits) // entry ID -> unattached hits
+	var order []string
+	record := func(e *knowledge.Entry, namespace, pod, line string) {
+		h, ok := hits[e.ID]
+		if !ok {
+			h = &knownIssueHits{entry: e, namespace: namespace, pod: pod}
+			hits[e.ID] = h
+			order = append(order, e.ID)
+		}
+		h.count++
+		if len(h.lines) < maxKnownIssueEvidence {
+			h.lines = append(h.lines, line)
+		}
+	}
+
+	if ds != nil && len(kb.Entries) > 0 {
+		if pods, err := ds.GetAllPods(); err == nil {
+			for _, pod := range pods {
+				for _, e := range kb.Match(pod.State + "\n" + pod.KubectlStatus) {
+					attachKnownIssue(items, e, extractNamespace(pod.NamespaceID), pod.Name, "")
+				}
+			}
+		}
+		if events, err := ds.GetAllEvents(); err == nil {
+			for _, ev := range events {
+				for _, e := range kb.Match(ev.Reason + ": " + ev.Message) {
+					if !attachKnownIssue(items, e, ev.Namespace, ev.PodName, ev.Reason) {
+						record(e, ev.Namespace, ev.PodName, fmt.Sprintf("[event] %s %s %s (x%d): %s",
+							ev.Reason, ev.Namespace, ev.Object, ev.Count, ev.Message))
+					}
+				}
+			}
+		}
+		if matches, err := ds.SearchLogs(kb, maxKnownIssueLogMatches); err == nil {
+			for _, m := range matches {
+				for _, e := range kb.Match(m.Line) {
+					if !attachKnownIssue(items, e, m.Namespace, m.PodName, "") {
+						record(e, m.Namespace, m.PodName, fmt.Sprintf("[log] %s:%d: %s",
+							m.Source, m.LineNumber, strings.TrimSpace(m.Line)))
+					}
+				}
+			}
+		}
+	}
+
+	severities := map[string]AttentionSeverity{
+		knowledge.SeverityCritical: SeverityCritical,
+		knowledge.SeverityWarning:  SeverityWarning,
+		knowledge.SeverityInfo:     SeverityInfo,
+	}
+	for _, id := range order {
+		h := hits[id]
+		if hasKnownIssue(items, id) {
+			continue // Already explained on an item
+		}
+		items = append(items, AttentionItem{
+			Severity:     severities[h.entry.Severity],
+			Emoji:        knownIssueEmoji,
+			Title:        h.entry.Title,
+			Description:  fmt.Sprintf("%d matching log lines/events", h.count),
+			Namespace:    h.namespace,
+			Count:        h.count,
+			PodName:      h.pod,
+			ResourceType: "knownissue",
+			Evidence:     h.lines,
+			KnownIssues:  []*knowledge.Entry{h.entry},
+			Timestamp:    time.Now(),
+		})
+	}
+
+	if len(kb.Problems) > 0 {
+		var evidence []string
+		for _, p := range kb.Problems {
+			evidence = append(evidence, p.String())
+		}
+		items = append(items, AttentionItem{
+			Severity:     SeverityWarning,
+			Emoji:        knownIssueEmoji,
+			Title:        fmt.Sprintf("%d knowledge base problems (run r8s kb lint)", len(kb.Problems)),
+			Description:  "Invalid entries were skipped",
+			Count:        len(kb.Problems),
+			ResourceType: "knownissue",
+			Evidence:     evidence,
+			Timestamp:    time.Now(),
+		})
+	}
+
+	sortAttentionItems(items)
+	return items
+}
+
+// attachKnownIssue adds an entry matched in an event (reason set) or log line to the items
+// about the same pod, or the event item aggregating that reason. It reports whether any
+// item took it.
+func attachKnownIssue(items []AttentionItem, e *knowledge.Entry, namespace, pod, reason string) bool {
+	attached := false
+	for i := range items {
+		item := &items[i]
+		eventMatch := reason != "" && item.ResourceType == "event" && strings.HasSuffix(item.Title, " "+reason)
+		podMatch := pod != "" && item.PodName == pod && (namespace == "" || item.Namespace == "" || item.Namespace == namespace)
+		if eventMatch || podMatch {
+			item.addKnownIssue(e)
+			attached = true
+		}
+	}
+	return attached
+}
+
+// hasKnownIssue reports whether any item carries the entry with the given ID
+func hasKnownIssue(items []AttentionItem, id string) bool {
+	for _, item := range items {
+		for _, e := range item.KnownIssues {
+			if e.ID == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// addKnownIssue adds an entry to the item unless it already has it
+func (item *AttentionItem) addKnownIssue(e *knowledge.Entry) {
+	for _, existing := range item.KnownIssues {
+		if existing.ID == e.ID {
+			return
+		}
+	}
+	item.KnownIssues = append(item.KnownIssues, e)
+}
+
+// AllKnownIssues returns the entries of the item and of its incident symptoms, without duplicates
+func (item AttentionItem) AllKnownIssues() []*knowledge.Entry {
+	all := AttentionItem{KnownIssues: append([]*knowledge.Entry(nil), item.KnownIssues...)}
+	for _, symptom := range item.Symptoms {
+		for _, e := range symptom.KnownIssues {
+			all.addKnownIssue(e)
+		}
+	}
+	return all.KnownIssues
+}
+
+// describeAttentionItem shows the detail pane of an attention item: what was detected, and
+// for each matching knowledge base entry the explanation, likely cause, fix and docs
+func (a *App) describeAttentionItem(item AttentionItem) tea.Cmd {
+	var b strings.Builder
+	severity := map[AttentionSeverity]string{SeverityCritical: "Critical", SeverityWarning: "Warning", SeverityInfo: "Info"}
+	fmt.Fprintf(&b, "Severity:  %s\n", severity[item.Severity])
+	fmt.Fprintf(&b, "Type:      %s\n", item.ResourceType)
+	if item.Namespace != "" {
+		fmt.Fprintf(&b, "Namespace: %s\n", item.Namespace)
+	}
+	if item.PodName != "" {
+		fmt.Fprintf(&b, "Pod:       %s\n", item.PodName)
+	}
+	if item.Count > 0 {
+		fmt.Fprintf(&b, "Count:     %d\n", item.Count)
+	}
+	fmt.Fprintf(&b, "\n%s\n", item.Description)
+
+	if len(item.Symptoms) > 0 {
+		fmt.Fprintf(&b, "\nRelated issues (%s):\n", item.Incident)
+		for _, symptom := range item.Symptoms {
+			fmt.Fprintf(&b, "  %s %s  %s\n", symptom.Emoji, symptom.Title, symptom.Description)
+		}
+	}
+	if len(item.Evidence) > 0 {
+		b.WriteString("\nEvidence:\n")
+		for _, line := range item.Evidence {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+	}
+
+	entries := item.AllKnownIssues()
+	if len(entries) == 0 {
+		b.WriteString("\nNo known issue matched. Add entries for your own errors in ~/.r8s/kb.d/ (see r8s kb --help).\n")
+	}
+	for _, e := range entries {
+		fmt.Fprintf(&b, "\n%s KNOWN ISSUE: %s [%s]\n", knownIssueEmoji, e.Title, e.ID)
+		if e.Explanation != "" {
+			fmt.Fprintf(&b, "\nWhat it means:\n  %s\n", e.Explanation)
+		}
+		if e.Cause != "" {
+			fmt.Fprintf(&b, "\nLikely cause:\n  %s\n", e.Cause)
+		}
+		if len(e.Remediation) > 0 {
+			b.WriteString("\nHow to fix:\n")
+			for i, step := range e.Remediation {
+				fmt.Fprintf(&b, "  %d. %s\n", i+1, step)
+			}
+		}
+		if len(e.Links) > 0 {
+			b.WriteString("\nDocs:\n")
+			for _, link := range e.Links {
+				fmt.Fprintf(&b, "  %s\n", link)
+			}
+		}
+		if e.Source != knowledge.BuiltinSource {
+			fmt.Fprintf(&b, "\n(from %s)\n", e.Source)
+		}
+	}
+	if a.knowledge != nil && len(entries) > 0 {
+		fmt.Fprintf(&b, "\nKnowledge base %s\n", a.knowledge.Version)
+	}
+
+	title := item.Title
+	content := b.String()
+	return func() tea.Msg {
+		return describeMsg{title: title, content: content}
+	}
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/Rancheroo/r8s/internal/knowledge"
+)
+
+// TestMatchKnownIssues tests attaching knowledge base entries by item text, pod and event reason
+func TestMatchKnownIssues(t *testing.T) {
+	kb := knowledge.Load()
+	items := []AttentionItem{
+		{Severity: SeverityCritical, Title: "web-1", Description: "CrashLoopBackOff", ResourceType: "pod", Namespace: "app", PodName: "web-1",
+			Evidence: []string{"[event] BackOff: Back-off restarting failed container web in pod web-1"}},
+		{Severity: SeverityWarning, Title: "12× DNSConfigForming", Description: "Warning events", ResourceType: "event",
+			Evidence: []string{"Nameserver limits were exceeded, some nameservers have been omitted"}},
+		{Severity: SeverityWarning, Title: "3× FailedMount", Description: "Warning events", ResourceType: "event",
+			Evidence: []string{`MountVolume.SetUp failed for volume "data" : secret "db" not found`}},
+		{Severity: SeverityInfo, Title: "api-2", Description: "Pending", ResourceType: "pod", Namespace: "app", PodName: "api-2"},
+		// r8s's own wording is not evidence of a known issue
+		{Severity: SeverityWarning, Title: "sysctl fs.inotify.max_user_watches", ResourceType: "hostconfig",
+			Description: `8192, expected >= 524288: file watchers fail with "no space left on device" when exhausted`},
+	}
+
+	items = MatchKnownIssues(nil, items, kb) // Item evidence only
+
+	want := map[string]string{
+		"web-1":                "crashloop-backoff",
+		"12× DNSConfigForming": "dns-nameserver-limit",
+		"3× FailedMount":       "volume-mount-failed",
+	}
+	for _, item := range items {
+		id, ok := want[item.Title]
+		if !ok {
+			continue
+		}
+		if !hasKnownIssue([]AttentionItem{item}, id) {
+			t.Errorf("%s: known issues %v, want %s", item.Title, item.KnownIssues, id)
+		}
+		delete(want, item.Title)
+	}
+	if len(want) > 0 {
+		t.Errorf("items missing after matching: %v", want)
+	}
+	for _, item := range items {
+		if item.ResourceType == "hostconfig" && len(item.KnownIssues) > 0 {
+			t.Errorf("%s matched its own description: %v", item.Title, item.KnownIssues[0].ID)
+		}
+	}
+
+	// A log line from a pod is attached to that pod's items only
+	entry := kb.Lookup("too-many-open-files")
+	if !attachKnownIssue(items, entry, "app", "api-2", "") {
+		t.Fatal("log line from api-2 was not attached")
+	}
+	if attachKnownIssue(items, entry, "other", "api-2", "") {
+		t.Error("log line from another namespace's api-2 was attached")
+	}
+
+	// Incidents show the known issues of their symptoms once
+	incident := AttentionItem{Title: "app namespace (2 pods)", Symptoms: items}
+	incident.addKnownIssue(kb.Lookup("crashloop-backoff"))
+	seen := make(map[string]int)
+	for _, e := range incident.AllKnownIssues() {
+		seen[e.ID]++
+	}
+	if seen["crashloop-backoff"] != 1 || seen["too-many-open-files"] != 1 || seen["dns-nameserver-limit"] != 1 {
+		t.Errorf("AllKnownIssues() = %v", seen)
+	}
+}
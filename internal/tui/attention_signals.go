@@ -7,7 +7,9 @@ import (
 	"time"
 
 	"github.com/Rancheroo/r8s/internal/datasource"
+	"github.com/Rancheroo/r8s/internal/knowledge"
 	"github.com/Rancheroo/r8s/internal/rancher"
+	"github.com/Rancheroo/r8s/internal/rules"
 )
 
 // AttentionSeverity represents the severity level of an attention item
@@ -58,20 +60,54 @@ type AttentionItem struct {
 	Namespace    string
 	Count        int       // For aggregated items (e.g., restart count, error count)
 	Timestamp    time.Time // When detected
-	ResourceType string    // "pod", "node", "etcd", "daemonset", "event", "log", "system"
+	ResourceType string    // "pod", "node", "etcd", "daemonset", "event", "log", "system", "webhook", "helmchart", "apiservice", "networkpolicy", "rollout", "hpa", "runtime", "metrics", "certificate", "network", "system", "kernel", "hostconfig", "controlplane", "rke2config", "rule", "incident", "knownissue"
 
 	// Navigation context for drill-down
 	PodName       string
 	ContainerName string
 	ClusterID     string
 
+	// Structured details for incident correlation, so it never depends on display text
+	ResourceName string   // Name of the node, APIService, Deployment, DaemonSet or HPA the item is about
+	Ready        int      // Ready pods of a DaemonSet item
+	Desired      int      // Desired pods of a DaemonSet item
+	DependsOn    []string // APIServices the item needs (an HPA's unavailable metrics APIs)
+
 	// Expandable content for aggregate items (events)
-	AffectedPods      []string       // Top 10 pod names involved in this event
-	AffectedPodCounts map[string]int // Event count per pod
+	AffectedPods          []string          // Top 10 pod names involved in this event
+	AffectedPodCounts     map[string]int    // Event count per pod
+	AffectedPodNamespaces map[string]string // Namespace per pod; empty if pods of that name are in several
+
+	// Evidence holds correlated event/log lines shown when the item is expanded
+	Evidence []string
+
+	// Incident correlation: the causal chain that made this item the suspected root cause
+	// of Symptoms, the related items nested under it
+	Incident string
+	Symptoms []AttentionItem
+
+	// KnownIssues are the knowledge base entries matching this item, its events or its logs
+	KnownIssues []*knowledge.Entry
+}
+
+// IsExpandable reports whether the item has symptoms, pods, evidence or known issues to show
+// when expanded
+func (item AttentionItem) IsExpandable() bool {
+	return len(item.Symptoms) > 0 || len(item.AffectedPods) > 0 || len(item.Evidence) > 0 || len(item.KnownIssues) > 0
+}
+
+// SubItemCount returns the number of expanded lines selectable in sub-navigation: the shown
+// symptoms of an incident, otherwise the affected pods
+func (item AttentionItem) SubItemCount() int {
+	if len(item.Symptoms) > 0 {
+		return min(len(item.Symptoms), maxIncidentSymptoms)
+	}
+	return len(item.AffectedPods)
 }
 
-// ComputeAttentionItems runs all signal detectors and returns prioritized list of issues
-func ComputeAttentionItems(ds datasource.DataSource, scanDepth int) []AttentionItem {
+// ComputeAttentionItems runs all signal detectors and the user-defined rules (may be nil) and
+// returns prioritized list of issues
+func ComputeAttentionItems(ds datasource.DataSource, scanDepth int, userRules *rules.RuleSet) []AttentionItem {
 	var items []AttentionItem
 
 	// Default scan depth if not set
@@ -85,6 +121,58 @@ func ComputeAttentionItems(ds datasource.DataSource, scanDepth int) []AttentionI
 	// Tier 2: Cluster Health (Critical)
 	items = append(items, detectClusterHealth(ds)...)
 
+	// Tier 2b: Admission webhooks (Critical when a Fail-policy webhook has no backend)
+	items = append(items, detectWebhookHealth(ds)...)
+
+	// Tier 2b: Aggregated APIServices (Critical when unavailable)
+	items = append(items, detectAPIServiceHealth(ds)...)
+
+	// Tier 2b: Stalled Deployment rollouts (new ReplicaSet not ready, old one still serving)
+	items = append(items, detectStalledRollouts(ds)...)
+
+	// Tier 2b: HPAs that cannot scale (pinned at max, <unknown> metrics, missing target)
+	items = append(items, detectHPAScalingLimits(ds)...)
+
+	// Tier 2b: Container runtime (NotReady conditions, crash loops, kubelet/API desync)
+	items = append(items, detectRuntimeIssues(ds)...)
+
+	// Tier 2b: etcd (no leader, learners, missing members, fragmentation, NOSPACE risk, snapshots)
+	items = append(items, detectEtcdIssues(ds)...)
+
+	// Tier 2b: Metrics checks (etcd disk latency p99, leader changes, proposal failures, slow applies)
+	items = append(items, detectMetricCheckFailures(ds)...)
+
+	// Tier 2b: Certificates (expired, not yet valid, expiring, broken chain; linked x509 errors)
+	items = append(items, detectCertificateIssues(ds)...)
+
+	// Tier 2b: Node networking (RKE2 ports not listening, missing routes, MTU mismatch, mixed iptables backends)
+	items = append(items, detectNetworkCheckFailures(ds)...)
+
+	// Tier 2b: System resources (full disks and inodes on any mount, swap, load, fd saturation, ro mounts, failed units)
+	items = append(items, detectSystemResourceIssues(ds)...)
+
+	// Tier 2b: Kernel events (OOM kills attributed to pods, hung tasks, disk I/O errors, conntrack full, segfaults)
+	items = append(items, detectKernelEvents(ds)...)
+
+	// Tier 2b: Host config (sysctls, kernel modules, conflicting firewalls, AppArmor)
+	items = append(items, detectHostConfigIssues(ds)...)
+
+	// Tier 2b: Control plane (static pods not running, audit logging off, anonymous auth, no secrets encryption, profiling)
+	items = append(items, detectControlPlaneFindings(ds)...)
+
+	// Tier 2b: RKE2 config (unknown or misspelled keys, server keys on agents, invalid cni/CIDRs)
+	items = append(items, detectRKE2ConfigIssues(ds)...)
+
+	// Tier 2b: NetworkPolicies (default-deny namespaces, connection errors in isolated pods)
+	items = append(items, detectNetworkPolicyIsolation(ds)...)
+
+	// Tier 2c: HelmChart add-ons (one item per failed install, replacing its pod/event noise)
+	helmItems, absorbed := detectHelmChartHealth(ds)
+	items = append(items, helmItems...)
+
+	// Tier 2d: User-defined rules from ~/.r8s/rules.d and the bundle's rules.d
+	items = append(items, detectUserRules(ds, userRules)...)
+
 	// Tier 3: Events (Warning)
 	items = append(items, detectEventIssues(ds)...)
 
@@ -95,6 +183,9 @@ func ComputeAttentionItems(ds datasource.DataSource, scanDepth int) []AttentionI
 	// Tier 5: System Health (Bundle only)
 	items = append(items, detectSystemHealth(ds)...)
 
+	// Drop pod/event/daemonset items already explained by a failed HelmChart
+	items = suppressAbsorbedItems(items, absorbed)
+
 	// Sort by severity (Critical → Warning → Info)
 	sortAttentionItems(items)
 
@@ -106,6 +197,40 @@ func ComputeAttentionItems(ds datasource.DataSource, scanDepth int) []AttentionI
 	return items
 }
 
+// prescannedLogs is a data source whose SearchLogs answers the searches run in advance from
+// their results, so the searches run together share one pass over the logs
+type prescannedLogs struct {
+	datasource.DataSource
+	searches []*datasource.LogSearch
+}
+
+// PrescanLogs runs the log searches of the user rules (may be nil) and the knowledge base (may
+// be nil) in one pass and returns ds with SearchLogs answering them from that pass
+func PrescanLogs(ds datasource.DataSource, userRules *rules.RuleSet, kb *knowledge.Base) datasource.DataSource {
+	searches := userRules.LogSearches()
+	if kb != nil && len(kb.Entries) > 0 {
+		searches = append(searches, &datasource.LogSearch{Pattern: kb, MaxMatches: maxKnownIssueLogMatches})
+	}
+	if len(searches) == 0 || ds.ScanLogs(searches...) != nil {
+		return ds
+	}
+	return &prescannedLogs{DataSource: ds, searches: searches}
+}
+
+// SearchLogs returns the prescanned matches of pattern, searching the logs for other patterns
+// or larger caps
+func (p *prescannedLogs) SearchLogs(pattern datasource.LineMatcher, maxMatches int) ([]datasource.LogMatch, error) {
+	for _, s := range p.searches {
+		if s.Pattern == pattern && (s.MaxMatches <= 0 || (maxMatches > 0 && maxMatches <= s.MaxMatches)) {
+			if maxMatches > 0 && len(s.Matches) > maxMatches {
+				return s.Matches[:maxMatches], nil
+			}
+			return s.Matches, nil
+		}
+	}
+	return p.DataSource.SearchLogs(pattern, maxMatches)
+}
+
 // detectPodHealth detects pod-level issues
 func detectPodHealth(ds datasource.DataSource) []AttentionItem {
 	var items []AttentionItem
@@ -117,6 +242,14 @@ func detectPodHealth(ds datasource.DataSource) []AttentionItem {
 		return items
 	}
 
+	// Classified pull failures by namespace/pod, so ImagePullBackOff items say why
+	pullFailures := make(map[string]datasource.ImagePullFailure)
+	if report, err := ds.GetImages(); err == nil && report != nil {
+		for _, f := range report.PullFailures {
+			pullFailures[f.Namespace+"/"+f.Pod] = f
+		}
+	}
+
 	for _, pod := range pods {
 		// Extract namespace from NamespaceID (may be "cluster:namespace" format)
 		namespace := pod.NamespaceID
@@ -181,7 +314,7 @@ func detectPodHealth(ds datasource.DataSource) []AttentionItem {
 		// Critical: ImagePullBackOff / ErrImagePull (case-insensitive)
 		if strings.Contains(stateLower, "imagepullbackoff") || strings.Contains(stateLower, "errimagepull") ||
 			strings.Contains(kubectlStatusLower, "imagepullbackoff") || strings.Contains(kubectlStatusLower, "errimagepull") {
-			items = append(items, AttentionItem{
+			item := AttentionItem{
 				Severity:     SeverityCritical,
 				Emoji:        "🚫",
 				Title:        pod.Name,
@@ -190,7 +323,12 @@ func detectPodHealth(ds datasource.DataSource) []AttentionItem {
 				ResourceType: "pod",
 				PodName:      pod.Name,
 				Timestamp:    time.Now(),
-			})
+			}
+			if f, ok := pullFailures[namespace+"/"+pod.Name]; ok && f.Class != datasource.PullFailureUnknown {
+				item.Description = fmt.Sprintf("ImagePullBackOff (%s): %s", f.Class, f.Image)
+				item.Evidence = append(item.Evidence, pullFailureExplanation(f.Class), f.Message)
+			}
+			items = append(items, item)
 			continue
 		}
 
@@ -267,6 +405,7 @@ func detectClusterHealth(ds datasource.DataSource) []AttentionItem {
 					Description:  "NotReady",
 					Namespace:    "cluster",
 					ResourceType: "node",
+					ResourceName: node.Name,
 					Timestamp:    time.Now(),
 				})
 			}
@@ -307,19 +446,20 @@ func detectClusterHealth(ds datasource.DataSource) []AttentionItem {
 	if err == nil {
 		for _, ds := range daemonsets {
 			// Parse "X/Y" ready format
-			if strings.Contains(ds.Ready, "/") {
-				parts := strings.Split(ds.Ready, "/")
-				if len(parts) == 2 && parts[0] != parts[1] {
-					items = append(items, AttentionItem{
-						Severity:     SeverityWarning,
-						Emoji:        "🔧",
-						Title:        fmt.Sprintf("%s DS", ds.Name),
-						Description:  fmt.Sprintf("%s ready", ds.Ready),
-						Namespace:    ds.Namespace,
-						ResourceType: "daemonset",
-						Timestamp:    time.Now(),
-					})
-				}
+			var ready, desired int
+			if _, err := fmt.Sscanf(ds.Ready, "%d/%d", &ready, &desired); err == nil && ready != desired {
+				items = append(items, AttentionItem{
+					Severity:     SeverityWarning,
+					Emoji:        "🔧",
+					Title:        fmt.Sprintf("%s DS", ds.Name),
+					Description:  fmt.Sprintf("%s ready", ds.Ready),
+					Namespace:    ds.Namespace,
+					ResourceType: "daemonset",
+					ResourceName: ds.Name,
+					Ready:        ready,
+					Desired:      desired,
+					Timestamp:    time.Now(),
+				})
 			}
 		}
 	}
@@ -338,8 +478,9 @@ func detectEventIssues(ds datasource.DataSource) []AttentionItem {
 
 	// Aggregate Warning events by reason and track affected pods
 	type eventStats struct {
-		count int
-		pods  map[string]int // pod name -> event count
+		count      int
+		pods       map[string]int    // pod name -> event count
+		namespaces map[string]string // pod name -> namespace, "" if the name is in several
 	}
 	warningStats := make(map[string]*eventStats)
 
@@ -347,14 +488,21 @@ func detectEventIssues(ds datasource.DataSource) []AttentionItem {
 		if event.Type == "Warning" && event.Count > 0 {
 			if warningStats[event.Reason] == nil {
 				warningStats[event.Reason] = &eventStats{
-					pods: make(map[string]int),
+					pods:       make(map[string]int),
+					namespaces: make(map[string]string),
 				}
 			}
 			warningStats[event.Reason].count += event.Count
 
 			// Track pod name if available
 			if event.PodName != "" {
-				warningStats[event.Reason].pods[event.PodName] += event.Count
+				stats := warningStats[event.Reason]
+				if ns, seen := stats.namespaces[event.PodName]; seen && ns != event.Namespace {
+					stats.namespaces[event.PodName] = ""
+				} else {
+					stats.namespaces[event.PodName] = event.Namespace
+				}
+				stats.pods[event.PodName] += event.Count
 			}
 		}
 	}
@@ -378,16 +526,391 @@ func detectEventIssues(ds datasource.DataSource) []AttentionItem {
 
 			// Collapsed format: "467339× DNSConfigForming" with expandable pod list
 			items = append(items, AttentionItem{
-				Severity:          severity,
-				Emoji:             emoji,
-				Title:             fmt.Sprintf("%d× %s", stats.count, reason),
-				Description:       "Warning events",
-				Namespace:         "cluster",
-				Count:             stats.count,
-				ResourceType:      "event",
-				AffectedPods:      affectedPods,
-				AffectedPodCounts: stats.pods, // Store full count map for display
-				Timestamp:         time.Now(),
+				Severity:              severity,
+				Emoji:                 emoji,
+				Title:                 fmt.Sprintf("%d× %s", stats.count, reason),
+				Description:           "Warning events",
+				Namespace:             "cluster",
+				Count:                 stats.count,
+				ResourceType:          "event",
+				AffectedPods:          affectedPods,
+				AffectedPodCounts:     stats.pods, // Store full count map for display
+				AffectedPodNamespaces: stats.namespaces,
+				Timestamp:             time.Now(),
+			})
+		}
+	}
+
+	return items
+}
+
+// detectWebhookHealth detects admission webhooks whose backing service has no ready endpoints.
+// A Fail-policy webhook without a backend rejects every matching API request, so it is critical;
+// in table-only bundles the policy and Service are inferred, so it is only a warning.
+func detectWebhookHealth(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	webhooks, err := ds.GetWebhookHealth()
+	if err != nil {
+		return items
+	}
+
+	for _, wh := range webhooks {
+		if !wh.Resolved {
+			continue // Unknown backend - nothing to check against
+		}
+
+		policy := wh.FailurePolicy
+		if wh.PolicyInferred {
+			policy += "?" // Table-only bundle: policy is the known default, not observed
+		}
+
+		item := AttentionItem{
+			Emoji:        "🪝",
+			Title:        fmt.Sprintf("%s (%s)", wh.WebhookName, wh.Kind),
+			Namespace:    wh.ServiceNamespace,
+			Count:        len(wh.Failures),
+			ResourceType: "webhook",
+			AffectedPods: wh.BackendPods,
+			Evidence:     wh.Failures,
+			Timestamp:    time.Now(),
+		}
+		if len(wh.BackendPods) > 0 {
+			item.PodName = wh.BackendPods[0]
+		}
+
+		switch {
+		case !wh.HasReadyBackend:
+			item.Severity = SeverityWarning
+			if wh.FailurePolicy == "Fail" && !wh.PolicyInferred {
+				item.Severity = SeverityCritical
+			}
+			if !wh.ServiceFound {
+				item.Description = fmt.Sprintf("Service %s missing (%s)", wh.ServiceName, policy)
+			} else {
+				item.Description = fmt.Sprintf("No ready backend (%s)", policy)
+			}
+		case len(wh.Failures) > 0:
+			// Backend looks fine now, but calls have been failing
+			item.Severity = SeverityWarning
+			item.Description = fmt.Sprintf("%d failed calls", len(wh.Failures))
+		default:
+			continue
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// detectAPIServiceHealth detects unavailable aggregated APIServices. An unavailable
+// APIService breaks namespace deletion, HPA metrics and kubectl discovery, so it is critical.
+func detectAPIServiceHealth(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	apiServices, err := ds.GetAPIServiceHealth()
+	if err != nil {
+		return items
+	}
+
+	for _, svc := range apiServices {
+		item := AttentionItem{
+			Emoji:        "🔌",
+			Title:        fmt.Sprintf("%s (APIService)", svc.Name),
+			Namespace:    svc.ServiceNamespace,
+			Count:        len(svc.Failures),
+			ResourceType: "apiservice",
+			ResourceName: svc.Name,
+			AffectedPods: svc.BackendPods,
+			Timestamp:    time.Now(),
+		}
+		if len(svc.BackendPods) > 0 {
+			item.PodName = svc.BackendPods[0]
+		}
+
+		backend := fmt.Sprintf("service %s/%s: %d ready endpoint(s)", svc.ServiceNamespace, svc.ServiceName, len(svc.Endpoints))
+		if !svc.ServiceFound {
+			backend = fmt.Sprintf("service %s/%s: not found", svc.ServiceNamespace, svc.ServiceName)
+		}
+
+		switch {
+		case !svc.Available:
+			item.Severity = SeverityCritical
+			item.Description = "Unavailable"
+			if svc.Reason != "" {
+				item.Description = fmt.Sprintf("Unavailable (%s)", svc.Reason)
+			}
+			item.Evidence = append(item.Evidence, backend,
+				"impact: namespace deletion, HPA and kubectl discovery fail for this API group")
+		case len(svc.Failures) > 0:
+			// Available now, but discovery has been failing
+			item.Severity = SeverityWarning
+			item.Description = fmt.Sprintf("%d discovery errors", len(svc.Failures))
+			item.Evidence = append(item.Evidence, backend)
+		default:
+			continue
+		}
+
+		item.Evidence = append(item.Evidence, svc.Failures...)
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// detectStalledRollouts reports Deployments whose new ReplicaSet has no ready pods while an
+// old ReplicaSet is still serving. The service is up on the old revision, so it is a warning;
+// the new pods (usually ImagePullBackOff or CrashLoopBackOff) are linked for drill-down.
+func detectStalledRollouts(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	rollouts, err := ds.GetRollouts("")
+	if err != nil {
+		return items
+	}
+
+	for _, r := range rollouts {
+		if !r.Stalled {
+			continue
+		}
+		newRS := r.ReplicaSets[0]
+
+		item := AttentionItem{
+			Severity:     SeverityWarning,
+			Emoji:        "⏸️",
+			Title:        fmt.Sprintf("%s (Deployment)", r.Name),
+			Description:  fmt.Sprintf("Rollout stalled: new ReplicaSet 0/%d ready", newRS.Desired),
+			Namespace:    r.Namespace,
+			Count:        newRS.Desired,
+			ResourceType: "rollout",
+			ResourceName: r.Name,
+			Timestamp:    time.Now(),
+		}
+
+		item.Evidence = append(item.Evidence, fmt.Sprintf("new: %s desired=%d ready=0 images=%s",
+			newRS.Name, newRS.Desired, strings.Join(newRS.Images, ",")))
+		for _, rs := range r.ReplicaSets[1:] {
+			if rs.Ready > 0 {
+				item.Evidence = append(item.Evidence, fmt.Sprintf("serving: %s ready=%d/%d images=%s",
+					rs.Name, rs.Ready, rs.Desired, strings.Join(rs.Images, ",")))
+			}
+		}
+		for _, pod := range r.NewPods {
+			item.AffectedPods = append(item.AffectedPods, pod.Name)
+			item.Evidence = append(item.Evidence, fmt.Sprintf("pod %s: %s", pod.Name, pod.KubectlStatus))
+		}
+		if len(r.NewPods) > 0 {
+			item.PodName = r.NewPods[0].Name
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// detectHPAScalingLimits reports HPAs that cannot do their job: pinned at maxReplicas,
+// unable to read metrics (usually a broken metrics-server APIService), or scaling a
+// target that does not exist
+func detectHPAScalingLimits(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	hpas, err := ds.GetHPAs("", "")
+	if err != nil {
+		return items
+	}
+
+	for _, hpa := range hpas {
+		if len(hpa.Problems) == 0 {
+			continue
+		}
+
+		item := AttentionItem{
+			Severity:     SeverityWarning,
+			Emoji:        "📈",
+			Title:        fmt.Sprintf("%s (HPA)", hpa.Name),
+			Description:  strings.Join(hpa.Problems, "; "),
+			Namespace:    hpa.Namespace,
+			Count:        hpa.Replicas,
+			ResourceType: "hpa",
+			ResourceName: hpa.Name,
+			DependsOn:    hpa.UnavailableAPIs,
+			Timestamp:    time.Now(),
+		}
+		if len(hpa.UnavailableAPIs) > 0 {
+			item.Description += fmt.Sprintf(" - %s unavailable", strings.Join(hpa.UnavailableAPIs, ", "))
+		}
+
+		item.Evidence = append(item.Evidence, fmt.Sprintf("target %s/%s, replicas %d (min %d, max %d), metrics %s",
+			hpa.TargetKind, hpa.TargetName, hpa.Replicas, hpa.MinReplicas, hpa.MaxReplicas, formatHPATargets(hpa.Metrics)))
+		for _, api := range hpa.UnavailableAPIs {
+			item.Evidence = append(item.Evidence, fmt.Sprintf("metrics APIService %s unavailable", api))
+		}
+		for _, c := range hpa.Conditions {
+			if (c.Type == "ScalingActive" && c.Status == "False") || (c.Type == "ScalingLimited" && c.Status == "True") {
+				item.Evidence = append(item.Evidence, fmt.Sprintf("%s=%s %s: %s", c.Type, c.Status, c.Reason, c.Message))
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// detectRuntimeIssues reports NotReady containerd conditions (Critical), containers crictl
+// shows crash-looping, and pods the runtime and API disagree on. A sandbox with no API pod,
+// or a Running API pod with no sandbox, means kubelet has lost sync with the API server.
+func detectRuntimeIssues(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	status, err := ds.GetRuntime()
+	if err != nil || status == nil {
+		return items
+	}
+
+	if len(status.NotReadyConditions) > 0 {
+		items = append(items, AttentionItem{
+			Severity:     SeverityCritical,
+			Emoji:        "🐳",
+			Title:        fmt.Sprintf("Container runtime on %s", status.NodeName),
+			Description:  "NotReady: " + strings.Join(status.NotReadyConditions, "; "),
+			Count:        len(status.NotReadyConditions),
+			ResourceType: "runtime",
+			Timestamp:    time.Now(),
+			Evidence:     status.NotReadyConditions,
+		})
+	}
+
+	var crashLoops, desync []datasource.RuntimeIssue
+	for _, issue := range status.Issues {
+		if issue.Type == datasource.RuntimeIssueCrashLoop {
+			crashLoops = append(crashLoops, issue)
+		} else {
+			desync = append(desync, issue)
+		}
+	}
+
+	if len(crashLoops) > 0 {
+		item := AttentionItem{
+			Severity:     SeverityWarning,
+			Emoji:        "🐳",
+			Title:        fmt.Sprintf("Runtime crash loops on %s", status.NodeName),
+			Description:  fmt.Sprintf("%d containers exited after repeated restarts (crictl)", len(crashLoops)),
+			Count:        len(crashLoops),
+			ResourceType: "runtime",
+			Timestamp:    time.Now(),
+		}
+		for _, issue := range crashLoops {
+			item.AffectedPods = append(item.AffectedPods, issue.Pod)
+			item.Evidence = append(item.Evidence, fmt.Sprintf("%s/%s: %s", issue.Namespace, issue.Pod, issue.Detail))
+		}
+		if len(item.AffectedPods) > 10 {
+			item.AffectedPods = item.AffectedPods[:10]
+		}
+		if len(crashLoops) == 1 {
+			item.Namespace = crashLoops[0].Namespace
+			item.PodName = crashLoops[0].Pod
+			item.ContainerName = crashLoops[0].Container
+		}
+		items = append(items, item)
+	}
+
+	if len(desync) > 0 {
+		missingFromAPI, missingFromRuntime := 0, 0
+		for _, issue := range desync {
+			if issue.Type == datasource.RuntimeIssueMissingFromAPI {
+				missingFromAPI++
+			} else {
+				missingFromRuntime++
+			}
+		}
+
+		item := AttentionItem{
+			Severity:     SeverityWarning,
+			Emoji:        "🐳",
+			Title:        fmt.Sprintf("Kubelet/API desync on %s", status.NodeName),
+			Description:  fmt.Sprintf("%d sandboxes not in API, %d API pods not in runtime", missingFromAPI, missingFromRuntime),
+			Count:        len(desync),
+			ResourceType: "runtime",
+			Timestamp:    time.Now(),
+		}
+		for _, issue := range desync {
+			item.AffectedPods = append(item.AffectedPods, issue.Pod)
+			item.Evidence = append(item.Evidence, fmt.Sprintf("%s/%s: %s", issue.Namespace, issue.Pod, issue.Detail))
+		}
+		if len(item.AffectedPods) > 10 {
+			item.AffectedPods = item.AffectedPods[:10]
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// detectEtcdIssues reports one item per etcd analysis finding: no leader, learners,
+// etcd-role nodes missing from the member list, fragmentation, DB size near the quota
+// (NOSPACE risk) and missing or stale snapshots
+func detectEtcdIssues(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	status, err := ds.GetEtcdStatus()
+	if err != nil || status == nil {
+		return items
+	}
+
+	for _, issue := range status.Issues {
+		severity := SeverityWarning
+		if issue.Critical {
+			severity = SeverityCritical
+		}
+		item := AttentionItem{
+			Severity:     severity,
+			Emoji:        "🗄️",
+			Title:        "ETCD",
+			Description:  issue.Detail,
+			Namespace:    "etcd",
+			ResourceType: "etcd",
+			Timestamp:    time.Now(),
+		}
+		if issue.Member != "" {
+			item.Evidence = []string{fmt.Sprintf("%s: member %s", issue.Type, issue.Member)}
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// detectMetricCheckFailures reports built-in metrics checks that are not OK, such as slow
+// etcd WAL fsync or backend commit p99, leader loss or churn, and failed proposals
+func detectMetricCheckFailures(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	sources, err := ds.GetMetrics()
+	if err != nil {
+		return items
+	}
+
+	for _, source := range sources {
+		for _, check := range source.Checks {
+			if check.Status == datasource.MetricCheckOK {
+				continue
+			}
+			severity := SeverityWarning
+			if check.Status == datasource.MetricCheckCritical {
+				severity = SeverityCritical
+			}
+			items = append(items, AttentionItem{
+				Severity:     severity,
+				Emoji:        "📈",
+				Title:        strings.ToUpper(source.Component),
+				Description:  fmt.Sprintf("%s %s: %s", check.Name, check.Value, check.Detail),
+				Namespace:    source.Component,
+				ResourceType: "metrics",
+				Timestamp:    time.Now(),
+				Evidence:     []string{fmt.Sprintf("%s (%s)", check.Metric, source.File)},
 			})
 		}
 	}
@@ -395,6 +918,621 @@ func detectEventIssues(ds datasource.DataSource) []AttentionItem {
 	return items
 }
 
+// detectCertificateIssues reports RKE2 certificates that are expired, not yet valid (clock
+// skew) or expiring at collection time, and certificates whose chain does not match the
+// bundle's CA, with the x509 errors from logs and events that they likely explain.
+// x509 errors no certificate explains are reported together.
+func detectCertificateIssues(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	report, err := ds.GetCertificates()
+	if err != nil || report == nil {
+		return items
+	}
+
+	for _, c := range report.Certificates {
+		var description string
+		switch c.Expiry {
+		case datasource.CertExpired:
+			description = fmt.Sprintf("Expired %s (%s)", formatCertExpiresIn(c.ExpiresIn), c.NotAfter.Format("2006-01-02"))
+		case datasource.CertNotYetValid:
+			description = fmt.Sprintf("Not valid until %s: node clock behind by %s (clock skew)",
+				c.NotBefore.Format("2006-01-02 15:04"), formatDuration(c.NotBefore.Sub(report.CollectedAt)))
+		case datasource.CertExpiring:
+			description = fmt.Sprintf("Expires %s (%s)", formatCertExpiresIn(c.ExpiresIn), c.NotAfter.Format("2006-01-02"))
+		}
+		if c.Chain == datasource.CertChainInvalid {
+			if description != "" {
+				description += "; "
+			}
+			description += "chain: " + c.ChainDetail
+		}
+		if description == "" {
+			continue
+		}
+
+		severity := SeverityWarning
+		if c.Critical {
+			severity = SeverityCritical
+		}
+		item := AttentionItem{
+			Severity:     severity,
+			Emoji:        "🔐",
+			Title:        c.Name,
+			Description:  description,
+			Namespace:    "certs",
+			Count:        len(c.LogErrors),
+			ResourceType: "certificate",
+			Timestamp:    time.Now(),
+			Evidence:     c.LogErrors,
+		}
+		if len(item.Evidence) > 10 {
+			item.Evidence = item.Evidence[:10]
+		}
+		items = append(items, item)
+	}
+
+	if len(report.UnlinkedErrors) > 0 {
+		item := AttentionItem{
+			Severity:     SeverityWarning,
+			Emoji:        "🔐",
+			Title:        "x509 errors",
+			Description:  fmt.Sprintf("%d certificate errors in events and logs not explained by rke2/certs", len(report.UnlinkedErrors)),
+			Namespace:    "certs",
+			Count:        len(report.UnlinkedErrors),
+			ResourceType: "certificate",
+			Timestamp:    time.Now(),
+			Evidence:     report.UnlinkedErrors,
+		}
+		if len(item.Evidence) > 10 {
+			item.Evidence = item.Evidence[:10]
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// detectNetworkCheckFailures reports failed node networking checks: required RKE2 ports not
+// listening, missing pod/service CIDR routes, MTU mismatches and mixed iptables backends
+func detectNetworkCheckFailures(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	report, err := ds.GetNetworking()
+	if err != nil || report == nil {
+		return items
+	}
+
+	for _, check := range report.Checks {
+		if check.Status == datasource.NetworkCheckOK {
+			continue
+		}
+		severity := SeverityWarning
+		if check.Status == datasource.NetworkCheckCritical {
+			severity = SeverityCritical
+		}
+		items = append(items, AttentionItem{
+			Severity:     severity,
+			Emoji:        "🌐",
+			Title:        check.Name,
+			Description:  check.Detail,
+			Namespace:    report.Node,
+			ResourceType: "network",
+			Timestamp:    time.Now(),
+		})
+	}
+
+	return items
+}
+
+// detectSystemResourceIssues reports problems found in systeminfo: full disks or inode
+// exhaustion on any host filesystem, swap in use, load far above the core count, file
+// handle saturation, read-only mounts, zombie or uninterruptible processes, and failed or
+// restart-looping systemd units (with their journald lines as evidence)
+func detectSystemResourceIssues(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	res, err := ds.GetSystemResources()
+	if err != nil || res == nil {
+		return items
+	}
+
+	for _, issue := range res.Issues {
+		severity := SeverityWarning
+		if issue.Critical {
+			severity = SeverityCritical
+		}
+		emoji := "🖥️"
+		switch issue.Type {
+		case datasource.SystemIssueDiskFull, datasource.SystemIssueInodes, datasource.SystemIssueReadOnlyMount:
+			emoji = "💿"
+		case datasource.SystemIssueSwap:
+			emoji = "💾"
+		case datasource.SystemIssueUnitFailed, datasource.SystemIssueUnitRestarting,
+			datasource.SystemIssueUnitInactive, datasource.SystemIssueNoTimeSync:
+			emoji = "⚙️"
+		}
+		items = append(items, AttentionItem{
+			Severity:     severity,
+			Emoji:        emoji,
+			Title:        issue.Subject,
+			Description:  issue.Detail,
+			Namespace:    "system",
+			ResourceType: "system",
+			Timestamp:    time.Now(),
+			Evidence:     issue.Evidence,
+		})
+	}
+
+	return items
+}
+
+// kernelEventTitles names the non-OOM kernel event types in attention items
+var kernelEventTitles = map[string]string{
+	datasource.KernelEventHungTask:      "Hung tasks in kernel",
+	datasource.KernelEventIOError:       "Disk I/O errors",
+	datasource.KernelEventConntrackFull: "Conntrack table full",
+	datasource.KernelEventSegfault:      "Segfaults",
+}
+
+// detectKernelEvents reports dmesg problems. OOM kills get one item per victim (pod when the
+// cgroup path identifies one, else process), critical when the whole node ran out of memory;
+// I/O errors are grouped per device and the other types per type. The dmesg lines are evidence.
+func detectKernelEvents(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	log, err := ds.GetKernelEvents()
+	if err != nil || log == nil {
+		return items
+	}
+
+	// Group by victim or type, keeping first-seen order
+	index := make(map[string]int)
+	for _, e := range log.Events {
+		key := e.Type
+		switch e.Type {
+		case datasource.KernelEventOOMKill:
+			key += "/" + e.Constraint + "/" + kernelEventSubject(e)
+			if e.Pod == "" && e.PodUID == "" {
+				key += "/" + e.Process
+			}
+		case datasource.KernelEventIOError:
+			key += "/" + e.Device
+		}
+
+		i, seen := index[key]
+		if !seen {
+			item := AttentionItem{
+				Severity:     SeverityWarning,
+				Emoji:        "🐧",
+				Namespace:    log.Node,
+				ResourceType: "kernel",
+				Timestamp:    time.Now(),
+			}
+			switch e.Type {
+			case datasource.KernelEventOOMKill:
+				item.Emoji = "💥"
+				victim := kernelEventSubject(e)
+				if victim == "" {
+					victim = e.Process
+				}
+				item.Title = fmt.Sprintf("OOMKilled by kernel: %s (%s)", victim, e.Constraint)
+				if e.Constraint == datasource.OOMConstraintNode {
+					item.Severity = SeverityCritical
+				}
+				if e.Pod != "" {
+					item.Namespace = e.Namespace
+					item.PodName = e.Pod
+					item.ContainerName = e.Container
+				}
+			case datasource.KernelEventIOError, datasource.KernelEventConntrackFull:
+				item.Severity = SeverityCritical
+				item.Title = kernelEventTitles[e.Type]
+				if e.Device != "" {
+					item.Title += " on " + e.Device
+				}
+			default:
+				item.Title = kernelEventTitles[e.Type]
+			}
+			index[key] = len(items)
+			i = len(items)
+			items = append(items, item)
+		}
+
+		item := &items[i]
+		item.Count++
+		if !e.Time.IsZero() {
+			item.Timestamp = e.Time
+		}
+		if len(item.Evidence) < 10 {
+			item.Evidence = append(item.Evidence, e.Lines...)
+		}
+		switch e.Type {
+		case datasource.KernelEventOOMKill:
+			item.Description = fmt.Sprintf("%s killed %d×, last at %s", e.Process, item.Count, formatKernelTime(e))
+			if e.AnonRSS > 0 {
+				item.Description += fmt.Sprintf(" with %s anon RSS", formatBytes(e.AnonRSS*1024))
+			}
+		default:
+			item.Description = fmt.Sprintf("%d× in dmesg, last at %s: %s", item.Count, formatKernelTime(e), e.Message)
+		}
+	}
+
+	return items
+}
+
+// detectHostConfigIssues reports host config lint rules that warn or fail: sysctls RKE2 needs,
+// missing br_netfilter/overlay modules, running firewalld/nm-cloud-setup/ufw and AppArmor
+// enabled without its parser
+func detectHostConfigIssues(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	host, err := ds.GetHostConfig()
+	if err != nil || host == nil {
+		return items
+	}
+
+	for _, check := range host.Checks {
+		severity := SeverityWarning
+		switch check.Status {
+		case datasource.HostCheckFail:
+			severity = SeverityCritical
+		case datasource.HostCheckWarn:
+		default:
+			continue
+		}
+		items = append(items, AttentionItem{
+			Severity:     severity,
+			Emoji:        "🔧",
+			Title:        fmt.Sprintf("%s %s", check.Category, check.Name),
+			Description:  fmt.Sprintf("%s, expected %s: %s", check.Actual, check.Expected, check.Detail),
+			Namespace:    host.Node,
+			ResourceType: "hostconfig",
+			Timestamp:    time.Now(),
+		})
+	}
+
+	return items
+}
+
+// detectControlPlaneFindings reports static pod manifest findings: components whose container
+// is not running, settings that weaken security, and CIDRs that differ from RKE2 defaults
+func detectControlPlaneFindings(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	cp, err := ds.GetControlPlane()
+	if err != nil || cp == nil {
+		return items
+	}
+
+	for _, f := range cp.Findings {
+		item := AttentionItem{
+			Severity:     SeverityInfo,
+			Emoji:        "🎛️",
+			Title:        fmt.Sprintf("%s --%s", f.Component, f.Flag),
+			Description:  f.Detail,
+			Namespace:    cp.Node,
+			ResourceType: "controlplane",
+			Timestamp:    time.Now(),
+		}
+		switch f.Severity {
+		case datasource.ControlPlaneDown:
+			item.Severity = SeverityCritical
+			item.Title = f.Component + " is down"
+			// Link to the static pod so its logs are one keypress away
+			for _, p := range cp.Components {
+				if p.Component == f.Component {
+					item.Namespace, item.PodName, item.ContainerName = p.Namespace, p.PodName, p.Container
+				}
+			}
+		case datasource.ControlPlaneRisky:
+			item.Severity = SeverityWarning
+		}
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// detectRKE2ConfigIssues reports 50-rancher.yaml keys RKE2 ignores (unknown, misspelled or
+// server-only on an agent) and invalid cni or CIDR values
+func detectRKE2ConfigIssues(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	config, err := ds.GetRKE2Config()
+	if err != nil || config == nil {
+		return items
+	}
+
+	for _, issue := range config.Issues {
+		items = append(items, AttentionItem{
+			Severity:     SeverityWarning,
+			Emoji:        "📝",
+			Title:        fmt.Sprintf("RKE2 config %s: %s", issue.Type, issue.Key),
+			Description:  issue.Detail,
+			Namespace:    config.Node,
+			ResourceType: "rke2config",
+			Timestamp:    time.Now(),
+		})
+	}
+
+	return items
+}
+
+// detectUserRules reports the findings of user-defined rules, with the rule's runbook first in
+// the evidence, and one item listing rules that failed to load
+func detectUserRules(ds datasource.DataSource, userRules *rules.RuleSet) []AttentionItem {
+	var items []AttentionItem
+	if userRules == nil {
+		return items
+	}
+
+	severities := map[string]AttentionSeverity{
+		rules.SeverityCritical: SeverityCritical,
+		rules.SeverityWarning:  SeverityWarning,
+		rules.SeverityInfo:     SeverityInfo,
+	}
+	for _, f := range userRules.Evaluate(ds) {
+		title := f.Rule.Title
+		if f.Resource != "" {
+			title = fmt.Sprintf("%s: %s", f.Rule.Title, f.Resource)
+		}
+		var evidence []string
+		if f.Rule.Runbook != "" {
+			evidence = append(evidence, "Runbook: "+f.Rule.Runbook)
+		}
+		evidence = append(evidence, f.Evidence...)
+
+		items = append(items, AttentionItem{
+			Severity:     severities[f.Rule.Severity],
+			Emoji:        f.Rule.Icon(),
+			Title:        title,
+			Description:  f.Description,
+			Namespace:    f.Namespace,
+			Count:        f.Count,
+			PodName:      f.Pod,
+			ResourceType: "rule",
+			Evidence:     evidence,
+			Timestamp:    time.Now(),
+		})
+	}
+
+	if len(userRules.Problems) > 0 {
+		var evidence []string
+		for _, p := range userRules.Problems {
+			evidence = append(evidence, p.String())
+		}
+		items = append(items, AttentionItem{
+			Severity:     SeverityWarning,
+			Emoji:        rules.DefaultEmoji,
+			Title:        fmt.Sprintf("%d rule problems (run r8s rules lint)", len(userRules.Problems)),
+			Description:  "Invalid rules were skipped",
+			Count:        len(userRules.Problems),
+			ResourceType: "rule",
+			Evidence:     evidence,
+			Timestamp:    time.Now(),
+		})
+	}
+
+	return items
+}
+
+// detectNetworkPolicyIsolation reports one item per namespace that is default-deny or whose
+// isolated pods log connection refused/timeout errors. A default-deny namespace on its own is
+// informational; connection errors from pods a policy isolates are a likely cause of outages.
+func detectNetworkPolicyIsolation(ds datasource.DataSource) []AttentionItem {
+	var items []AttentionItem
+
+	policies, err := ds.GetNetworkPolicies()
+	if err != nil {
+		return items
+	}
+
+	// Group by namespace, keeping first-seen order
+	var namespaces []string
+	byNamespace := make(map[string][]datasource.NetworkPolicyStatus)
+	for _, policy := range policies {
+		if _, ok := byNamespace[policy.Namespace]; !ok {
+			namespaces = append(namespaces, policy.Namespace)
+		}
+		byNamespace[policy.Namespace] = append(byNamespace[policy.Namespace], policy)
+	}
+
+	for _, ns := range namespaces {
+		var defaultDeny, errors []string
+		var errorPods []string
+		denyIngress, denyEgress, inferred := false, false, false
+
+		for _, policy := range byNamespace[ns] {
+			if policy.DefaultDeny {
+				defaultDeny = append(defaultDeny, policy.Name)
+				denyIngress = denyIngress || policy.DenyIngress
+				denyEgress = denyEgress || policy.DenyEgress
+				inferred = inferred || policy.Inferred
+			}
+			for _, m := range policy.ConnectionErrors {
+				errors = append(errors, fmt.Sprintf("[%s] %s:%d: %s", policy.Name, m.Source, m.LineNumber, m.Line))
+				if !containsName(errorPods, m.PodName) {
+					errorPods = append(errorPods, m.PodName)
+				}
+			}
+		}
+
+		if len(defaultDeny) == 0 && len(errors) == 0 {
+			continue
+		}
+
+		item := AttentionItem{
+			Severity:     SeverityInfo,
+			Emoji:        "🛡️",
+			Title:        fmt.Sprintf("%s (NetworkPolicy)", ns),
+			Namespace:    ns,
+			Count:        len(errors),
+			ResourceType: "networkpolicy",
+			Timestamp:    time.Now(),
+		}
+
+		if len(defaultDeny) > 0 {
+			direction := "ingress"
+			switch {
+			case denyIngress && denyEgress:
+				direction = "ingress+egress"
+			case denyEgress:
+				direction = "egress"
+			}
+			item.Description = "Default-deny " + direction
+			note := ""
+			if inferred {
+				note = " (inferred from policy name)"
+			}
+			item.Evidence = append(item.Evidence, fmt.Sprintf("default-deny policy: %s%s", strings.Join(defaultDeny, ", "), note))
+		}
+
+		if len(errors) > 0 {
+			item.Severity = SeverityWarning
+			if item.Description != "" {
+				item.Description += ", "
+			}
+			item.Description += fmt.Sprintf("%d connection errors in isolated pods", len(errors))
+			item.AffectedPods = errorPods
+			if len(item.AffectedPods) > 10 {
+				item.AffectedPods = item.AffectedPods[:10]
+			}
+			item.PodName = errorPods[0]
+			item.Evidence = append(item.Evidence, errors...)
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
+
+// helmChartAbsorbed holds the pods and workloads explained by HelmChart items: pods only for
+// failed installs, so a degraded add-on's own crashing or unpullable pods stay visible
+type helmChartAbsorbed struct {
+	pods      map[string]bool // "namespace/pod"
+	workloads map[string]bool // "namespace/name"
+}
+
+// detectHelmChartHealth reports HelmCharts whose install failed or whose workloads are not
+// ready. Each chart becomes one item with the job, pod and workload state as evidence; the
+// workloads it covers, and the pods of failed installs, are returned so their individual
+// items can be suppressed.
+func detectHelmChartHealth(ds datasource.DataSource) ([]AttentionItem, helmChartAbsorbed) {
+	var items []AttentionItem
+	absorbed := helmChartAbsorbed{pods: make(map[string]bool), workloads: make(map[string]bool)}
+
+	charts, err := ds.GetHelmCharts()
+	if err != nil {
+		return items, absorbed
+	}
+
+	for _, chart := range charts {
+		if chart.Problem == "" {
+			continue
+		}
+
+		item := AttentionItem{
+			Severity:     SeverityWarning,
+			Emoji:        "📦",
+			Title:        fmt.Sprintf("%s (HelmChart)", chart.Name),
+			Description:  chart.Problem,
+			Namespace:    chart.Namespace,
+			ResourceType: "helmchart",
+			Timestamp:    time.Now(),
+		}
+		if chart.Failed || chart.JobFailed {
+			item.Severity = SeverityCritical
+		}
+
+		if chart.JobStatus != "" || chart.JobCompletions != "" {
+			item.Evidence = append(item.Evidence, fmt.Sprintf("job %s: %s (%s)", chart.JobName, chart.JobStatus, chart.JobCompletions))
+		}
+		installFailed := chart.Failed || chart.JobFailed
+		for _, pod := range chart.InstallPods {
+			item.Evidence = append(item.Evidence, fmt.Sprintf("pod %s: %s (%d restarts)", pod.Name, pod.Status, pod.Restarts))
+			item.AffectedPods = append(item.AffectedPods, pod.Name)
+			item.PodName = pod.Name // Newest install pod last - Enter opens its logs
+			if installFailed {
+				absorbed.pods[chart.Namespace+"/"+pod.Name] = true
+			}
+		}
+		for _, w := range chart.Workloads {
+			if w.Ready >= w.Desired {
+				continue
+			}
+			if w.Ready == 0 {
+				item.Severity = SeverityCritical // Add-on completely down
+			}
+			item.Evidence = append(item.Evidence, fmt.Sprintf("%s %s/%s: %d/%d ready", w.Kind, w.Namespace, w.Name, w.Ready, w.Desired))
+			absorbed.workloads[w.Namespace+"/"+w.Name] = true
+		}
+		targetNS := chart.TargetNamespace
+		if targetNS == "" {
+			targetNS = chart.Namespace
+		}
+		for _, pod := range chart.WorkloadPods {
+			if installFailed {
+				absorbed.pods[targetNS+"/"+pod] = true
+			}
+			if len(item.AffectedPods) < 10 {
+				item.AffectedPods = append(item.AffectedPods, pod)
+			}
+		}
+		for _, line := range chart.InstallErrors {
+			item.Evidence = append(item.Evidence, "log: "+line)
+		}
+
+		items = append(items, item)
+	}
+
+	return items, absorbed
+}
+
+// suppressAbsorbedItems removes pod and daemonset items covered by a HelmChart item and
+// subtracts covered pods from aggregated event items
+func suppressAbsorbedItems(items []AttentionItem, absorbed helmChartAbsorbed) []AttentionItem {
+	if len(absorbed.pods) == 0 && len(absorbed.workloads) == 0 {
+		return items
+	}
+
+	var kept []AttentionItem
+	for _, item := range items {
+		switch item.ResourceType {
+		case "pod":
+			if absorbed.pods[item.Namespace+"/"+item.PodName] {
+				continue
+			}
+		case "daemonset":
+			if absorbed.workloads[item.Namespace+"/"+item.ResourceName] {
+				continue
+			}
+		case "event":
+			var pods []string
+			for _, pod := range item.AffectedPods {
+				if ns := item.AffectedPodNamespaces[pod]; ns != "" && absorbed.pods[ns+"/"+pod] {
+					item.Count -= item.AffectedPodCounts[pod]
+					continue
+				}
+				pods = append(pods, pod)
+			}
+			if len(pods) == len(item.AffectedPods) {
+				break
+			}
+			if item.Count <= 0 {
+				continue
+			}
+			item.AffectedPods = pods
+			if idx := strings.Index(item.Title, "× "); idx >= 0 {
+				item.Title = fmt.Sprintf("%d%s", item.Count, item.Title[idx:])
+			}
+		}
+		kept = append(kept, item)
+	}
+	return kept
+}
+
 // getTopPods returns the top N pods by event count
 func getTopPods(pods map[string]int, n int) []string {
 	type podCount struct {
@@ -532,18 +1670,7 @@ func detectSystemHealth(ds datasource.DataSource) []AttentionItem {
 		})
 	}
 
-	// Disk pressure (>85% used)
-	if sysHealth.DiskUsedPercent > 85 {
-		items = append(items, AttentionItem{
-			Severity:     SeverityInfo,
-			Emoji:        "💿",
-			Title:        "Disk",
-			Description:  fmt.Sprintf("%.0f%% used", sysHealth.DiskUsedPercent),
-			Namespace:    "system",
-			ResourceType: "system",
-			Timestamp:    time.Now(),
-		})
-	}
+	// Disk pressure is reported per filesystem by detectSystemResourceIssues
 
 	return items
 }
@@ -211,3 +211,52 @@ func BenchmarkRenderAttentionDashboard_1000Items(b *testing.B) {
 		_ = app.renderAttentionDashboard()
 	}
 }
+
+// TestSuppressAbsorbedItems tests that pods, daemonsets and events covered by a
+// failed HelmChart item are not reported again
+func TestSuppressAbsorbedItems(t *testing.T) {
+	absorbed := helmChartAbsorbed{
+		pods:      map[string]bool{"kube-system/helm-install-rke2-canal-x7k2p": true},
+		workloads: map[string]bool{"kube-system/rke2-canal": true},
+	}
+
+	items := []AttentionItem{
+		{Title: "rke2-canal (HelmChart)", ResourceType: "helmchart", PodName: "helm-install-rke2-canal-x7k2p", Namespace: "kube-system"},
+		{Title: "helm-install-rke2-canal-x7k2p", ResourceType: "pod", PodName: "helm-install-rke2-canal-x7k2p", Namespace: "kube-system"},
+		{Title: "rke2-canal DS", ResourceType: "daemonset", ResourceName: "rke2-canal", Namespace: "kube-system"},
+		{Title: "12× BackOff", ResourceType: "event", Count: 12,
+			AffectedPods:          []string{"helm-install-rke2-canal-x7k2p"},
+			AffectedPodCounts:     map[string]int{"helm-install-rke2-canal-x7k2p": 12},
+			AffectedPodNamespaces: map[string]string{"helm-install-rke2-canal-x7k2p": "kube-system"}},
+		{Title: "9× Unhealthy", ResourceType: "event", Count: 9,
+			AffectedPods:          []string{"helm-install-rke2-canal-x7k2p", "other-pod"},
+			AffectedPodCounts:     map[string]int{"helm-install-rke2-canal-x7k2p": 4, "other-pod": 5},
+			AffectedPodNamespaces: map[string]string{"helm-install-rke2-canal-x7k2p": "kube-system", "other-pod": "default"}},
+		{Title: "other-pod", ResourceType: "pod", PodName: "other-pod", Namespace: "default"},
+		// Same name as the absorbed install pod, in another namespace
+		{Title: "helm-install-rke2-canal-x7k2p", ResourceType: "pod", PodName: "helm-install-rke2-canal-x7k2p", Namespace: "tenant"},
+		{Title: "7× Failed", ResourceType: "event", Count: 7,
+			AffectedPods:          []string{"helm-install-rke2-canal-x7k2p"},
+			AffectedPodCounts:     map[string]int{"helm-install-rke2-canal-x7k2p": 7},
+			AffectedPodNamespaces: map[string]string{"helm-install-rke2-canal-x7k2p": "tenant"}},
+	}
+
+	kept := suppressAbsorbedItems(items, absorbed)
+
+	var titles []string
+	for _, item := range kept {
+		titles = append(titles, item.Title)
+	}
+	want := []string{"rke2-canal (HelmChart)", "5× Unhealthy", "other-pod", "helm-install-rke2-canal-x7k2p", "7× Failed"}
+	if len(titles) != len(want) {
+		t.Fatalf("kept %v, want %v", titles, want)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Errorf("item %d = %q, want %q", i, titles[i], want[i])
+		}
+	}
+	if len(kept[1].AffectedPods) != 1 || kept[1].AffectedPods[0] != "other-pod" {
+		t.Errorf("expected absorbed pod removed from event, got %v", kept[1].AffectedPods)
+	}
+}
@@ -0,0 +1,253 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// certificatesMsg carries the bundle's RKE2 certificates
+type certificatesMsg struct {
+	report *datasource.CertificateReport
+}
+
+// fetchCertificates fetches certificates using the unified data source
+func (a *App) fetchCertificates() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		report, err := a.dataSource.GetCertificates()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch certificates: %w", err)}
+		}
+
+		return certificatesMsg{report: report}
+	}
+}
+
+// formatCertExpiresIn formats time to expiry relative to collection, e.g. "in 350d4h" or "3d2h ago"
+func formatCertExpiresIn(d time.Duration) string {
+	if d < 0 {
+		return formatDuration(-d) + " ago"
+	}
+	return "in " + formatDuration(d)
+}
+
+// certExpiryLabel returns the STATUS column text for a certificate
+func certExpiryLabel(c datasource.CertificateStatus) string {
+	switch c.Expiry {
+	case datasource.CertExpired:
+		return "✗ expired"
+	case datasource.CertNotYetValid:
+		return "✗ not yet valid"
+	case datasource.CertExpiring:
+		if c.Critical {
+			return "✗ expiring"
+		}
+		return "⚠ expiring"
+	default:
+		return "✓ valid"
+	}
+}
+
+// certChainLabel returns the CHAIN column text for a certificate
+func certChainLabel(c datasource.CertificateStatus) string {
+	switch c.Chain {
+	case datasource.CertChainValid:
+		return "✓ " + c.ChainCA
+	case datasource.CertChainIssuerMatch:
+		return "✓ " + c.ChainCA + " (name)"
+	case datasource.CertChainInvalid:
+		return "✗ " + c.ChainCA
+	case datasource.CertChainRoot:
+		return "root CA"
+	default:
+		return "? CA not in bundle"
+	}
+}
+
+// certRank orders certificates: critical expiry, other problems, then the rest
+func certRank(c datasource.CertificateStatus) int {
+	switch {
+	case c.Critical:
+		return 0
+	case c.Expiry != datasource.CertValid || c.Chain == datasource.CertChainInvalid:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// sortedCertificates returns indexes into the report's certificates, problems first, then
+// soonest to expire
+func (a *App) sortedCertificates() []int {
+	if a.certificates == nil {
+		return nil
+	}
+	certs := a.certificates.Certificates
+	order := make([]int, len(certs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		ci, cj := certs[order[i]], certs[order[j]]
+		if certRank(ci) != certRank(cj) {
+			return certRank(ci) < certRank(cj)
+		}
+		return ci.NotAfter.Before(cj.NotAfter)
+	})
+	return order
+}
+
+// certificateProblemCount returns how many certificates are expired, expiring, not yet
+// valid, or have a broken chain
+func (a *App) certificateProblemCount() int {
+	if a.certificates == nil {
+		return 0
+	}
+	count := 0
+	for _, c := range a.certificates.Certificates {
+		if certRank(c) <= 1 {
+			count++
+		}
+	}
+	return count
+}
+
+// updateCertificatesTable builds the certificates table
+func (a *App) updateCertificatesTable() {
+	if a.certificates == nil || len(a.certificates.Certificates) == 0 {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No certificates in bundle (rke2/certs)"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("name", "NAME", 34),
+		table.NewColumn("subject", "SUBJECT CN", 36),
+		table.NewColumn("issuer", "ISSUER CN", 30),
+		table.NewColumn("notafter", "NOT AFTER", 12),
+		table.NewColumn("expires", "EXPIRES", 14),
+		table.NewColumn("status", "STATUS", 16),
+		table.NewColumn("chain", "CHAIN", 22),
+		table.NewColumn("errors", "x509 ERRS", 9),
+	}
+
+	rows := []table.Row{}
+	for _, i := range a.sortedCertificates() {
+		c := a.certificates.Certificates[i]
+		errors := "-"
+		if len(c.LogErrors) > 0 {
+			errors = fmt.Sprintf("%d", len(c.LogErrors))
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"name":     c.File,
+			"subject":  c.CommonName,
+			"issuer":   c.IssuerCN,
+			"notafter": c.NotAfter.Format("2006-01-02"),
+			"expires":  formatCertExpiresIn(c.ExpiresIn),
+			"status":   certExpiryLabel(c),
+			"chain":    certChainLabel(c),
+			"errors":   errors,
+			"index":    i,
+		}))
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// describeCertificate shows a certificate's names, validity relative to collection time,
+// chain check and correlated x509 errors
+func (a *App) describeCertificate(row table.RowData) tea.Cmd {
+	idx, ok := row["index"].(int)
+	if a.certificates == nil || !ok || idx < 0 || idx >= len(a.certificates.Certificates) {
+		return nil
+	}
+	c := a.certificates.Certificates[idx]
+	collected := a.certificates.CollectedAt
+	unlinked := a.certificates.UnlinkedErrors
+
+	return func() tea.Msg {
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "File:       rke2/certs/%s (%s)\n", c.File, c.Format)
+		fmt.Fprintf(&b, "Subject:    %s\n", c.Subject)
+		fmt.Fprintf(&b, "Issuer:     %s\n", c.Issuer)
+		fmt.Fprintf(&b, "Serial:     %s\n", c.SerialNumber)
+		if c.IsCA {
+			b.WriteString("CA:         yes\n")
+		}
+		if len(c.ExtKeyUsage) > 0 {
+			fmt.Fprintf(&b, "Usage:      %s\n", strings.Join(c.ExtKeyUsage, ", "))
+		}
+		if len(c.DNSNames)+len(c.IPAddresses) > 0 {
+			b.WriteString("SANs:\n")
+			for _, name := range c.DNSNames {
+				fmt.Fprintf(&b, "  DNS: %s\n", name)
+			}
+			for _, ip := range c.IPAddresses {
+				fmt.Fprintf(&b, "  IP:  %s\n", ip)
+			}
+		}
+
+		fmt.Fprintf(&b, "\nValidity (bundle collected %s):\n", collected.Format("2006-01-02 15:04 MST"))
+		fmt.Fprintf(&b, "  Not before: %s", c.NotBefore.Format("2006-01-02 15:04 MST"))
+		if c.Expiry == datasource.CertNotYetValid {
+			fmt.Fprintf(&b, "  ✗ %s after collection: node clock is behind the issuer's (clock skew)", formatDuration(c.NotBefore.Sub(collected)))
+		}
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "  Not after:  %s  (%s)\n", c.NotAfter.Format("2006-01-02 15:04 MST"), formatCertExpiresIn(c.ExpiresIn))
+		fmt.Fprintf(&b, "  Status:     %s\n", certExpiryLabel(c))
+
+		b.WriteString("\nChain:\n")
+		fmt.Fprintf(&b, "  %s: %s\n", certChainLabel(c), c.ChainDetail)
+		if c.AuthorityKeyID != "" {
+			fmt.Fprintf(&b, "  Authority key: %s\n", c.AuthorityKeyID)
+		}
+		if c.SubjectKeyID != "" {
+			fmt.Fprintf(&b, "  Subject key:   %s\n", c.SubjectKeyID)
+		}
+
+		fmt.Fprintf(&b, "\nx509 errors linked to this certificate (%d):\n", len(c.LogErrors))
+		if len(c.LogErrors) == 0 {
+			b.WriteString("  (none)\n")
+		}
+		for _, line := range c.LogErrors {
+			fmt.Fprintf(&b, "  %s\n", line)
+		}
+		if len(unlinked) > 0 {
+			fmt.Fprintf(&b, "\nOther x509 errors in the bundle (%d), not matched to a certificate:\n", len(unlinked))
+			for i, line := range unlinked {
+				if i == 10 {
+					fmt.Fprintf(&b, "  ... and %d more\n", len(unlinked)-10)
+					break
+				}
+				fmt.Fprintf(&b, "  %s\n", line)
+			}
+		}
+
+		return describeMsg{
+			title:   fmt.Sprintf("Certificate: %s", c.Name),
+			content: b.String(),
+		}
+	}
+}
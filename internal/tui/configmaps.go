@@ -0,0 +1,186 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// configMapsMsg carries ConfigMaps for the current namespace
+type configMapsMsg struct {
+	configMaps []rancher.ConfigMap
+}
+
+// fetchConfigMaps fetches ConfigMaps using the unified data source
+func (a *App) fetchConfigMaps(projectID, namespaceName string) tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		configMaps, err := a.dataSource.GetConfigMaps(projectID, namespaceName)
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch configmaps: %w", err)}
+		}
+
+		return configMapsMsg{configMaps: configMaps}
+	}
+}
+
+// updateConfigMapsTable builds the ConfigMaps table
+func (a *App) updateConfigMapsTable() {
+	if len(a.configMaps) == 0 {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No configmaps available"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("name", "NAME", 40),
+		table.NewColumn("namespace", "NAMESPACE", 20),
+		table.NewColumn("data", "DATA", 6),
+		table.NewColumn("keys", "KEYS", 50),
+		table.NewColumn("age", "AGE", 8),
+	}
+
+	rows := []table.Row{}
+	for i, cm := range a.configMaps {
+		keys := strings.Join(cm.Keys, ", ")
+		if cm.Data == nil {
+			keys = "(content not collected)"
+		}
+
+		rows = append(rows, table.NewRow(table.RowData{
+			"name":      cm.Name,
+			"namespace": cm.Namespace,
+			"data":      fmt.Sprintf("%d", cm.DataCount),
+			"keys":      keys,
+			"age":       cm.Age,
+			"index":     i,
+		}))
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// selectedConfigMap returns the ConfigMap for a table row
+func (a *App) selectedConfigMap(row table.RowData) *rancher.ConfigMap {
+	idx, ok := row["index"].(int)
+	if !ok || idx < 0 || idx >= len(a.configMaps) {
+		return nil
+	}
+	return &a.configMaps[idx]
+}
+
+// viewConfigMapData opens the selected ConfigMap's content in a scrollable viewport
+func (a *App) viewConfigMapData(row table.RowData) tea.Cmd {
+	cm := a.selectedConfigMap(row)
+	if cm == nil {
+		return nil
+	}
+
+	a.viewStack = append(a.viewStack, a.currentView)
+	view := a.currentView
+	view.viewType = ViewConfigMapData
+	view.configMapName = cm.Name
+	a.currentView = view
+
+	a.configMapViewing = *cm
+	a.logViewport = viewport.New(a.width-4, a.height-6)
+	a.logViewport.SetContent(renderConfigMapContent(*cm))
+	return nil
+}
+
+// renderConfigMapContent renders every key of a ConfigMap with syntax highlighting
+func renderConfigMapContent(cm rancher.ConfigMap) string {
+	if cm.Data == nil {
+		return fmt.Sprintf("ConfigMap content was not collected: this bundle has `kubectl get configmaps` table output only (%d keys).\n"+
+			"Collect with -o yaml to view data.", cm.DataCount)
+	}
+	if len(cm.Keys) == 0 {
+		return "(no data)"
+	}
+
+	var lines []string
+	for i, key := range cm.Keys {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		value := cm.Data[key]
+		format := detectContentFormat(key, value)
+
+		header := fmt.Sprintf("── %s (%s, %d bytes) ", key, format, len(value))
+		lines = append(lines, headerStyle.Render(header+strings.Repeat("─", max(0, 60-lipgloss.Width(header)))))
+		lines = append(lines, highlightContent(format, value)...)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// renderConfigMapDataView renders the ConfigMap content viewport
+func (a *App) renderConfigMapDataView() string {
+	breadcrumb := breadcrumbStyle.Render(a.getBreadcrumb())
+
+	cm := a.configMapViewing
+	header := lipgloss.NewStyle().
+		Foreground(colorCyan).
+		Bold(true).
+		Render(fmt.Sprintf("ConfigMap: %s/%s (%d keys)", cm.Namespace, cm.Name, cm.DataCount))
+
+	status := statusStyle.Render(fmt.Sprintf(" %d%% | ↑/↓ PgUp/PgDn=scroll 'g'/'G'=top/bottom | 'Esc'=back '?'=help 'q'=quit ",
+		int(a.logViewport.ScrollPercent()*100)))
+
+	return lipgloss.JoinVertical(lipgloss.Left, breadcrumb, header, a.logViewport.View(), status)
+}
+
+// describeConfigMap lists the ConfigMap's keys with their size and detected format
+func (a *App) describeConfigMap(row table.RowData) tea.Cmd {
+	cm := a.selectedConfigMap(row)
+	if cm == nil {
+		return nil
+	}
+	c := *cm
+
+	return func() tea.Msg {
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "ConfigMap:  %s/%s\n", c.Namespace, c.Name)
+		if c.Age != "" {
+			fmt.Fprintf(&b, "Age:        %s\n", c.Age)
+		}
+		fmt.Fprintf(&b, "Keys:       %d\n", c.DataCount)
+
+		if c.Data == nil {
+			b.WriteString("\nContent not collected (table output only)\n")
+		} else {
+			b.WriteString("\nKEYS\n")
+			for _, key := range c.Keys {
+				value := c.Data[key]
+				fmt.Fprintf(&b, "  %-40s %-8s %6d bytes  %d lines\n", key, detectContentFormat(key, value),
+					len(value), strings.Count(value, "\n")+1)
+			}
+			b.WriteString("\nPress Enter on the ConfigMap to view its content\n")
+		}
+
+		return describeMsg{
+			title:   fmt.Sprintf("ConfigMap: %s", c.Name),
+			content: b.String(),
+		}
+	}
+}
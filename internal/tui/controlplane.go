@@ -0,0 +1,224 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// controlPlaneMsg carries the bundle node's static pod manifests and findings
+type controlPlaneMsg struct {
+	controlPlane *datasource.ControlPlane
+}
+
+// fetchControlPlane fetches control plane components using the unified data source
+func (a *App) fetchControlPlane() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		cp, err := a.dataSource.GetControlPlane()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch control plane: %w", err)}
+		}
+
+		return controlPlaneMsg{controlPlane: cp}
+	}
+}
+
+// controlPlaneFindingMark returns the mark for a control plane finding severity
+func controlPlaneFindingMark(severity string) string {
+	switch severity {
+	case datasource.ControlPlaneDown:
+		return "✗"
+	case datasource.ControlPlaneRisky:
+		return "⚠"
+	default:
+		return "ℹ"
+	}
+}
+
+// staticPodState describes a static pod's container state from crictl
+func staticPodState(p datasource.StaticPod) string {
+	if p.ContainerState == "" {
+		return "not listed by crictl"
+	}
+	return fmt.Sprintf("%s (attempt %d)", p.ContainerState, p.Attempt)
+}
+
+// controlPlaneStatusText summarizes the Control plane view for the status bar
+func (a *App) controlPlaneStatusText() string {
+	if a.controlPlane == nil {
+		return "no pod manifests"
+	}
+	counts := make(map[string]int)
+	for _, f := range a.controlPlane.Findings {
+		counts[f.Severity]++
+	}
+	return fmt.Sprintf("%s: %d components, %d down, %d risky, %d notable", a.controlPlane.Node, len(a.controlPlane.Components),
+		counts[datasource.ControlPlaneDown], counts[datasource.ControlPlaneRisky], counts[datasource.ControlPlaneNotable])
+}
+
+// updateControlPlaneTable builds the Control plane view: findings, then each component's
+// static pod followed by its flags in manifest order, filtered with '/'
+func (a *App) updateControlPlaneTable() {
+	if a.controlPlane == nil || len(a.controlPlane.Components) == 0 {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No static pod manifests in bundle (rke2/pod-manifests/; agent nodes have none)"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("component", "COMPONENT", 26),
+		table.NewColumn("name", "NAME", 36),
+		table.NewColumn("value", "VALUE", 44),
+		table.NewColumn("detail", "DETAIL", 80),
+	}
+
+	rows := []table.Row{}
+	add := func(section, component, name, value, detail string, index int, pod datasource.StaticPod) {
+		if a.resourceFilter != "" && !matchesFilter(a.resourceFilter, component, name, value, detail) {
+			return
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"section":   section,
+			"component": component,
+			"name":      name,
+			"value":     value,
+			"detail":    detail,
+			"index":     index,
+			"namespace": pod.Namespace,
+			"pod":       pod.PodName,
+			"logs":      pod.Logs,
+		}))
+	}
+
+	cp := a.controlPlane
+	for i, f := range cp.Findings {
+		name := f.Flag
+		if name != "" {
+			name = "--" + name
+		}
+		add("finding", controlPlaneFindingMark(f.Severity)+" "+f.Component, name, f.Severity, f.Detail, i, a.staticPod(f.Component))
+	}
+	for i, p := range cp.Components {
+		logs := "no logs in bundle"
+		if p.Logs {
+			logs = "Enter=logs"
+		}
+		add("pod", p.Component, p.PodName, staticPodState(p), fmt.Sprintf("%s  %s", p.Image, logs), i, p)
+		for _, f := range p.Flags {
+			add("flag", "  "+p.Component, "--"+f.Name, f.Value, "", i, p)
+		}
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// staticPod returns a component's static pod, or the zero value if it has no manifest
+func (a *App) staticPod(component string) datasource.StaticPod {
+	if a.controlPlane != nil {
+		for _, p := range a.controlPlane.Components {
+			if p.Component == component {
+				return p
+			}
+		}
+	}
+	return datasource.StaticPod{}
+}
+
+// handleControlPlaneEnter opens the selected component's static pod logs, or describes
+// the row when the bundle has no logs for it
+func (a *App) handleControlPlaneEnter(row table.RowData) tea.Cmd {
+	if logs, _ := row["logs"].(bool); !logs {
+		return a.describeControlPlaneRow(row)
+	}
+	return a.handleRuntimeEnter(row)
+}
+
+// describeControlPlaneRow shows a finding, or a component's static pod with all its flags
+func (a *App) describeControlPlaneRow(row table.RowData) tea.Cmd {
+	index, ok := row["index"].(int)
+	section, _ := row["section"].(string)
+	if a.controlPlane == nil || !ok || index < 0 {
+		return nil
+	}
+	cp := a.controlPlane
+
+	var b strings.Builder
+	var p datasource.StaticPod
+	if section == "finding" {
+		if index >= len(cp.Findings) {
+			return nil
+		}
+		f := cp.Findings[index]
+		fmt.Fprintf(&b, "Finding:   %s %s\n", controlPlaneFindingMark(f.Severity), f.Severity)
+		fmt.Fprintf(&b, "Component: %s\n", f.Component)
+		if f.Flag != "" {
+			fmt.Fprintf(&b, "Flag:      --%s\n", f.Flag)
+		}
+		fmt.Fprintf(&b, "\n%s\n\n", f.Detail)
+		p = a.staticPod(f.Component)
+		if p.Component == "" {
+			content := b.String()
+			return func() tea.Msg {
+				return describeMsg{title: "Control plane: " + f.Component, content: content}
+			}
+		}
+	} else {
+		if index >= len(cp.Components) {
+			return nil
+		}
+		p = cp.Components[index]
+	}
+
+	fmt.Fprintf(&b, "Component: %s\n", p.Component)
+	fmt.Fprintf(&b, "Manifest:  rke2/pod-manifests/%s\n", p.File)
+	fmt.Fprintf(&b, "Pod:       %s/%s\n", p.Namespace, p.PodName)
+	fmt.Fprintf(&b, "Container: %s  %s\n", p.Container, staticPodState(p))
+	fmt.Fprintf(&b, "Image:     %s\n", p.Image)
+	switch {
+	case p.Logs && p.PreviousLogs:
+		b.WriteString("Logs:      current and previous container (Enter to view)\n")
+	case p.Logs:
+		b.WriteString("Logs:      current container (Enter to view)\n")
+	default:
+		b.WriteString("Logs:      not in bundle\n")
+	}
+
+	fmt.Fprintf(&b, "\nFlags (%d):\n", len(p.Flags))
+	for _, f := range p.Flags {
+		fmt.Fprintf(&b, "  --%s=%s\n", f.Name, f.Value)
+	}
+
+	var findings []string
+	for _, f := range cp.Findings {
+		if f.Component == p.Component && section != "finding" {
+			findings = append(findings, fmt.Sprintf("  %s %s: %s", controlPlaneFindingMark(f.Severity), f.Severity, f.Detail))
+		}
+	}
+	if len(findings) > 0 {
+		b.WriteString("\nFindings:\n" + strings.Join(findings, "\n") + "\n")
+	}
+
+	content := b.String()
+	return func() tea.Msg {
+		return describeMsg{title: "Control plane: " + p.Component, content: content}
+	}
+}
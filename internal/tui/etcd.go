@@ -0,0 +1,271 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// etcdStatusMsg carries the etcd cluster status
+type etcdStatusMsg struct {
+	status *datasource.EtcdStatus
+}
+
+// fetchEtcdStatus fetches etcd members, quota and snapshots using the unified data source
+func (a *App) fetchEtcdStatus() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		status, err := a.dataSource.GetEtcdStatus()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch etcd status: %w", err)}
+		}
+
+		return etcdStatusMsg{status: status}
+	}
+}
+
+// formatBytes formats a byte count with binary units, e.g. "48.1 MiB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
+
+// formatDuration formats a duration in its two largest units, e.g. "4h15m" or "3d2h"
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%dd%dh", int(d.Hours())/24, int(d.Hours())%24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
+
+// etcdMemberState returns the STATUS column: started, unstarted or learner
+func etcdMemberState(m datasource.EtcdMemberStatus) string {
+	if m.IsLearner {
+		return "learner"
+	}
+	return m.Status
+}
+
+// etcdLeaderName returns the leader's member name, or "none"
+func (a *App) etcdLeaderName() string {
+	if a.etcd == nil {
+		return "none"
+	}
+	for _, m := range a.etcd.Members {
+		if m.IsLeader {
+			return m.Name
+		}
+	}
+	return "none"
+}
+
+// updateEtcdTable builds the etcd member table
+func (a *App) updateEtcdTable() {
+	if a.etcd == nil || len(a.etcd.Members) == 0 {
+		message := "No etcd data in bundle (not an etcd node)"
+		if a.etcd != nil {
+			message = "No etcd members in memberlist or endpointstatus"
+		}
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": message})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("name", "NAME", 40),
+		table.NewColumn("id", "ID", 17),
+		table.NewColumn("status", "STATUS", 10),
+		table.NewColumn("leader", "LEADER", 7),
+		table.NewColumn("version", "VERSION", 8),
+		table.NewColumn("dbsize", "DB SIZE", 10),
+		table.NewColumn("inuse", "IN USE", 10),
+		table.NewColumn("frag", "FRAG", 6),
+		table.NewColumn("quota", "QUOTA", 6),
+		table.NewColumn("term", "TERM", 6),
+		table.NewColumn("index", "RAFT INDEX", 11),
+		table.NewColumn("health", "HEALTH", 24),
+	}
+
+	rows := []table.Row{}
+	for i, m := range a.etcd.Members {
+		leader, dbSize, inUse, frag, quota := "", "-", "-", "-", "-"
+		term, raftIndex := "-", "-"
+		if m.IsLeader {
+			leader = "★"
+		}
+		if m.HasStatus {
+			dbSize = formatBytes(m.DBSize)
+			quota = fmt.Sprintf("%.0f%%", m.QuotaRatio*100)
+			term = fmt.Sprintf("%d", m.RaftTerm)
+			raftIndex = fmt.Sprintf("%d", m.RaftIndex)
+		}
+		if m.DBSizeInUse > 0 {
+			inUse = formatBytes(m.DBSizeInUse)
+			frag = fmt.Sprintf("%.0f%%", m.Fragmentation*100)
+		}
+
+		health := "-"
+		if m.HasHealth {
+			if m.Healthy {
+				health = fmt.Sprintf("✓ %s", m.Latency.Round(100*time.Microsecond))
+			} else {
+				health = "✗ " + m.HealthError
+			}
+		}
+		if m.Errors != "" {
+			health = "✗ " + m.Errors
+		}
+
+		name := m.Name
+		if name == "" {
+			name = "(unstarted)"
+		}
+
+		rows = append(rows, table.NewRow(table.RowData{
+			"name":    name,
+			"id":      m.ID,
+			"status":  etcdMemberState(m),
+			"leader":  leader,
+			"version": m.Version,
+			"dbsize":  dbSize,
+			"inuse":   inUse,
+			"frag":    frag,
+			"quota":   quota,
+			"term":    term,
+			"index":   raftIndex,
+			"health":  health,
+			"member":  i,
+		}))
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// etcdStatusText summarizes leader, quota, snapshot age and issues for the status bar
+func (a *App) etcdStatusText() string {
+	if a.etcd == nil {
+		return "no etcd data"
+	}
+	snapshot := "no snapshots"
+	if len(a.etcd.Snapshots) > 0 {
+		snapshot = "snapshot " + formatDuration(a.etcd.LatestSnapshotAge) + " ago"
+	} else if !a.etcd.SnapshotsCollected {
+		snapshot = "snapshots not collected"
+	}
+	return fmt.Sprintf("%d members, leader %s, quota %s, %s, %d issues",
+		len(a.etcd.Members), a.etcdLeaderName(), formatBytes(a.etcd.QuotaBytes), snapshot, len(a.etcd.Issues))
+}
+
+// describeEtcd shows the selected member, then cluster-wide issues and snapshots
+func (a *App) describeEtcd(row table.RowData) tea.Cmd {
+	if a.etcd == nil {
+		return nil
+	}
+	status := *a.etcd
+	idx, ok := row["member"].(int)
+	if !ok || idx < 0 || idx >= len(status.Members) {
+		return nil
+	}
+	m := status.Members[idx]
+
+	return func() tea.Msg {
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "Member:     %s (ID %s)\n", m.Name, m.ID)
+		fmt.Fprintf(&b, "Status:     %s", m.Status)
+		if m.IsLearner {
+			b.WriteString(", learner (non-voting)")
+		}
+		if m.IsLeader {
+			b.WriteString(", LEADER")
+		}
+		b.WriteString("\n")
+		fmt.Fprintf(&b, "Peer URLs:  %s\n", strings.Join(m.PeerURLs, ", "))
+		fmt.Fprintf(&b, "Client URLs: %s\n", strings.Join(m.ClientURLs, ", "))
+
+		if m.HasStatus {
+			fmt.Fprintf(&b, "\nVersion:    %s\n", m.Version)
+			fmt.Fprintf(&b, "Raft:       term %d, index %d, applied %d\n", m.RaftTerm, m.RaftIndex, m.RaftAppliedIndex)
+			fmt.Fprintf(&b, "DB size:    %s (%.1f%% of %s quota", formatBytes(m.DBSize), m.QuotaRatio*100, formatBytes(status.QuotaBytes))
+			if !status.QuotaFromMetrics {
+				b.WriteString(", etcd default")
+			}
+			b.WriteString(")\n")
+			if m.DBSizeInUse > 0 {
+				fmt.Fprintf(&b, "In use:     %s (%.0f%% fragmented, %s reclaimable by defrag)\n",
+					formatBytes(m.DBSizeInUse), m.Fragmentation*100, formatBytes(m.DBSize-m.DBSizeInUse))
+			}
+		} else {
+			b.WriteString("\nEndpoint status: not collected for this member\n")
+		}
+		if m.HasHealth {
+			if m.Healthy {
+				fmt.Fprintf(&b, "Health:     ✓ healthy, proposal committed in %s\n", m.Latency)
+			} else {
+				fmt.Fprintf(&b, "Health:     ✗ %s\n", m.HealthError)
+			}
+		}
+		if m.Errors != "" {
+			fmt.Fprintf(&b, "Errors:     %s\n", m.Errors)
+		}
+
+		b.WriteString("\nIssues:\n")
+		if len(status.Issues) == 0 {
+			b.WriteString("  ✓ None\n")
+		}
+		for _, issue := range status.Issues {
+			mark := "⚠"
+			if issue.Critical {
+				mark = "✗"
+			}
+			fmt.Fprintf(&b, "  %s [%s] %s\n", mark, issue.Type, issue.Detail)
+		}
+
+		fmt.Fprintf(&b, "\nSnapshots (%d, schedule %q, retention %d):\n", len(status.Snapshots), status.SnapshotSchedule, status.SnapshotRetention)
+		if status.SnapshotsDisabled {
+			b.WriteString("  (etcd-disable-snapshots is set in 50-rancher.yaml)\n")
+		}
+		if !status.SnapshotsCollected {
+			b.WriteString("  (findserverdbsnapshots not collected)\n")
+		}
+		for _, snap := range status.Snapshots {
+			fmt.Fprintf(&b, "  %s  %s  %s\n", snap.Time.Format("2006-01-02 15:04"), formatBytes(snap.Size), snap.Name)
+		}
+
+		return describeMsg{
+			title:   fmt.Sprintf("etcd member: %s", m.Name),
+			content: b.String(),
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// helmChartsMsg carries HelmCharts with their install job and workload state
+type helmChartsMsg struct {
+	charts []datasource.HelmChartStatus
+}
+
+// fetchHelmCharts fetches HelmCharts using the unified data source
+func (a *App) fetchHelmCharts() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		charts, err := a.dataSource.GetHelmCharts()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch HelmCharts: %w", err)}
+		}
+
+		return helmChartsMsg{charts: charts}
+	}
+}
+
+// updateHelmChartsTable builds the HelmCharts table
+func (a *App) updateHelmChartsTable() {
+	if len(a.helmCharts) == 0 {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No HelmCharts available"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("name", "NAME", 32),
+		table.NewColumn("namespace", "NAMESPACE", 15),
+		table.NewColumn("chart", "CHART/VERSION", 25),
+		table.NewColumn("target", "TARGET NS", 15),
+		table.NewColumn("job", "INSTALL JOB", 14),
+		table.NewColumn("workloads", "WORKLOADS", 10),
+		table.NewColumn("health", "HEALTH", 30),
+	}
+
+	rows := []table.Row{}
+	for i, chart := range a.helmCharts {
+		chartRef := chart.Chart
+		if chartRef == "" {
+			chartRef = "(bundled)"
+		}
+		if chart.Version != "" {
+			chartRef += " " + chart.Version
+		}
+
+		target := chart.TargetNamespace
+		if target == "" {
+			target = chart.Namespace
+		}
+
+		job := chart.JobStatus
+		if job == "" {
+			job = chart.JobCompletions
+		}
+		if job == "" {
+			job = "-"
+		}
+
+		ready := 0
+		for _, w := range chart.Workloads {
+			if w.Ready >= w.Desired {
+				ready++
+			}
+		}
+		workloads := "-"
+		if len(chart.Workloads) > 0 {
+			workloads = fmt.Sprintf("%d/%d", ready, len(chart.Workloads))
+		}
+
+		health := "✓ Healthy"
+		if chart.Problem != "" {
+			health = "✗ " + chart.Problem
+		}
+
+		rows = append(rows, table.NewRow(table.RowData{
+			"name":      chart.Name,
+			"namespace": chart.Namespace,
+			"chart":     chartRef,
+			"target":    target,
+			"job":       job,
+			"workloads": workloads,
+			"health":    health,
+			"index":     i,
+		}))
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// selectedHelmChart returns the HelmChart for a table row
+func (a *App) selectedHelmChart(row table.RowData) *datasource.HelmChartStatus {
+	idx, ok := row["index"].(int)
+	if !ok || idx < 0 || idx >= len(a.helmCharts) {
+		return nil
+	}
+	return &a.helmCharts[idx]
+}
+
+// viewHelmInstallLogs opens the logs of the newest helm-install pod for the selected chart
+func (a *App) viewHelmInstallLogs(row table.RowData) tea.Cmd {
+	chart := a.selectedHelmChart(row)
+	if chart == nil || len(chart.InstallPods) == 0 {
+		a.error = "No helm-install pod found for this HelmChart"
+		return nil
+	}
+	podName := chart.InstallPods[len(chart.InstallPods)-1].Name
+
+	a.viewStack = append(a.viewStack, a.currentView)
+	a.currentView = ViewContext{
+		viewType:      ViewLogs,
+		clusterID:     a.currentView.clusterID,
+		clusterName:   a.currentView.clusterName,
+		namespaceName: chart.Namespace,
+		podName:       podName,
+	}
+	a.filterLevel = ""
+	a.loading = true
+	return a.fetchLogs(a.currentView.clusterID, chart.Namespace, podName)
+}
+
+// describeHelmChart shows the chart, its install job and pods, and workload health
+func (a *App) describeHelmChart(row table.RowData) tea.Cmd {
+	chart := a.selectedHelmChart(row)
+	if chart == nil {
+		return nil
+	}
+	c := *chart
+
+	return func() tea.Msg {
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "HelmChart:  %s/%s\n", c.Namespace, c.Name)
+		if c.Chart != "" {
+			fmt.Fprintf(&b, "Chart:      %s\n", c.Chart)
+		}
+		if c.Version != "" {
+			fmt.Fprintf(&b, "Version:    %s\n", c.Version)
+		}
+		if c.TargetNamespace != "" {
+			fmt.Fprintf(&b, "Target NS:  %s\n", c.TargetNamespace)
+		}
+		fmt.Fprintf(&b, "Bootstrap:  %v\n", c.Bootstrap)
+		fmt.Fprintf(&b, "Failed:     %v\n", c.Failed)
+		if c.Problem != "" {
+			fmt.Fprintf(&b, "\n✗ PROBLEM: %s\n", c.Problem)
+		}
+
+		b.WriteString("\nINSTALL JOB\n")
+		if c.JobStatus == "" && c.JobCompletions == "" {
+			fmt.Fprintf(&b, "  %s (not found in bundle)\n", c.JobName)
+		} else {
+			fmt.Fprintf(&b, "  %s  status=%s completions=%s\n", c.JobName, c.JobStatus, c.JobCompletions)
+		}
+		for _, pod := range c.InstallPods {
+			fmt.Fprintf(&b, "  pod %s  %s  restarts=%d  age=%s\n", pod.Name, pod.Status, pod.Restarts, pod.Age)
+		}
+
+		if len(c.InstallErrors) > 0 {
+			b.WriteString("\nINSTALL LOG ERRORS\n")
+			for _, line := range c.InstallErrors {
+				fmt.Fprintf(&b, "  %s\n", line)
+			}
+		}
+
+		b.WriteString("\nWORKLOADS\n")
+		if len(c.Workloads) == 0 {
+			b.WriteString("  (none matched - CRD-only charts deploy no workloads)\n")
+		}
+		for _, w := range c.Workloads {
+			mark := "✓"
+			if w.Ready < w.Desired {
+				mark = "✗"
+			}
+			fmt.Fprintf(&b, "  %s %-12s %s/%s  %d/%d ready\n", mark, w.Kind, w.Namespace, w.Name, w.Ready, w.Desired)
+		}
+
+		return describeMsg{
+			title:   fmt.Sprintf("HelmChart: %s", c.Name),
+			content: b.String(),
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package tui
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// contentFormat identifies the syntax of a ConfigMap value
+type contentFormat int
+
+const (
+	formatPlain contentFormat = iota
+	formatYAML
+	formatJSON
+	formatCorefile
+)
+
+// String returns the display name of the format
+func (f contentFormat) String() string {
+	switch f {
+	case formatYAML:
+		return "yaml"
+	case formatJSON:
+		return "json"
+	case formatCorefile:
+		return "Corefile"
+	default:
+		return "text"
+	}
+}
+
+var (
+	// yamlKeyRe matches "  - key: value" lines
+	yamlKeyRe = regexp.MustCompile(`^(\s*)(-\s+)?([^\s#:][^:#]*?):(\s+|$)(.*)$`)
+
+	// jsonTokenRe matches JSON strings (with an optional trailing colon for keys) and literals
+	jsonTokenRe = regexp.MustCompile(`"(?:[^"\\]|\\.)*"(\s*:)?|\btrue\b|\bfalse\b|\bnull\b|-?\b\d+(?:\.\d+)?\b`)
+
+	// literalRe matches unquoted YAML scalars worth highlighting
+	literalRe = regexp.MustCompile(`^(true|false|null|~|-?\d+(\.\d+)?)$`)
+)
+
+// detectContentFormat guesses the syntax of a ConfigMap value from its key and content.
+// CoreDNS reads "Corefile" and "*.server"/"*.override" keys; kube-proxy's "config.conf"
+// and most Rancher settings are YAML.
+func detectContentFormat(key, content string) contentFormat {
+	if key == "Corefile" || strings.HasSuffix(key, ".server") || strings.HasSuffix(key, ".override") {
+		return formatCorefile
+	}
+
+	trimmed := strings.TrimSpace(content)
+	if strings.HasSuffix(key, ".json") ||
+		(strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[")) && json.Valid([]byte(trimmed)) {
+		return formatJSON
+	}
+
+	// Plain values like "10.0.0.1 node-1" unmarshal as a string, not a mapping
+	var doc map[string]interface{}
+	if strings.Contains(trimmed, ":") && yaml.Unmarshal([]byte(trimmed), &doc) == nil && len(doc) > 0 {
+		return formatYAML
+	}
+	return formatPlain
+}
+
+// highlightContent returns content split into lines with syntax highlighting applied
+func highlightContent(format contentFormat, content string) []string {
+	lines := strings.Split(content, "\n")
+
+	switch format {
+	case formatJSON:
+		// Pretty-print single-line JSON so it can be read in the viewport
+		if len(lines) == 1 {
+			var v interface{}
+			if json.Unmarshal([]byte(content), &v) == nil {
+				if pretty, err := json.MarshalIndent(v, "", "  "); err == nil {
+					lines = strings.Split(string(pretty), "\n")
+				}
+			}
+		}
+		for i, line := range lines {
+			lines[i] = highlightJSONLine(line)
+		}
+	case formatYAML:
+		for i, line := range lines {
+			lines[i] = highlightYAMLLine(line)
+		}
+	case formatCorefile:
+		for i, line := range lines {
+			lines[i] = highlightCorefileLine(line)
+		}
+	}
+
+	return lines
+}
+
+// highlightYAMLLine colors comments, keys, list markers and scalar values
+func highlightYAMLLine(line string) string {
+	if strings.HasPrefix(strings.TrimSpace(line), "#") {
+		return syntaxCommentStyle.Render(line)
+	}
+
+	m := yamlKeyRe.FindStringSubmatch(line)
+	if m == nil {
+		// List item or continuation of a block scalar
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "- ") {
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			return indent + syntaxLiteralStyle.Render("-") + " " + highlightScalar(strings.TrimPrefix(trimmed, "- "))
+		}
+		return line
+	}
+
+	indent, dash, key, sep, value := m[1], m[2], m[3], m[4], m[5]
+	if dash != "" {
+		dash = syntaxLiteralStyle.Render(strings.TrimSpace(dash)) + dash[1:]
+	}
+	return indent + dash + syntaxKeyStyle.Render(key) + ":" + sep + highlightScalar(value)
+}
+
+// highlightScalar colors a YAML value: quoted strings, literals and trailing comments
+func highlightScalar(value string) string {
+	comment := ""
+	if i := strings.Index(value, " #"); i >= 0 && !strings.HasPrefix(value, "\"") && !strings.HasPrefix(value, "'") {
+		value, comment = value[:i], syntaxCommentStyle.Render(value[i:])
+	}
+
+	switch {
+	case strings.HasPrefix(value, "\"") || strings.HasPrefix(value, "'"):
+		return syntaxStringStyle.Render(value) + comment
+	case literalRe.MatchString(strings.TrimSpace(value)):
+		return syntaxLiteralStyle.Render(value) + comment
+	default:
+		return value + comment
+	}
+}
+
+// highlightJSONLine colors keys, strings and literals
+func highlightJSONLine(line string) string {
+	return jsonTokenRe.ReplaceAllStringFunc(line, func(token string) string {
+		switch {
+		case strings.HasSuffix(token, ":"):
+			key := strings.TrimRight(token, ": \t")
+			return syntaxKeyStyle.Render(key) + token[len(key):]
+		case strings.HasPrefix(token, "\""):
+			return syntaxStringStyle.Render(token)
+		default:
+			return syntaxLiteralStyle.Render(token)
+		}
+	})
+}
+
+// highlightCorefileLine colors server blocks, plugin names and comments
+func highlightCorefileLine(line string) string {
+	trimmed := strings.TrimSpace(line)
+	switch {
+	case trimmed == "":
+		return line
+	case strings.HasPrefix(trimmed, "#"):
+		return syntaxCommentStyle.Render(line)
+	case line == trimmed && strings.HasSuffix(trimmed, "{"):
+		// Server block, e.g. ".:53 {" or "cluster.local:53 {"
+		return syntaxBlockStyle.Render(line)
+	case trimmed == "}":
+		return line
+	}
+
+	// Plugin directive: first word is the plugin name
+	indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+	plugin, args, _ := strings.Cut(trimmed, " ")
+	if args != "" {
+		args = " " + args
+	}
+	return indent + syntaxKeyStyle.Render(plugin) + args
+}
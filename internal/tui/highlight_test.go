@@ -0,0 +1,72 @@
+package tui
+
+import "testing"
+
+// TestDetectContentFormat tests format detection for common RKE2/Rancher ConfigMap values
+func TestDetectContentFormat(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		content string
+		want    contentFormat
+	}{
+		{
+			name:    "CoreDNS Corefile",
+			key:     "Corefile",
+			content: ".:53 {\n    errors\n    health\n}",
+			want:    formatCorefile,
+		},
+		{
+			name:    "CoreDNS custom server block",
+			key:     "example.server",
+			content: "example.com:53 {\n    forward . 10.0.0.10\n}",
+			want:    formatCorefile,
+		},
+		{
+			name:    "kube-proxy config.conf",
+			key:     "config.conf",
+			content: "apiVersion: kubeproxy.config.k8s.io/v1alpha1\nkind: KubeProxyConfiguration\nmode: ipvs\n",
+			want:    formatYAML,
+		},
+		{
+			name:    "Calico CNI config",
+			key:     "cni_network_config",
+			content: `{"name": "k8s-pod-network", "cniVersion": "0.3.1", "plugins": []}`,
+			want:    formatJSON,
+		},
+		{
+			name:    "CoreDNS NodeHosts",
+			key:     "NodeHosts",
+			content: "10.0.0.1 node-1",
+			want:    formatPlain,
+		},
+		{
+			name:    "Rancher setting URL",
+			key:     "server-url",
+			content: "https://rancher.example.com",
+			want:    formatPlain,
+		},
+		{
+			name:    "CA bundle",
+			key:     "ca.crt",
+			content: "-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----",
+			want:    formatPlain,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectContentFormat(tt.key, tt.content); got != tt.want {
+				t.Errorf("detectContentFormat(%q) = %v, want %v", tt.key, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestHighlightContent_PrettyPrintsJSON tests that single-line JSON is expanded for the viewport
+func TestHighlightContent_PrettyPrintsJSON(t *testing.T) {
+	lines := highlightContent(formatJSON, `{"a":1,"b":{"c":true}}`)
+	if len(lines) != 6 {
+		t.Errorf("expected 6 pretty-printed lines, got %d: %q", len(lines), lines)
+	}
+}
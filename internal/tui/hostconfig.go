@@ -0,0 +1,135 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// hostConfigMsg carries the bundle node's host config lint results
+type hostConfigMsg struct {
+	host *datasource.HostConfig
+}
+
+// fetchHostConfig fetches host config lint results using the unified data source
+func (a *App) fetchHostConfig() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		host, err := a.dataSource.GetHostConfig()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch host config: %w", err)}
+		}
+
+		return hostConfigMsg{host: host}
+	}
+}
+
+// hostCheckMark returns the mark for a host config check result
+func hostCheckMark(status string) string {
+	switch status {
+	case datasource.HostCheckFail:
+		return "✗"
+	case datasource.HostCheckWarn:
+		return "⚠"
+	case datasource.HostCheckSkip:
+		return "–"
+	default:
+		return "✓"
+	}
+}
+
+// hostConfigStatusText summarizes the Host config view for the status bar
+func (a *App) hostConfigStatusText() string {
+	if a.hostConfig == nil {
+		return "no systeminfo data"
+	}
+	counts := make(map[string]int)
+	for _, c := range a.hostConfig.Checks {
+		counts[c.Status]++
+	}
+	return fmt.Sprintf("%s (%s, %s): %d pass, %d warn, %d fail", a.hostConfig.Node, a.hostConfig.OS, a.hostConfig.Kernel,
+		counts[datasource.HostCheckPass], counts[datasource.HostCheckWarn], counts[datasource.HostCheckFail])
+}
+
+// updateHostConfigTable builds the Host config view: one row per lint rule, filtered with '/'
+func (a *App) updateHostConfigTable() {
+	if a.hostConfig == nil {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No system information in bundle (systeminfo/)"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("status", "STATUS", 8),
+		table.NewColumn("category", "CATEGORY", 10),
+		table.NewColumn("name", "RULE", 38),
+		table.NewColumn("expected", "EXPECTED", 28),
+		table.NewColumn("actual", "ACTUAL", 44),
+	}
+
+	rows := []table.Row{}
+	for i, c := range a.hostConfig.Checks {
+		if a.resourceFilter != "" && !matchesFilter(a.resourceFilter, c.Status, c.Category, c.Name, c.Actual) {
+			continue
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"status":   hostCheckMark(c.Status) + " " + c.Status,
+			"category": c.Category,
+			"name":     c.Name,
+			"expected": c.Expected,
+			"actual":   c.Actual,
+			"index":    i,
+		}))
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// describeHostCheck shows a host config rule with why RKE2 needs it
+func (a *App) describeHostCheck(row table.RowData) tea.Cmd {
+	index, ok := row["index"].(int)
+	if a.hostConfig == nil || !ok || index < 0 || index >= len(a.hostConfig.Checks) {
+		return nil
+	}
+	h := a.hostConfig
+	c := h.Checks[index]
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Rule:      %s (%s)\n", c.Name, c.Category)
+	fmt.Fprintf(&b, "Result:    %s %s\n", hostCheckMark(c.Status), c.Status)
+	fmt.Fprintf(&b, "Expected:  %s\n", c.Expected)
+	fmt.Fprintf(&b, "Actual:    %s\n", c.Actual)
+	if c.Detail != "" {
+		fmt.Fprintf(&b, "\nWhy:\n  %s\n", c.Detail)
+	}
+	fmt.Fprintf(&b, "\nNode:      %s\n", h.Node)
+	fmt.Fprintf(&b, "OS:        %s\n", h.OS)
+	fmt.Fprintf(&b, "Kernel:    %s\n", h.Kernel)
+	fmt.Fprintf(&b, "Collected: %d sysctls, %d modules, %d packages\n", h.Sysctls, h.Modules, h.Packages)
+	if h.ProtectKernel {
+		b.WriteString("\nprotect-kernel-defaults is enabled: kubelet exits if its required sysctls differ.\n")
+	}
+
+	content := b.String()
+	return func() tea.Msg {
+		return describeMsg{title: "Host config: " + c.Name, content: content}
+	}
+}
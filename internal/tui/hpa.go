@@ -0,0 +1,208 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+	"github.com/Rancheroo/r8s/internal/rancher"
+)
+
+// hpasMsg carries HPAs for the current namespace
+type hpasMsg struct {
+	hpas []datasource.HPAStatus
+}
+
+// fetchHPAs fetches HPAs using the unified data source
+func (a *App) fetchHPAs(projectID, namespaceName string) tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		hpas, err := a.dataSource.GetHPAs(projectID, namespaceName)
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch HPAs: %w", err)}
+		}
+
+		return hpasMsg{hpas: hpas}
+	}
+}
+
+// hpaProblemCount returns how many HPAs have a scaling problem
+func hpaProblemCount(hpas []datasource.HPAStatus) int {
+	count := 0
+	for _, hpa := range hpas {
+		if len(hpa.Problems) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// formatHPATargets formats metrics the way kubectl does: "cpu: 45%/80%, memory: <unknown>/70%"
+func formatHPATargets(metrics []rancher.HPAMetric) string {
+	if len(metrics) == 0 {
+		return "<none>"
+	}
+
+	var parts []string
+	for _, m := range metrics {
+		current := m.Current
+		if current == "" {
+			current = "<unknown>"
+		}
+		part := current + "/" + m.Target
+		if m.Name != "" {
+			part = m.Name + ": " + part
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// updateHPAsTable builds the HPAs table
+func (a *App) updateHPAsTable() {
+	if len(a.hpas) == 0 {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No HPAs available"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("name", "NAME", 28),
+		table.NewColumn("reference", "REFERENCE", 30),
+		table.NewColumn("targets", "TARGETS", 32),
+		table.NewColumn("min", "MIN", 5),
+		table.NewColumn("max", "MAX", 5),
+		table.NewColumn("replicas", "REPLICAS", 9),
+		table.NewColumn("status", "STATUS", 40),
+	}
+
+	rows := []table.Row{}
+	for i, hpa := range a.hpas {
+		status := "✓ OK"
+		if len(hpa.Problems) > 0 {
+			status = "✗ " + strings.Join(hpa.Problems, "; ")
+		}
+
+		rows = append(rows, table.NewRow(table.RowData{
+			"name":      hpa.Name,
+			"namespace": hpa.Namespace,
+			"reference": hpa.TargetKind + "/" + hpa.TargetName,
+			"targets":   formatHPATargets(hpa.Metrics),
+			"min":       fmt.Sprintf("%d", hpa.MinReplicas),
+			"max":       fmt.Sprintf("%d", hpa.MaxReplicas),
+			"replicas":  fmt.Sprintf("%d", hpa.Replicas),
+			"status":    status,
+			"index":     i,
+		}))
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// selectedHPA returns the HPA for a table row
+func (a *App) selectedHPA(row table.RowData) *datasource.HPAStatus {
+	idx, ok := row["index"].(int)
+	if !ok || idx < 0 || idx >= len(a.hpas) {
+		return nil
+	}
+	return &a.hpas[idx]
+}
+
+// describeHPA shows the HPA's metrics, scale target, conditions and what limits scaling
+func (a *App) describeHPA(row table.RowData) tea.Cmd {
+	hpa := a.selectedHPA(row)
+	if hpa == nil {
+		return nil
+	}
+	h := *hpa
+
+	return func() tea.Msg {
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "HPA:        %s/%s\n", h.Namespace, h.Name)
+		if h.Age != "" {
+			fmt.Fprintf(&b, "Age:        %s\n", h.Age)
+		}
+		fmt.Fprintf(&b, "Replicas:   %d (min %d, max %d)\n", h.Replicas, h.MinReplicas, h.MaxReplicas)
+
+		target := fmt.Sprintf("%s/%s", h.TargetKind, h.TargetName)
+		switch {
+		case h.TargetFound:
+			fmt.Fprintf(&b, "Target:     %s (%d/%d ready)\n", target, h.TargetReady, h.TargetDesired)
+		case h.TargetMissing:
+			fmt.Fprintf(&b, "Target:     %s ✗ NOT FOUND in bundle\n", target)
+		default:
+			fmt.Fprintf(&b, "Target:     %s (not collected)\n", target)
+		}
+
+		b.WriteString("\nMETRICS\n")
+		if len(h.Metrics) == 0 {
+			b.WriteString("  (none)\n")
+		}
+		for _, m := range h.Metrics {
+			name := m.Name
+			if name == "" {
+				name = "-"
+			}
+			current := m.Current
+			if current == "" {
+				current = "<unknown>"
+			}
+			fmt.Fprintf(&b, "  %-10s %-20s current %-12s target %s\n", m.Type, name, current, m.Target)
+		}
+
+		if len(h.Conditions) > 0 {
+			b.WriteString("\nCONDITIONS\n")
+			for _, c := range h.Conditions {
+				fmt.Fprintf(&b, "  %-15s %-6s %s", c.Type, c.Status, c.Reason)
+				if c.Message != "" {
+					fmt.Fprintf(&b, ": %s", c.Message)
+				}
+				b.WriteString("\n")
+			}
+		}
+
+		if len(h.Problems) > 0 {
+			b.WriteString("\nPROBLEMS\n")
+			for _, p := range h.Problems {
+				fmt.Fprintf(&b, "  ✗ %s\n", p)
+			}
+		}
+		if len(h.UnknownMetrics) > 0 {
+			b.WriteString("\nMETRICS API\n")
+			if len(h.UnavailableAPIs) > 0 {
+				for _, api := range h.UnavailableAPIs {
+					fmt.Fprintf(&b, "  ✗ APIService %s is unavailable - the HPA cannot read metrics\n", api)
+				}
+			} else {
+				fmt.Fprintf(&b, "  %s reported available; check the metrics adapter's logs and the HPA events\n",
+					strings.Join(h.MetricsAPIs, ", "))
+			}
+		}
+		if h.PinnedAtMax {
+			b.WriteString("\nThe HPA wants to scale beyond maxReplicas; raise maxReplicas or reduce load per pod\n")
+		}
+
+		return describeMsg{
+			title:   fmt.Sprintf("HPA: %s", h.Name),
+			content: b.String(),
+		}
+	}
+}
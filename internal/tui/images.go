@@ -0,0 +1,255 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// imagesMsg carries the bundle's image inventory
+type imagesMsg struct {
+	report *datasource.ImageReport
+}
+
+// fetchImages fetches the image inventory using the unified data source
+func (a *App) fetchImages() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		report, err := a.dataSource.GetImages()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch images: %w", err)}
+		}
+
+		return imagesMsg{report: report}
+	}
+}
+
+// pullFailureExplanation describes what a pull failure class usually means
+func pullFailureExplanation(class string) string {
+	switch class {
+	case datasource.PullFailureNotFound:
+		return "image, tag or repository does not exist (check for typos, or a private Docker Hub repo)"
+	case datasource.PullFailureAuth:
+		return "registry rejected the pull: missing or wrong imagePullSecrets / registries.yaml credentials"
+	case datasource.PullFailureTLS:
+		return "registry certificate not trusted: add its CA to registries.yaml or fix the certificate"
+	case datasource.PullFailureTimeout:
+		return "registry unreachable from the node: proxy, firewall, DNS or mirror configuration"
+	default:
+		return "cause not recognized from the event message"
+	}
+}
+
+// imageChecks returns the CHECK column flags for an image, most severe first
+func imageChecks(img datasource.ImageStatus) []string {
+	var checks []string
+	if len(img.PullFailures) > 0 {
+		classes := []string{}
+		for _, f := range img.PullFailures {
+			if !containsName(classes, f.Class) {
+				classes = append(classes, f.Class)
+			}
+		}
+		checks = append(checks, "✗ pull failed: "+strings.Join(classes, ","))
+	}
+	if img.UnexpectedRegistry {
+		checks = append(checks, "⚠ registry "+img.Registry)
+	}
+	if img.Latest {
+		checks = append(checks, "⚠ :latest")
+	}
+	if img.Unused {
+		checks = append(checks, "unused")
+	}
+	return checks
+}
+
+// imageRank orders images by the severity of their checks: pull failures, warnings, the rest
+func imageRank(img datasource.ImageStatus) int {
+	switch {
+	case len(img.PullFailures) > 0:
+		return 0
+	case img.UnexpectedRegistry || img.Latest:
+		return 1
+	case img.Unused:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// sortedImages returns indexes into the report's images, problems first
+func (a *App) sortedImages() []int {
+	if a.images == nil {
+		return nil
+	}
+	order := make([]int, len(a.images.Images))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return imageRank(a.images.Images[order[i]]) < imageRank(a.images.Images[order[j]])
+	})
+	return order
+}
+
+// imageProblemCount returns how many images have a pull failure or warning
+func (a *App) imageProblemCount() int {
+	if a.images == nil {
+		return 0
+	}
+	count := 0
+	for _, img := range a.images.Images {
+		if imageRank(img) <= 1 {
+			count++
+		}
+	}
+	return count
+}
+
+// updateImagesTable builds the image inventory table
+func (a *App) updateImagesTable() {
+	if a.images == nil || len(a.images.Images) == 0 {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No image data in bundle (workloads -o wide, crictl images or events)"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("name", "IMAGE", 50),
+		table.NewColumn("tag", "TAG", 24),
+		table.NewColumn("registry", "REGISTRY", 22),
+		table.NewColumn("size", "SIZE", 8),
+		table.NewColumn("node", "ON NODE", 8),
+		table.NewColumn("usedby", "USED BY", 30),
+		table.NewColumn("check", "CHECK", 40),
+	}
+
+	rows := []table.Row{}
+	for _, i := range a.sortedImages() {
+		img := a.images.Images[i]
+
+		tag := img.Tag
+		if tag == "" {
+			tag = "@" + img.Digest
+		}
+		size, node := "-", "-"
+		if img.OnNode {
+			size = img.Size
+			node = "✓"
+			if img.RunningOnNode {
+				node = "✓ running"
+			}
+		}
+		usedBy := "-"
+		if len(img.UsedBy) == 1 {
+			usedBy = img.UsedBy[0]
+		} else if len(img.UsedBy) > 1 {
+			usedBy = fmt.Sprintf("%s (+%d)", img.UsedBy[0], len(img.UsedBy)-1)
+		}
+		check := "✓"
+		if checks := imageChecks(img); len(checks) > 0 {
+			check = strings.Join(checks, "; ")
+		}
+
+		rows = append(rows, table.NewRow(table.RowData{
+			"name":     img.Repository,
+			"tag":      tag,
+			"registry": img.Registry,
+			"size":     size,
+			"node":     node,
+			"usedby":   usedBy,
+			"check":    check,
+			"index":    i,
+		}))
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// selectedImage returns the image for a table row
+func (a *App) selectedImage(row table.RowData) *datasource.ImageStatus {
+	idx, ok := row["index"].(int)
+	if a.images == nil || !ok || idx < 0 || idx >= len(a.images.Images) {
+		return nil
+	}
+	return &a.images.Images[idx]
+}
+
+// describeImage shows where an image is used, whether it is on the node, and why pulls fail
+func (a *App) describeImage(row table.RowData) tea.Cmd {
+	image := a.selectedImage(row)
+	if image == nil {
+		return nil
+	}
+	img := *image
+	expected := a.images.ExpectedRegistries
+
+	return func() tea.Msg {
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "Image:      %s\n", img.Image)
+		fmt.Fprintf(&b, "Registry:   %s", img.Registry)
+		if img.UnexpectedRegistry {
+			fmt.Fprintf(&b, "  ⚠ expected %s", strings.Join(expected, ", "))
+		}
+		b.WriteString("\n")
+		if img.Latest {
+			b.WriteString("Tag:        latest ⚠ mutable tag, nodes may run different builds\n")
+		}
+		if img.OnNode {
+			fmt.Fprintf(&b, "On node:    yes (ID %s, %s)", img.ID, img.Size)
+			switch {
+			case img.RunningOnNode:
+				b.WriteString(", used by a running container\n")
+			case img.Unused:
+				b.WriteString(", unused - candidate for garbage collection\n")
+			default:
+				b.WriteString("\n")
+			}
+		} else {
+			b.WriteString("On node:    no\n")
+		}
+
+		fmt.Fprintf(&b, "\nUsed by (%d):\n", len(img.UsedBy))
+		if len(img.UsedBy) == 0 {
+			b.WriteString("  (no Deployment, DaemonSet, StatefulSet or Job in the bundle)\n")
+		}
+		for _, u := range img.UsedBy {
+			fmt.Fprintf(&b, "  %s\n", u)
+		}
+
+		if len(img.PullFailures) > 0 {
+			fmt.Fprintf(&b, "\nPull failures (%d):\n", len(img.PullFailures))
+			for _, f := range img.PullFailures {
+				fmt.Fprintf(&b, "  ✗ %s/%s [%s] x%d\n", f.Namespace, f.Pod, f.Class, f.Count)
+				fmt.Fprintf(&b, "    → %s\n", pullFailureExplanation(f.Class))
+				fmt.Fprintf(&b, "    %s\n", f.Message)
+			}
+		}
+
+		return describeMsg{
+			title:   fmt.Sprintf("Image: %s", img.Image),
+			content: b.String(),
+		}
+	}
+}
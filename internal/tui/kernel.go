@@ -0,0 +1,185 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// kernelEventsMsg carries the bundle node's dmesg events
+type kernelEventsMsg struct {
+	log *datasource.KernelLog
+}
+
+// fetchKernelEvents fetches dmesg events using the unified data source
+func (a *App) fetchKernelEvents() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		log, err := a.dataSource.GetKernelEvents()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch kernel events: %w", err)}
+		}
+
+		return kernelEventsMsg{log: log}
+	}
+}
+
+// formatKernelTime formats an event's wall-clock time, falling back to seconds since boot
+func formatKernelTime(e datasource.KernelEvent) string {
+	if !e.Time.IsZero() {
+		return e.Time.Format("2006-01-02 15:04:05")
+	}
+	if e.Uptime >= 0 {
+		return fmt.Sprintf("boot+%.0fs", e.Uptime)
+	}
+	return "-"
+}
+
+// kernelEventSubject names what a kernel event is about: the OOM victim's pod (or pod UID
+// when the container is gone), the hung or faulting process, or the failing device
+func kernelEventSubject(e datasource.KernelEvent) string {
+	switch {
+	case e.Type == datasource.KernelEventOOMKill && e.Pod != "":
+		return e.Namespace + "/" + e.Pod
+	case e.Type == datasource.KernelEventOOMKill && e.PodUID != "":
+		return "pod " + e.PodUID
+	case e.Device != "":
+		return e.Device
+	case e.Process != "":
+		return fmt.Sprintf("%s (%d)", e.Process, e.PID)
+	}
+	return ""
+}
+
+// kernelStatusText summarizes the Kernel view for the status bar
+func (a *App) kernelStatusText() string {
+	if a.kernel == nil {
+		return "no dmesg data"
+	}
+	counts := make(map[string]int)
+	for _, e := range a.kernel.Events {
+		counts[e.Type]++
+	}
+	return fmt.Sprintf("%s: %d dmesg lines, %d OOM kills, %d hung tasks, %d I/O errors, %d conntrack full, %d segfaults",
+		a.kernel.Node, a.kernel.Lines, counts[datasource.KernelEventOOMKill], counts[datasource.KernelEventHungTask],
+		counts[datasource.KernelEventIOError], counts[datasource.KernelEventConntrackFull], counts[datasource.KernelEventSegfault])
+}
+
+// updateKernelTable builds the Kernel view: dmesg events in log order, filtered with '/'
+func (a *App) updateKernelTable() {
+	if a.kernel == nil || len(a.kernel.Events) == 0 {
+		message := "No dmesg in bundle (systeminfo/dmesg)"
+		if a.kernel != nil {
+			message = fmt.Sprintf("No OOM kills, hung tasks, I/O errors, conntrack drops or segfaults in %d dmesg lines", a.kernel.Lines)
+		}
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": message})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("time", "TIME", 20),
+		table.NewColumn("type", "TYPE", 15),
+		table.NewColumn("subject", "SUBJECT", 45),
+		table.NewColumn("message", "MESSAGE", 90),
+	}
+
+	rows := []table.Row{}
+	for i, e := range a.kernel.Events {
+		when := formatKernelTime(e)
+		subject := kernelEventSubject(e)
+		message := e.Message
+		if e.Type == datasource.KernelEventOOMKill {
+			message = fmt.Sprintf("%s killed (%s), anon-rss %s", e.Process, e.Constraint, formatBytes(e.AnonRSS*1024))
+		}
+		if a.resourceFilter != "" && !matchesFilter(a.resourceFilter, when, e.Type, subject, message) {
+			continue
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"time":    when,
+			"type":    e.Type,
+			"subject": subject,
+			"message": message,
+			"index":   i,
+		}))
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// describeKernelEvent shows an event's raw dmesg lines; OOM kills also show the cgroup
+// path and the container and pod the victim was attributed to
+func (a *App) describeKernelEvent(row table.RowData) tea.Cmd {
+	index, ok := row["index"].(int)
+	if a.kernel == nil || !ok || index < 0 || index >= len(a.kernel.Events) {
+		return nil
+	}
+	e := a.kernel.Events[index]
+
+	var b strings.Builder
+	title := fmt.Sprintf("Kernel: %s %s", e.Type, kernelEventSubject(e))
+	fmt.Fprintf(&b, "Type:       %s\n", e.Type)
+	fmt.Fprintf(&b, "Time:       %s\n", formatKernelTime(e))
+	if e.Uptime >= 0 {
+		fmt.Fprintf(&b, "Uptime:     %.6fs since boot\n", e.Uptime)
+	}
+	if e.Process != "" {
+		fmt.Fprintf(&b, "Process:    %s (pid %d)\n", e.Process, e.PID)
+	}
+	if e.Device != "" {
+		fmt.Fprintf(&b, "Device:     %s\n", e.Device)
+	}
+
+	if e.Type == datasource.KernelEventOOMKill {
+		fmt.Fprintf(&b, "Constraint: %s\n", e.Constraint)
+		if e.Trigger != "" {
+			fmt.Fprintf(&b, "Invoked by: %s\n", e.Trigger)
+		}
+		if e.AnonRSS > 0 {
+			fmt.Fprintf(&b, "Anon RSS:   %s\n", formatBytes(e.AnonRSS*1024))
+		}
+		if e.MemcgPath != "" {
+			fmt.Fprintf(&b, "Cgroup:     %s\n", e.MemcgPath)
+		}
+		b.WriteString("\nAttribution:\n")
+		switch {
+		case e.Pod != "":
+			fmt.Fprintf(&b, "  Pod:       %s/%s\n", e.Namespace, e.Pod)
+			fmt.Fprintf(&b, "  Container: %s (%s)\n", e.Container, e.ContainerID)
+		case e.PodUID != "":
+			fmt.Fprintf(&b, "  Pod UID:   %s (container no longer listed by crictl)\n", e.PodUID)
+		case e.MemcgPath == "":
+			b.WriteString("  Unknown: dmesg does not name the victim's cgroup\n")
+		default:
+			b.WriteString("  Not a pod: the victim's cgroup is outside kubepods\n")
+		}
+	}
+
+	b.WriteString("\ndmesg:\n")
+	for _, line := range e.Lines {
+		fmt.Fprintf(&b, "  %s\n", line)
+	}
+
+	content := b.String()
+	return func() tea.Msg {
+		return describeMsg{title: title, content: content}
+	}
+}
@@ -0,0 +1,291 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// metricsMsg carries the bundle's metrics scrapes
+type metricsMsg struct {
+	sources []datasource.MetricsSource
+}
+
+// fetchMetrics fetches metrics scrapes using the unified data source
+func (a *App) fetchMetrics() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		sources, err := a.dataSource.GetMetrics()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch metrics: %w", err)}
+		}
+
+		return metricsMsg{sources: sources}
+	}
+}
+
+// formatSeconds formats a latency in seconds, e.g. "850µs", "4.2ms", "1.25s"
+func formatSeconds(s float64) string {
+	switch {
+	case math.IsNaN(s):
+		return "-"
+	case s < 0.001:
+		return fmt.Sprintf("%.0fµs", s*1e6)
+	case s < 1:
+		return fmt.Sprintf("%.1fms", s*1e3)
+	default:
+		return fmt.Sprintf("%.2fs", s)
+	}
+}
+
+// formatMetricNumber formats a sample value: whole numbers in full, others to 4 significant digits
+func formatMetricNumber(v float64) string {
+	if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.4g", v)
+}
+
+// formatMetricLabels formats labels as `{a="x",b="y"}`, sorted by name
+func formatMetricLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%q", name, labels[name])
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+// formatHistogramValue summarizes a histogram as percentile estimates, in time units
+// for _seconds metrics
+func formatHistogramValue(name string, h *datasource.MetricHistogram) string {
+	if h.Count == 0 {
+		return "no observations"
+	}
+	format := formatMetricNumber
+	if strings.HasSuffix(name, "_seconds") {
+		format = formatSeconds
+	}
+	return fmt.Sprintf("p50 %s  p90 %s  p99 %s", format(h.P50), format(h.P90), format(h.P99))
+}
+
+// metricCheckMark returns the CHECK mark for a check status
+func metricCheckMark(status string) string {
+	switch status {
+	case datasource.MetricCheckCritical:
+		return "✗"
+	case datasource.MetricCheckWarning:
+		return "⚠"
+	default:
+		return "✓"
+	}
+}
+
+// metricMatchesFilter reports whether a series matches every filter term. A term with "="
+// matches a label (`method=Range`), any other term a substring of the metric name.
+func metricMatchesFilter(filter string, s datasource.MetricSeries) bool {
+	for _, term := range strings.Fields(strings.ToLower(filter)) {
+		if name, value, ok := strings.Cut(term, "="); ok {
+			matched := false
+			for k, v := range s.Labels {
+				if strings.ToLower(k) == name && strings.Contains(strings.ToLower(v), value) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+			continue
+		}
+		if !strings.Contains(strings.ToLower(s.Name), term) {
+			return false
+		}
+	}
+	return true
+}
+
+// metricsCheckFailures returns how many built-in checks are not OK
+func (a *App) metricsCheckFailures() int {
+	count := 0
+	for _, source := range a.metrics {
+		for _, c := range source.Checks {
+			if c.Status != datasource.MetricCheckOK {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+// metricsCount returns the filtered and total series counts
+func (a *App) metricsCount() (int, int) {
+	shown, total := 0, 0
+	for _, source := range a.metrics {
+		for _, s := range source.Series {
+			total++
+			if metricMatchesFilter(a.resourceFilter, s) {
+				shown++
+			}
+		}
+	}
+	return shown, total
+}
+
+// updateMetricsTable builds the metrics explorer: built-in check results first, then every
+// series matching the filter
+func (a *App) updateMetricsTable() {
+	if len(a.metrics) == 0 {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No metrics scrapes in bundle (etcd/etcd-metrics-*)"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("component", "SOURCE", 10),
+		table.NewColumn("name", "METRIC", 56),
+		table.NewColumn("labels", "LABELS", 44),
+		table.NewColumn("type", "TYPE", 10),
+		table.NewColumn("value", "VALUE", 40),
+	}
+
+	rows := []table.Row{}
+	for si, source := range a.metrics {
+		for ci, c := range source.Checks {
+			if a.resourceFilter != "" && !matchesFilter(a.resourceFilter, c.Name, c.Metric) {
+				continue
+			}
+			rows = append(rows, table.NewRow(table.RowData{
+				"component": source.Component,
+				"name":      metricCheckMark(c.Status) + " " + c.Name,
+				"labels":    c.Detail,
+				"type":      "check",
+				"value":     c.Value,
+				"source":    si,
+				"check":     ci,
+			}))
+		}
+	}
+	for si, source := range a.metrics {
+		for i, s := range source.Series {
+			if !metricMatchesFilter(a.resourceFilter, s) {
+				continue
+			}
+			value := formatMetricNumber(s.Value)
+			if s.Histogram != nil {
+				value = formatHistogramValue(s.Name, s.Histogram)
+			}
+			rows = append(rows, table.NewRow(table.RowData{
+				"component": source.Component,
+				"name":      s.Name,
+				"labels":    formatMetricLabels(s.Labels),
+				"type":      s.Type,
+				"value":     value,
+				"source":    si,
+				"series":    i,
+			}))
+		}
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// describeMetric shows a series with its HELP text, labels and histogram buckets, or a
+// check with the series it was computed from
+func (a *App) describeMetric(row table.RowData) tea.Cmd {
+	si, ok := row["source"].(int)
+	if !ok || si < 0 || si >= len(a.metrics) {
+		return nil
+	}
+	source := a.metrics[si]
+
+	var series []datasource.MetricSeries
+	var check *datasource.MetricCheck
+	if ci, ok := row["check"].(int); ok && ci >= 0 && ci < len(source.Checks) {
+		check = &source.Checks[ci]
+		for _, s := range source.Series {
+			if s.Name == check.Metric {
+				series = append(series, s)
+			}
+		}
+	} else if i, ok := row["series"].(int); ok && i >= 0 && i < len(source.Series) {
+		series = append(series, source.Series[i])
+	} else {
+		return nil
+	}
+
+	return func() tea.Msg {
+		var b strings.Builder
+		title := "Metric: "
+
+		if check != nil {
+			title = "Check: " + check.Name
+			fmt.Fprintf(&b, "Check:      %s %s (%s)\n", metricCheckMark(check.Status), check.Name, check.Status)
+			fmt.Fprintf(&b, "Value:      %s\n", check.Value)
+			fmt.Fprintf(&b, "Detail:     %s\n", check.Detail)
+			fmt.Fprintf(&b, "Metric:     %s\n\n", check.Metric)
+		} else {
+			title += series[0].Name
+		}
+		fmt.Fprintf(&b, "Source:     %s (%s)\n", source.Component, source.File)
+
+		for _, s := range series {
+			fmt.Fprintf(&b, "\n%s%s\n", s.Name, formatMetricLabels(s.Labels))
+			fmt.Fprintf(&b, "  Type:     %s\n", s.Type)
+			if s.Help != "" {
+				fmt.Fprintf(&b, "  Help:     %s\n", s.Help)
+			}
+			if s.Histogram == nil {
+				fmt.Fprintf(&b, "  Value:    %s\n", formatMetricNumber(s.Value))
+				continue
+			}
+
+			h := s.Histogram
+			fmt.Fprintf(&b, "  Count:    %s\n", formatMetricNumber(h.Count))
+			fmt.Fprintf(&b, "  Sum:      %s\n", formatMetricNumber(h.Sum))
+			if h.Count > 0 {
+				fmt.Fprintf(&b, "  Mean:     %s\n", formatMetricNumber(h.Sum/h.Count))
+			}
+			fmt.Fprintf(&b, "  Estimate: %s (interpolated within buckets)\n", formatHistogramValue(s.Name, h))
+			b.WriteString("  Buckets:\n")
+			below := 0.0
+			for _, bucket := range h.Buckets {
+				share := 0.0
+				if h.Count > 0 {
+					share = (bucket.Count - below) / h.Count * 100
+				}
+				fmt.Fprintf(&b, "    le %-10s %14s  %5.1f%%\n", formatMetricNumber(bucket.UpperBound), formatMetricNumber(bucket.Count), share)
+				below = bucket.Count
+			}
+		}
+
+		return describeMsg{title: title, content: b.String()}
+	}
+}
@@ -0,0 +1,346 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// networkPoliciesMsg carries NetworkPolicies with the pods they select
+type networkPoliciesMsg struct {
+	policies []datasource.NetworkPolicyStatus
+}
+
+// isolatedPod is a pod selected by one or more NetworkPolicies
+type isolatedPod struct {
+	Namespace string
+	Name      string
+	Policies  []int // Indexes into App.networkPolicies
+}
+
+// fetchNetworkPolicies fetches NetworkPolicies using the unified data source
+func (a *App) fetchNetworkPolicies() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		policies, err := a.dataSource.GetNetworkPolicies()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch NetworkPolicies: %w", err)}
+		}
+
+		return networkPoliciesMsg{policies: policies}
+	}
+}
+
+// isolatedPods inverts the policy → pods mapping, ordered by namespace and pod name
+func isolatedPods(policies []datasource.NetworkPolicyStatus) []isolatedPod {
+	var pods []isolatedPod
+	index := make(map[string]int)
+
+	for i, policy := range policies {
+		for _, name := range policy.SelectedPods {
+			key := policy.Namespace + "/" + name
+			idx, ok := index[key]
+			if !ok {
+				idx = len(pods)
+				index[key] = idx
+				pods = append(pods, isolatedPod{Namespace: policy.Namespace, Name: name})
+			}
+			pods[idx].Policies = append(pods[idx].Policies, i)
+		}
+	}
+
+	// Simple bubble sort by namespace, then name
+	for i := 0; i < len(pods); i++ {
+		for j := i + 1; j < len(pods); j++ {
+			if pods[j].Namespace+"/"+pods[j].Name < pods[i].Namespace+"/"+pods[i].Name {
+				pods[i], pods[j] = pods[j], pods[i]
+			}
+		}
+	}
+	return pods
+}
+
+// networkPolicyEffect summarizes what a policy does to the pods it selects
+func networkPolicyEffect(policy datasource.NetworkPolicyStatus) string {
+	var effect string
+	switch {
+	case policy.AllowAll:
+		effect = "allow-all"
+	case policy.DenyIngress && policy.DenyEgress:
+		effect = "deny ingress+egress"
+	case policy.DenyIngress:
+		effect = "deny ingress"
+	case policy.DenyEgress:
+		effect = "deny egress"
+	case policy.HasSpec:
+		effect = fmt.Sprintf("%d ingress / %d egress rules", policy.IngressRules, policy.EgressRules)
+	default:
+		effect = "isolates (rules not collected)"
+	}
+
+	if policy.DefaultDeny {
+		effect = "default-" + effect
+	}
+	if policy.Inferred {
+		effect += " (from name)"
+	}
+	return effect
+}
+
+// updateNetworkPoliciesTable builds the NetworkPolicy table, or the pod → policies table
+// when a.netpolByPod is set
+func (a *App) updateNetworkPoliciesTable() {
+	if len(a.networkPolicies) == 0 {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No NetworkPolicies available"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	var columns []table.Column
+	rows := []table.Row{}
+
+	if a.netpolByPod {
+		columns = []table.Column{
+			table.NewColumn("namespace", "NAMESPACE", 20),
+			table.NewColumn("name", "POD", 45),
+			table.NewColumn("policies", "ISOLATED BY", 50),
+			table.NewColumn("errors", "CONN ERRORS", 12),
+		}
+
+		for _, pod := range isolatedPods(a.networkPolicies) {
+			var names []string
+			errors := 0
+			for _, i := range pod.Policies {
+				policy := a.networkPolicies[i]
+				names = append(names, policy.Name)
+				errors += countPodMatches(policy.ConnectionErrors, pod.Name)
+			}
+
+			errorText := "-"
+			if errors > 0 {
+				errorText = fmt.Sprintf("⚠ %d", errors)
+			}
+
+			rows = append(rows, table.NewRow(table.RowData{
+				"namespace": pod.Namespace,
+				"name":      pod.Name,
+				"policies":  strings.Join(names, ", "),
+				"errors":    errorText,
+			}))
+		}
+	} else {
+		columns = []table.Column{
+			table.NewColumn("namespace", "NAMESPACE", 20),
+			table.NewColumn("name", "NAME", 38),
+			table.NewColumn("selector", "POD-SELECTOR", 28),
+			table.NewColumn("selected", "PODS", 8),
+			table.NewColumn("effect", "EFFECT", 30),
+			table.NewColumn("errors", "CONN ERRORS", 12),
+		}
+
+		for i, policy := range a.networkPolicies {
+			selected := fmt.Sprintf("%d", len(policy.SelectedPods))
+			if !policy.Exact {
+				selected += "+" // Lower bound: matched via workload/service selectors
+			}
+
+			errorText := "-"
+			if len(policy.ConnectionErrors) > 0 {
+				errorText = fmt.Sprintf("⚠ %d", len(policy.ConnectionErrors))
+			}
+
+			rows = append(rows, table.NewRow(table.RowData{
+				"namespace": policy.Namespace,
+				"name":      policy.Name,
+				"selector":  policy.SelectorText,
+				"selected":  selected,
+				"effect":    networkPolicyEffect(policy),
+				"errors":    errorText,
+				"index":     i,
+			}))
+		}
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// countPodMatches counts connection errors logged by a pod
+func countPodMatches(matches []datasource.LogMatch, podName string) int {
+	count := 0
+	for _, m := range matches {
+		if m.PodName == podName {
+			count++
+		}
+	}
+	return count
+}
+
+// handleNetworkPolicyEnter opens the selected pod's logs in pod mode, or describes the policy
+func (a *App) handleNetworkPolicyEnter(row table.RowData) tea.Cmd {
+	if !a.netpolByPod {
+		return a.describeNetworkPolicy(row)
+	}
+
+	namespace := safeRowString(row, "namespace")
+	podName := safeRowString(row, "name")
+	if namespace == "" || podName == "" {
+		return nil
+	}
+
+	a.viewStack = append(a.viewStack, a.currentView)
+	a.currentView = ViewContext{
+		viewType:      ViewLogs,
+		clusterID:     a.currentView.clusterID,
+		clusterName:   a.currentView.clusterName,
+		namespaceName: namespace,
+		podName:       podName,
+	}
+	a.filterLevel = ""
+	a.loading = true
+	return a.fetchLogs(a.currentView.clusterID, namespace, podName)
+}
+
+// describeNetworkPolicy shows a policy and the pods it selects, or in pod mode the
+// policies isolating the selected pod
+func (a *App) describeNetworkPolicy(row table.RowData) tea.Cmd {
+	policies := a.networkPolicies
+
+	if a.netpolByPod {
+		namespace := safeRowString(row, "namespace")
+		podName := safeRowString(row, "name")
+
+		return func() tea.Msg {
+			var b strings.Builder
+			fmt.Fprintf(&b, "Pod:  %s/%s\n", namespace, podName)
+
+			b.WriteString("\nISOLATED BY\n")
+			for _, policy := range policies {
+				if policy.Namespace != namespace || !containsName(policy.SelectedPods, podName) {
+					continue
+				}
+				fmt.Fprintf(&b, "  %s  selector=%s  %s\n", policy.Name, policy.SelectorText, networkPolicyEffect(policy))
+				for _, m := range policy.ConnectionErrors {
+					if m.PodName == podName {
+						fmt.Fprintf(&b, "    ⚠ line %d: %s\n", m.LineNumber, m.Line)
+					}
+				}
+			}
+
+			return describeMsg{
+				title:   fmt.Sprintf("Pod isolation: %s", podName),
+				content: b.String(),
+			}
+		}
+	}
+
+	idx, ok := row["index"].(int)
+	if !ok || idx < 0 || idx >= len(policies) {
+		return nil
+	}
+	p := policies[idx]
+
+	return func() tea.Msg {
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "NetworkPolicy:  %s/%s\n", p.Namespace, p.Name)
+		fmt.Fprintf(&b, "Pod selector:   %s\n", p.SelectorText)
+		if p.HasSpec {
+			fmt.Fprintf(&b, "Policy types:   %s\n", strings.Join(p.PolicyTypes, ", "))
+			fmt.Fprintf(&b, "Rules:          %d ingress, %d egress\n", p.IngressRules, p.EgressRules)
+		} else {
+			b.WriteString("Rules:          not collected (table output only)\n")
+		}
+		fmt.Fprintf(&b, "Effect:         %s\n", networkPolicyEffect(p))
+
+		// Namespace-wide default-deny, from this or any other policy
+		var defaultDeny []string
+		for _, other := range policies {
+			if other.Namespace == p.Namespace && other.DefaultDeny {
+				defaultDeny = append(defaultDeny, other.Name)
+			}
+		}
+		if len(defaultDeny) > 0 {
+			fmt.Fprintf(&b, "\n🛡️  Namespace %s is default-deny (%s)\n", p.Namespace, strings.Join(defaultDeny, ", "))
+		} else {
+			fmt.Fprintf(&b, "\nNamespace %s has no default-deny policy\n", p.Namespace)
+		}
+
+		fmt.Fprintf(&b, "\nSELECTED PODS (%s)\n", p.Resolution)
+		if len(p.SelectedPods) == 0 {
+			b.WriteString("  (none found in bundle)\n")
+		}
+		for _, pod := range p.SelectedPods {
+			fmt.Fprintf(&b, "  %s\n", pod)
+		}
+
+		if len(p.ConnectionErrors) > 0 {
+			b.WriteString("\nCONNECTION ERRORS IN SELECTED PODS\n")
+			for _, m := range p.ConnectionErrors {
+				fmt.Fprintf(&b, "  %s:%d: %s\n", m.Source, m.LineNumber, m.Line)
+			}
+		}
+
+		return describeMsg{
+			title:   fmt.Sprintf("NetworkPolicy: %s", p.Name),
+			content: b.String(),
+		}
+	}
+}
+
+// podIsolatingPolicies returns the names of the NetworkPolicies selecting a pod
+func (a *App) podIsolatingPolicies(namespace, podName string) []string {
+	if a.dataSource == nil {
+		return nil
+	}
+
+	policies, err := a.dataSource.GetNetworkPolicies()
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, policy := range policies {
+		if policy.Namespace == namespace && containsName(policy.SelectedPods, podName) {
+			names = append(names, policy.Name)
+		}
+	}
+	return names
+}
+
+// isolationNote returns the annotation for a connection error logged by a pod isolated
+// by NetworkPolicies, or "" if the line is not a connection error or the pod is not isolated
+func (a *App) isolationNote(line string) string {
+	if len(a.logIsolatingPolicies) == 0 || !datasource.ConnectionErrorPattern.MatchString(line) {
+		return ""
+	}
+	return logAnnotationStyle.Render(fmt.Sprintf("  ⛔ pod isolated by NetworkPolicy %s",
+		strings.Join(a.logIsolatingPolicies, ", ")))
+}
+
+// containsName reports whether names contains name
+func containsName(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,287 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// networkingMsg carries the bundle node's network configuration
+type networkingMsg struct {
+	report *datasource.NetworkReport
+}
+
+// fetchNetworking fetches the node's network configuration using the unified data source
+func (a *App) fetchNetworking() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		report, err := a.dataSource.GetNetworking()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch networking: %w", err)}
+		}
+
+		return networkingMsg{report: report}
+	}
+}
+
+// networkCheckFailures returns how many network checks are not OK
+func (a *App) networkCheckFailures() int {
+	if a.networking == nil {
+		return 0
+	}
+	count := 0
+	for _, c := range a.networking.Checks {
+		if c.Status != datasource.NetworkCheckOK {
+			count++
+		}
+	}
+	return count
+}
+
+// formatSocketAddress formats a listening address as ss does, e.g. "*:6443" or "[::1]:10248"
+func formatSocketAddress(s datasource.ListeningSocket) string {
+	if strings.Contains(s.Address, ":") {
+		return fmt.Sprintf("[%s]:%d", s.Address, s.Port)
+	}
+	return fmt.Sprintf("%s:%d", s.Address, s.Port)
+}
+
+// updateNetworkingTable builds the networking view: check results, then interfaces, listening
+// sockets, CNI config and routes (local and broadcast routes are left to describe), filtered with '/'
+func (a *App) updateNetworkingTable() {
+	if a.networking == nil {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No networking data in bundle (networking/)"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("section", "TYPE", 8),
+		table.NewColumn("name", "NAME", 40),
+		table.NewColumn("value", "VALUE", 24),
+		table.NewColumn("detail", "DETAIL", 90),
+	}
+
+	rows := []table.Row{}
+	add := func(section, name, value, detail string, index int) {
+		if a.resourceFilter != "" && !matchesFilter(a.resourceFilter, section, name, value, detail) {
+			return
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"section": section,
+			"name":    name,
+			"value":   value,
+			"detail":  detail,
+			"index":   index,
+		}))
+	}
+
+	n := a.networking
+	for i, c := range n.Checks {
+		add("check", metricCheckMark(c.Status)+" "+c.Name, c.Status, c.Detail, i)
+	}
+	for i, iface := range n.Interfaces {
+		name := iface.Name
+		if iface.Peer != "" {
+			name += "@" + iface.Peer
+		}
+		add("iface", name, fmt.Sprintf("mtu %d %s", iface.MTU, iface.State), iface.Kind+"  "+strings.Join(iface.Addresses, " "), i)
+	}
+	for i, s := range n.Sockets {
+		process := s.Process
+		if s.PID > 0 {
+			process = fmt.Sprintf("%s (%d)", s.Process, s.PID)
+		}
+		add("listen", s.Protocol+" "+formatSocketAddress(s), process, "", i)
+	}
+	for i, c := range n.CNI {
+		mtu := "mtu auto"
+		if c.MTU > 0 {
+			mtu = fmt.Sprintf("mtu %d", c.MTU)
+		}
+		add("cni", c.File, mtu, c.Name+": "+strings.Join(c.Plugins, " → "), i)
+	}
+	for i, r := range n.Routes {
+		if r.Type == "local" || r.Type == "broadcast" {
+			continue
+		}
+		add("route", r.Destination, "table "+r.Table, r.Text, i)
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// describeNetworkRow shows the selected check, interface (with its routes and sockets),
+// socket, CNI config or route
+func (a *App) describeNetworkRow(row table.RowData) tea.Cmd {
+	index, ok := row["index"].(int)
+	section, _ := row["section"].(string)
+	if a.networking == nil || !ok || index < 0 {
+		return nil
+	}
+	n := a.networking
+
+	var b strings.Builder
+	title := ""
+	switch section {
+	case "check":
+		if index >= len(n.Checks) {
+			return nil
+		}
+		c := n.Checks[index]
+		title = "Check: " + c.Name
+		fmt.Fprintf(&b, "Check:        %s %s (%s)\n", metricCheckMark(c.Status), c.Name, c.Status)
+		fmt.Fprintf(&b, "Detail:       %s\n\n", c.Detail)
+		role := "agent"
+		if n.IsServer {
+			role = "server"
+		}
+		if n.IsEtcd {
+			role += ", etcd"
+		}
+		fmt.Fprintf(&b, "Node:         %s (%s)\n", n.Node, role)
+		fmt.Fprintf(&b, "Cluster CIDR: %s\n", n.ClusterCIDR)
+		fmt.Fprintf(&b, "Service CIDR: %s\n", n.ServiceCIDR)
+		if n.Uplink != "" {
+			fmt.Fprintf(&b, "Uplink:       %s\n", n.Uplink)
+		}
+		if n.PodMTU > 0 {
+			fmt.Fprintf(&b, "Pod MTU:      %d (%s)\n", n.PodMTU, n.PodMTUSource)
+		}
+		if n.IptablesHeader != "" {
+			fmt.Fprintf(&b, "iptables:     %s, %d rules (%s)\n", n.IptablesBackend, n.IptablesRules, n.IptablesHeader)
+		}
+		if len(n.NftTables) > 0 {
+			fmt.Fprintf(&b, "nftables:     %s\n", strings.Join(n.NftTables, ", "))
+		}
+
+	case "iface":
+		if index >= len(n.Interfaces) {
+			return nil
+		}
+		iface := n.Interfaces[index]
+		title = "Interface: " + iface.Name
+		fmt.Fprintf(&b, "Name:       %s\n", iface.Name)
+		if iface.Peer != "" {
+			fmt.Fprintf(&b, "Peer:       %s (in another network namespace)\n", iface.Peer)
+		}
+		fmt.Fprintf(&b, "Kind:       %s\n", iface.Kind)
+		fmt.Fprintf(&b, "MTU:        %d\n", iface.MTU)
+		fmt.Fprintf(&b, "State:      %s\n", iface.State)
+		fmt.Fprintf(&b, "Flags:      %s\n", strings.Join(iface.Flags, ","))
+		b.WriteString("Addresses:\n")
+		if len(iface.Addresses) == 0 {
+			b.WriteString("  (none)\n")
+		}
+		for _, addr := range iface.Addresses {
+			fmt.Fprintf(&b, "  %s\n", addr)
+		}
+		b.WriteString("\nRoutes via this interface:\n")
+		count := 0
+		for _, r := range n.Routes {
+			if r.Device == iface.Name {
+				fmt.Fprintf(&b, "  %s\n", r.Text)
+				count++
+			}
+		}
+		if count == 0 {
+			b.WriteString("  (none)\n")
+		}
+
+	case "listen":
+		if index >= len(n.Sockets) {
+			return nil
+		}
+		s := n.Sockets[index]
+		title = fmt.Sprintf("Socket: %s %d", s.Protocol, s.Port)
+		fmt.Fprintf(&b, "Protocol:   %s\n", s.Protocol)
+		fmt.Fprintf(&b, "Address:    %s\n", formatSocketAddress(s))
+		if s.Process != "" {
+			fmt.Fprintf(&b, "Process:    %s (pid %d)\n", s.Process, s.PID)
+		}
+		b.WriteString("\nOther sockets on this port:\n")
+		count := 0
+		for i, other := range n.Sockets {
+			if i != index && other.Port == s.Port {
+				fmt.Fprintf(&b, "  %s %s %s\n", other.Protocol, formatSocketAddress(other), other.Process)
+				count++
+			}
+		}
+		if count == 0 {
+			b.WriteString("  (none)\n")
+		}
+
+	case "cni":
+		if index >= len(n.CNI) {
+			return nil
+		}
+		c := n.CNI[index]
+		title = "CNI: " + c.File
+		fmt.Fprintf(&b, "File:       /etc/cni/net.d/%s\n", c.File)
+		fmt.Fprintf(&b, "Network:    %s\n", c.Name)
+		fmt.Fprintf(&b, "Plugins:    %s\n", strings.Join(c.Plugins, " → "))
+		if c.MTU > 0 {
+			fmt.Fprintf(&b, "MTU:        %d\n", c.MTU)
+		} else {
+			b.WriteString("MTU:        auto-detected by the CNI\n")
+		}
+
+	case "route":
+		if index >= len(n.Routes) {
+			return nil
+		}
+		r := n.Routes[index]
+		title = "Route: " + r.Destination
+		fmt.Fprintf(&b, "Route:       %s\n", r.Text)
+		fmt.Fprintf(&b, "Type:        %s\n", r.Type)
+		fmt.Fprintf(&b, "Destination: %s\n", r.Destination)
+		if r.Gateway != "" {
+			fmt.Fprintf(&b, "Gateway:     %s\n", r.Gateway)
+		}
+		if r.Device != "" {
+			fmt.Fprintf(&b, "Device:      %s\n", r.Device)
+		}
+		fmt.Fprintf(&b, "Table:       %s\n", r.Table)
+		if r.Protocol != "" {
+			fmt.Fprintf(&b, "Protocol:    %s\n", r.Protocol)
+		}
+		if r.Scope != "" {
+			fmt.Fprintf(&b, "Scope:       %s\n", r.Scope)
+		}
+		if r.Source != "" {
+			fmt.Fprintf(&b, "Source:      %s\n", r.Source)
+		}
+		if len(n.Rules) > 0 {
+			b.WriteString("\nPolicy rules (ip rule):\n")
+			for _, rule := range n.Rules {
+				fmt.Fprintf(&b, "  %s\n", rule)
+			}
+		}
+
+	default:
+		return nil
+	}
+
+	content := b.String()
+	return func() tea.Msg {
+		return describeMsg{title: title, content: content}
+	}
+}
@@ -0,0 +1,190 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/rbac"
+)
+
+// maxForbiddenLines caps how many forbidden log lines are shown per subject
+const maxForbiddenLines = 10
+
+// rbacMsg carries the RBAC evaluator and the subjects to list
+type rbacMsg struct {
+	evaluator *rbac.Evaluator
+	subjects  []rbac.SubjectSummary
+}
+
+// fetchRBAC loads roles and bindings and builds the subject list
+func (a *App) fetchRBAC() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		roles, err := a.dataSource.GetRoles()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch roles: %w", err)}
+		}
+		bindings, err := a.dataSource.GetRoleBindings()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch role bindings: %w", err)}
+		}
+
+		evaluator := rbac.NewEvaluator(roles, bindings)
+		return rbacMsg{evaluator: evaluator, subjects: evaluator.Subjects()}
+	}
+}
+
+// updateRBACTable builds the subjects table for the RBAC view
+func (a *App) updateRBACTable() {
+	if len(a.rbacSubjects) == 0 {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No role bindings available"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("subject", "SUBJECT", 55),
+		table.NewColumn("kind", "KIND", 15),
+		table.NewColumn("bindings", "BINDINGS", 9),
+		table.NewColumn("roles", "ROLES", 40),
+		table.NewColumn("risk", "RISK", 40),
+	}
+
+	rows := []table.Row{}
+	for i, s := range a.rbacSubjects {
+		roles := strings.Join(s.Roles, ", ")
+		if len(roles) > 40 {
+			roles = roles[:37] + "..."
+		}
+		risk := ""
+		if len(s.Risks) > 0 {
+			risk = "⚠️  " + s.Risks[0]
+			if len(s.Risks) > 1 {
+				risk += fmt.Sprintf(" (+%d)", len(s.Risks)-1)
+			}
+		}
+
+		rows = append(rows, table.NewRow(table.RowData{
+			"subject":  rbac.SubjectString(s.Subject),
+			"kind":     s.Subject.Kind,
+			"bindings": fmt.Sprintf("%d", len(s.Bindings)),
+			"roles":    roles,
+			"risk":     risk,
+			"index":    i,
+		}))
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// describeRBACSubject shows what the selected subject can do, its risks, and any
+// forbidden errors for it found in logs
+func (a *App) describeRBACSubject(row table.RowData) tea.Cmd {
+	idx, ok := row["index"].(int)
+	if !ok || idx < 0 || idx >= len(a.rbacSubjects) || a.rbacEvaluator == nil {
+		return nil
+	}
+	summary := a.rbacSubjects[idx]
+	evaluator := a.rbacEvaluator
+
+	return func() tea.Msg {
+		var b strings.Builder
+		subject := summary.Subject
+
+		fmt.Fprintf(&b, "Subject:  %s\n", rbac.SubjectString(subject))
+		fmt.Fprintf(&b, "Kind:     %s\n", subject.Kind)
+		if subject.Namespace != "" {
+			fmt.Fprintf(&b, "Namespace: %s\n", subject.Namespace)
+		}
+
+		if len(summary.Risks) > 0 {
+			b.WriteString("\n⚠️  RISKY GRANTS\n")
+			for _, risk := range summary.Risks {
+				fmt.Fprintf(&b, "  • %s\n", risk)
+			}
+		}
+
+		b.WriteString("\nWHAT CAN THIS SUBJECT DO\n")
+		for _, g := range evaluator.SubjectGrants(subject) {
+			via := ""
+			if g.Subject != subject {
+				via = fmt.Sprintf(" [via %s %s]", strings.ToLower(g.Subject.Kind), g.Subject.Name)
+			}
+			fmt.Fprintf(&b, "\n  %s/%s (%s) -> %s/%s%s\n", g.Binding.Kind, g.Binding.Name, g.Scope, g.Role.Kind, g.Role.Name, via)
+
+			rules := evaluator.Rules(g)
+			if len(rules) == 0 {
+				b.WriteString("      (rules not collected in bundle)\n")
+				continue
+			}
+			if g.Inferred {
+				b.WriteString("      (inferred from built-in defaults)\n")
+			}
+			for _, rule := range rules {
+				fmt.Fprintf(&b, "      %s\n", rbac.FormatRule(rule))
+			}
+		}
+
+		// Correlate forbidden errors in logs with this subject
+		var forbidden []string
+		if events, err := a.dataSource.GetAllEvents(); err == nil {
+			for _, event := range events {
+				if f, ok := rbac.ParseForbidden(event.Message); ok && f.Subject == subject {
+					forbidden = append(forbidden, fmt.Sprintf("  [event %s/%s] %s %s\n      → %s",
+						event.Namespace, event.Object, f.Verb, f.Resource, evaluator.Explain(f)))
+				}
+			}
+		}
+		if matches, err := a.dataSource.SearchLogs(rbac.ForbiddenPattern, 500); err == nil {
+			for _, m := range matches {
+				f, ok := rbac.ParseForbidden(m.Line)
+				if !ok || f.Subject != subject {
+					continue
+				}
+				forbidden = append(forbidden, fmt.Sprintf("  [%s:%d] %s %s\n      → %s",
+					m.Source, m.LineNumber, f.Verb, f.Resource, evaluator.Explain(f)))
+				if len(forbidden) >= maxForbiddenLines {
+					break
+				}
+			}
+		}
+		if len(forbidden) > 0 {
+			b.WriteString("\nFORBIDDEN ERRORS IN LOGS\n")
+			b.WriteString(strings.Join(forbidden, "\n"))
+			b.WriteString("\n")
+		}
+
+		return describeMsg{
+			title:   fmt.Sprintf("RBAC: %s", rbac.SubjectString(subject)),
+			content: b.String(),
+		}
+	}
+}
+
+// rbacRiskyCount returns the number of subjects with risky grants
+func rbacRiskyCount(subjects []rbac.SubjectSummary) int {
+	count := 0
+	for _, s := range subjects {
+		if len(s.Risks) > 0 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,371 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// resourceTypesMsg carries the kubectl output files available in the bundle
+type resourceTypesMsg struct {
+	types []datasource.ResourceType
+}
+
+// resourceTableMsg carries one kubectl output file as a generic table
+type resourceTableMsg struct {
+	table *datasource.ResourceTable
+}
+
+// Column width bounds for generic resource tables
+const (
+	minResourceColumnWidth = 4
+	maxResourceColumnWidth = 50
+)
+
+// fetchResourceTypes fetches the resource type list using the unified data source
+func (a *App) fetchResourceTypes() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		types, err := a.dataSource.GetResourceTypes()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch resource types: %w", err)}
+		}
+
+		return resourceTypesMsg{types: types}
+	}
+}
+
+// fetchResourceTable fetches a generic resource table using the unified data source
+func (a *App) fetchResourceTable(name string) tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		t, err := a.dataSource.GetResourceTable(name)
+		if err != nil {
+			return errMsg{err}
+		}
+
+		return resourceTableMsg{table: t}
+	}
+}
+
+// openResourceTypes pushes the resource type list
+func (a *App) openResourceTypes(clusterID, clusterName string) tea.Cmd {
+	a.viewStack = append(a.viewStack, a.currentView)
+	a.currentView = ViewContext{
+		viewType:    ViewResources,
+		clusterID:   clusterID,
+		clusterName: clusterName,
+	}
+	a.resourceFilter = ""
+	a.loading = true
+	return a.fetchResourceTypes()
+}
+
+// openResourceTable pushes a generic table for a resource file, resource name, kind or short name
+func (a *App) openResourceTable(name string) tea.Cmd {
+	a.viewStack = append(a.viewStack, a.currentView)
+	a.currentView = ViewContext{
+		viewType:     ViewResourceTable,
+		clusterID:    a.currentView.clusterID,
+		clusterName:  a.currentView.clusterName,
+		resourceName: name,
+	}
+	a.resourceSortCol = -1
+	a.resourceSortDesc = false
+	a.resourceFilter = ""
+	a.loading = true
+	return a.fetchResourceTable(name)
+}
+
+// isResourceView reports whether the current view is part of the generic resource browser
+func (a *App) isResourceView() bool {
+	return a.currentView.viewType == ViewResources || a.currentView.viewType == ViewResourceTable
+}
+
+// handlePromptKey handles typing in the '/' filter and ':' jump prompts
+func (a *App) handlePromptKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		if a.promptMode == '/' {
+			a.resourceFilter = ""
+			a.updateTable()
+		}
+		a.promptMode = 0
+		a.promptText = ""
+		return a, nil
+	case "enter":
+		mode, text := a.promptMode, strings.TrimSpace(a.promptText)
+		a.promptMode = 0
+		a.promptText = ""
+		if mode == ':' && text != "" {
+			return a, a.openResourceTable(text)
+		}
+		return a, nil
+	case "backspace":
+		if len(a.promptText) > 0 {
+			a.promptText = a.promptText[:len(a.promptText)-1]
+		}
+	default:
+		if len(msg.String()) == 1 {
+			a.promptText += msg.String()
+		}
+	}
+
+	// Filter as you type
+	if a.promptMode == '/' {
+		a.resourceFilter = a.promptText
+		a.updateTable()
+	}
+	return a, nil
+}
+
+// promptStatusText returns the status bar while a prompt is open
+func (a *App) promptStatusText() string {
+	if a.promptMode == ':' {
+		return fmt.Sprintf(" :%s_ | resource, kind or short name (e.g. pods, hpa, HelmChart) | Enter=jump Esc=cancel ", a.promptText)
+	}
+	return fmt.Sprintf(" /%s_ | Enter=keep filter Esc=clear ", a.promptText)
+}
+
+// matchesFilter reports whether any value contains the filter text (case-insensitive)
+func matchesFilter(filter string, values ...string) bool {
+	if filter == "" {
+		return true
+	}
+	filter = strings.ToLower(filter)
+	for _, v := range values {
+		if strings.Contains(strings.ToLower(v), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateResourceTypesTable builds the list of kubectl output files
+func (a *App) updateResourceTypesTable() {
+	columns := []table.Column{
+		table.NewColumn("kind", "KIND", 32),
+		table.NewColumn("name", "FILE", 32),
+		table.NewColumn("apiversion", "APIVERSION", 32),
+		table.NewColumn("scope", "SCOPE", 10),
+		table.NewColumn("shortnames", "SHORTNAMES", 14),
+		table.NewColumn("count", "ROWS", 6),
+	}
+
+	rows := []table.Row{}
+	for i, t := range a.resourceTypes {
+		shortNames := strings.Join(t.ShortNames, ",")
+		if !matchesFilter(a.resourceFilter, t.Kind, t.File, t.Resource, t.APIVersion, shortNames) {
+			continue
+		}
+
+		kind, scope, count := t.Kind, "Cluster", fmt.Sprintf("%d", t.Count)
+		if kind == "" {
+			kind = "-"
+			scope = "-"
+		} else if t.Namespaced {
+			scope = "Namespace"
+		}
+		if !t.IsTable {
+			count = "-"
+		}
+
+		rows = append(rows, table.NewRow(table.RowData{
+			"kind":       kind,
+			"name":       t.File,
+			"apiversion": t.APIVersion,
+			"scope":      scope,
+			"shortnames": shortNames,
+			"count":      count,
+			"index":      i,
+		}))
+	}
+
+	if len(rows) == 0 {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No kubectl output files match"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// updateResourceTableView renders a generic resource table from its header columns,
+// applying the current filter and sort column
+func (a *App) updateResourceTableView() {
+	t := a.resourceTable
+	if t == nil || len(t.Headers) == 0 {
+		message := "No table output for this resource"
+		if t != nil && t.Message != "" {
+			message = strings.SplitN(t.Message, "\n", 2)[0]
+		}
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 100)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": message})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	// Filter and sort a copy so the file order can be restored
+	view := *t
+	view.Rows = nil
+	for _, row := range t.Rows {
+		if matchesFilter(a.resourceFilter, row...) {
+			view.Rows = append(view.Rows, row)
+		}
+	}
+	view.SortRows(a.resourceSortCol, a.resourceSortDesc)
+
+	// Size columns to their content
+	widths := make([]int, len(view.Headers))
+	for i, h := range view.Headers {
+		widths[i] = len(h) + 2 // Room for the sort marker
+	}
+	for _, row := range view.Rows {
+		for i, cell := range row {
+			if len(cell) > widths[i] {
+				widths[i] = len(cell)
+			}
+		}
+	}
+
+	columns := []table.Column{}
+	for i, h := range view.Headers {
+		title := h
+		if i == a.resourceSortCol {
+			if a.resourceSortDesc {
+				title += " ▼"
+			} else {
+				title += " ▲"
+			}
+		}
+		width := max(minResourceColumnWidth, min(widths[i], maxResourceColumnWidth))
+		columns = append(columns, table.NewColumn(fmt.Sprintf("c%d", i), title, width))
+	}
+
+	nameCol := -1
+	for i, h := range view.Headers {
+		if h == "NAME" {
+			nameCol = i
+		}
+	}
+
+	rows := []table.Row{}
+	for _, cells := range view.Rows {
+		data := table.RowData{}
+		for i, cell := range cells {
+			data[fmt.Sprintf("c%d", i)] = cell
+		}
+		if nameCol >= 0 {
+			data["name"] = cells[nameCol]
+		}
+		rows = append(rows, table.NewRow(data))
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// cycleResourceSort sorts by the next column, wrapping back to file order
+func (a *App) cycleResourceSort() {
+	if a.resourceTable == nil {
+		return
+	}
+	a.resourceSortCol++
+	if a.resourceSortCol >= len(a.resourceTable.Headers) {
+		a.resourceSortCol = -1
+	}
+	a.updateTable()
+}
+
+// resourceTableCount returns the filtered and total row counts of the generic table
+func (a *App) resourceTableCount() (int, int) {
+	if a.resourceTable == nil {
+		return 0, 0
+	}
+	shown := 0
+	for _, row := range a.resourceTable.Rows {
+		if matchesFilter(a.resourceFilter, row...) {
+			shown++
+		}
+	}
+	return shown, len(a.resourceTable.Rows)
+}
+
+// resourceTableTitle names the table's resource for breadcrumbs, e.g. "HorizontalPodAutoscaler (hpa)"
+func (a *App) resourceTableTitle() string {
+	if a.resourceTable == nil {
+		return a.currentView.resourceName
+	}
+	if a.resourceTable.Kind == "" {
+		return a.resourceTable.File
+	}
+	return fmt.Sprintf("%s (%s)", a.resourceTable.Kind, a.resourceTable.File)
+}
+
+// handleResourceEnter opens the table of the selected resource type
+func (a *App) handleResourceEnter(row table.RowData) tea.Cmd {
+	file := safeRowString(row, "name")
+	if file == "" {
+		return nil
+	}
+	return a.openResourceTable(file)
+}
+
+// describeResourceRow shows every column of the selected generic table row
+func (a *App) describeResourceRow(row table.RowData) tea.Cmd {
+	t := a.resourceTable
+	if t == nil || len(t.Headers) == 0 {
+		return nil
+	}
+	title := a.resourceTableTitle()
+
+	return func() tea.Msg {
+		var b strings.Builder
+
+		width := 0
+		for _, h := range t.Headers {
+			width = max(width, len(h)+1)
+		}
+		for i, h := range t.Headers {
+			fmt.Fprintf(&b, "%-*s  %s\n", width, h+":", safeRowString(row, fmt.Sprintf("c%d", i)))
+		}
+		if t.APIVersion != "" {
+			fmt.Fprintf(&b, "\nAPI: %s, Kind=%s, namespaced=%t (file rke2/kubectl/%s)\n", t.APIVersion, t.Kind, t.Namespaced, t.File)
+		}
+
+		return describeMsg{
+			title:   fmt.Sprintf("%s: %s", title, safeRowString(row, "name")),
+			content: b.String(),
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// rke2ConfigMsg carries the bundle node's RKE2 config and lint issues
+type rke2ConfigMsg struct {
+	config *datasource.RKE2Config
+}
+
+// fetchRKE2Config fetches the RKE2 config using the unified data source
+func (a *App) fetchRKE2Config() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		config, err := a.dataSource.GetRKE2Config()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch rke2 config: %w", err)}
+		}
+
+		return rke2ConfigMsg{config: config}
+	}
+}
+
+// rke2EffectiveSetting is a setting other views depend on, with its effective value
+type rke2EffectiveSetting struct {
+	key   string
+	value string
+	usage string // Which analysis uses it
+}
+
+// rke2EffectiveSettings returns the effective CNI, CIDRs, tls-san and snapshot settings
+func rke2EffectiveSettings(c *datasource.RKE2Config) []rke2EffectiveSetting {
+	snapshots := c.SnapshotSchedule
+	if c.SnapshotsDisabled {
+		snapshots = "disabled"
+	}
+	return []rke2EffectiveSetting{
+		{"cni", strings.Join(c.CNI, ","), "Networking view: CNI config check"},
+		{"cluster-cidr", c.ClusterCIDR, "Networking view: pod CIDR route check"},
+		{"service-cidr", c.ServiceCIDR, "Networking view: service CIDR route check"},
+		{"cluster-dns", c.ClusterDNS, "CoreDNS service IP"},
+		{"tls-san", strings.Join(c.TLSSANs, ","), "Extra names in the serving certificate (Certificates view)"},
+		{"etcd-snapshot-schedule-cron", snapshots, "etcd view: stale snapshot check allows two intervals"},
+		{"etcd-snapshot-retention", fmt.Sprintf("%d", c.SnapshotRetention), "Snapshots kept per node"},
+	}
+}
+
+// rke2SettingSource returns where an effective setting's value comes from
+func rke2SettingSource(c *datasource.RKE2Config, key string) string {
+	for _, s := range c.Settings {
+		if s.Key == key {
+			return "50-rancher.yaml"
+		}
+	}
+	if key == "tls-san" {
+		return "not set"
+	}
+	return "RKE2 default"
+}
+
+// rke2ConfigStatusText summarizes the RKE2 config view for the status bar
+func (a *App) rke2ConfigStatusText() string {
+	if a.rke2Config == nil {
+		return "no rke2 config"
+	}
+	c := a.rke2Config
+	role := c.Role
+	if role == "" {
+		role = "unknown role"
+	}
+	return fmt.Sprintf("%s (%s): %d settings, %d unit files, %d issues", c.Node, role, len(c.Settings), len(c.Units), len(c.Issues))
+}
+
+// updateRKE2ConfigTable builds the RKE2 config view: issues, effective settings, the keys of
+// 50-rancher.yaml in file order, then the service units, filtered with '/'
+func (a *App) updateRKE2ConfigTable() {
+	if a.rke2Config == nil {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No RKE2 config in bundle (rke2/50-rancher.yaml, rke2/rke2-*.service)"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("section", "TYPE", 10),
+		table.NewColumn("name", "NAME", 36),
+		table.NewColumn("value", "VALUE", 50),
+		table.NewColumn("detail", "DETAIL", 70),
+	}
+
+	rows := []table.Row{}
+	add := func(section, name, value, detail string, index int) {
+		if a.resourceFilter != "" && !matchesFilter(a.resourceFilter, section, name, value, detail) {
+			return
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"section": section,
+			"name":    name,
+			"value":   value,
+			"detail":  detail,
+			"index":   index,
+		}))
+	}
+
+	c := a.rke2Config
+	for i, issue := range c.Issues {
+		add("issue", "⚠ "+issue.Key, issue.Type, issue.Detail, i)
+	}
+	for i, s := range rke2EffectiveSettings(c) {
+		add("effective", s.key, s.value, rke2SettingSource(c, s.key), i)
+	}
+	for i, s := range c.Settings {
+		detail := ""
+		switch {
+		case !s.Known:
+			detail = "unknown key"
+		case s.Masked:
+			detail = "secret (masked)"
+		}
+		add("config", s.Key, s.Value, detail, i)
+	}
+	for i, u := range c.Units {
+		add("unit", u.Name, u.ExecStart, strings.Join(u.EnvironmentFiles, " "), i)
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// describeRKE2ConfigRow shows an issue, a setting with its lint results, or a unit file's
+// [Service] directives
+func (a *App) describeRKE2ConfigRow(row table.RowData) tea.Cmd {
+	index, ok := row["index"].(int)
+	section, _ := row["section"].(string)
+	if a.rke2Config == nil || !ok || index < 0 {
+		return nil
+	}
+	c := a.rke2Config
+
+	var b strings.Builder
+	title := ""
+	key := ""
+	switch section {
+	case "issue":
+		if index >= len(c.Issues) {
+			return nil
+		}
+		issue := c.Issues[index]
+		title = "RKE2 config issue: " + issue.Key
+		key = issue.Key
+		fmt.Fprintf(&b, "Issue:   %s\n", issue.Type)
+		fmt.Fprintf(&b, "Key:     %s\n", issue.Key)
+		fmt.Fprintf(&b, "\n%s\n", issue.Detail)
+	case "effective":
+		settings := rke2EffectiveSettings(c)
+		if index >= len(settings) {
+			return nil
+		}
+		s := settings[index]
+		title = "RKE2 setting: " + s.key
+		fmt.Fprintf(&b, "Setting: %s\n", s.key)
+		fmt.Fprintf(&b, "Value:   %s\n", s.value)
+		fmt.Fprintf(&b, "Source:  %s\n", rke2SettingSource(c, s.key))
+		fmt.Fprintf(&b, "Used by: %s\n", s.usage)
+	case "config":
+		if index >= len(c.Settings) {
+			return nil
+		}
+		s := c.Settings[index]
+		title = "RKE2 config: " + s.Key
+		key = s.Key
+		fmt.Fprintf(&b, "Key:     %s\n", s.Key)
+		fmt.Fprintf(&b, "Value:   %s\n", s.Value)
+		if s.Masked {
+			b.WriteString("         (secret, masked)\n")
+		}
+		known := "yes"
+		if !s.Known {
+			known = "no"
+		}
+		fmt.Fprintf(&b, "Known:   %s\n", known)
+	case "unit":
+		if index >= len(c.Units) {
+			return nil
+		}
+		u := c.Units[index]
+		title = "RKE2 unit: " + u.Name
+		fmt.Fprintf(&b, "Unit:      rke2/%s\n", u.Name)
+		fmt.Fprintf(&b, "ExecStart: %s\n", u.ExecStart)
+		b.WriteString("\n[Service]\n")
+		for _, d := range u.Directives {
+			fmt.Fprintf(&b, "  %s=%s\n", d.Name, d.Value)
+		}
+	default:
+		return nil
+	}
+
+	// Show every issue for the key, e.g. an unknown key's suggestion from its config row
+	if key != "" && section != "issue" {
+		for _, issue := range c.Issues {
+			if issue.Key == key {
+				fmt.Fprintf(&b, "\n⚠ %s: %s\n", issue.Type, issue.Detail)
+			}
+		}
+	}
+	fmt.Fprintf(&b, "\nNode:    %s (%s)\n", c.Node, c.Role)
+
+	content := b.String()
+	return func() tea.Msg {
+		return describeMsg{title: title, content: content}
+	}
+}
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// formatRolloutHistory renders a Deployment's ReplicaSet revisions, newest first
+func formatRolloutHistory(r datasource.DeploymentRollout) string {
+	var b strings.Builder
+
+	state := "complete"
+	switch {
+	case r.Stalled:
+		state = "✗ STALLED - new ReplicaSet has no ready pods, old ReplicaSet still serving"
+	case r.InProgress:
+		state = "in progress"
+	case len(r.ReplicaSets) == 0:
+		state = "unknown (no ReplicaSets in bundle)"
+	}
+	fmt.Fprintf(&b, "ROLLOUT: %s (%d/%d ready)\n", state, r.Ready, r.Desired)
+
+	if len(r.ReplicaSets) > 0 {
+		fmt.Fprintf(&b, "\n  %-4s %-8s %-45s %7s %7s %5s %6s  %s\n", "", "REVISION", "REPLICASET", "DESIRED", "CURRENT", "READY", "AGE", "IMAGES")
+	}
+	for i, rs := range r.ReplicaSets {
+		marker := "old"
+		if i == 0 {
+			marker = "new"
+		}
+		revision := "-"
+		if rs.Revision > 0 {
+			revision = fmt.Sprintf("%d", rs.Revision)
+		}
+		images := strings.Join(rs.Images, ", ")
+		if images == "" {
+			images = "(not collected)"
+		}
+		fmt.Fprintf(&b, "  %-4s %-8s %-45s %7d %7d %5d %6s  %s\n", marker, revision, rs.Name, rs.Desired, rs.Current, rs.Ready, rs.Age, images)
+	}
+
+	if len(r.NewPods) > 0 && (r.Stalled || r.InProgress) {
+		b.WriteString("\n  New ReplicaSet pods:\n")
+		for _, pod := range r.NewPods {
+			fmt.Fprintf(&b, "    %s  %s  ready=%s  restarts=%d\n", pod.Name, pod.KubectlStatus, pod.KubectlReady, pod.KubectlRestarts)
+		}
+	}
+
+	return b.String()
+}
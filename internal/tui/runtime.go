@@ -0,0 +1,274 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// runtimeMsg carries the crictl state of the bundle's node
+type runtimeMsg struct {
+	status *datasource.RuntimeStatus
+}
+
+// fetchRuntime fetches container runtime state using the unified data source
+func (a *App) fetchRuntime() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		status, err := a.dataSource.GetRuntime()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch runtime state: %w", err)}
+		}
+
+		return runtimeMsg{status: status}
+	}
+}
+
+// runtimeIssueLabel returns the short CHECK column text for an issue
+func runtimeIssueLabel(issue datasource.RuntimeIssue) string {
+	switch issue.Type {
+	case datasource.RuntimeIssueCrashLoop:
+		return fmt.Sprintf("✗ crash loop (attempt %d)", issue.Attempt)
+	case datasource.RuntimeIssueMissingFromAPI:
+		return "✗ not in API (orphaned sandbox)"
+	case datasource.RuntimeIssueMissingFromRuntime:
+		return "✗ not in runtime (no sandbox)"
+	default:
+		return "✗ " + issue.Type
+	}
+}
+
+// runtimeIssuesFor returns the issues affecting a runtime container: its own crash loop
+// plus any pod-level desync
+func runtimeIssuesFor(issues []datasource.RuntimeIssue, namespace, pod, container string) []datasource.RuntimeIssue {
+	var matched []datasource.RuntimeIssue
+	for _, issue := range issues {
+		if issue.Namespace != namespace || issue.Pod != pod {
+			continue
+		}
+		if issue.Container == "" || issue.Container == container {
+			matched = append(matched, issue)
+		}
+	}
+	return matched
+}
+
+// formatRuntimeBytes formats a byte count as crictl does, e.g. "29.42MB"
+func formatRuntimeBytes(n int64) string {
+	units := []string{"B", "kB", "MB", "GB", "TB"}
+	value := float64(n)
+	unit := 0
+	for value >= 1000 && unit < len(units)-1 {
+		value /= 1000
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%dB", n)
+	}
+	return fmt.Sprintf("%.2f%s", value, units[unit])
+}
+
+// updateRuntimeTable builds the runtime containers table. Pods the API expects on this node
+// but the runtime does not have are listed first, as there is no container row to mark.
+func (a *App) updateRuntimeTable() {
+	if a.runtime == nil || (len(a.runtime.Containers) == 0 && len(a.runtime.Issues) == 0) {
+		message := "No crictl data in bundle (rke2/crictl)"
+		if a.runtime != nil {
+			message = "No containers reported by the runtime"
+		}
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": message})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("id", "CONTAINER", 14),
+		table.NewColumn("name", "NAME", 24),
+		table.NewColumn("pod", "POD", 38),
+		table.NewColumn("namespace", "NAMESPACE", 18),
+		table.NewColumn("state", "STATE", 10),
+		table.NewColumn("attempt", "ATTEMPT", 8),
+		table.NewColumn("podid", "POD ID", 14),
+		table.NewColumn("cpu", "CPU%", 6),
+		table.NewColumn("mem", "MEM", 9),
+		table.NewColumn("created", "CREATED", 14),
+		table.NewColumn("check", "CHECK", 34),
+	}
+
+	rows := []table.Row{}
+	for i, issue := range a.runtime.Issues {
+		if issue.Type != datasource.RuntimeIssueMissingFromRuntime {
+			continue
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"id":        "-",
+			"name":      "-",
+			"pod":       issue.Pod,
+			"namespace": issue.Namespace,
+			"state":     "-",
+			"attempt":   "-",
+			"podid":     "-",
+			"cpu":       "-",
+			"mem":       "-",
+			"created":   "-",
+			"check":     runtimeIssueLabel(issue),
+			"issue":     i,
+		}))
+	}
+
+	for i, c := range a.runtime.Containers {
+		check := "✓"
+		if issues := runtimeIssuesFor(a.runtime.Issues, c.Namespace, c.PodName, c.Name); len(issues) > 0 {
+			var labels []string
+			for _, issue := range issues {
+				labels = append(labels, runtimeIssueLabel(issue))
+			}
+			check = strings.Join(labels, "; ")
+		}
+
+		cpu, mem := "-", "-"
+		if c.HasStats {
+			cpu = fmt.Sprintf("%.2f", c.CPUPercent)
+			mem = c.Memory
+		}
+
+		rows = append(rows, table.NewRow(table.RowData{
+			"id":        c.ID,
+			"name":      c.Name,
+			"pod":       c.PodName,
+			"namespace": c.Namespace,
+			"state":     c.State,
+			"attempt":   fmt.Sprintf("%d", c.Attempt),
+			"podid":     c.PodID,
+			"cpu":       cpu,
+			"mem":       mem,
+			"created":   c.Created,
+			"check":     check,
+			"index":     i,
+		}))
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// runtimeIssueCount returns how many crash-loop and desync issues the runtime has
+func (a *App) runtimeIssueCount() int {
+	if a.runtime == nil {
+		return 0
+	}
+	return len(a.runtime.Issues)
+}
+
+// handleRuntimeEnter opens the logs of the selected container's pod
+func (a *App) handleRuntimeEnter(row table.RowData) tea.Cmd {
+	namespace := safeRowString(row, "namespace")
+	podName := safeRowString(row, "pod")
+	if podName == "" || namespace == "" {
+		return nil
+	}
+
+	a.viewStack = append(a.viewStack, a.currentView)
+	a.currentView = ViewContext{
+		viewType:      ViewLogs,
+		clusterID:     a.currentView.clusterID,
+		clusterName:   a.currentView.clusterName,
+		namespaceName: namespace,
+		podName:       podName,
+	}
+	a.filterLevel = ""
+	a.loading = true
+	return a.fetchLogs(a.currentView.clusterID, namespace, podName)
+}
+
+// describeRuntimeContainer shows the selected container with node-level runtime state:
+// version, NotReady conditions, image filesystem usage and cross-check results
+func (a *App) describeRuntimeContainer(row table.RowData) tea.Cmd {
+	if a.runtime == nil {
+		return nil
+	}
+	status := *a.runtime
+
+	var podIssue *datasource.RuntimeIssue
+	if idx, ok := row["issue"].(int); ok && idx >= 0 && idx < len(status.Issues) {
+		podIssue = &status.Issues[idx]
+	}
+	idx, hasContainer := row["index"].(int)
+	hasContainer = hasContainer && idx >= 0 && idx < len(status.Containers)
+
+	return func() tea.Msg {
+		var b strings.Builder
+		title := "Runtime: " + status.NodeName
+
+		if hasContainer {
+			c := status.Containers[idx]
+			title = fmt.Sprintf("Container: %s/%s/%s", c.Namespace, c.PodName, c.Name)
+
+			fmt.Fprintf(&b, "Container:  %s\n", c.ID)
+			fmt.Fprintf(&b, "Name:       %s (attempt %d)\n", c.Name, c.Attempt)
+			fmt.Fprintf(&b, "State:      %s\n", c.State)
+			fmt.Fprintf(&b, "Created:    %s\n", c.Created)
+			fmt.Fprintf(&b, "Image:      %s\n", c.Image)
+			fmt.Fprintf(&b, "Pod:        %s/%s (sandbox %s)\n", c.Namespace, c.PodName, c.PodID)
+			for _, p := range status.Pods {
+				if p.ID == c.PodID || strings.HasPrefix(p.ID, c.PodID) {
+					fmt.Fprintf(&b, "Sandbox:    %s, created %s\n", p.State, p.Created)
+					break
+				}
+			}
+			if c.HasStats {
+				fmt.Fprintf(&b, "Usage:      CPU %.2f%%, memory %s\n", c.CPUPercent, c.Memory)
+			}
+
+			b.WriteString("\nCross-check:\n")
+			issues := runtimeIssuesFor(status.Issues, c.Namespace, c.PodName, c.Name)
+			if len(issues) == 0 {
+				b.WriteString("  ✓ Consistent with kubectl pods\n")
+			}
+			for _, issue := range issues {
+				fmt.Fprintf(&b, "  ✗ %s\n", issue.Detail)
+			}
+		} else if podIssue != nil {
+			title = fmt.Sprintf("Pod: %s/%s", podIssue.Namespace, podIssue.Pod)
+			fmt.Fprintf(&b, "Pod:        %s/%s\n", podIssue.Namespace, podIssue.Pod)
+			fmt.Fprintf(&b, "\n✗ %s\n", podIssue.Detail)
+			b.WriteString("  The API schedules this pod here but containerd has no sandbox for it;\n")
+			b.WriteString("  kubelet may be failing to sync or the node was reset.\n")
+		}
+
+		b.WriteString("\nNode runtime:\n")
+		fmt.Fprintf(&b, "  Node:     %s\n", status.NodeName)
+		if status.Version != "" {
+			fmt.Fprintf(&b, "  Version:  %s\n", status.Version)
+		}
+		if len(status.NotReadyConditions) == 0 {
+			b.WriteString("  Status:   ✓ RuntimeReady, NetworkReady\n")
+		}
+		for _, cond := range status.NotReadyConditions {
+			fmt.Fprintf(&b, "  ✗ %s\n", cond)
+		}
+		fmt.Fprintf(&b, "  Counts:   %d containers, %d sandboxes, %d images\n", len(status.Containers), len(status.Pods), len(status.Images))
+		if status.ImageFSMountpoint != "" {
+			fmt.Fprintf(&b, "  ImageFS:  %s, %s used, %d inodes\n", status.ImageFSMountpoint, formatRuntimeBytes(status.ImageFSUsedBytes), status.ImageFSInodesUsed)
+		}
+
+		return describeMsg{title: title, content: b.String()}
+	}
+}
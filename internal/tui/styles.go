@@ -102,6 +102,29 @@ var (
 	logDebugStyle = lipgloss.NewStyle().
 			Foreground(colorGray)
 
+	// Annotation appended to connection errors from a NetworkPolicy-isolated pod
+	logAnnotationStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("213")). // Magenta - distinct from log levels
+				Italic(true)
+
+	// Config content highlighting (ConfigMap YAML/JSON/Corefile)
+	syntaxKeyStyle = lipgloss.NewStyle().
+			Foreground(colorCyan)
+
+	syntaxStringStyle = lipgloss.NewStyle().
+				Foreground(colorGreen)
+
+	syntaxLiteralStyle = lipgloss.NewStyle().
+				Foreground(colorYellow)
+
+	syntaxCommentStyle = lipgloss.NewStyle().
+				Foreground(colorGray).
+				Italic(true)
+
+	syntaxBlockStyle = lipgloss.NewStyle().
+				Foreground(colorBlue).
+				Bold(true)
+
 	// Search match highlighting - high contrast for visibility
 	searchMatchStyle = lipgloss.NewStyle().
 				Background(lipgloss.Color("226")). // Bright yellow (#FFFF00)
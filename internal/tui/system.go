@@ -0,0 +1,315 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// systemTopProcesses is how many processes (largest RSS first) the System view lists
+const systemTopProcesses = 20
+
+// systemResourcesMsg carries the bundle node's system resources
+type systemResourcesMsg struct {
+	resources *datasource.SystemResources
+}
+
+// fetchSystemResources fetches system resources using the unified data source
+func (a *App) fetchSystemResources() tea.Cmd {
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		resources, err := a.dataSource.GetSystemResources()
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to fetch system resources: %w", err)}
+		}
+
+		return systemResourcesMsg{resources: resources}
+	}
+}
+
+// systemIssueMark returns the mark for a system issue
+func systemIssueMark(issue datasource.SystemIssue) string {
+	if issue.Critical {
+		return "✗"
+	}
+	return "⚠"
+}
+
+// formatPercentUsage formats a df percentage, "-" when unknown
+func formatPercentUsage(percent int) string {
+	if percent < 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%d%%", percent)
+}
+
+// systemStatusText summarizes the System view for the status bar
+func (a *App) systemStatusText() string {
+	if a.system == nil {
+		return "no systeminfo data"
+	}
+	critical := 0
+	for _, issue := range a.system.Issues {
+		if issue.Critical {
+			critical++
+		}
+	}
+	return fmt.Sprintf("%s: %d issues (%d critical), %d filesystems, %d processes",
+		a.system.Hostname, len(a.system.Issues), critical, len(a.system.Filesystems), len(a.system.Processes))
+}
+
+// updateSystemTable builds the System view: issues, CPU/memory/file handle summaries, then
+// filesystems, block devices, the largest processes, systemd units and ulimits, filtered with '/'
+func (a *App) updateSystemTable() {
+	if a.system == nil {
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": "No system information in bundle (systeminfo/)"})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 8).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("section", "TYPE", 8),
+		table.NewColumn("name", "NAME", 36),
+		table.NewColumn("value", "VALUE", 26),
+		table.NewColumn("detail", "DETAIL", 90),
+	}
+
+	rows := []table.Row{}
+	add := func(section, name, value, detail string, index int) {
+		if a.resourceFilter != "" && !matchesFilter(a.resourceFilter, section, name, value, detail) {
+			return
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"section": section,
+			"name":    name,
+			"value":   value,
+			"detail":  detail,
+			"index":   index,
+		}))
+	}
+
+	s := a.system
+	for i, issue := range s.Issues {
+		add("issue", systemIssueMark(issue)+" "+issue.Subject, issue.Type, issue.Detail, i)
+	}
+
+	perCore := 0.0
+	if s.Cores > 0 {
+		perCore = s.Load5 / float64(s.Cores)
+	}
+	add("cpu", "CPU", fmt.Sprintf("%d cores", s.Cores),
+		fmt.Sprintf("load %.2f %.2f %.2f (%.2f per core)  up %s  %s", s.Load1, s.Load5, s.Load15, perCore, s.Uptime, s.CPUModel), 0)
+	if s.MemTotal > 0 {
+		add("memory", "Memory", fmt.Sprintf("%d/%d MiB (%d%%)", s.MemUsed, s.MemTotal, s.MemUsed*100/s.MemTotal),
+			fmt.Sprintf("%d MiB available  swap %d/%d MiB", s.MemAvailable, s.SwapUsed, s.SwapTotal), 0)
+	}
+	if s.VmstatSamples > 0 {
+		add("vmstat", "vmstat", fmt.Sprintf("%d samples", s.VmstatSamples),
+			fmt.Sprintf("avg runnable %.1f  blocked %.1f  iowait %.1f%%  steal %.1f%%  max swap in/out %d/%d KiB/s",
+				s.AvgRunnable, s.AvgBlocked, s.AvgIOWait, s.AvgSteal, s.MaxSwapIn, s.MaxSwapOut), 0)
+	}
+	if s.FileHandlesMax > 0 {
+		add("files", "File handles", fmt.Sprintf("%d allocated", s.FileHandlesAllocated), fmt.Sprintf("fs.file-max %d", s.FileHandlesMax), 0)
+	}
+
+	for i, fs := range s.Filesystems {
+		mode := "rw"
+		if fs.ReadOnly {
+			mode = "ro"
+		}
+		detail := fmt.Sprintf("inodes %s  %s %s %s", formatPercentUsage(fs.IUsePercent), fs.Device, fs.Type, mode)
+		if fs.RKE2Data {
+			detail += "  (RKE2 data)"
+		}
+		add("fs", fs.MountPoint, fmt.Sprintf("%s (%s/%s)", formatPercentUsage(fs.UsePercent), fs.Used, fs.Size), detail, i)
+	}
+	for i, b := range s.BlockDevices {
+		detail := b.Type
+		if b.ReadOnly {
+			detail += " ro"
+		}
+		if len(b.MountPoints) > 0 {
+			detail += "  " + strings.Join(b.MountPoints, " ")
+		}
+		add("disk", b.Name, b.Size, detail, i)
+	}
+	for i, p := range s.Processes {
+		if i == systemTopProcesses {
+			break
+		}
+		add("proc", fmt.Sprintf("%d %s", p.PID, processName(p.Command)), "rss "+formatBytes(p.RSS*1024),
+			fmt.Sprintf("cpu %.1f%%  mem %.1f%%  %s  %s", p.CPU, p.Memory, p.Stat, p.User), i)
+	}
+	for i, u := range s.Units {
+		detail := u.Description
+		if u.UnitFileState != "" {
+			detail += "  [" + u.UnitFileState + "]"
+		}
+		if u.Journal != "" {
+			detail += "  log: " + u.Journal
+		}
+		add("unit", u.Name, fmt.Sprintf("%s (%s)", u.Active, u.Sub), detail, i)
+	}
+	for i, u := range s.Ulimits {
+		add("limit", fmt.Sprintf("%s (%s %s)", u.Name, u.Kind, u.Flag), u.Value, "", i)
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 8).
+		Focused(true).
+		BorderRounded()
+}
+
+// processName returns a command line's executable name
+func processName(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+	name := strings.Trim(fields[0], "[]")
+	if i := strings.LastIndex(name, "/"); i >= 0 && !strings.HasPrefix(fields[0], "[") {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// describeSystemRow shows an issue, a filesystem with its mount options, a process's full
+// command line, or a unit with its journal; summary rows show the whole node summary
+func (a *App) describeSystemRow(row table.RowData) tea.Cmd {
+	index, ok := row["index"].(int)
+	section, _ := row["section"].(string)
+	if a.system == nil || !ok || index < 0 {
+		return nil
+	}
+	s := a.system
+
+	var b strings.Builder
+	title := ""
+	switch section {
+	case "issue":
+		if index >= len(s.Issues) {
+			return nil
+		}
+		issue := s.Issues[index]
+		title = "Issue: " + issue.Subject
+		severity := "warning"
+		if issue.Critical {
+			severity = "critical"
+		}
+		fmt.Fprintf(&b, "Issue:    %s %s (%s)\n", systemIssueMark(issue), issue.Type, severity)
+		fmt.Fprintf(&b, "Subject:  %s\n", issue.Subject)
+		fmt.Fprintf(&b, "Detail:   %s\n", issue.Detail)
+		if len(issue.Evidence) > 0 {
+			b.WriteString("\nJournal:\n")
+			for _, line := range issue.Evidence {
+				fmt.Fprintf(&b, "  %s\n", line)
+			}
+		}
+
+	case "fs":
+		if index >= len(s.Filesystems) {
+			return nil
+		}
+		fs := s.Filesystems[index]
+		title = "Filesystem: " + fs.MountPoint
+		fmt.Fprintf(&b, "Mount point: %s\n", fs.MountPoint)
+		fmt.Fprintf(&b, "Device:      %s\n", fs.Device)
+		if fs.Type != "" {
+			fmt.Fprintf(&b, "Type:        %s\n", fs.Type)
+		}
+		fmt.Fprintf(&b, "Size:        %s  used %s  avail %s  (%s)\n", fs.Size, fs.Used, fs.Avail, formatPercentUsage(fs.UsePercent))
+		if fs.Inodes > 0 {
+			fmt.Fprintf(&b, "Inodes:      %d of %d used (%s)\n", fs.IUsed, fs.Inodes, formatPercentUsage(fs.IUsePercent))
+		} else {
+			b.WriteString("Inodes:      not limited\n")
+		}
+		if len(fs.Options) > 0 {
+			fmt.Fprintf(&b, "Options:     %s\n", strings.Join(fs.Options, ","))
+		}
+		if fs.RKE2Data {
+			b.WriteString("\nHolds /var/lib/rancher: containerd images and snapshots, etcd data and RKE2 agent state.\n")
+		}
+
+	case "unit":
+		if index >= len(s.Units) {
+			return nil
+		}
+		u := s.Units[index]
+		title = "Unit: " + u.Name
+		fmt.Fprintf(&b, "Unit:        %s\n", u.Name)
+		fmt.Fprintf(&b, "Description: %s\n", u.Description)
+		fmt.Fprintf(&b, "State:       %s (%s)\n", u.Active, u.Sub)
+		if u.UnitFileState != "" {
+			fmt.Fprintf(&b, "Unit file:   %s\n", u.UnitFileState)
+		}
+		if u.Role != "" {
+			fmt.Fprintf(&b, "Needed for:  %s\n", u.Role)
+		}
+		if u.Restarts > 0 {
+			fmt.Fprintf(&b, "Restarts:    %d (systemd restart counter)\n", u.Restarts)
+		}
+		if u.Journal != "" {
+			fmt.Fprintf(&b, "\nLast lines of %s:\n", u.Journal)
+			for _, line := range u.JournalTail {
+				fmt.Fprintf(&b, "  %s\n", line)
+			}
+		} else {
+			b.WriteString("\nNo journald log for this unit in the bundle.\n")
+		}
+
+	case "proc":
+		if index >= len(s.Processes) {
+			return nil
+		}
+		p := s.Processes[index]
+		title = fmt.Sprintf("Process: %d %s", p.PID, processName(p.Command))
+		fmt.Fprintf(&b, "PID:      %d\n", p.PID)
+		fmt.Fprintf(&b, "User:     %s\n", p.User)
+		fmt.Fprintf(&b, "State:    %s\n", p.Stat)
+		fmt.Fprintf(&b, "CPU:      %.1f%%\n", p.CPU)
+		fmt.Fprintf(&b, "Memory:   %.1f%% (RSS %s)\n", p.Memory, formatBytes(p.RSS*1024))
+		b.WriteString("Command:\n")
+		for _, arg := range strings.Fields(p.Command) {
+			fmt.Fprintf(&b, "  %s\n", arg)
+		}
+
+	default:
+		title = "System: " + s.Hostname
+		fmt.Fprintf(&b, "Host:     %s\n", s.Hostname)
+		fmt.Fprintf(&b, "Kernel:   %s\n", s.Kernel)
+		fmt.Fprintf(&b, "CPU:      %d x %s\n", s.Cores, s.CPUModel)
+		fmt.Fprintf(&b, "Uptime:   %s\n", s.Uptime)
+		fmt.Fprintf(&b, "Load:     %.2f %.2f %.2f (1m 5m 15m)\n", s.Load1, s.Load5, s.Load15)
+		fmt.Fprintf(&b, "Memory:   %d MiB used of %d MiB, %d MiB available\n", s.MemUsed, s.MemTotal, s.MemAvailable)
+		fmt.Fprintf(&b, "Swap:     %d MiB used of %d MiB\n", s.SwapUsed, s.SwapTotal)
+		if s.FileHandlesMax > 0 {
+			fmt.Fprintf(&b, "Files:    %d handles allocated, fs.file-max %d\n", s.FileHandlesAllocated, s.FileHandlesMax)
+		}
+		if len(s.ReadOnlyMounts) > 0 {
+			b.WriteString("\nRead-only mounts:\n")
+			for _, m := range s.ReadOnlyMounts {
+				fmt.Fprintf(&b, "  %s\n", m)
+			}
+		}
+	}
+
+	content := b.String()
+	return func() tea.Msg {
+		return describeMsg{title: title, content: content}
+	}
+}
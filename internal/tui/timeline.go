@@ -0,0 +1,492 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+const (
+	// maxTimelinePerFile caps the error and warning lines kept from each log file (newest first)
+	maxTimelinePerFile = 5000
+
+	// timelineTimeFormat is the TIME column; entries derived from kubectl ages end in "~"
+	timelineTimeFormat = "01-02 15:04:05.000"
+)
+
+// timelineBars are the histogram bar heights, from empty to the busiest bucket
+var timelineBars = []rune(" ▁▂▃▄▅▆▇█")
+
+// timelineMsg carries the merged timeline of the bundle
+type timelineMsg struct {
+	timeline *datasource.Timeline
+}
+
+// timelineRange is a zoomed-in time window of the timeline
+type timelineRange struct {
+	from, to time.Time
+}
+
+// timelineLineMatcher keeps the log lines the log view colors as errors or warnings
+type timelineLineMatcher struct{}
+
+func (timelineLineMatcher) MatchString(line string) bool {
+	return isErrorLog(line) || isWarnLog(line)
+}
+
+// fetchTimeline builds the timeline using the unified data source. Going back to the
+// timeline (e.g. from a log opened on one of its entries) reuses the loaded one; 'r'
+// clears it to rebuild.
+func (a *App) fetchTimeline() tea.Cmd {
+	if a.timeline != nil {
+		tl := a.timeline
+		return func() tea.Msg {
+			return timelineMsg{timeline: tl}
+		}
+	}
+	return func() tea.Msg {
+		if a.dataSource == nil {
+			return errMsg{fmt.Errorf("no data source available")}
+		}
+
+		tl, err := a.dataSource.GetTimeline(timelineLineMatcher{}, maxTimelinePerFile)
+		if err != nil {
+			return errMsg{fmt.Errorf("failed to build timeline: %w", err)}
+		}
+		for i := range tl.Entries {
+			e := &tl.Entries[i]
+			if e.Level != "" {
+				continue
+			}
+			e.Level = datasource.TimelineLevelWarning
+			if isErrorLog(e.Message) {
+				e.Level = datasource.TimelineLevelError
+			}
+		}
+
+		return timelineMsg{timeline: tl}
+	}
+}
+
+// timelineWindow returns the zoomed-in window, or ok=false when the whole timeline is shown
+func (a *App) timelineWindow() (timelineRange, bool) {
+	if len(a.timelineZoom) == 0 {
+		return timelineRange{}, false
+	}
+	return a.timelineZoom[len(a.timelineZoom)-1], true
+}
+
+// visibleTimelineEntries returns the indexes of the entries in the zoom window that match
+// the '/' filter, oldest first
+func (a *App) visibleTimelineEntries() []int {
+	if a.timeline == nil {
+		return nil
+	}
+	window, zoomed := a.timelineWindow()
+	var indexes []int
+	for i, e := range a.timeline.Entries {
+		if zoomed && (e.Time.Before(window.from) || !e.Time.Before(window.to)) {
+			continue
+		}
+		if a.resourceFilter != "" && !matchesFilter(a.resourceFilter, e.Level, e.Kind, e.Node, e.Namespace,
+			e.Pod, e.Component, e.Source, e.Message) {
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+	return indexes
+}
+
+// timelineSpan returns the time range the histogram covers: the zoom window, or the
+// span of the visible entries
+func (a *App) timelineSpan() timelineRange {
+	if window, zoomed := a.timelineWindow(); zoomed {
+		return window
+	}
+	if len(a.timelineRows) == 0 {
+		return timelineRange{}
+	}
+	from := a.timeline.Entries[a.timelineRows[0]].Time
+	to := a.timeline.Entries[a.timelineRows[len(a.timelineRows)-1]].Time
+	return timelineRange{from: from, to: to.Add(time.Millisecond)} // Include the last entry
+}
+
+// timelineBucketCount is the number of histogram buckets: one per column of the screen
+func (a *App) timelineBucketCount() int {
+	n := a.width - 4
+	if n < 10 {
+		n = 10
+	}
+	return n
+}
+
+// timelineBucket returns the histogram bucket of a time
+func timelineBucket(t time.Time, span timelineRange, buckets int) int {
+	total := span.to.Sub(span.from)
+	if total <= 0 {
+		return 0
+	}
+	b := int(int64(t.Sub(span.from)) * int64(buckets) / int64(total))
+	if b < 0 {
+		return 0
+	}
+	if b >= buckets {
+		return buckets - 1
+	}
+	return b
+}
+
+// bucketRange returns the time range of a histogram bucket
+func (s timelineRange) bucketRange(bucket, buckets int) timelineRange {
+	size := s.to.Sub(s.from) / time.Duration(buckets)
+	if size < time.Millisecond {
+		size = time.Millisecond
+	}
+	from := s.from.Add(size * time.Duration(bucket))
+	return timelineRange{from: from, to: from.Add(size)}
+}
+
+// highlightedTimelineEntry returns the index of the entry under the table cursor (-1 if none)
+func (a *App) highlightedTimelineEntry() int {
+	index, ok := a.table.HighlightedRow().Data["index"].(int)
+	if a.timeline == nil || !ok || index < 0 || index >= len(a.timeline.Entries) {
+		return -1
+	}
+	return index
+}
+
+// timelineLevelMark returns the mark for a timeline entry level
+func timelineLevelMark(level string) string {
+	switch level {
+	case datasource.TimelineLevelError:
+		return "✗"
+	case datasource.TimelineLevelWarning:
+		return "⚠"
+	default:
+		return "ℹ"
+	}
+}
+
+// timelineStatusText summarizes the Timeline view for the status bar
+func (a *App) timelineStatusText() string {
+	if a.timeline == nil {
+		return "no timeline"
+	}
+	errors, warnings := 0, 0
+	for _, i := range a.timelineRows {
+		switch a.timeline.Entries[i].Level {
+		case datasource.TimelineLevelError:
+			errors++
+		case datasource.TimelineLevelWarning:
+			warnings++
+		}
+	}
+	text := fmt.Sprintf("%d entries, %d errors, %d warnings", len(a.timelineRows), errors, warnings)
+	if len(a.timelineZoom) > 0 {
+		text += fmt.Sprintf(" (zoom %d)", len(a.timelineZoom))
+	}
+	if a.timeline.Dropped > 0 {
+		text += fmt.Sprintf(", %d older log lines past %d per file left out", a.timeline.Dropped, maxTimelinePerFile)
+	}
+	return text
+}
+
+// updateTimelineTable builds the Timeline view: every source's entries in time order,
+// limited to the zoom window and filtered with '/'
+func (a *App) updateTimelineTable() {
+	a.timelineRows = a.visibleTimelineEntries()
+	if len(a.timelineRows) == 0 {
+		message := "No timestamped log errors/warnings, events, kernel events or restarts in bundle"
+		if a.timeline != nil && len(a.timeline.Entries) > 0 {
+			message = "No entries match the filter in this time window ('Z' to zoom out)"
+		}
+		a.table = table.New([]table.Column{table.NewColumn("message", "MESSAGE", 80)}).
+			WithRows([]table.Row{table.NewRow(table.RowData{"message": message})}).
+			HeaderStyle(headerStyle).
+			WithBaseStyle(baseStyle).
+			WithPageSize(a.height - 12).
+			Focused(false).
+			BorderRounded()
+		return
+	}
+
+	columns := []table.Column{
+		table.NewColumn("time", "TIME", 19),
+		table.NewColumn("level", "LEVEL", 9),
+		table.NewColumn("kind", "KIND", 7),
+		table.NewColumn("node", "NODE", 22),
+		table.NewColumn("namespace", "NAMESPACE", 18),
+		table.NewColumn("pod", "POD", 28),
+		table.NewColumn("component", "COMPONENT", 14),
+		table.NewColumn("message", "MESSAGE", 90),
+	}
+
+	highlight := 0
+	rows := make([]table.Row, 0, len(a.timelineRows))
+	for row, i := range a.timelineRows {
+		e := a.timeline.Entries[i]
+		when := e.Time.Format(timelineTimeFormat)
+		if e.Approximate {
+			when += "~"
+		}
+		rows = append(rows, table.NewRow(table.RowData{
+			"time":      when,
+			"level":     timelineLevelMark(e.Level) + " " + e.Level,
+			"kind":      e.Kind,
+			"node":      e.Node,
+			"namespace": e.Namespace,
+			"pod":       e.Pod,
+			"component": e.Component,
+			"message":   strings.TrimSpace(e.Message),
+			"index":     i,
+		}))
+		if i == a.timelineSelected {
+			highlight = row
+		}
+	}
+
+	a.table = table.New(columns).
+		WithRows(rows).
+		HeaderStyle(headerStyle).
+		WithBaseStyle(baseStyle).
+		WithPageSize(a.height - 12).
+		Focused(true).
+		BorderRounded().
+		WithHighlightedRow(highlight)
+}
+
+// renderTimelineHistogram renders entry counts per time bucket above the table, colored by
+// the worst level in each bucket; the bucket of the highlighted entry is marked
+func (a *App) renderTimelineHistogram() string {
+	if len(a.timelineRows) == 0 {
+		return ""
+	}
+	buckets := a.timelineBucketCount()
+	span := a.timelineSpan()
+
+	counts := make([]int, buckets)
+	worst := make([]string, buckets)
+	peak := 0
+	for _, i := range a.timelineRows {
+		e := a.timeline.Entries[i]
+		b := timelineBucket(e.Time, span, buckets)
+		counts[b]++
+		if counts[b] > peak {
+			peak = counts[b]
+		}
+		if worst[b] != datasource.TimelineLevelError {
+			if e.Level == datasource.TimelineLevelError || e.Level == datasource.TimelineLevelWarning {
+				worst[b] = e.Level
+			}
+		}
+	}
+
+	selected := -1
+	if i := a.highlightedTimelineEntry(); i >= 0 {
+		selected = timelineBucket(a.timeline.Entries[i].Time, span, buckets)
+	}
+
+	var bar strings.Builder
+	for b, count := range counts {
+		height := 0
+		if count > 0 {
+			height = (count*(len(timelineBars)-1) + peak - 1) / peak // 1..8, rounded up so any entry shows
+		}
+		char := string(timelineBars[height])
+		switch {
+		case b == selected:
+			bar.WriteString(searchMatchStyle.Render(char))
+		case worst[b] == datasource.TimelineLevelError:
+			bar.WriteString(logErrorStyle.Render(char))
+		case worst[b] == datasource.TimelineLevelWarning:
+			bar.WriteString(logWarnStyle.Render(char))
+		default:
+			bar.WriteString(logInfoStyle.Render(char))
+		}
+	}
+
+	bucketSize := span.to.Sub(span.from) / time.Duration(buckets)
+	left := span.from.Format("2006-01-02 15:04:05")
+	right := span.to.Format("2006-01-02 15:04:05")
+	middle := fmt.Sprintf("1 bar = %s, peak %d  [ ]=prev/next burst z/Z=zoom in/out", formatTimelineDuration(bucketSize), peak)
+	gap := buckets - len(left) - len(right) - len(middle)
+	labels := left + "  " + middle + "  " + right
+	if gap >= 4 {
+		pad := strings.Repeat(" ", (gap-4)/2)
+		labels = left + pad + "  " + middle + "  " + pad + right
+	}
+
+	labelStyle := lipgloss.NewStyle().Foreground(colorGray)
+	return lipgloss.JoinVertical(lipgloss.Left, " "+bar.String(), " "+labelStyle.Render(labels))
+}
+
+// formatTimelineDuration formats a histogram bucket size
+func formatTimelineDuration(d time.Duration) string {
+	switch {
+	case d >= time.Minute:
+		return strings.TrimSuffix(d.Round(time.Minute).String(), "0s") // "1h45m", not "1h45m0s"
+	case d >= time.Second:
+		return d.Round(time.Second).String()
+	default:
+		return d.Round(time.Millisecond).String()
+	}
+}
+
+// moveTimelineBurst moves the cursor to the first entry of the previous (dir -1) or next
+// (dir 1) non-empty histogram bucket
+func (a *App) moveTimelineBurst(dir int) {
+	current := a.highlightedTimelineEntry()
+	if current < 0 {
+		return
+	}
+	buckets := a.timelineBucketCount()
+	span := a.timelineSpan()
+	bucket := timelineBucket(a.timeline.Entries[current].Time, span, buckets)
+
+	target := -1
+	if dir > 0 {
+		for _, i := range a.timelineRows {
+			if timelineBucket(a.timeline.Entries[i].Time, span, buckets) > bucket {
+				target = i
+				break
+			}
+		}
+	} else {
+		// Last bucket before this one, then its first entry
+		prev := -1
+		for _, i := range a.timelineRows {
+			b := timelineBucket(a.timeline.Entries[i].Time, span, buckets)
+			if b >= bucket {
+				break
+			}
+			if b != prev {
+				prev, target = b, i
+			}
+		}
+	}
+	if target < 0 {
+		return
+	}
+	a.timelineSelected = target
+	a.updateTable()
+}
+
+// zoomTimeline zooms into the histogram bucket of the highlighted entry (in=true), or back
+// out one level
+func (a *App) zoomTimeline(in bool) {
+	current := a.highlightedTimelineEntry()
+	if in {
+		if current < 0 {
+			return
+		}
+		buckets := a.timelineBucketCount()
+		span := a.timelineSpan()
+		window := span.bucketRange(timelineBucket(a.timeline.Entries[current].Time, span, buckets), buckets)
+		if window.to.Sub(window.from) <= time.Millisecond && len(a.timelineZoom) > 0 {
+			return // As far as timestamps go
+		}
+		a.timelineZoom = append(a.timelineZoom, window)
+	} else {
+		if len(a.timelineZoom) == 0 {
+			return
+		}
+		a.timelineZoom = a.timelineZoom[:len(a.timelineZoom)-1]
+	}
+	a.timelineSelected = current
+	a.updateTable()
+}
+
+// handleTimelineEnter opens the source of an entry: a log line opens its log scrolled to and
+// highlighting that line; events, restarts and OOM kills of a pod open the pod's logs
+// (the previous container's for restarts). Other entries are described.
+func (a *App) handleTimelineEnter(row table.RowData) tea.Cmd {
+	index, ok := row["index"].(int)
+	if a.timeline == nil || !ok || index < 0 || index >= len(a.timeline.Entries) {
+		return nil
+	}
+	e := a.timeline.Entries[index]
+	a.timelineSelected = index
+
+	view := ViewContext{
+		viewType:    ViewLogs,
+		clusterID:   a.currentView.clusterID,
+		clusterName: a.currentView.clusterName,
+	}
+	switch {
+	case e.Kind == datasource.TimelineLog && e.Pod != "":
+		view.namespaceName, view.podName = e.Namespace, e.Pod
+		a.showPrevious = e.Previous
+		a.logJumpLine = e.LineNumber
+	case e.Kind == datasource.TimelineLog:
+		view.logPath = e.Path
+		a.showPrevious = false
+		a.logJumpLine = e.LineNumber
+	case e.Pod != "" && e.Namespace != "":
+		view.namespaceName, view.podName = e.Namespace, e.Pod
+		a.showPrevious = e.Kind == datasource.TimelineRestart
+		a.logJumpLine = 0
+	default:
+		return a.describeTimelineEntry(row)
+	}
+
+	a.viewStack = append(a.viewStack, a.currentView)
+	a.currentView = view
+	a.currentContainer = ""
+	a.filterLevel = ""
+	a.loading = true
+	return a.fetchLogs(view.clusterID, view.namespaceName, view.podName)
+}
+
+// describeTimelineEntry shows an entry with all its tags and where it came from
+func (a *App) describeTimelineEntry(row table.RowData) tea.Cmd {
+	index, ok := row["index"].(int)
+	if a.timeline == nil || !ok || index < 0 || index >= len(a.timeline.Entries) {
+		return nil
+	}
+	e := a.timeline.Entries[index]
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Time:      %s\n", e.Time.Format("2006-01-02 15:04:05.000000 MST"))
+	if e.Approximate {
+		fmt.Fprintf(&b, "           (approximate: kubectl age relative to collection at %s)\n",
+			a.timeline.CollectedAt.Format("2006-01-02 15:04:05"))
+	}
+	fmt.Fprintf(&b, "Kind:      %s\n", e.Kind)
+	fmt.Fprintf(&b, "Level:     %s %s\n", timelineLevelMark(e.Level), e.Level)
+	if e.Node != "" {
+		fmt.Fprintf(&b, "Node:      %s\n", e.Node)
+	}
+	if e.Namespace != "" {
+		fmt.Fprintf(&b, "Namespace: %s\n", e.Namespace)
+	}
+	if e.Pod != "" {
+		fmt.Fprintf(&b, "Pod:       %s\n", e.Pod)
+	}
+	if e.Component != "" {
+		fmt.Fprintf(&b, "Component: %s\n", e.Component)
+	}
+	if e.Path != "" {
+		previous := ""
+		if e.Previous {
+			previous = " (previous container)"
+		}
+		fmt.Fprintf(&b, "Source:    %s:%d%s\n", e.Source, e.LineNumber, previous)
+		fmt.Fprintf(&b, "File:      %s\n", e.Path)
+	}
+	fmt.Fprintf(&b, "\n%s\n", e.Message)
+	if e.Kind == datasource.TimelineLog || e.Pod != "" {
+		b.WriteString("\nPress Enter on the entry to open its log.\n")
+	}
+
+	title := fmt.Sprintf("Timeline: %s %s", e.Kind, e.Time.Format(timelineTimeFormat))
+	content := b.String()
+	return func() tea.Msg {
+		return describeMsg{title: title, content: content}
+	}
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Rancheroo/r8s/internal/datasource"
+)
+
+// TestTimelineBurstsAndZoom tests moving between bursts and zooming into one, with the
+// '/' filter applied inside the zoom window
+func TestTimelineBurstsAndZoom(t *testing.T) {
+	start := time.Date(2025, 12, 4, 9, 0, 0, 0, time.UTC)
+	entry := func(offset time.Duration, pod string) datasource.TimelineEntry {
+		return datasource.TimelineEntry{Time: start.Add(offset), Kind: datasource.TimelineLog,
+			Level: datasource.TimelineLevelError, Pod: pod, Message: "error"}
+	}
+	app := &App{
+		width:  104, // 100 buckets
+		height: 40,
+		currentView: ViewContext{
+			viewType: ViewTimeline,
+		},
+		timeline: &datasource.Timeline{Entries: []datasource.TimelineEntry{
+			entry(0, "web"),
+			entry(time.Second, "api"),
+			entry(50*time.Minute+30*time.Second, "web"),
+			entry(50*time.Minute+31*time.Second, "api"),
+			entry(100*time.Minute, "web"),
+		}},
+		timelineSelected: -1,
+	}
+	app.updateTable()
+
+	if len(app.timelineRows) != 5 || app.highlightedTimelineEntry() != 0 {
+		t.Fatalf("rows = %v, highlighted = %d", app.timelineRows, app.highlightedTimelineEntry())
+	}
+
+	app.moveTimelineBurst(1)
+	if got := app.highlightedTimelineEntry(); got != 2 {
+		t.Errorf("next burst = entry %d, want 2", got)
+	}
+	app.moveTimelineBurst(1)
+	app.moveTimelineBurst(1) // Already in the last burst
+	if got := app.highlightedTimelineEntry(); got != 4 {
+		t.Errorf("last burst = entry %d, want 4", got)
+	}
+	app.moveTimelineBurst(-1)
+	if got := app.highlightedTimelineEntry(); got != 2 {
+		t.Errorf("previous burst = entry %d, want 2", got)
+	}
+
+	app.zoomTimeline(true)
+	if len(app.timelineZoom) != 1 || len(app.timelineRows) != 2 || app.highlightedTimelineEntry() != 2 {
+		t.Fatalf("zoomed rows = %v, highlighted = %d", app.timelineRows, app.highlightedTimelineEntry())
+	}
+
+	app.resourceFilter = "api"
+	app.updateTable()
+	if len(app.timelineRows) != 1 || app.timelineRows[0] != 3 {
+		t.Errorf("filtered zoomed rows = %v, want [3]", app.timelineRows)
+	}
+
+	// Zooming out keeps the cursor on the entry it was on
+	app.resourceFilter = ""
+	app.zoomTimeline(false)
+	if len(app.timelineZoom) != 0 || len(app.timelineRows) != 5 || app.highlightedTimelineEntry() != 3 {
+		t.Errorf("zoomed out rows = %v, highlighted = %d", app.timelineRows, app.highlightedTimelineEntry())
+	}
+}
@@ -0,0 +1,71 @@
+// Package userfiles finds the YAML files users extend r8s with (attention rules and knowledge
+// base entries) and reports the problems found loading them.
+package userfiles
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Problem is a user file, or an item in it, that failed to load or validate
+type Problem struct {
+	File    string
+	ID      string // ID of the rule or entry, empty for file-level problems
+	Message string
+}
+
+// String formats the problem as "file: id: message"
+func (p Problem) String() string {
+	if p.ID == "" {
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.File, p.ID, p.Message)
+}
+
+// DefaultDirs returns the existing directories named name in ~/.r8s and in bundleRoot
+func DefaultDirs(bundleRoot, name string) []string {
+	var candidates []string
+	if home, err := os.UserHomeDir(); err == nil {
+		candidates = append(candidates, filepath.Join(home, ".r8s", name))
+	}
+	if bundleRoot != "" {
+		candidates = append(candidates, filepath.Join(bundleRoot, name))
+	}
+
+	var dirs []string
+	for _, dir := range candidates {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// YAMLFiles returns path if it is a file, or the *.yaml and *.yml files in it, in name order,
+// if it is a directory
+func YAMLFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if !entry.IsDir() && (ext == ".yaml" || ext == ".yml") {
+			files = append(files, filepath.Join(path, entry.Name()))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}